@@ -0,0 +1,119 @@
+// Package apierror is the error model shared by the services. Domain errors
+// carry a Kind, which decides the HTTP and gRPC status, a machine-readable
+// code and optional per-field details. Transports render them as RFC 7807
+// problem+json bodies.
+package apierror
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// Kind classifies an error and decides its status code
+type Kind int
+
+const (
+	Internal        Kind = iota // 500, the default for unknown errors
+	Invalid                     // 400, the request is malformed
+	Unauthenticated             // 401
+	Forbidden                   // 403
+	NotFound                    // 404
+	Conflict                    // 409, the request clashes with the current state
+	Unprocessable               // 422, the request is well formed but not acceptable
+	RateLimited                 // 429
+	Unavailable                 // 503, a dependency is down
+)
+
+// Status returns the HTTP status code of k
+func (k Kind) Status() int {
+	switch k {
+	case Invalid:
+		return http.StatusBadRequest
+	case Unauthenticated:
+		return http.StatusUnauthorized
+	case Forbidden:
+		return http.StatusForbidden
+	case NotFound:
+		return http.StatusNotFound
+	case Conflict:
+		return http.StatusConflict
+	case Unprocessable:
+		return http.StatusUnprocessableEntity
+	case RateLimited:
+		return http.StatusTooManyRequests
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// KindFromStatus is the inverse of Kind.Status, used by clients
+func KindFromStatus(status int) Kind {
+	for k := Internal; k <= Unavailable; k++ {
+		if k.Status() == status {
+			return k
+		}
+	}
+	if status >= 400 && status < 500 {
+		return Invalid
+	}
+	return Internal
+}
+
+// FieldError explains why a single request field was rejected
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Error is a domain error. Services declare them as package level sentinels
+// so they can still be compared with ==.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// WithField returns a copy of e which also reports field as rejected
+func (e *Error) WithField(field, reason string) *Error {
+	c := *e
+	c.Fields = append(append([]FieldError(nil), e.Fields...), FieldError{Field: field, Reason: reason})
+	return &c
+}
+
+// ErrMalformedBody reports a request body that is not valid JSON for the route
+var ErrMalformedBody = New(Invalid, "malformed_body", "malformed request body")
+
+// ErrInternal stands for any error that is not an *Error, so its text never
+// reaches the client. The transports log the error itself.
+var ErrInternal = New(Internal, "internal", "internal error")
+
+// From returns err as an *Error. JSON decoding errors become
+// ErrMalformedBody, anything else unknown is ErrInternal.
+func From(err error) *Error {
+	switch e := err.(type) {
+	case *Error:
+		return e
+	case *json.SyntaxError:
+		return ErrMalformedBody.WithField("body", e.Error())
+	case *json.UnmarshalTypeError:
+		field := e.Field
+		if field == "" {
+			field = "body"
+		}
+		return ErrMalformedBody.WithField(field, "expected "+e.Type.String())
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrMalformedBody.WithField("body", "empty or truncated")
+	}
+	return ErrInternal
+}
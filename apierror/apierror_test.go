@@ -0,0 +1,102 @@
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errTaken = New(Conflict, "taken", "already taken")
+
+func TestStatus(t *testing.T) {
+	for kind, want := range map[Kind]int{
+		Invalid:       400,
+		NotFound:      404,
+		Conflict:      409,
+		Unprocessable: 422,
+		Internal:      500,
+		Unavailable:   503,
+	} {
+		if got := kind.Status(); got != want {
+			t.Errorf("Expected %d for kind %d, got %d", want, kind, got)
+		}
+		if KindFromStatus(want) != kind {
+			t.Errorf("Expected %d to map back to kind %d", want, kind)
+		}
+	}
+}
+
+func TestFrom(t *testing.T) {
+	if From(errTaken) != errTaken {
+		t.Error("Expected *Error to be returned as is")
+	}
+	if e := From(errors.New("boom")); e != ErrInternal {
+		t.Error("Expected unknown errors to be internal")
+	}
+	if p := ProblemFrom(errors.New("store: open /var/db: permission denied")); p.Detail != ErrInternal.Message {
+		t.Errorf("Expected the text of an unknown error not to be shown, got %q", p.Detail)
+	}
+
+	var v struct {
+		N int `json:"n"`
+	}
+	err := json.Unmarshal([]byte(`{"n":"x"}`), &v)
+	if e := From(err); e.Code != ErrMalformedBody.Code || len(e.Fields) != 1 || e.Fields[0].Field != "n" {
+		t.Errorf("Expected a malformed body on field n, got %+v", e)
+	}
+	err = json.NewDecoder(strings.NewReader("")).Decode(&v)
+	if e := From(err); e.Kind != Invalid {
+		t.Error("Expected an empty body to be invalid")
+	}
+}
+
+func TestWithField(t *testing.T) {
+	e := errTaken.WithField("name", "in use")
+	if len(errTaken.Fields) != 0 {
+		t.Error("WithField changed the sentinel")
+	}
+	if e.Code != errTaken.Code || len(e.Fields) != 1 {
+		t.Error("WithField lost the code or the field")
+	}
+}
+
+func TestProblemRoundTrip(t *testing.T) {
+	rec := httptest.NewRecorder()
+	EncodeError(context.Background(), errTaken.WithField("name", "in use"), rec)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected 409, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Expected %s, got %s", ProblemContentType, ct)
+	}
+	var p Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal("Failed to decode the problem")
+	}
+	if p.Type != "/errors/taken" || p.Title != "Conflict" || p.Status != 409 || p.Detail != "already taken" || p.Code != "taken" {
+		t.Errorf("Unexpected problem %+v", p)
+	}
+
+	e := DecodeError(rec.Result())
+	if e.Kind != Conflict || e.Code != "taken" || e.Message != "already taken" || len(e.Fields) != 1 {
+		t.Errorf("Unexpected decoded error %+v", e)
+	}
+}
+
+func TestGRPCError(t *testing.T) {
+	err := GRPCError(errTaken)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected FailedPrecondition, got %v", status.Code(err))
+	}
+	if status.Code(GRPCError(errors.New("boom"))) != codes.Internal {
+		t.Error("Expected unknown errors to be Internal")
+	}
+}
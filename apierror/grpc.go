@@ -0,0 +1,35 @@
+package apierror
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCCode returns the gRPC status code of k
+func (k Kind) GRPCCode() codes.Code {
+	switch k {
+	case Invalid, Unprocessable:
+		return codes.InvalidArgument
+	case Unauthenticated:
+		return codes.Unauthenticated
+	case Forbidden:
+		return codes.PermissionDenied
+	case NotFound:
+		return codes.NotFound
+	case Conflict:
+		return codes.FailedPrecondition
+	case RateLimited:
+		return codes.ResourceExhausted
+	case Unavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// GRPCError converts err to a gRPC status error, the counterpart of
+// EncodeError for the gRPC transports
+func GRPCError(err error) error {
+	e := From(err)
+	return status.Error(e.Kind.GRPCCode(), e.Message)
+}
@@ -0,0 +1,65 @@
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 body of a failed request. Code and Fields are
+// extension members.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// ProblemFrom describes err as a Problem
+func ProblemFrom(err error) Problem {
+	e := From(err)
+	status := e.Kind.Status()
+	return Problem{
+		Type:   "/errors/" + e.Code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: e.Message,
+		Code:   e.Code,
+		Fields: e.Fields,
+	}
+}
+
+// EncodeError writes err as problem+json. It serves both as the
+// ServerErrorEncoder of every HTTP transport and for business errors
+// carried in responses.
+func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
+	if err == nil {
+		panic("EncodeError with nil error")
+	}
+	p := ProblemFrom(err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// DecodeError reads the problem+json body of a failed response back into an
+// *Error. It returns nil for successful responses.
+func DecodeError(resp *http.Response) *Error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	var p Problem
+	json.NewDecoder(resp.Body).Decode(&p)
+	e := &Error{Kind: KindFromStatus(resp.StatusCode), Code: p.Code, Message: p.Detail, Fields: p.Fields}
+	if e.Code == "" {
+		e.Code = "unknown"
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
@@ -0,0 +1,90 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	IssueEndpoint  endpoint.Endpoint
+	ListEndpoint   endpoint.Endpoint
+	RotateEndpoint endpoint.Endpoint
+	RevokeEndpoint endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		IssueEndpoint:  MakeIssueEndpoint(s),
+		ListEndpoint:   MakeListEndpoint(s),
+		RotateEndpoint: MakeRotateEndpoint(s),
+		RevokeEndpoint: MakeRevokeEndpoint(s),
+	}
+}
+
+func MakeIssueEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(issueRequest)
+		k, e := s.Issue(ctx, req.Name, req.Scopes, req.RateLimit)
+		return issueResponse{Key: k, Err: e}, e
+	}
+}
+
+func MakeListEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		kk, e := s.List(ctx)
+		return listResponse{Keys: kk, Err: e}, e
+	}
+}
+
+func MakeRotateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		k, e := s.Rotate(ctx, req.ID)
+		return issueResponse{Key: k, Err: e}, e
+	}
+}
+
+func MakeRevokeEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e := s.Revoke(ctx, req.ID)
+		return revokeResponse{Err: e}, e
+	}
+}
+
+//
+
+type issueRequest struct {
+	Name      string       `json:"name"`
+	Scopes    []auth.Scope `json:"scopes"`
+	RateLimit int          `json:"rateLimit"`
+}
+
+type issueResponse struct {
+	Err error     `json:"err,omitempty"`
+	Key IssuedKey `json:"key"`
+}
+
+func (r issueResponse) error() error { return r.Err }
+
+type listRequest struct {
+}
+
+type listResponse struct {
+	Err  error    `json:"err,omitempty"`
+	Keys []APIKey `json:"keys"`
+}
+
+func (r listResponse) error() error { return r.Err }
+
+type idRequest struct {
+	ID string `json:"id"`
+}
+
+type revokeResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r revokeResponse) error() error { return r.Err }
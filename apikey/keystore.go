@@ -0,0 +1,123 @@
+package apikey
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+)
+
+// The key store which stores the issued partner API keys. Only the SHA-256
+// hash of a key is kept, the plain key is handed out once when issued.
+
+type KeyStore interface {
+	Create(APIKey) (APIKey, error)
+	Update(APIKey) (APIKey, error)
+	Find(id string) (APIKey, error)
+	FindByHash(hash string) (APIKey, error)
+	GetAll() ([]APIKey, error)
+	Touch(id string, t time.Time) error
+}
+
+type APIKey struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Prefix    string       `json:"prefix"`
+	Hash      string       `json:"-"`
+	Scopes    []auth.Scope `json:"scopes"`
+	RateLimit int          `json:"rateLimit"` // requests per minute
+	CreatedAt time.Time    `json:"createdAt"`
+	RotatedAt time.Time    `json:"rotatedAt,omitempty"`
+	LastUsed  time.Time    `json:"lastUsed,omitempty"`
+	Revoked   bool         `json:"revoked"`
+}
+
+var (
+	ErrInconsistentIDs = apierror.New(apierror.Conflict, "inconsistent_ids", "inconsistent IDs")
+	ErrNotFound        = apierror.New(apierror.NotFound, "api_key_not_found", "not found")
+	ErrInvalidReq      = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInternal        = apierror.New(apierror.Internal, "internal", "internal data error")
+)
+
+type InMemStore struct {
+	mtx    sync.RWMutex
+	m      map[string]APIKey
+	byHash map[string]string // hash -> id
+}
+
+func NewInMemKeyStore() (KeyStore, error) {
+	s := &InMemStore{m: make(map[string]APIKey, 0), byHash: make(map[string]string, 0)}
+	return s, nil
+}
+
+func (s *InMemStore) Create(k APIKey) (APIKey, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[k.ID]; ok {
+		return APIKey{}, ErrInconsistentIDs
+	}
+	s.m[k.ID] = k
+	s.byHash[k.Hash] = k.ID
+	return k, nil
+}
+
+func (s *InMemStore) Update(k APIKey) (APIKey, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	old, ok := s.m[k.ID]
+	if !ok {
+		return APIKey{}, ErrInconsistentIDs
+	}
+	delete(s.byHash, old.Hash)
+	s.m[k.ID] = k
+	s.byHash[k.Hash] = k.ID
+	return k, nil
+}
+
+func (s *InMemStore) Find(id string) (APIKey, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	k, ok := s.m[id]
+	if !ok {
+		return APIKey{}, ErrNotFound
+	}
+	return k, nil
+}
+
+func (s *InMemStore) FindByHash(hash string) (APIKey, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	id, ok := s.byHash[hash]
+	if !ok {
+		return APIKey{}, ErrNotFound
+	}
+	return s.m[id], nil
+}
+
+func (s *InMemStore) GetAll() ([]APIKey, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	kk := make([]APIKey, 0)
+	for _, k := range s.m {
+		kk = append(kk, k)
+	}
+	sort.Slice(kk, func(i, j int) bool {
+		return kk[i].CreatedAt.Before(kk[j].CreatedAt)
+	})
+	return kk, nil
+}
+
+// Touch records t as the last time the key was used
+func (s *InMemStore) Touch(id string, t time.Time) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	k, ok := s.m[id]
+	if !ok {
+		return ErrNotFound
+	}
+	k.LastUsed = t
+	s.m[id] = k
+	return nil
+}
@@ -0,0 +1,53 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Issue(ctx context.Context, name string, scopes []auth.Scope, rateLimit int) (k IssuedKey, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Issue", "name", name, "scopes", len(scopes), "id", k.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Issue(ctx, name, scopes, rateLimit)
+}
+
+func (mw loggingMiddleware) List(ctx context.Context) (kk []APIKey, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "List", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.List(ctx)
+}
+
+func (mw loggingMiddleware) Rotate(ctx context.Context, id string) (k IssuedKey, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Rotate", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Rotate(ctx, id)
+}
+
+func (mw loggingMiddleware) Revoke(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Revoke", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Revoke(ctx, id)
+}
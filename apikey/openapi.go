@@ -0,0 +1,33 @@
+package apikey
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(auth.Scope(""), auth.ScopeParkingRead, auth.ScopeParkingWrite, auth.ScopeBookingRead, auth.ScopeBookingWrite, auth.ScopeAdmin)
+
+	d.Operation("POST", "/apikey/v1/", "issueKey", "Issue a partner API key, the plain key is only returned here and on rotation").
+		Tag("apikey").Require(requirements["IssueKey"]).
+		Body(issueRequest{}).
+		Returns(http.StatusOK, "The issued key", issueResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/apikey/v1/", "listKeys", "List the issued keys without their secrets").
+		Tag("apikey").Require(requirements["ListKeys"]).
+		Returns(http.StatusOK, "Issued keys", listResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/apikey/v1/{id}/rotate", "rotateKey", "Replace the secret of a key").
+		Tag("apikey").Require(requirements["RotateKey"]).
+		PathParam("id", "Key id").
+		Returns(http.StatusOK, "The key with its new secret", issueResponse{}).
+		Fails(http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/apikey/v1/{id}", "revokeKey", "Revoke a key").
+		Tag("apikey").Require(requirements["RevokeKey"]).
+		PathParam("id", "Key id").
+		Returns(http.StatusOK, "Empty object", revokeResponse{}).
+		Fails(http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
@@ -0,0 +1,147 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+)
+
+// API key management service for partner integrations
+
+const (
+	keyPrefix        = "rct_"
+	DefaultRateLimit = 60
+)
+
+var (
+	ErrInvalidScope = apierror.New(apierror.Unprocessable, "invalid_scope", "invalid scope passed in key request").
+			WithField("scopes", "must be parking:read, parking:write, booking:read, booking:write or admin")
+	ErrNoScopes = apierror.New(apierror.Unprocessable, "missing_scopes", "at least one scope is required").
+			WithField("scopes", "must not be empty")
+	ErrKeyRevoked = apierror.New(apierror.Conflict, "api_key_already_revoked", "key already revoked")
+)
+
+// IssuedKey is returned when a key is issued or rotated. Key holds the plain
+// key and is never returned again.
+type IssuedKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type Service interface {
+	Issue(ctx context.Context, name string, scopes []auth.Scope, rateLimit int) (IssuedKey, error)
+	List(ctx context.Context) ([]APIKey, error)
+	Rotate(ctx context.Context, id string) (IssuedKey, error)
+	Revoke(ctx context.Context, id string) error
+}
+
+type service struct {
+	keyStore KeyStore
+	now      func() time.Time
+}
+
+func NewService(store KeyStore) Service {
+	return &service{keyStore: store, now: time.Now}
+}
+
+func (s *service) Issue(ctx context.Context, name string, scopes []auth.Scope, rateLimit int) (IssuedKey, error) {
+	if name == "" || rateLimit < 0 {
+		return IssuedKey{}, ErrInvalidReq
+	}
+	if len(scopes) == 0 {
+		return IssuedKey{}, ErrNoScopes
+	}
+	for _, sc := range scopes {
+		if !sc.Valid() {
+			return IssuedKey{}, ErrInvalidScope
+		}
+	}
+	if rateLimit == 0 {
+		rateLimit = DefaultRateLimit
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		return IssuedKey{}, ErrInternal
+	}
+	plain, err := newPlainKey()
+	if err != nil {
+		return IssuedKey{}, ErrInternal
+	}
+	k, err := s.keyStore.Create(APIKey{
+		ID:        id,
+		Name:      name,
+		Prefix:    plain[:len(keyPrefix)+6],
+		Hash:      hashKey(plain),
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		return IssuedKey{}, err
+	}
+	return IssuedKey{APIKey: k, Key: plain}, nil
+}
+
+func (s *service) List(ctx context.Context) ([]APIKey, error) {
+	return s.keyStore.GetAll()
+}
+
+// Rotate replaces the secret of a key, keeping its id, scopes and limits.
+// The old key stops working immediately.
+func (s *service) Rotate(ctx context.Context, id string) (IssuedKey, error) {
+	k, err := s.keyStore.Find(id)
+	if err != nil {
+		return IssuedKey{}, err
+	}
+	if k.Revoked {
+		return IssuedKey{}, ErrKeyRevoked
+	}
+	plain, err := newPlainKey()
+	if err != nil {
+		return IssuedKey{}, ErrInternal
+	}
+	k.Prefix = plain[:len(keyPrefix)+6]
+	k.Hash = hashKey(plain)
+	k.RotatedAt = s.now()
+	k, err = s.keyStore.Update(k)
+	if err != nil {
+		return IssuedKey{}, err
+	}
+	return IssuedKey{APIKey: k, Key: plain}, nil
+}
+
+func (s *service) Revoke(ctx context.Context, id string) error {
+	k, err := s.keyStore.Find(id)
+	if err != nil {
+		return err
+	}
+	k.Revoked = true
+	_, err = s.keyStore.Update(k)
+	return err
+}
+
+func newPlainKey() (string, error) {
+	secret, err := randomHex(24)
+	if err != nil {
+		return "", err
+	}
+	return keyPrefix + secret, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,100 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+)
+
+func TestIssueAndLookup(t *testing.T) {
+	store, err := NewInMemKeyStore()
+	if err != nil {
+		t.Error("Failed to create inmem key store")
+	}
+	service := NewService(store)
+	verifier := NewVerifier(store)
+
+	k, err := service.Issue(nil, "partner", []auth.Scope{auth.ScopeParkingRead}, 0)
+	if err != nil {
+		t.Error("Error in issuing key")
+	}
+	if k.RateLimit != DefaultRateLimit {
+		t.Error("Default rate limit not applied")
+	}
+
+	stored, _ := store.Find(k.ID)
+	if stored.Hash == k.Key || stored.Hash != hashKey(k.Key) {
+		t.Error("Key not stored hashed")
+	}
+
+	p, err := verifier.Lookup(k.Key)
+	if err != nil {
+		t.Error("Error in looking up key")
+	}
+	if !p.HasScope(auth.ScopeParkingRead) || p.HasScope(auth.ScopeBookingWrite) {
+		t.Error("Incorrect scopes on principal")
+	}
+	stored, _ = store.Find(k.ID)
+	if stored.LastUsed.IsZero() {
+		t.Error("Last used timestamp not tracked")
+	}
+
+	if _, err := service.Issue(nil, "partner", []auth.Scope{"parking:delete"}, 0); err != ErrInvalidScope {
+		t.Error("Expecting an unknown scope to be rejected")
+	}
+}
+
+func TestRotateAndRevoke(t *testing.T) {
+	store, _ := NewInMemKeyStore()
+	service := NewService(store)
+	verifier := NewVerifier(store)
+
+	k, _ := service.Issue(nil, "partner", []auth.Scope{auth.ScopeBookingWrite}, 0)
+	rotated, err := service.Rotate(nil, k.ID)
+	if err != nil {
+		t.Error("Error in rotating key")
+	}
+	if rotated.ID != k.ID || rotated.Key == k.Key {
+		t.Error("Incorrect rotated key")
+	}
+	if _, err := verifier.Lookup(k.Key); err != auth.ErrUnknownKey {
+		t.Error("Expecting the old key to stop working after rotation")
+	}
+	if _, err := verifier.Lookup(rotated.Key); err != nil {
+		t.Error("Rotated key does not work")
+	}
+
+	if err := service.Revoke(nil, k.ID); err != nil {
+		t.Error("Error in revoking key")
+	}
+	if _, err := verifier.Lookup(rotated.Key); err != auth.ErrKeyRevoked {
+		t.Error("Expecting a revoked key to be rejected")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	store, _ := NewInMemKeyStore()
+	service := NewService(store)
+	verifier := NewVerifier(store)
+	now := time.Now()
+	verifier.now = func() time.Time { return now }
+
+	k, _ := service.Issue(nil, "partner", []auth.Scope{auth.ScopeParkingRead}, 2)
+	for i := 0; i < 2; i++ {
+		if _, err := verifier.Lookup(k.Key); err != nil {
+			t.Error("Request within the rate limit rejected")
+		}
+	}
+	if _, err := verifier.Lookup(k.Key); err != auth.ErrRateLimited {
+		t.Error("Expecting request over the rate limit to be rejected")
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, err := verifier.Lookup(k.Key); err != nil {
+		t.Error("Expecting the bucket to refill over time")
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
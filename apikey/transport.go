@@ -0,0 +1,103 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"IssueKey":  {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleAdmin}},
+	"ListKeys":  {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleAdmin}},
+	"RotateKey": {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleAdmin}},
+	"RevokeKey": {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleAdmin}},
+}
+
+// MakeHTTPHandler mounts the key management endpoints into an http.Handler.
+// All of them are restricted to admins.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("POST").Path("/apikey/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "IssueKey")(e.IssueEndpoint),
+		decodeIssueRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/apikey/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListKeys")(e.ListEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/apikey/v1/{id}/rotate").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "RotateKey")(e.RotateEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/apikey/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "RevokeKey")(e.RevokeEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeIssueRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req issueRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req listRequest
+	return req, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return idRequest{ID: id}, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
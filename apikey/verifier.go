@@ -0,0 +1,69 @@
+package apikey
+
+import (
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+)
+
+// Verifier authenticates requests carrying a partner API key. It implements
+// auth.KeyStore, enforces the per key rate limit and records when each key
+// was last used.
+type Verifier struct {
+	keyStore KeyStore
+	now      func() time.Time
+
+	mtx     sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is a token bucket refilled at RateLimit tokens per minute
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewVerifier(store KeyStore) *Verifier {
+	return &Verifier{keyStore: store, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+func (v *Verifier) Lookup(key string) (auth.Principal, error) {
+	k, err := v.keyStore.FindByHash(hashKey(key))
+	if err != nil {
+		return auth.Principal{}, auth.ErrUnknownKey
+	}
+	if k.Revoked {
+		return auth.Principal{}, auth.ErrKeyRevoked
+	}
+	now := v.now()
+	if !v.allow(k, now) {
+		return auth.Principal{}, auth.ErrRateLimited
+	}
+	if err := v.keyStore.Touch(k.ID, now); err != nil {
+		return auth.Principal{}, err
+	}
+	return auth.Principal{Subject: "apikey:" + k.ID, Scopes: k.Scopes}, nil
+}
+
+func (v *Verifier) allow(k APIKey, now time.Time) bool {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	limit := float64(k.RateLimit)
+	b, ok := v.buckets[k.ID]
+	if !ok {
+		b = &bucket{tokens: limit, last: now}
+		v.buckets[k.ID] = b
+	}
+	b.tokens += now.Sub(b.last).Minutes() * limit
+	if b.tokens > limit {
+		b.tokens = limit
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+// Authentication and role based authorization shared by the parking and
+// booking services
+
+type Role string
+
+const (
+	RoleDriver   Role = "driver"
+	RoleOperator Role = "operator"
+	RoleAdmin    Role = "admin"
+	RoleService  Role = "service"
+	// RoleOfficer is held by enforcement officers patrolling the spots
+	RoleOfficer Role = "officer"
+	// RolePartner is the role partner API keys act with
+	RolePartner Role = "partner"
+)
+
+// AllRoles is a convenience for routes that any authenticated caller may use
+var AllRoles = []Role{RoleDriver, RoleOperator, RoleAdmin, RoleService, RoleOfficer, RolePartner}
+
+// Scope limits what a partner API key may do. ScopeAdmin grants everything.
+type Scope string
+
+const (
+	ScopeParkingRead  Scope = "parking:read"
+	ScopeParkingWrite Scope = "parking:write"
+	ScopeBookingRead  Scope = "booking:read"
+	ScopeBookingWrite Scope = "booking:write"
+	ScopeAdmin        Scope = "admin"
+)
+
+func (s Scope) Valid() bool {
+	switch s {
+	case ScopeParkingRead, ScopeParkingWrite, ScopeBookingRead, ScopeBookingWrite, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+var (
+	ErrUnauthenticated = apierror.New(apierror.Unauthenticated, "unauthenticated", "missing or invalid credentials")
+	ErrForbidden       = apierror.New(apierror.Forbidden, "forbidden", "insufficient role for this operation")
+	ErrInvalidToken    = apierror.New(apierror.Unauthenticated, "invalid_token", "invalid token")
+	ErrTokenExpired    = apierror.New(apierror.Unauthenticated, "token_expired", "token expired")
+	ErrUnknownKey      = apierror.New(apierror.Unauthenticated, "unknown_api_key", "unknown api key")
+	ErrKeyRevoked      = apierror.New(apierror.Unauthenticated, "api_key_revoked", "api key revoked")
+	ErrRateLimited     = apierror.New(apierror.RateLimited, "rate_limited", "rate limit exceeded")
+)
+
+// Principal is the authenticated caller of a request. Users carry roles,
+// scoped API keys carry scopes.
+type Principal struct {
+	Subject string  `json:"sub"`
+	Roles   []Role  `json:"roles,omitempty"`
+	Scopes  []Scope `json:"scopes,omitempty"`
+}
+
+// HasAnyRole reports whether the principal holds at least one of roles
+func (p Principal) HasAnyRole(roles ...Role) bool {
+	for _, have := range p.Roles {
+		for _, want := range roles {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the principal holds scope or the admin scope
+func (p Principal) HasScope(scope Scope) bool {
+	for _, have := range p.Scopes {
+		if have == scope || have == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether p may call a route declaring scope and roles.
+// Principals carrying scopes need the scope and act as partners, or as
+// admins when they hold the admin scope.
+func (p Principal) Allows(scope Scope, roles ...Role) bool {
+	if len(p.Scopes) == 0 {
+		return p.HasAnyRole(roles...)
+	}
+	role := RolePartner
+	if p.HasScope(ScopeAdmin) {
+		role = RoleAdmin
+	}
+	return p.HasScope(scope) && Principal{Roles: []Role{role}}.HasAnyRole(roles...)
+}
+
+// TokenVerifier validates bearer tokens
+type TokenVerifier interface {
+	VerifyToken(token string) (Principal, error)
+}
+
+// KeyStore resolves API keys to the principal they were issued to
+type KeyStore interface {
+	Lookup(key string) (Principal, error)
+}
+
+// Authenticator resolves the credentials stored in the context by
+// HTTPToContext to a Principal
+type Authenticator interface {
+	Authenticate(ctx context.Context) (Principal, error)
+}
+
+type authenticator struct {
+	tokens TokenVerifier
+	keys   KeyStore
+}
+
+// NewAuthenticator returns an Authenticator that accepts bearer tokens
+// verified by tv and API keys found in ks. Either may be nil to disable
+// that kind of credential.
+func NewAuthenticator(tv TokenVerifier, ks KeyStore) Authenticator {
+	return &authenticator{tokens: tv, keys: ks}
+}
+
+func (a *authenticator) Authenticate(ctx context.Context) (Principal, error) {
+	if token, ok := ctx.Value(bearerTokenKey).(string); ok && token != "" {
+		if a.tokens == nil {
+			return Principal{}, ErrUnauthenticated
+		}
+		return a.tokens.VerifyToken(token)
+	}
+	if key, ok := ctx.Value(apiKeyKey).(string); ok && key != "" {
+		if a.keys == nil {
+			return Principal{}, ErrUnauthenticated
+		}
+		return a.keys.Lookup(key)
+	}
+	return Principal{}, ErrUnauthenticated
+}
+
+type contextKey int
+
+const (
+	bearerTokenKey contextKey = iota
+	apiKeyKey
+	principalKey
+)
+
+// PrincipalFromContext returns the principal stored by the authorization
+// middleware, if any
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	if ctx == nil {
+		return Principal{}, false
+	}
+	p, ok := ctx.Value(principalKey).(Principal)
+	return p, ok
+}
+
+// NewContext returns a copy of ctx carrying the principal p
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// System is the principal of the background work of the services, which
+// acts for every user
+var System = Principal{Subject: "system", Roles: []Role{RoleService}}
+
+// CanAccess reports whether the caller in ctx may act on a resource owned by
+// owner. Operators, admins, service accounts and admin keys may act for
+// anyone. A context without a principal may act for no one.
+func CanAccess(ctx context.Context, owner string) bool {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return false
+	}
+	return p.Subject == owner || p.HasAnyRole(RoleOperator, RoleAdmin, RoleService) || p.HasScope(ScopeAdmin)
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+type countingCounter struct {
+	count float64
+}
+
+func (c *countingCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c *countingCounter) Add(delta float64)                          { c.count += delta }
+
+func okEndpoint(ctx context.Context, request interface{}) (interface{}, error) {
+	p, _ := PrincipalFromContext(ctx)
+	return p.Subject, nil
+}
+
+func requestContext(header, value string) context.Context {
+	r, _ := http.NewRequest("GET", "/", nil)
+	if header != "" {
+		r.Header.Set(header, value)
+	}
+	return HTTPToContext(context.Background(), r)
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	tokens := NewHMACTokens([]byte("secret"))
+	tok, err := tokens.Issue(Principal{Subject: "alice", Roles: []Role{RoleDriver}}, time.Minute)
+	if err != nil {
+		t.Error("Failed to issue token")
+	}
+	p, err := tokens.VerifyToken(tok)
+	if err != nil {
+		t.Error("Failed to verify token")
+	}
+	if p.Subject != "alice" || !p.HasAnyRole(RoleDriver) {
+		t.Error("Incorrect principal returned")
+	}
+
+	if _, err := NewHMACTokens([]byte("other")).VerifyToken(tok); err != ErrInvalidToken {
+		t.Error("Expecting a token signed with another secret to be rejected")
+	}
+
+	expired, _ := tokens.Issue(Principal{Subject: "alice"}, -time.Minute)
+	if _, err := tokens.VerifyToken(expired); err != ErrTokenExpired {
+		t.Error("Expecting an expired token to be rejected")
+	}
+}
+
+func TestRequire(t *testing.T) {
+	tokens := NewHMACTokens([]byte("secret"))
+	keys := NewInMemKeyStore()
+	keys.Add("svc-key", Principal{Subject: "svc", Roles: []Role{RoleService}})
+	keys.Add("partner-key", Principal{Subject: "acme", Scopes: []Scope{ScopeParkingWrite}})
+	keys.Add("admin-key", Principal{Subject: "ops", Scopes: []Scope{ScopeAdmin}})
+
+	denied := &countingCounter{}
+	a := NewAuthorizer(NewAuthenticator(tokens, keys), log.NewNopLogger(), denied)
+	e := a.Require("Update", ScopeParkingWrite, RoleOperator, RoleAdmin)(okEndpoint)
+
+	if _, err := e(requestContext("", ""), nil); err != ErrUnauthenticated {
+		t.Error("Expecting anonymous request to be unauthenticated")
+	}
+	if _, err := e(requestContext(APIKeyHeader, "bogus"), nil); err != ErrUnauthenticated {
+		t.Error("Expecting unknown api key to be unauthenticated")
+	}
+	if _, err := e(requestContext(APIKeyHeader, "svc-key"), nil); err != ErrForbidden {
+		t.Error("Expecting service account to be forbidden")
+	}
+	if _, err := e(requestContext(APIKeyHeader, "partner-key"), nil); err != ErrForbidden {
+		t.Error("Expecting partner key to be forbidden on an operator route despite its scope")
+	}
+	if _, err := e(requestContext(APIKeyHeader, "admin-key"), nil); err != nil {
+		t.Error("Expecting admin key to be allowed")
+	}
+	read := a.Require("GetAll", ScopeParkingRead, AllRoles...)(okEndpoint)
+	if _, err := read(requestContext(APIKeyHeader, "partner-key"), nil); err != ErrForbidden {
+		t.Error("Expecting partner key without the scope to be forbidden")
+	}
+	write := a.Require("SetOccupancy", ScopeParkingWrite, RoleService, RolePartner)(okEndpoint)
+	if _, err := write(requestContext(APIKeyHeader, "partner-key"), nil); err != nil {
+		t.Error("Expecting partner key to be allowed on a partner route with its scope")
+	}
+
+	tok, _ := tokens.Issue(Principal{Subject: "op", Roles: []Role{RoleOperator}}, time.Minute)
+	resp, err := e(requestContext("Authorization", "Bearer "+tok), nil)
+	if err != nil {
+		t.Error("Expecting operator to be allowed")
+	}
+	if resp != "op" {
+		t.Error("Principal not passed on in the context")
+	}
+
+	if denied.count != 5 {
+		t.Errorf("Expecting 5 denials to be counted, got %v", denied.count)
+	}
+}
+
+func TestCanAccess(t *testing.T) {
+	if CanAccess(context.Background(), "alice") {
+		t.Error("Expecting a context without a principal to be denied")
+	}
+	if !CanAccess(NewContext(context.Background(), System), "alice") {
+		t.Error("Expecting the system principal to act for anyone")
+	}
+	alice := NewContext(context.Background(), Principal{Subject: "alice", Roles: []Role{RoleDriver}})
+	if !CanAccess(alice, "alice") || CanAccess(alice, "bob") {
+		t.Error("Expecting drivers to act for themselves only")
+	}
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"sync"
+)
+
+// In memory store of API keys, used for service accounts
+
+type InMemKeyStore struct {
+	mtx sync.RWMutex
+	m   map[string]Principal
+}
+
+func NewInMemKeyStore() *InMemKeyStore {
+	return &InMemKeyStore{m: make(map[string]Principal, 0)}
+}
+
+func (s *InMemKeyStore) Add(key string, p Principal) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.m[key] = p
+}
+
+func (s *InMemKeyStore) Lookup(key string) (Principal, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	p, ok := s.m[key]
+	if !ok {
+		return Principal{}, ErrUnknownKey
+	}
+	return p, nil
+}
+
+type chainedKeyStore []KeyStore
+
+// ChainKeyStores returns a KeyStore that asks each of stores in turn
+func ChainKeyStores(stores ...KeyStore) KeyStore {
+	return chainedKeyStore(stores)
+}
+
+func (c chainedKeyStore) Lookup(key string) (Principal, error) {
+	for _, s := range c {
+		p, err := s.Lookup(key)
+		if err != ErrUnknownKey {
+			return p, err
+		}
+	}
+	return Principal{}, ErrUnknownKey
+}
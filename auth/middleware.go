@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+// Authorizer builds endpoint middlewares which authenticate the caller and
+// enforce the roles declared by each route. Every denial is logged and
+// counted.
+type Authorizer struct {
+	authn  Authenticator
+	logger log.Logger
+	denied metrics.Counter
+}
+
+func NewAuthorizer(authn Authenticator, logger log.Logger, denied metrics.Counter) *Authorizer {
+	return &Authorizer{authn: authn, logger: logger, denied: denied}
+}
+
+// Require returns a middleware which only lets callers holding one of roles,
+// or API keys carrying scope and acting with one of roles, through. Callers without valid credentials get
+// ErrUnauthenticated, keys over their rate limit get ErrRateLimited and
+// callers without a matching role or scope get ErrForbidden.
+func (a *Authorizer) Require(method string, scope Scope, roles ...Role) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			p, err := a.authn.Authenticate(ctx)
+			if err == ErrRateLimited {
+				a.deny(method, "ratelimited", "", err)
+				return nil, ErrRateLimited
+			}
+			if err != nil {
+				a.deny(method, "unauthenticated", "", err)
+				return nil, ErrUnauthenticated
+			}
+			if !p.Allows(scope, roles...) {
+				a.deny(method, "forbidden", p.Subject, ErrForbidden)
+				return nil, ErrForbidden
+			}
+			return next(NewContext(ctx, p), request)
+		}
+	}
+}
+
+// Requirement is the scope and roles a method is restricted to
+type Requirement struct {
+	Scope Scope
+	Roles []Role
+}
+
+// Requirements maps the methods of a service to their Requirement. A service
+// builds both its endpoints and its API description from one table.
+type Requirements map[string]Requirement
+
+// Enforce is Require with the requirement reqs holds for method. Methods
+// missing from reqs let nobody through.
+func (a *Authorizer) Enforce(reqs Requirements, method string) endpoint.Middleware {
+	r := reqs[method]
+	return a.Require(method, r.Scope, r.Roles...)
+}
+
+func (a *Authorizer) deny(method, reason, subject string, err error) {
+	a.denied.With("method", method, "reason", reason).Add(1)
+	a.logger.Log("method", method, "reason", reason, "subject", subject, "err", err)
+}
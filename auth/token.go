@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// HS256 signed JWT style bearer tokens
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type tokenClaims struct {
+	Principal
+	ExpiresAt int64 `json:"exp"`
+}
+
+var defaultHeader = tokenHeader{Alg: "HS256", Typ: "JWT"}
+
+// HMACTokens issues and verifies bearer tokens signed with a shared secret
+type HMACTokens struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewHMACTokens(secret []byte) *HMACTokens {
+	return &HMACTokens{secret: secret, now: time.Now}
+}
+
+// Issue returns a token for p which is valid for ttl
+func (t *HMACTokens) Issue(p Principal, ttl time.Duration) (string, error) {
+	h, err := json.Marshal(defaultHeader)
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(tokenClaims{Principal: p, ExpiresAt: t.now().Add(ttl).Unix()})
+	if err != nil {
+		return "", err
+	}
+	unsigned := encodeSegment(h) + "." + encodeSegment(c)
+	return unsigned + "." + encodeSegment(t.sign(unsigned)), nil
+}
+
+func (t *HMACTokens) VerifyToken(token string) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+	if !hmac.Equal(sig, t.sign(parts[0]+"."+parts[1])) {
+		return Principal{}, ErrInvalidToken
+	}
+
+	var h tokenHeader
+	if err := decodeSegment(parts[0], &h); err != nil || h.Alg != defaultHeader.Alg {
+		return Principal{}, ErrInvalidToken
+	}
+	var c tokenClaims
+	if err := decodeSegment(parts[1], &c); err != nil || c.Subject == "" {
+		return Principal{}, ErrInvalidToken
+	}
+	if t.now().Unix() >= c.ExpiresAt {
+		return Principal{}, ErrTokenExpired
+	}
+	return c.Principal, nil
+}
+
+func (t *HMACTokens) sign(s string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(s))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegment(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	APIKeyHeader = "X-API-Key"
+)
+
+// HTTPToContext moves the bearer token and API key of an incoming request
+// into the context. Use it as a ServerBefore option.
+func HTTPToContext(ctx context.Context, r *http.Request) context.Context {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, bearerPrefix) {
+		ctx = context.WithValue(ctx, bearerTokenKey, strings.TrimSpace(h[len(bearerPrefix):]))
+	}
+	if k := r.Header.Get(APIKeyHeader); k != "" {
+		ctx = context.WithValue(ctx, apiKeyKey, k)
+	}
+	return ctx
+}
+
+// ContextToHTTP copies the bearer token and API key stored by HTTPToContext
+// onto an outgoing request, so a caller's credentials travel with calls to
+// other services. Use it as a ClientBefore option.
+func ContextToHTTP(ctx context.Context, r *http.Request) context.Context {
+	if token, ok := ctx.Value(bearerTokenKey).(string); ok && token != "" {
+		r.Header.Set("Authorization", bearerPrefix+token)
+	}
+	if key, ok := ctx.Value(apiKeyKey).(string); ok && key != "" {
+		r.Header.Set(APIKeyHeader, key)
+	}
+	return ctx
+}
+
+// GRPCToContext moves the bearer token and API key of an incoming gRPC call
+// into the context. Use it as a ServerBefore option.
+func GRPCToContext(ctx context.Context, md metadata.MD) context.Context {
+	if h := md.Get("authorization"); len(h) > 0 && strings.HasPrefix(h[0], bearerPrefix) {
+		ctx = context.WithValue(ctx, bearerTokenKey, strings.TrimSpace(h[0][len(bearerPrefix):]))
+	}
+	if k := md.Get(strings.ToLower(APIKeyHeader)); len(k) > 0 && k[0] != "" {
+		ctx = context.WithValue(ctx, apiKeyKey, k[0])
+	}
+	return ctx
+}
@@ -1,30 +1,189 @@
 package booking
 
 import (
-	"errors"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/atuldaemon/rct/apierror"
 )
 
 type BookingStore interface {
-	Book(spotId int, startTime time.Time, duration time.Duration) (Booking, error)
+	// Book stores b as a new booking, with the next id and booked status.
+	// Book and Update fail with ErrOverlap when another booking of the spot
+	// blocks the window of b, each widened by its buffers.
+	Book(b Booking) (Booking, error)
+	Update(b Booking) (Booking, error)
+	// Overlap fails with ErrOverlap when Book would, without storing b
+	Overlap(b Booking) error
 	Delete(bookingId int) error
 	Find(bookingId int) (Booking, error)
+	FindByVehicle(vehicleId int) ([]Booking, error)
+	// FindBySpot returns the bookings of the spot ordered by start time
+	FindBySpot(spotId int) ([]Booking, error)
 	GetAll() ([]Booking, error)
+	// Query returns the bookings matching q, sorted and limited as q asks
+	Query(q Query) ([]Booking, error)
+}
+
+// Query selects bookings, the zero value of a field matches any booking
+type Query struct {
+	SpotId int
+	User   string
+	Status Status
+	// From and To select the bookings whose time window overlaps them,
+	// either may be left open
+	From time.Time
+	To   time.Time
+	// Sort is one of SortFields, prefixed with - to sort descending. The
+	// bookings are sorted by id when it is empty.
+	Sort string
+	// Limit is the most bookings returned, all when zero
+	Limit int
+}
+
+// SortFields are the fields a Query may sort by
+var SortFields = []string{"id", "start", "end"}
+
+// Matches reports whether b is selected by q, regardless of Sort and
+// Limit
+func (q Query) Matches(b Booking) bool {
+	if q.SpotId != 0 && b.SpotId != q.SpotId {
+		return false
+	}
+	if q.User != "" && b.User != q.User {
+		return false
+	}
+	if q.Status != "" && b.Status != q.Status {
+		return false
+	}
+	if !q.From.IsZero() && !b.EndTime().After(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !b.StartTime.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// Valid checks the status, time range, sort field and limit of q
+func (q Query) Valid() error {
+	field := strings.TrimPrefix(q.Sort, "-")
+	known := q.Sort == ""
+	for _, f := range SortFields {
+		known = known || field == f
+	}
+	if !known {
+		return ErrInvalidReq.WithField("sort", "must be one of "+strings.Join(SortFields, ", ")+", optionally prefixed with -")
+	}
+	switch q.Status {
+	case "", StatusBooked, StatusCheckedIn, StatusExpired, StatusNoShow:
+	default:
+		return ErrInvalidReq.WithField("status", "no such status")
+	}
+	if q.Limit < 0 {
+		return ErrInvalidReq.WithField("limit", "must not be negative")
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.To.After(q.From) {
+		return ErrInvalidReq.WithField("to", "must be after from")
+	}
+	return nil
+}
+
+// apply sorts and limits bb as q asks
+func (q Query) apply(bb []Booking) []Booking {
+	desc := strings.HasPrefix(q.Sort, "-")
+	var less func(a, b Booking) bool
+	switch strings.TrimPrefix(q.Sort, "-") {
+	case "start":
+		less = func(a, b Booking) bool { return a.StartTime.Before(b.StartTime) }
+	case "end":
+		less = func(a, b Booking) bool { return a.EndTime().Before(b.EndTime()) }
+	default:
+		less = func(a, b Booking) bool { return a.ID < b.ID }
+	}
+	// bookings that tie keep the order they were made in
+	sort.Slice(bb, func(i, j int) bool { return bb[i].ID < bb[j].ID })
+	sort.SliceStable(bb, func(i, j int) bool {
+		if desc {
+			return less(bb[j], bb[i])
+		}
+		return less(bb[i], bb[j])
+	})
+	if q.Limit > 0 && len(bb) > q.Limit {
+		bb = bb[:q.Limit]
+	}
+	return bb
 }
 
 type Booking struct {
-	ID        int           `json:"id"`
-	SpotId    int           `json:"spotId"`
+	ID        int `json:"id"`
+	SpotId    int `json:"spotId"`
+	VehicleId int `json:"vehicleId"`
+	// User is the owner of the vehicle when it was booked
+	User      string        `json:"user,omitempty"`
 	StartTime time.Time     `json:"startTime"`
 	Duration  time.Duration `json:"duration"`
+	Status    Status        `json:"status"`
+	// Price is what the booked window costs at the hourly cost of the spot
+	Price string `json:"price,omitempty"`
+	// Prepaid bookings, such as those of a monthly pass, are paid for
+	// elsewhere and cost nothing however they change
+	Prepaid bool `json:"prepaid,omitempty"`
+	// CheckedInAt is when the vehicle arrived, zero until it checks in
+	CheckedInAt time.Time `json:"checkedInAt,omitempty"`
+	// EnteredAt and ExitedAt are when the gate pass was used, zero until
+	// then
+	EnteredAt time.Time `json:"enteredAt,omitempty"`
+	ExitedAt  time.Time `json:"exitedAt,omitempty"`
+	// GroupId is the group the booking was made with, zero for a booking
+	// of its own
+	GroupId int `json:"groupId,omitempty"`
+	// BufferBefore and BufferAfter are the buffers of the spot when it was
+	// booked
+	BufferBefore time.Duration `json:"bufferBefore,omitempty"`
+	BufferAfter  time.Duration `json:"bufferAfter,omitempty"`
+}
+
+type Status string
+
+const (
+	StatusBooked    Status = "booked"
+	StatusCheckedIn Status = "checked_in"
+	// StatusExpired bookings have ended and released their spot
+	StatusExpired Status = "expired"
+	// StatusNoShow bookings were not checked in within the grace period of
+	// their facility and released their spot early
+	StatusNoShow Status = "no_show"
+)
+
+// Released reports whether the booking gave its spot back
+func (b Booking) Released() bool {
+	return b.Status == StatusExpired || b.Status == StatusNoShow
+}
+
+// EndTime is when the booked time window closes
+func (b Booking) EndTime() time.Time {
+	return b.StartTime.Add(b.Duration)
+}
+
+// Blocked is the window the booking keeps its spot from other bookings, the
+// booked window widened by the buffers
+func (b Booking) Blocked() (from, until time.Time) {
+	return b.StartTime.Add(-b.BufferBefore), b.EndTime().Add(b.BufferAfter)
+}
+
+// ActiveAt reports whether t falls within the booked time window
+func (b Booking) ActiveAt(t time.Time) bool {
+	return !t.Before(b.StartTime) && t.Before(b.EndTime())
 }
 
 var (
-	ErrInconsistentIDs = errors.New("inconsistent IDs")
-	ErrNotFound        = errors.New("not found")
-	ErrInvalidReq      = errors.New("invalid request")
-	ErrInternal        = errors.New("internal data error")
+	ErrInconsistentIDs = apierror.New(apierror.Conflict, "inconsistent_ids", "inconsistent IDs")
+	ErrNotFound        = apierror.New(apierror.NotFound, "booking_not_found", "not found")
+	ErrInvalidReq      = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInternal        = apierror.New(apierror.Internal, "internal", "internal data error")
 )
 
 type InMemStore struct {
@@ -38,15 +197,63 @@ func NewInMemBookingStore() (BookingStore, error) {
 	return s, nil
 }
 
-func (s *InMemStore) Book(spotId int, startTime time.Time, duration time.Duration) (Booking, error) {
+func (s *InMemStore) Book(b Booking) (Booking, error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
-	b := Booking{ID: s.nxtId, SpotId: spotId, StartTime: startTime, Duration: duration}
+	if err := s.overlap(b); err != nil {
+		return Booking{}, err
+	}
+	b.ID, b.Status = s.nxtId, StatusBooked
 	s.m[b.ID] = b
 	s.nxtId++
 	return b, nil
 }
 
+func (s *InMemStore) Update(b Booking) (Booking, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	cur, ok := s.m[b.ID]
+	if !ok {
+		return Booking{}, ErrInconsistentIDs
+	}
+	// the window of a booking is checked when it moves
+	from, until := b.Blocked()
+	curFrom, curUntil := cur.Blocked()
+	if b.SpotId != cur.SpotId || !from.Equal(curFrom) || !until.Equal(curUntil) {
+		if err := s.overlap(b); err != nil {
+			return Booking{}, err
+		}
+	}
+	s.m[b.ID] = b
+	return b, nil
+}
+
+func (s *InMemStore) Overlap(b Booking) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.overlap(b)
+}
+
+// overlap fails when another booking of the spot of b blocks its window,
+// a no-show leaves the spot to others. The caller holds mtx.
+func (s *InMemStore) overlap(b Booking) error {
+	from, until := b.Blocked()
+	for _, o := range s.m {
+		if o.ID == b.ID || o.SpotId != b.SpotId || o.Status == StatusNoShow {
+			continue
+		}
+		oFrom, oUntil := o.Blocked()
+		if !from.Before(oUntil) || !oFrom.Before(until) {
+			continue
+		}
+		if o.StartTime.Before(b.StartTime) {
+			return ErrOverlap.WithField("start", "the spot is taken until "+oUntil.Add(b.BufferBefore).Format(time.RFC3339))
+		}
+		return ErrOverlap.WithField("end", "the spot is booked from "+oFrom.Add(-b.BufferAfter).Format(time.RFC3339))
+	}
+	return nil
+}
+
 func (s *InMemStore) Delete(bookingId int) error {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
@@ -55,6 +262,8 @@ func (s *InMemStore) Delete(bookingId int) error {
 }
 
 func (s *InMemStore) Find(bookingId int) (Booking, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	b, ok := s.m[bookingId]
 	if !ok {
 		return Booking{}, ErrNotFound
@@ -62,10 +271,40 @@ func (s *InMemStore) Find(bookingId int) (Booking, error) {
 	return b, nil
 }
 
+func (s *InMemStore) FindByVehicle(vehicleId int) ([]Booking, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	bb := make([]Booking, 0)
+	for _, b := range s.m {
+		if b.VehicleId == vehicleId {
+			bb = append(bb, b)
+		}
+	}
+	return bb, nil
+}
+
 func (s *InMemStore) GetAll() ([]Booking, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
 	bb := make([]Booking, 0)
 	for _, b := range s.m {
 		bb = append(bb, b)
 	}
 	return bb, nil
 }
+
+func (s *InMemStore) FindBySpot(spotId int) ([]Booking, error) {
+	return s.Query(Query{SpotId: spotId, Sort: "start"})
+}
+
+func (s *InMemStore) Query(q Query) ([]Booking, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	bb := make([]Booking, 0)
+	for _, b := range s.m {
+		if q.Matches(b) {
+			bb = append(bb, b)
+		}
+	}
+	return q.apply(bb), nil
+}
@@ -0,0 +1,77 @@
+package booking
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// BufferStore keeps the turnover buffers of the spots and facilities
+type BufferStore interface {
+	// SetBuffer adds or replaces the buffer of its spot or facility
+	SetBuffer(b Buffer) (Buffer, error)
+	// Buffer returns the buffer of the spot, falling back to the buffer of
+	// its facility and then to the buffer of the empty facility
+	Buffer(spotId int, facility string) (Buffer, error)
+	Buffers() ([]Buffer, error)
+}
+
+// Buffer is the time a spot is kept free before and after each of its
+// bookings, for valets to move cars or to clean up. A buffer of SpotId
+// overrides the buffer of the spot's facility, the buffer of the empty
+// facility applies to every other spot.
+type Buffer struct {
+	SpotId   int           `json:"spotId,omitempty"`
+	Facility string        `json:"facility,omitempty"`
+	Before   time.Duration `json:"before"`
+	After    time.Duration `json:"after"`
+}
+
+type bufferKey struct {
+	spotId   int
+	facility string
+}
+
+type InMemBufferStore struct {
+	mtx     sync.RWMutex
+	buffers map[bufferKey]Buffer
+}
+
+func NewInMemBufferStore() (BufferStore, error) {
+	return &InMemBufferStore{buffers: make(map[bufferKey]Buffer)}, nil
+}
+
+func (s *InMemBufferStore) SetBuffer(b Buffer) (Buffer, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.buffers[bufferKey{b.SpotId, b.Facility}] = b
+	return b, nil
+}
+
+func (s *InMemBufferStore) Buffer(spotId int, facility string) (Buffer, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if b, ok := s.buffers[bufferKey{spotId: spotId}]; ok {
+		return b, nil
+	}
+	if b, ok := s.buffers[bufferKey{facility: facility}]; ok {
+		return b, nil
+	}
+	return s.buffers[bufferKey{}], nil
+}
+
+func (s *InMemBufferStore) Buffers() ([]Buffer, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	bb := make([]Buffer, 0, len(s.buffers))
+	for _, b := range s.buffers {
+		bb = append(bb, b)
+	}
+	sort.Slice(bb, func(i, j int) bool {
+		if bb[i].SpotId != bb[j].SpotId {
+			return bb[i].SpotId < bb[j].SpotId
+		}
+		return bb[i].Facility < bb[j].Facility
+	})
+	return bb, nil
+}
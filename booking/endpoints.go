@@ -4,20 +4,127 @@ import (
 	"context"
 	"time"
 
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/parking"
 	"github.com/go-kit/kit/endpoint"
 )
 
 type Endpoints struct {
-	GetAllEndpoint  endpoint.Endpoint
-	BookingEndpoint endpoint.Endpoint
-	DeleteEndpoint  endpoint.Endpoint
+	GetAllEndpoint      endpoint.Endpoint
+	BookingEndpoint     endpoint.Endpoint
+	DeleteEndpoint      endpoint.Endpoint
+	FindByPlateEndpoint endpoint.Endpoint
+	CheckInEndpoint     endpoint.Endpoint
+	PassEndpoint        endpoint.Endpoint
+	GateEndpoint        endpoint.Endpoint
+	HoldEndpoint        endpoint.Endpoint
+	ConfirmEndpoint     endpoint.Endpoint
+	ReleaseEndpoint     endpoint.Endpoint
+	BookGroupEndpoint   endpoint.Endpoint
+	FindGroupEndpoint   endpoint.Endpoint
+	CancelGroupEndpoint endpoint.Endpoint
+	BookBestEndpoint    endpoint.Endpoint
+	ListEndpoint        endpoint.Endpoint
+	FindEndpoint        endpoint.Endpoint
+	UpdateEndpoint      endpoint.Endpoint
+	ExtendEndpoint      endpoint.Endpoint
+	ShortenEndpoint     endpoint.Endpoint
+
+	NoShowPoliciesEndpoint  endpoint.Endpoint
+	SetNoShowPolicyEndpoint endpoint.Endpoint
+	NoShowsEndpoint         endpoint.Endpoint
+	BuffersEndpoint         endpoint.Endpoint
+	SetBufferEndpoint       endpoint.Endpoint
 }
 
 func MakeServerEndpoints(s Service) Endpoints {
 	return Endpoints{
-		GetAllEndpoint:  MakeGetAllEndpoint(s),
-		BookingEndpoint: MakeBookingEndpoint(s),
-		DeleteEndpoint:  MakeDeleteEndpoint(s),
+		GetAllEndpoint:      MakeGetAllEndpoint(s),
+		BookingEndpoint:     MakeBookingEndpoint(s),
+		DeleteEndpoint:      MakeDeleteEndpoint(s),
+		FindByPlateEndpoint: MakeFindByPlateEndpoint(s),
+		CheckInEndpoint:     MakeCheckInEndpoint(s),
+		PassEndpoint:        MakePassEndpoint(s),
+		GateEndpoint:        MakeGateEndpoint(s),
+		HoldEndpoint:        MakeHoldEndpoint(s),
+		ConfirmEndpoint:     MakeConfirmEndpoint(s),
+		ReleaseEndpoint:     MakeReleaseEndpoint(s),
+		BookGroupEndpoint:   MakeBookGroupEndpoint(s),
+		FindGroupEndpoint:   MakeFindGroupEndpoint(s),
+		CancelGroupEndpoint: MakeCancelGroupEndpoint(s),
+		BookBestEndpoint:    MakeBookBestEndpoint(s),
+		ListEndpoint:        MakeListEndpoint(s),
+		FindEndpoint:        MakeFindEndpoint(s),
+		UpdateEndpoint:      MakeUpdateEndpoint(s),
+		ExtendEndpoint:      MakeExtendEndpoint(s),
+		ShortenEndpoint:     MakeShortenEndpoint(s),
+
+		NoShowPoliciesEndpoint:  MakeNoShowPoliciesEndpoint(s),
+		SetNoShowPolicyEndpoint: MakeSetNoShowPolicyEndpoint(s),
+		NoShowsEndpoint:         MakeNoShowsEndpoint(s),
+		BuffersEndpoint:         MakeBuffersEndpoint(s),
+		SetBufferEndpoint:       MakeSetBufferEndpoint(s),
+	}
+}
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeAuthorizedEndpoints and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"GetAll":            {Scope: auth.ScopeBookingRead, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin, auth.RoleService}},
+	"Book":              {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"Delete":            {Scope: auth.ScopeBookingWrite, Roles: []auth.Role{auth.RoleDriver, auth.RolePartner, auth.RoleOperator, auth.RoleAdmin}},
+	"FindActiveByPlate": {Scope: auth.ScopeBookingRead, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin, auth.RoleService}},
+	"CheckIn":           {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"Pass":              {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"Gate":              {Scope: auth.ScopeBookingWrite, Roles: []auth.Role{auth.RoleService, auth.RoleOperator, auth.RoleAdmin}},
+	"Hold":              {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"Confirm":           {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"Release":           {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"BookGroup":         {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"FindGroup":         {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"CancelGroup":       {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"BookBest":          {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"List":              {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"Find":              {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"Update":            {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"Extend":            {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"Shorten":           {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"NoShowPolicies":    {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"SetNoShowPolicy":   {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"NoShows":           {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"Buffers":           {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"SetBuffer":         {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+}
+
+// MakeAuthorizedEndpoints returns the server endpoints, each wrapped with the
+// scope and roles allowed to call it. Every transport serves these.
+func MakeAuthorizedEndpoints(s Service, a *auth.Authorizer) Endpoints {
+	e := MakeServerEndpoints(s)
+	return Endpoints{
+		GetAllEndpoint:          a.Enforce(requirements, "GetAll")(e.GetAllEndpoint),
+		BookingEndpoint:         a.Enforce(requirements, "Book")(e.BookingEndpoint),
+		DeleteEndpoint:          a.Enforce(requirements, "Delete")(e.DeleteEndpoint),
+		FindByPlateEndpoint:     a.Enforce(requirements, "FindActiveByPlate")(e.FindByPlateEndpoint),
+		CheckInEndpoint:         a.Enforce(requirements, "CheckIn")(e.CheckInEndpoint),
+		PassEndpoint:            a.Enforce(requirements, "Pass")(e.PassEndpoint),
+		GateEndpoint:            a.Enforce(requirements, "Gate")(e.GateEndpoint),
+		HoldEndpoint:            a.Enforce(requirements, "Hold")(e.HoldEndpoint),
+		ConfirmEndpoint:         a.Enforce(requirements, "Confirm")(e.ConfirmEndpoint),
+		ReleaseEndpoint:         a.Enforce(requirements, "Release")(e.ReleaseEndpoint),
+		BookGroupEndpoint:       a.Enforce(requirements, "BookGroup")(e.BookGroupEndpoint),
+		FindGroupEndpoint:       a.Enforce(requirements, "FindGroup")(e.FindGroupEndpoint),
+		CancelGroupEndpoint:     a.Enforce(requirements, "CancelGroup")(e.CancelGroupEndpoint),
+		BookBestEndpoint:        a.Enforce(requirements, "BookBest")(e.BookBestEndpoint),
+		ListEndpoint:            a.Enforce(requirements, "List")(e.ListEndpoint),
+		FindEndpoint:            a.Enforce(requirements, "Find")(e.FindEndpoint),
+		UpdateEndpoint:          a.Enforce(requirements, "Update")(e.UpdateEndpoint),
+		ExtendEndpoint:          a.Enforce(requirements, "Extend")(e.ExtendEndpoint),
+		ShortenEndpoint:         a.Enforce(requirements, "Shorten")(e.ShortenEndpoint),
+		NoShowPoliciesEndpoint:  a.Enforce(requirements, "NoShowPolicies")(e.NoShowPoliciesEndpoint),
+		SetNoShowPolicyEndpoint: a.Enforce(requirements, "SetNoShowPolicy")(e.SetNoShowPolicyEndpoint),
+		NoShowsEndpoint:         a.Enforce(requirements, "NoShows")(e.NoShowsEndpoint),
+		BuffersEndpoint:         a.Enforce(requirements, "Buffers")(e.BuffersEndpoint),
+		SetBufferEndpoint:       a.Enforce(requirements, "SetBuffer")(e.SetBufferEndpoint),
 	}
 }
 
@@ -31,8 +138,8 @@ func MakeGetAllEndpoint(s Service) endpoint.Endpoint {
 func MakeBookingEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(bookingRequest)
-		// TODO: using a default timeslot of 30 mins. Need to take a param
-		b, e := s.Book(ctx, req.SpotId, time.Now(), time.Duration(30*time.Minute))
+		start, duration := timeslot(req.Start, req.End)
+		b, e := s.Book(ctx, req.SpotId, req.VehicleId, start, duration)
 		return bookingResponse{Booking: b, Err: e}, e
 	}
 }
@@ -45,15 +152,224 @@ func MakeDeleteEndpoint(s Service) endpoint.Endpoint {
 	}
 }
 
+func MakeFindByPlateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(findByPlateRequest)
+		bb, e := s.FindActiveByPlate(ctx, req.Plate)
+		return getAllResponse{Bookings: bb, Err: e}, e
+	}
+}
+
+func MakeCheckInEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(deleteRequest)
+		b, e := s.CheckIn(ctx, req.BookingId)
+		return bookingResponse{Booking: b, Err: e}, e
+	}
+}
+
+func MakePassEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(passRequest)
+		p, e := s.Pass(ctx, req.BookingId)
+		return passResponse{Pass: p, Err: e, png: req.PNG}, e
+	}
+}
+
+func MakeGateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(gateRequest)
+		b, e := s.Gate(ctx, req.Token, req.Facility, req.Direction)
+		return bookingResponse{Booking: b, Err: e}, e
+	}
+}
+
+func MakeHoldEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(holdRequest)
+		start, duration := timeslot(req.Start, req.End)
+		h, e := s.Hold(ctx, req.SpotId, req.VehicleId, start, duration, time.Duration(req.TTL)*time.Second)
+		return holdResponse{Hold: h, Err: e}, e
+	}
+}
+
+func MakeConfirmEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(holdIdRequest)
+		b, e := s.Confirm(ctx, req.HoldId)
+		return bookingResponse{Booking: b, Err: e}, e
+	}
+}
+
+func MakeReleaseEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(holdIdRequest)
+		e := s.Release(ctx, req.HoldId)
+		return deleteResponse{Err: e}, e
+	}
+}
+
+func MakeBookGroupEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(groupRequest)
+		start, duration := timeslot(req.Start, req.End)
+		g, bb, e := s.BookGroup(ctx, GroupSpec{
+			VehicleIds: req.VehicleIds,
+			SpotIds:    req.SpotIds,
+			Lat:        req.Lat,
+			Lon:        req.Lon,
+			Radius:     req.Radius,
+			StartTime:  start,
+			Duration:   duration,
+		})
+		return groupResponse{Group: g, Bookings: bb, Err: e}, e
+	}
+}
+
+func MakeFindGroupEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(groupIdRequest)
+		g, bb, e := s.FindGroup(ctx, req.GroupId)
+		return groupResponse{Group: g, Bookings: bb, Err: e}, e
+	}
+}
+
+func MakeCancelGroupEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(groupIdRequest)
+		e := s.CancelGroup(ctx, req.GroupId)
+		return deleteResponse{Err: e}, e
+	}
+}
+
+func MakeBookBestEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(bestMatchRequest)
+		start, duration := timeslot(req.Start, req.End)
+		b, es, e := s.BookBest(ctx, req.Lat, req.Lon, req.Rad, req.Metric, req.VehicleId, start, duration)
+		return bestMatchResponse{Booking: b, Spot: es, Err: e}, e
+	}
+}
+
+func MakeListEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(listRequest)
+		bb, e := s.List(ctx, req.Query)
+		return getAllResponse{Bookings: bb, Err: e}, e
+	}
+}
+
+func MakeFindEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(deleteRequest)
+		b, e := s.Find(ctx, req.BookingId)
+		return bookingResponse{Booking: b, Err: e}, e
+	}
+}
+
+func MakeUpdateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(updateRequest)
+		b, e := s.Update(ctx, req.BookingId, Change{SpotId: req.SpotId, StartTime: req.Start, EndTime: req.End})
+		return bookingResponse{Booking: b, Err: e}, e
+	}
+}
+
+func MakeExtendEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(resizeRequest)
+		b, d, e := s.Extend(ctx, req.BookingId, time.Duration(req.Minutes)*time.Minute)
+		return resizeResponse{Booking: b, Difference: d, Err: e}, e
+	}
+}
+
+func MakeShortenEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(resizeRequest)
+		b, d, e := s.Shorten(ctx, req.BookingId, time.Duration(req.Minutes)*time.Minute)
+		return resizeResponse{Booking: b, Difference: d, Err: e}, e
+	}
+}
+
+func MakeNoShowPoliciesEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		pp, e := s.NoShowPolicies(ctx)
+		return noShowPoliciesResponse{Policies: pp, Err: e}, e
+	}
+}
+
+func MakeSetNoShowPolicyEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(noShowPolicyRequest)
+		p, e := s.SetNoShowPolicy(ctx, NoShowPolicy{
+			Facility: req.Facility,
+			Grace:    time.Duration(req.GraceMinutes) * time.Minute,
+			Fee:      req.Fee,
+			Strike:   req.Strike,
+		})
+		return noShowPolicyResponse{Policy: p, Err: e}, e
+	}
+}
+
+func MakeNoShowsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(noShowsRequest)
+		nn, e := s.NoShows(ctx, req.User)
+		return noShowsResponse{NoShows: nn, Err: e}, e
+	}
+}
+
+func MakeBuffersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		bb, e := s.Buffers(ctx)
+		return buffersResponse{Buffers: bb, Err: e}, e
+	}
+}
+
+func MakeSetBufferEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(bufferRequest)
+		b, e := s.SetBuffer(ctx, Buffer{
+			SpotId:   req.SpotId,
+			Facility: req.Facility,
+			Before:   time.Duration(req.BeforeMinutes) * time.Minute,
+			After:    time.Duration(req.AfterMinutes) * time.Minute,
+		})
+		return bufferResponse{Buffer: b, Err: e}, e
+	}
+}
+
+// timeslot defaults a window to the next 30 minutes, the timeslot of a
+// booking
+func timeslot(start, end time.Time) (time.Time, time.Duration) {
+	if start.IsZero() {
+		start = time.Now()
+	}
+	if end.IsZero() {
+		end = start.Add(30 * time.Minute)
+	}
+	return start, end.Sub(start)
+}
+
 //
 
 type getAllRequest struct {
 }
 
+type listRequest struct {
+	Query Query
+}
+
+type findByPlateRequest struct {
+	Plate string `json:"plate"`
+}
+
 type bookingRequest struct {
-	SpotId string `json:"id"`
-	//StartTime time.Time     `json:"startTime"`
-	//Duration  time.Duration `json:"duration"`
+	SpotId    string `json:"id"`
+	VehicleId string `json:"vehicleId"`
+	// Start and End default to the next 30 minutes
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
 }
 
 type bookingResponse struct {
@@ -73,9 +389,176 @@ type deleteResponse struct {
 
 func (r deleteResponse) error() error { return r.Err }
 
+// updateRequest moves a booking, the fields left out are kept
+type updateRequest struct {
+	BookingId string    `json:"-"`
+	SpotId    string    `json:"spotId,omitempty"`
+	Start     time.Time `json:"start,omitempty"`
+	End       time.Time `json:"end,omitempty"`
+}
+
+// resizeRequest moves the end of a booking by Minutes
+type resizeRequest struct {
+	BookingId string `json:"-"`
+	Minutes   int    `json:"minutes"`
+}
+
+type resizeResponse struct {
+	Err     error   `json:"err,omitempty"`
+	Booking Booking `json:"booking"`
+	// Difference is the change in price, negative when refunded
+	Difference string `json:"difference"`
+}
+
+func (r resizeResponse) error() error { return r.Err }
+
 type getAllResponse struct {
 	Err      error     `json:"err,omitempty"`
 	Bookings []Booking `json:"bookings"`
 }
 
 func (r getAllResponse) error() error { return r.Err }
+
+type passRequest struct {
+	BookingId string
+	// PNG asks for the pass as a QR code image
+	PNG bool
+}
+
+type passResponse struct {
+	Err  error `json:"err,omitempty"`
+	Pass Pass  `json:"pass"`
+	png  bool
+}
+
+func (r passResponse) error() error { return r.Err }
+
+type gateRequest struct {
+	Token     string    `json:"token"`
+	Facility  string    `json:"facility,omitempty"`
+	Direction Direction `json:"direction"`
+}
+
+type holdRequest struct {
+	SpotId    string `json:"id"`
+	VehicleId string `json:"vehicleId"`
+	// TTL is how many seconds the spot is held, 300 when left out
+	TTL int `json:"ttl,omitempty"`
+	// Start and End default to the next 30 minutes
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+type holdResponse struct {
+	Err  error `json:"err,omitempty"`
+	Hold Hold  `json:"hold"`
+}
+
+func (r holdResponse) error() error { return r.Err }
+
+type holdIdRequest struct {
+	HoldId string `json:"id"`
+}
+
+// groupRequest books a spot for each vehicle, on the spots listed or on the
+// nearest free spots within radius meters of lat, lon
+type groupRequest struct {
+	VehicleIds []string `json:"vehicleIds"`
+	SpotIds    []string `json:"spotIds,omitempty"`
+	Lat        string   `json:"lat,omitempty"`
+	Lon        string   `json:"lon,omitempty"`
+	Radius     string   `json:"radius,omitempty"`
+	// Start and End default to the next 30 minutes
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+type groupResponse struct {
+	Err      error     `json:"err,omitempty"`
+	Group    Group     `json:"group"`
+	Bookings []Booking `json:"bookings"`
+}
+
+func (r groupResponse) error() error { return r.Err }
+
+type groupIdRequest struct {
+	GroupId string `json:"id"`
+}
+
+// bestMatchRequest is a parking search with the vehicle to book the best
+// match for
+type bestMatchRequest struct {
+	Lat       string               `json:"lat"`
+	Lon       string               `json:"lon"`
+	Rad       string               `json:"rad"`
+	Metric    parking.SearchMetric `json:"metric"`
+	VehicleId string               `json:"vehicleId"`
+	// Start and End default to the next 30 minutes
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+type bestMatchResponse struct {
+	Err     error                `json:"err,omitempty"`
+	Booking Booking              `json:"booking"`
+	Spot    parking.ExtendedSpot `json:"spot"`
+}
+
+func (r bestMatchResponse) error() error { return r.Err }
+
+// noShowPolicyRequest sets the no-show policy of Facility, the empty
+// facility being the default
+type noShowPolicyRequest struct {
+	Facility     string `json:"facility,omitempty"`
+	GraceMinutes int    `json:"graceMinutes"`
+	Fee          string `json:"fee,omitempty"`
+	Strike       bool   `json:"strike,omitempty"`
+}
+
+type noShowPolicyResponse struct {
+	Err    error        `json:"err,omitempty"`
+	Policy NoShowPolicy `json:"policy"`
+}
+
+func (r noShowPolicyResponse) error() error { return r.Err }
+
+type noShowPoliciesResponse struct {
+	Err      error          `json:"err,omitempty"`
+	Policies []NoShowPolicy `json:"policies"`
+}
+
+func (r noShowPoliciesResponse) error() error { return r.Err }
+
+type noShowsRequest struct {
+	User string
+}
+
+type noShowsResponse struct {
+	Err     error    `json:"err,omitempty"`
+	NoShows []NoShow `json:"noShows"`
+}
+
+func (r noShowsResponse) error() error { return r.Err }
+
+// bufferRequest sets the buffers of a spot or of the spots of a facility,
+// the empty facility being the default
+type bufferRequest struct {
+	SpotId        int    `json:"spotId,omitempty"`
+	Facility      string `json:"facility,omitempty"`
+	BeforeMinutes int    `json:"beforeMinutes"`
+	AfterMinutes  int    `json:"afterMinutes"`
+}
+
+type bufferResponse struct {
+	Err    error  `json:"err,omitempty"`
+	Buffer Buffer `json:"buffer"`
+}
+
+func (r bufferResponse) error() error { return r.Err }
+
+type buffersResponse struct {
+	Err     error    `json:"err,omitempty"`
+	Buffers []Buffer `json:"buffers"`
+}
+
+func (r buffersResponse) error() error { return r.Err }
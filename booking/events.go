@@ -0,0 +1,79 @@
+package booking
+
+import (
+	"strconv"
+
+	"github.com/atuldaemon/rct/event"
+)
+
+// The typed booking events published on the event bus. Each carries the
+// booking as it is after the change.
+
+type BookingCreated struct {
+	Booking Booking `json:"booking"`
+}
+
+type BookingCancelled struct {
+	Booking Booking `json:"booking"`
+}
+
+type BookingCheckedIn struct {
+	Booking Booking `json:"booking"`
+}
+
+type BookingExpired struct {
+	Booking Booking `json:"booking"`
+}
+
+// BookingChanged carries a booking moved to another time window or spot,
+// with the booking as it was before
+type BookingChanged struct {
+	Booking  Booking `json:"booking"`
+	Previous Booking `json:"previous"`
+}
+
+// BookingNoShow carries a booking that was not checked in within the grace
+// period of its facility, with the fee and strike applied. Its spot is
+// free again.
+type BookingNoShow struct {
+	Booking Booking `json:"booking"`
+	NoShow  NoShow  `json:"noShow"`
+}
+
+// HoldReleased and HoldExpired carry a hold given up before it was
+// confirmed, its spot is free again
+type HoldReleased struct {
+	Hold Hold `json:"hold"`
+}
+
+type HoldExpired struct {
+	Hold Hold `json:"hold"`
+}
+
+func (BookingCreated) EventType() string   { return event.BookingCreated }
+func (BookingCancelled) EventType() string { return event.BookingCancelled }
+func (BookingCheckedIn) EventType() string { return event.BookingCheckedIn }
+func (BookingExpired) EventType() string   { return event.BookingExpired }
+func (BookingChanged) EventType() string   { return event.BookingChanged }
+func (BookingNoShow) EventType() string    { return event.BookingNoShow }
+func (HoldReleased) EventType() string     { return event.HoldReleased }
+func (HoldExpired) EventType() string      { return event.HoldExpired }
+
+func (e BookingCreated) AggregateID() string   { return BookingAggregate(e.Booking.ID) }
+func (e BookingCancelled) AggregateID() string { return BookingAggregate(e.Booking.ID) }
+func (e BookingCheckedIn) AggregateID() string { return BookingAggregate(e.Booking.ID) }
+func (e BookingExpired) AggregateID() string   { return BookingAggregate(e.Booking.ID) }
+func (e BookingChanged) AggregateID() string   { return BookingAggregate(e.Booking.ID) }
+func (e BookingNoShow) AggregateID() string    { return BookingAggregate(e.Booking.ID) }
+func (e HoldReleased) AggregateID() string     { return HoldAggregate(e.Hold.ID) }
+func (e HoldExpired) AggregateID() string      { return HoldAggregate(e.Hold.ID) }
+
+// BookingAggregate is the aggregate ID of the events of booking id
+func BookingAggregate(id int) string {
+	return "booking/" + strconv.Itoa(id)
+}
+
+// HoldAggregate is the aggregate ID of the events of hold id
+func HoldAggregate(id int) string {
+	return "hold/" + strconv.Itoa(id)
+}
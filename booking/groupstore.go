@@ -0,0 +1,79 @@
+package booking
+
+import (
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+// GroupStore keeps the groups of bookings made together
+type GroupStore interface {
+	Create(g Group) (Group, error)
+	Update(g Group) (Group, error)
+	Delete(groupId int) error
+	Find(groupId int) (Group, error)
+}
+
+// Group ties the bookings made at once for an event or a fleet, so they can
+// be cancelled as a unit. Each booking can still be changed on its own.
+type Group struct {
+	ID   int    `json:"id"`
+	User string `json:"user"`
+	// BookingIds are the bookings made for the group, in the order of
+	// its vehicles
+	BookingIds []int     `json:"bookingIds"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+var ErrGroupNotFound = apierror.New(apierror.NotFound, "group_not_found", "no such booking group").
+	WithField("id", "no such booking group")
+
+type InMemGroupStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Group
+	nxtId int
+}
+
+func NewInMemGroupStore() (GroupStore, error) {
+	return &InMemGroupStore{m: make(map[int]Group), nxtId: 1}, nil
+}
+
+func (s *InMemGroupStore) Create(g Group) (Group, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	g.ID = s.nxtId
+	s.nxtId++
+	s.m[g.ID] = g
+	return g, nil
+}
+
+func (s *InMemGroupStore) Update(g Group) (Group, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[g.ID]; !ok {
+		return Group{}, ErrGroupNotFound
+	}
+	s.m[g.ID] = g
+	return g, nil
+}
+
+func (s *InMemGroupStore) Delete(groupId int) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[groupId]; !ok {
+		return ErrGroupNotFound
+	}
+	delete(s.m, groupId)
+	return nil
+}
+
+func (s *InMemGroupStore) Find(groupId int) (Group, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	g, ok := s.m[groupId]
+	if !ok {
+		return Group{}, ErrGroupNotFound
+	}
+	return g, nil
+}
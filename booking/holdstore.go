@@ -0,0 +1,88 @@
+package booking
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+// HoldStore keeps the holds that are neither confirmed nor released yet
+type HoldStore interface {
+	Create(h Hold) (Hold, error)
+	Delete(holdId int) error
+	Find(holdId int) (Hold, error)
+	GetAll() ([]Hold, error)
+}
+
+// Hold reserves a spot for a vehicle until ExpiresAt, while the booking is
+// being paid for. Confirming it turns it into a booking of StartTime and
+// Duration.
+type Hold struct {
+	ID        int           `json:"id"`
+	SpotId    int           `json:"spotId"`
+	VehicleId int           `json:"vehicleId"`
+	User      string        `json:"user,omitempty"`
+	StartTime time.Time     `json:"startTime"`
+	Duration  time.Duration `json:"duration"`
+	ExpiresAt time.Time     `json:"expiresAt"`
+	// Price is quoted when the spot is held and kept by the booking
+	Price string `json:"price,omitempty"`
+	// BufferBefore and BufferAfter are those of the spot when it was held
+	BufferBefore time.Duration `json:"bufferBefore,omitempty"`
+	BufferAfter  time.Duration `json:"bufferAfter,omitempty"`
+}
+
+var ErrHoldNotFound = apierror.New(apierror.NotFound, "hold_not_found", "no such hold").
+	WithField("id", "no such hold")
+
+type InMemHoldStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Hold
+	nxtId int
+}
+
+func NewInMemHoldStore() (HoldStore, error) {
+	return &InMemHoldStore{m: make(map[int]Hold), nxtId: 1}, nil
+}
+
+func (s *InMemHoldStore) Create(h Hold) (Hold, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	h.ID = s.nxtId
+	s.nxtId++
+	s.m[h.ID] = h
+	return h, nil
+}
+
+func (s *InMemHoldStore) Delete(holdId int) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[holdId]; !ok {
+		return ErrHoldNotFound
+	}
+	delete(s.m, holdId)
+	return nil
+}
+
+func (s *InMemHoldStore) Find(holdId int) (Hold, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	h, ok := s.m[holdId]
+	if !ok {
+		return Hold{}, ErrHoldNotFound
+	}
+	return h, nil
+}
+
+func (s *InMemHoldStore) GetAll() ([]Hold, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	hh := make([]Hold, 0, len(s.m))
+	for _, h := range s.m {
+		hh = append(hh, h)
+	}
+	sort.Slice(hh, func(i, j int) bool { return hh[i].ID < hh[j].ID })
+	return hh, nil
+}
@@ -31,13 +31,13 @@ func (s *instrumentingService) GetAll(ctx context.Context) (b []Booking, err err
 	return s.Service.GetAll(ctx)
 }
 
-func (s *instrumentingService) Book(ctx context.Context, spotId string, startTime time.Time, duration time.Duration) (Booking, error) {
+func (s *instrumentingService) Book(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (Booking, error) {
 	defer func(begin time.Time) {
 		s.requestCount.With("method", "Book").Add(1)
 		s.requestLatency.With("method", "Book").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return s.Service.Book(ctx, spotId, startTime, duration)
+	return s.Service.Book(ctx, spotId, vehicleId, startTime, duration)
 }
 
 func (s *instrumentingService) Delete(ctx context.Context, bookingId string) error {
@@ -48,3 +48,12 @@ func (s *instrumentingService) Delete(ctx context.Context, bookingId string) err
 
 	return s.Service.Delete(ctx, bookingId)
 }
+
+func (s *instrumentingService) FindActiveByPlate(ctx context.Context, plate string) ([]Booking, error) {
+	defer func(begin time.Time) {
+		s.requestCount.With("method", "FindActiveByPlate").Add(1)
+		s.requestLatency.With("method", "FindActiveByPlate").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return s.Service.FindActiveByPlate(ctx, plate)
+}
@@ -2,8 +2,10 @@ package booking
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/atuldaemon/rct/parking"
 	"github.com/go-kit/kit/log"
 )
 
@@ -30,11 +32,46 @@ func (mw loggingMiddleware) GetAll(ctx context.Context) (b []Booking, err error)
 	return mw.next.GetAll(ctx)
 }
 
-func (mw loggingMiddleware) Book(ctx context.Context, spotId string, startTime time.Time, duration time.Duration) (b Booking, err error) {
+func (mw loggingMiddleware) List(ctx context.Context, q Query) (b []Booking, err error) {
 	defer func(begin time.Time) {
-		mw.logger.Log("method", "Book", "spotId", spotId, "startTime", startTime, "duration", duration, "took", time.Since(begin), "err", err)
+		mw.logger.Log("method", "List", "spotId", q.SpotId, "user", q.User, "status", q.Status, "sort", q.Sort, "limit", q.Limit, "n", len(b), "took", time.Since(begin), "err", err)
 	}(time.Now())
-	return mw.next.Book(ctx, spotId, startTime, duration)
+	return mw.next.List(ctx, q)
+}
+
+func (mw loggingMiddleware) Find(ctx context.Context, bookingId string) (b Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "bookingId", bookingId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Find(ctx, bookingId)
+}
+
+func (mw loggingMiddleware) Update(ctx context.Context, bookingId string, c Change) (b Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Update", "bookingId", bookingId, "spotId", c.SpotId, "startTime", c.StartTime, "endTime", c.EndTime, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Update(ctx, bookingId, c)
+}
+
+func (mw loggingMiddleware) Book(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (b Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Book", "spotId", spotId, "vehicleId", vehicleId, "startTime", startTime, "duration", duration, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Book(ctx, spotId, vehicleId, startTime, duration)
+}
+
+func (mw loggingMiddleware) BookPrepaid(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (b Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "BookPrepaid", "spotId", spotId, "vehicleId", vehicleId, "startTime", startTime, "duration", duration, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.BookPrepaid(ctx, spotId, vehicleId, startTime, duration)
+}
+
+func (mw loggingMiddleware) BookBest(ctx context.Context, lat, lon, radius string, metric parking.SearchMetric, vehicleId string, startTime time.Time, duration time.Duration) (b Booking, es parking.ExtendedSpot, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "BookBest", "lat", lat, "lon", lon, "radius", radius, "metric", metric, "vehicleId", vehicleId, "spotId", es.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.BookBest(ctx, lat, lon, radius, metric, vehicleId, startTime, duration)
 }
 
 func (mw loggingMiddleware) Delete(ctx context.Context, bookingId string) (err error) {
@@ -43,3 +80,129 @@ func (mw loggingMiddleware) Delete(ctx context.Context, bookingId string) (err e
 	}(time.Now())
 	return mw.next.Delete(ctx, bookingId)
 }
+
+func (mw loggingMiddleware) FindActiveByPlate(ctx context.Context, plate string) (b []Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "FindActiveByPlate", "plate", plate, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.FindActiveByPlate(ctx, plate)
+}
+
+func (mw loggingMiddleware) CheckIn(ctx context.Context, bookingId string) (b Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "CheckIn", "bookingId", bookingId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.CheckIn(ctx, bookingId)
+}
+
+func (mw loggingMiddleware) Expire(ctx context.Context) (bb []Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Expire", "expired", len(bb), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Expire(ctx)
+}
+
+func (mw loggingMiddleware) Extend(ctx context.Context, bookingId string, d time.Duration) (b Booking, difference string, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Extend", "bookingId", bookingId, "by", d, "difference", difference, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Extend(ctx, bookingId, d)
+}
+
+func (mw loggingMiddleware) Shorten(ctx context.Context, bookingId string, d time.Duration) (b Booking, difference string, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Shorten", "bookingId", bookingId, "by", d, "difference", difference, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Shorten(ctx, bookingId, d)
+}
+
+func (mw loggingMiddleware) BookGroup(ctx context.Context, spec GroupSpec) (g Group, bb []Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "BookGroup", "vehicles", len(spec.VehicleIds), "spotIds", strings.Join(spec.SpotIds, ","), "lat", spec.Lat, "lon", spec.Lon, "radius", spec.Radius, "groupId", g.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.BookGroup(ctx, spec)
+}
+
+func (mw loggingMiddleware) FindGroup(ctx context.Context, groupId string) (g Group, bb []Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "FindGroup", "groupId", groupId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.FindGroup(ctx, groupId)
+}
+
+func (mw loggingMiddleware) CancelGroup(ctx context.Context, groupId string) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "CancelGroup", "groupId", groupId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.CancelGroup(ctx, groupId)
+}
+
+func (mw loggingMiddleware) Pass(ctx context.Context, bookingId string) (p Pass, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Pass", "bookingId", bookingId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Pass(ctx, bookingId)
+}
+
+func (mw loggingMiddleware) Gate(ctx context.Context, token, facility string, d Direction) (b Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Gate", "facility", facility, "direction", d, "bookingId", b.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Gate(ctx, token, facility, d)
+}
+
+func (mw loggingMiddleware) Hold(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration, ttl time.Duration) (h Hold, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Hold", "spotId", spotId, "vehicleId", vehicleId, "ttl", ttl, "holdId", h.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Hold(ctx, spotId, vehicleId, startTime, duration, ttl)
+}
+
+func (mw loggingMiddleware) Confirm(ctx context.Context, holdId string) (b Booking, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Confirm", "holdId", holdId, "bookingId", b.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Confirm(ctx, holdId)
+}
+
+func (mw loggingMiddleware) Release(ctx context.Context, holdId string) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Release", "holdId", holdId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Release(ctx, holdId)
+}
+
+func (mw loggingMiddleware) SetNoShowPolicy(ctx context.Context, p NoShowPolicy) (_ NoShowPolicy, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "SetNoShowPolicy", "facility", p.Facility, "grace", p.Grace, "fee", p.Fee, "strike", p.Strike, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.SetNoShowPolicy(ctx, p)
+}
+
+func (mw loggingMiddleware) NoShowPolicies(ctx context.Context) (pp []NoShowPolicy, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "NoShowPolicies", "policies", len(pp), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.NoShowPolicies(ctx)
+}
+
+func (mw loggingMiddleware) NoShows(ctx context.Context, user string) (nn []NoShow, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "NoShows", "user", user, "noShows", len(nn), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.NoShows(ctx, user)
+}
+
+func (mw loggingMiddleware) SetBuffer(ctx context.Context, b Buffer) (_ Buffer, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "SetBuffer", "spotId", b.SpotId, "facility", b.Facility, "before", b.Before, "after", b.After, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.SetBuffer(ctx, b)
+}
+
+func (mw loggingMiddleware) Buffers(ctx context.Context) (bb []Buffer, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Buffers", "buffers", len(bb), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Buffers(ctx)
+}
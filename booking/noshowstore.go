@@ -0,0 +1,105 @@
+package booking
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// NoShowStore keeps the no-show policies of the facilities and the
+// no-shows recorded under them
+type NoShowStore interface {
+	// SetPolicy adds or replaces the policy of its facility
+	SetPolicy(p NoShowPolicy) (NoShowPolicy, error)
+	// Policy returns the policy of facility, falling back to the policy of
+	// the empty facility. The zero policy turns no-show detection off.
+	Policy(facility string) (NoShowPolicy, error)
+	Policies() ([]NoShowPolicy, error)
+	Record(n NoShow) (NoShow, error)
+	// NoShows returns the no-shows of user, of everyone when empty, in the
+	// order they were recorded
+	NoShows(user string) ([]NoShow, error)
+}
+
+// NoShowPolicy is how long a booking on a spot of Facility may go without
+// checking in, and what a no-show costs. The policy of the empty facility
+// applies to street parking and to facilities without a policy of their
+// own.
+type NoShowPolicy struct {
+	Facility string `json:"facility"`
+	// Grace is counted from the start of the booking, zero turns no-show
+	// detection off
+	Grace time.Duration `json:"grace"`
+	// Fee is charged for a no-show, in the units of parking.Spot.Cost
+	Fee string `json:"fee,omitempty"`
+	// Strike counts a no-show against the user
+	Strike bool `json:"strike,omitempty"`
+}
+
+// NoShow records a booking that was not checked in within the grace period
+// of its facility, with the fee and strike applied
+type NoShow struct {
+	BookingId int       `json:"bookingId"`
+	User      string    `json:"user"`
+	SpotId    int       `json:"spotId"`
+	Facility  string    `json:"facility,omitempty"`
+	Fee       string    `json:"fee,omitempty"`
+	Strike    bool      `json:"strike,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+type InMemNoShowStore struct {
+	mtx      sync.RWMutex
+	policies map[string]NoShowPolicy
+	noShows  []NoShow
+}
+
+func NewInMemNoShowStore() (NoShowStore, error) {
+	return &InMemNoShowStore{policies: make(map[string]NoShowPolicy)}, nil
+}
+
+func (s *InMemNoShowStore) SetPolicy(p NoShowPolicy) (NoShowPolicy, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.policies[p.Facility] = p
+	return p, nil
+}
+
+func (s *InMemNoShowStore) Policy(facility string) (NoShowPolicy, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if p, ok := s.policies[facility]; ok {
+		return p, nil
+	}
+	return s.policies[""], nil
+}
+
+func (s *InMemNoShowStore) Policies() ([]NoShowPolicy, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	pp := make([]NoShowPolicy, 0, len(s.policies))
+	for _, p := range s.policies {
+		pp = append(pp, p)
+	}
+	sort.Slice(pp, func(i, j int) bool { return pp[i].Facility < pp[j].Facility })
+	return pp, nil
+}
+
+func (s *InMemNoShowStore) Record(n NoShow) (NoShow, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.noShows = append(s.noShows, n)
+	return n, nil
+}
+
+func (s *InMemNoShowStore) NoShows(user string) ([]NoShow, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	nn := make([]NoShow, 0)
+	for _, n := range s.noShows {
+		if user == "" || n.User == user {
+			nn = append(nn, n)
+		}
+	}
+	return nn, nil
+}
@@ -0,0 +1,137 @@
+package booking
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(Status(""), StatusBooked, StatusCheckedIn, StatusExpired, StatusNoShow)
+	d.Enum(Direction(""), Entry, Exit)
+
+	d.Operation("GET", "/booking/v1/", "listBookings", "List the bookings matching the filters, drivers only see their own").
+		Tag("booking").Require(requirements["List"]).
+		QueryParam("spotId", "Only the bookings of this spot").
+		QueryParam("user", "Only the bookings of this user, drivers may only ask for themselves").
+		QueryParam("status", "Only the bookings with this status").
+		QueryParam("from", "RFC 3339 time, only the bookings ending after it").
+		QueryParam("to", "RFC 3339 time, only the bookings starting before it").
+		QueryParam("sort", "id, start or end, prefixed with - to sort descending, id when left out").
+		QueryParam("limit", "The most bookings returned, all when left out").
+		Returns(http.StatusOK, "The bookings", getAllResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/booking/v1/{id}", "findBooking", "Get a booking").
+		Tag("booking").Require(requirements["Find"]).
+		PathParam("id", "Booking id").
+		Returns(http.StatusOK, "The booking", bookingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PATCH", "/booking/v1/{id}", "updateBooking", "Move a booking that has not ended to another time window or spot").
+		Tag("booking").Require(requirements["Update"]).
+		PathParam("id", "Booking id").
+		Body(updateRequest{}).
+		Returns(http.StatusOK, "The changed booking", bookingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/", "book", "Book a spot for a vehicle for a time window, the next 30 minutes when left out").
+		Tag("booking").Require(requirements["Book"]).
+		Body(bookingRequest{}).
+		Returns(http.StatusOK, "The new booking", bookingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/booking/v1/{id}", "cancelBooking", "Cancel a booking and release its spot").
+		Tag("booking").Require(requirements["Delete"]).
+		PathParam("id", "Booking id").
+		Returns(http.StatusOK, "Empty object", deleteResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/booking/v1/plate/{plate}", "findActiveBookingsByPlate", "List the bookings active now for a license plate").
+		Tag("booking").Require(requirements["FindActiveByPlate"]).
+		PathParam("plate", "License plate, spacing and case are ignored").
+		Returns(http.StatusOK, "Active bookings", getAllResponse{}).
+		Fails(http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/booking/v1/noshows", "listNoShows", "List the no-shows recorded, drivers only see their own").
+		Tag("booking").Require(requirements["NoShows"]).
+		QueryParam("user", "Only the no-shows of this user, drivers may only ask for themselves").
+		Returns(http.StatusOK, "The no-shows in the order they were recorded", noShowsResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/booking/v1/noshows/policies", "listNoShowPolicies", "List the no-show grace periods and penalties of the facilities").
+		Tag("booking").Require(requirements["NoShowPolicies"]).
+		Returns(http.StatusOK, "The policies, the empty facility is the default", noShowPoliciesResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PUT", "/booking/v1/noshows/policies", "setNoShowPolicy", "Set how long bookings of a facility may go without checking in before their spot is released, and the fee or strike of a no-show").
+		Tag("booking").Require(requirements["SetNoShowPolicy"]).
+		Body(noShowPolicyRequest{}).
+		Returns(http.StatusOK, "The policy", noShowPolicyResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/booking/v1/buffers", "listBuffers", "List the turnover buffers kept free before and after the bookings of spots and facilities").
+		Tag("booking").Require(requirements["Buffers"]).
+		Returns(http.StatusOK, "The buffers, the empty facility is the default", buffersResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PUT", "/booking/v1/buffers", "setBuffer", "Set the buffers of a spot or of the spots of a facility, bookings made before keep theirs").
+		Tag("booking").Require(requirements["SetBuffer"]).
+		Body(bufferRequest{}).
+		Returns(http.StatusOK, "The buffer", bufferResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/{id}/checkin", "checkIn", "Record the arrival of the vehicle during the booked window").
+		Tag("booking").Require(requirements["CheckIn"]).
+		PathParam("id", "Booking id").
+		Returns(http.StatusOK, "The checked in booking", bookingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/{id}/extend", "extendBooking", "Add minutes to a booking that has not ended, unless another booking of the spot starts before").
+		Tag("booking").Require(requirements["Extend"]).
+		PathParam("id", "Booking id").
+		Body(resizeRequest{}).
+		Returns(http.StatusOK, "The booking and the price of the minutes added", resizeResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/{id}/shorten", "shortenBooking", "Take minutes off the end of a booking that has not ended").
+		Tag("booking").Require(requirements["Shorten"]).
+		PathParam("id", "Booking id").
+		Body(resizeRequest{}).
+		Returns(http.StatusOK, "The booking and the refund as a negative difference", resizeResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/booking/v1/{id}/pass", "getPass", "Get the signed gate pass of a booking, as JSON or as a QR code with Accept: image/png").
+		Tag("booking").Require(requirements["Pass"]).
+		PathParam("id", "Booking id").
+		Returns(http.StatusOK, "The pass, valid from 15 minutes before the booking until 15 minutes after", passResponse{}).
+		ReturnsAs(http.StatusOK, "The pass token as a QR code", PNGContentType, nil).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/gate", "validateGatePass", "Validate a pass at a gate and record the entry or exit, entering checks the booking in").
+		Tag("booking").Require(requirements["Gate"]).
+		Body(gateRequest{}).
+		Returns(http.StatusOK, "The booking with the entry or exit recorded", bookingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/holds", "holdSpot", "Hold a spot for a vehicle while the booking of a time window, the next 30 minutes when left out, is paid for").
+		Tag("booking").Require(requirements["Hold"]).
+		Body(holdRequest{}).
+		Returns(http.StatusOK, "The hold and when it expires", holdResponse{}).
+		Fails(http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/holds/{id}/confirm", "confirmHold", "Turn a hold into a booking before it expires").
+		Tag("booking").Require(requirements["Confirm"]).
+		PathParam("id", "Hold id").
+		Returns(http.StatusOK, "The new booking", bookingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/booking/v1/holds/{id}", "releaseHold", "Give up a hold and free its spot").
+		Tag("booking").Require(requirements["Release"]).
+		PathParam("id", "Hold id").
+		Returns(http.StatusOK, "Empty object", deleteResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/best", "bookBestMatch", "Search like /parking/v1/search/ and book the highest ranked spot that is free and fits the vehicle").
+		Tag("booking").Require(requirements["BookBest"]).
+		Body(bestMatchRequest{}).
+		Returns(http.StatusOK, "The booking and the spot chosen", bestMatchResponse{}).
+		Fails(http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/booking/v1/groups", "bookGroup", "Book a spot for each vehicle, on the spots listed or the nearest free ones, all or nothing").
+		Tag("booking").Require(requirements["BookGroup"]).
+		Body(groupRequest{}).
+		Returns(http.StatusOK, "The group and its bookings", groupResponse{}).
+		Fails(http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/booking/v1/groups/{id}", "findGroup", "Get a group with the bookings left of it").
+		Tag("booking").Require(requirements["FindGroup"]).
+		PathParam("id", "Group id").
+		Returns(http.StatusOK, "The group and its bookings", groupResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/booking/v1/groups/{id}", "cancelGroup", "Cancel the bookings left of a group and release their spots").
+		Tag("booking").Require(requirements["CancelGroup"]).
+		PathParam("id", "Group id").
+		Returns(http.StatusOK, "Empty object", deleteResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
@@ -0,0 +1,131 @@
+package booking
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+// Gate passes. A pass is a signed token naming the booking, its spot and
+// facility and when it opens the gate, so it can be shown as a QR code and
+// checked at the barrier.
+
+const (
+	// PassEarly is how long before the booking starts the pass opens the
+	// entry gate
+	PassEarly = 15 * time.Minute
+	// PassLate is how long after the booking ends the vehicle may still
+	// leave
+	PassLate = 15 * time.Minute
+)
+
+type Direction string
+
+const (
+	Entry Direction = "entry"
+	Exit  Direction = "exit"
+)
+
+var (
+	ErrInvalidPass   = apierror.New(apierror.Forbidden, "pass_invalid", "the pass is not valid")
+	ErrPassNotNow    = apierror.New(apierror.Forbidden, "pass_outside_window", "the pass is not valid at this time")
+	ErrWrongFacility = apierror.New(apierror.Forbidden, "pass_wrong_facility", "the pass is for another facility")
+	ErrPassUsed      = apierror.New(apierror.Conflict, "pass_already_used", "the pass was already used in this direction")
+	ErrNotEntered    = apierror.New(apierror.Conflict, "pass_not_entered", "the vehicle did not enter with this pass")
+	ErrNoPasses      = apierror.New(apierror.Unavailable, "passes_disabled", "gate passes are not issued by this service")
+)
+
+type Pass struct {
+	BookingId int       `json:"bookingId"`
+	SpotId    int       `json:"spotId"`
+	Facility  string    `json:"facility,omitempty"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+	Token     string    `json:"token"`
+}
+
+// passClaims is the signed part of the token, kept short so the QR code
+// stays small
+type passClaims struct {
+	BookingId int    `json:"b"`
+	SpotId    int    `json:"s"`
+	Facility  string `json:"f,omitempty"`
+	NotBefore int64  `json:"nbf"`
+	NotAfter  int64  `json:"exp"`
+}
+
+// Passes issues and verifies gate passes signed with a secret
+type Passes struct {
+	secret []byte
+}
+
+// NewPasses returns passes signed with secret. An empty secret is replaced
+// by a random one, the passes issued then stop working on restart.
+func NewPasses(secret []byte) (*Passes, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+	}
+	return &Passes{secret: secret}, nil
+}
+
+// Issue returns the pass of b, valid from PassEarly before it starts until
+// PassLate after it ends
+func (p *Passes) Issue(b Booking, facility string) Pass {
+	c := passClaims{
+		BookingId: b.ID,
+		SpotId:    b.SpotId,
+		Facility:  facility,
+		NotBefore: b.StartTime.Add(-PassEarly).Unix(),
+		NotAfter:  b.EndTime().Add(PassLate).Unix(),
+	}
+	body, _ := json.Marshal(c)
+	unsigned := base64.RawURLEncoding.EncodeToString(body)
+	return c.pass(unsigned + "." + base64.RawURLEncoding.EncodeToString(p.sign(unsigned)))
+}
+
+// Verify checks the signature of token, not its time window
+func (p *Passes) Verify(token string) (Pass, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return Pass{}, ErrInvalidPass
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sig, p.sign(parts[0])) {
+		return Pass{}, ErrInvalidPass
+	}
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Pass{}, ErrInvalidPass
+	}
+	var c passClaims
+	if err := json.Unmarshal(body, &c); err != nil || c.BookingId == 0 {
+		return Pass{}, ErrInvalidPass
+	}
+	return c.pass(token), nil
+}
+
+func (c passClaims) pass(token string) Pass {
+	return Pass{
+		BookingId: c.BookingId,
+		SpotId:    c.SpotId,
+		Facility:  c.Facility,
+		NotBefore: time.Unix(c.NotBefore, 0).UTC(),
+		NotAfter:  time.Unix(c.NotAfter, 0).UTC(),
+		Token:     token,
+	}
+}
+
+func (p *Passes) sign(s string) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(s))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,3133 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: booking.proto
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import pb "github.com/atuldaemon/rct/parking/pb"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Booking struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	SpotId               int64    `protobuf:"varint,2,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	VehicleId            int64    `protobuf:"varint,3,opt,name=vehicle_id,json=vehicleId" json:"vehicle_id,omitempty"`
+	StartTime            int64    `protobuf:"varint,4,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	Duration             int64    `protobuf:"varint,5,opt,name=duration" json:"duration,omitempty"`
+	User                 string   `protobuf:"bytes,6,opt,name=user" json:"user,omitempty"`
+	Status               string   `protobuf:"bytes,7,opt,name=status" json:"status,omitempty"`
+	Price                string   `protobuf:"bytes,8,opt,name=price" json:"price,omitempty"`
+	Prepaid              bool     `protobuf:"varint,9,opt,name=prepaid" json:"prepaid,omitempty"`
+	CheckedInAt          int64    `protobuf:"varint,10,opt,name=checked_in_at,json=checkedInAt" json:"checked_in_at,omitempty"`
+	EnteredAt            int64    `protobuf:"varint,11,opt,name=entered_at,json=enteredAt" json:"entered_at,omitempty"`
+	ExitedAt             int64    `protobuf:"varint,12,opt,name=exited_at,json=exitedAt" json:"exited_at,omitempty"`
+	GroupId              int64    `protobuf:"varint,13,opt,name=group_id,json=groupId" json:"group_id,omitempty"`
+	BufferBefore         int64    `protobuf:"varint,14,opt,name=buffer_before,json=bufferBefore" json:"buffer_before,omitempty"`
+	BufferAfter          int64    `protobuf:"varint,15,opt,name=buffer_after,json=bufferAfter" json:"buffer_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Booking) Reset()         { *m = Booking{} }
+func (m *Booking) String() string { return proto.CompactTextString(m) }
+func (*Booking) ProtoMessage()    {}
+func (*Booking) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{0}
+}
+func (m *Booking) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Booking.Unmarshal(m, b)
+}
+func (m *Booking) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Booking.Marshal(b, m, deterministic)
+}
+func (dst *Booking) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Booking.Merge(dst, src)
+}
+func (m *Booking) XXX_Size() int {
+	return xxx_messageInfo_Booking.Size(m)
+}
+func (m *Booking) XXX_DiscardUnknown() {
+	xxx_messageInfo_Booking.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Booking proto.InternalMessageInfo
+
+func (m *Booking) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Booking) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *Booking) GetVehicleId() int64 {
+	if m != nil {
+		return m.VehicleId
+	}
+	return 0
+}
+
+func (m *Booking) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Booking) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *Booking) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Booking) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Booking) GetPrice() string {
+	if m != nil {
+		return m.Price
+	}
+	return ""
+}
+
+func (m *Booking) GetPrepaid() bool {
+	if m != nil {
+		return m.Prepaid
+	}
+	return false
+}
+
+func (m *Booking) GetCheckedInAt() int64 {
+	if m != nil {
+		return m.CheckedInAt
+	}
+	return 0
+}
+
+func (m *Booking) GetEnteredAt() int64 {
+	if m != nil {
+		return m.EnteredAt
+	}
+	return 0
+}
+
+func (m *Booking) GetExitedAt() int64 {
+	if m != nil {
+		return m.ExitedAt
+	}
+	return 0
+}
+
+func (m *Booking) GetGroupId() int64 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *Booking) GetBufferBefore() int64 {
+	if m != nil {
+		return m.BufferBefore
+	}
+	return 0
+}
+
+func (m *Booking) GetBufferAfter() int64 {
+	if m != nil {
+		return m.BufferAfter
+	}
+	return 0
+}
+
+type GetAllRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAllRequest) Reset()         { *m = GetAllRequest{} }
+func (m *GetAllRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAllRequest) ProtoMessage()    {}
+func (*GetAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{1}
+}
+func (m *GetAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAllRequest.Unmarshal(m, b)
+}
+func (m *GetAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAllRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAllRequest.Merge(dst, src)
+}
+func (m *GetAllRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAllRequest.Size(m)
+}
+func (m *GetAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAllRequest proto.InternalMessageInfo
+
+type BookingsReply struct {
+	Bookings             []*Booking `protobuf:"bytes,1,rep,name=bookings" json:"bookings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *BookingsReply) Reset()         { *m = BookingsReply{} }
+func (m *BookingsReply) String() string { return proto.CompactTextString(m) }
+func (*BookingsReply) ProtoMessage()    {}
+func (*BookingsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{2}
+}
+func (m *BookingsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookingsReply.Unmarshal(m, b)
+}
+func (m *BookingsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookingsReply.Marshal(b, m, deterministic)
+}
+func (dst *BookingsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookingsReply.Merge(dst, src)
+}
+func (m *BookingsReply) XXX_Size() int {
+	return xxx_messageInfo_BookingsReply.Size(m)
+}
+func (m *BookingsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookingsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookingsReply proto.InternalMessageInfo
+
+func (m *BookingsReply) GetBookings() []*Booking {
+	if m != nil {
+		return m.Bookings
+	}
+	return nil
+}
+
+type ListRequest struct {
+	SpotId int64  `protobuf:"varint,1,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	User   string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	Status string `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	From   int64  `protobuf:"varint,4,opt,name=from" json:"from,omitempty"`
+	To     int64  `protobuf:"varint,5,opt,name=to" json:"to,omitempty"`
+	// id, start or end, prefixed with - to sort descending
+	Sort                 string   `protobuf:"bytes,6,opt,name=sort" json:"sort,omitempty"`
+	Limit                int32    `protobuf:"varint,7,opt,name=limit" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{3}
+}
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(dst, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *ListRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ListRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *ListRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *ListRequest) GetSort() string {
+	if m != nil {
+		return m.Sort
+	}
+	return ""
+}
+
+func (m *ListRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type BookingIdRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookingIdRequest) Reset()         { *m = BookingIdRequest{} }
+func (m *BookingIdRequest) String() string { return proto.CompactTextString(m) }
+func (*BookingIdRequest) ProtoMessage()    {}
+func (*BookingIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{4}
+}
+func (m *BookingIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookingIdRequest.Unmarshal(m, b)
+}
+func (m *BookingIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookingIdRequest.Marshal(b, m, deterministic)
+}
+func (dst *BookingIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookingIdRequest.Merge(dst, src)
+}
+func (m *BookingIdRequest) XXX_Size() int {
+	return xxx_messageInfo_BookingIdRequest.Size(m)
+}
+func (m *BookingIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookingIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookingIdRequest proto.InternalMessageInfo
+
+func (m *BookingIdRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// UpdateRequest moves a booking, the fields left out are kept
+type UpdateRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	SpotId               string   `protobuf:"bytes,2,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	Start                int64    `protobuf:"varint,3,opt,name=start" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,4,opt,name=end" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{5}
+}
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(dst, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateRequest) GetSpotId() string {
+	if m != nil {
+		return m.SpotId
+	}
+	return ""
+}
+
+func (m *UpdateRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *UpdateRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type BookRequest struct {
+	SpotId               string   `protobuf:"bytes,1,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	VehicleId            string   `protobuf:"bytes,2,opt,name=vehicle_id,json=vehicleId" json:"vehicle_id,omitempty"`
+	Start                int64    `protobuf:"varint,3,opt,name=start" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,4,opt,name=end" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookRequest) Reset()         { *m = BookRequest{} }
+func (m *BookRequest) String() string { return proto.CompactTextString(m) }
+func (*BookRequest) ProtoMessage()    {}
+func (*BookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{6}
+}
+func (m *BookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookRequest.Unmarshal(m, b)
+}
+func (m *BookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookRequest.Marshal(b, m, deterministic)
+}
+func (dst *BookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookRequest.Merge(dst, src)
+}
+func (m *BookRequest) XXX_Size() int {
+	return xxx_messageInfo_BookRequest.Size(m)
+}
+func (m *BookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookRequest proto.InternalMessageInfo
+
+func (m *BookRequest) GetSpotId() string {
+	if m != nil {
+		return m.SpotId
+	}
+	return ""
+}
+
+func (m *BookRequest) GetVehicleId() string {
+	if m != nil {
+		return m.VehicleId
+	}
+	return ""
+}
+
+func (m *BookRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *BookRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type BookReply struct {
+	Booking              *Booking `protobuf:"bytes,1,opt,name=booking" json:"booking,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookReply) Reset()         { *m = BookReply{} }
+func (m *BookReply) String() string { return proto.CompactTextString(m) }
+func (*BookReply) ProtoMessage()    {}
+func (*BookReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{7}
+}
+func (m *BookReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookReply.Unmarshal(m, b)
+}
+func (m *BookReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookReply.Marshal(b, m, deterministic)
+}
+func (dst *BookReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookReply.Merge(dst, src)
+}
+func (m *BookReply) XXX_Size() int {
+	return xxx_messageInfo_BookReply.Size(m)
+}
+func (m *BookReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookReply proto.InternalMessageInfo
+
+func (m *BookReply) GetBooking() *Booking {
+	if m != nil {
+		return m.Booking
+	}
+	return nil
+}
+
+type BookBestRequest struct {
+	Lat                  string   `protobuf:"bytes,1,opt,name=lat" json:"lat,omitempty"`
+	Lon                  string   `protobuf:"bytes,2,opt,name=lon" json:"lon,omitempty"`
+	Rad                  string   `protobuf:"bytes,3,opt,name=rad" json:"rad,omitempty"`
+	Metric               string   `protobuf:"bytes,4,opt,name=metric" json:"metric,omitempty"`
+	VehicleId            string   `protobuf:"bytes,5,opt,name=vehicle_id,json=vehicleId" json:"vehicle_id,omitempty"`
+	Start                int64    `protobuf:"varint,6,opt,name=start" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,7,opt,name=end" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookBestRequest) Reset()         { *m = BookBestRequest{} }
+func (m *BookBestRequest) String() string { return proto.CompactTextString(m) }
+func (*BookBestRequest) ProtoMessage()    {}
+func (*BookBestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{8}
+}
+func (m *BookBestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookBestRequest.Unmarshal(m, b)
+}
+func (m *BookBestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookBestRequest.Marshal(b, m, deterministic)
+}
+func (dst *BookBestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookBestRequest.Merge(dst, src)
+}
+func (m *BookBestRequest) XXX_Size() int {
+	return xxx_messageInfo_BookBestRequest.Size(m)
+}
+func (m *BookBestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookBestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookBestRequest proto.InternalMessageInfo
+
+func (m *BookBestRequest) GetLat() string {
+	if m != nil {
+		return m.Lat
+	}
+	return ""
+}
+
+func (m *BookBestRequest) GetLon() string {
+	if m != nil {
+		return m.Lon
+	}
+	return ""
+}
+
+func (m *BookBestRequest) GetRad() string {
+	if m != nil {
+		return m.Rad
+	}
+	return ""
+}
+
+func (m *BookBestRequest) GetMetric() string {
+	if m != nil {
+		return m.Metric
+	}
+	return ""
+}
+
+func (m *BookBestRequest) GetVehicleId() string {
+	if m != nil {
+		return m.VehicleId
+	}
+	return ""
+}
+
+func (m *BookBestRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *BookBestRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type BookBestReply struct {
+	Booking              *Booking         `protobuf:"bytes,1,opt,name=booking" json:"booking,omitempty"`
+	Spot                 *pb.ExtendedSpot `protobuf:"bytes,2,opt,name=spot" json:"spot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BookBestReply) Reset()         { *m = BookBestReply{} }
+func (m *BookBestReply) String() string { return proto.CompactTextString(m) }
+func (*BookBestReply) ProtoMessage()    {}
+func (*BookBestReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{9}
+}
+func (m *BookBestReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookBestReply.Unmarshal(m, b)
+}
+func (m *BookBestReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookBestReply.Marshal(b, m, deterministic)
+}
+func (dst *BookBestReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookBestReply.Merge(dst, src)
+}
+func (m *BookBestReply) XXX_Size() int {
+	return xxx_messageInfo_BookBestReply.Size(m)
+}
+func (m *BookBestReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookBestReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookBestReply proto.InternalMessageInfo
+
+func (m *BookBestReply) GetBooking() *Booking {
+	if m != nil {
+		return m.Booking
+	}
+	return nil
+}
+
+func (m *BookBestReply) GetSpot() *pb.ExtendedSpot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+type DeleteRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{10}
+}
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(dst, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteReply) Reset()         { *m = DeleteReply{} }
+func (m *DeleteReply) String() string { return proto.CompactTextString(m) }
+func (*DeleteReply) ProtoMessage()    {}
+func (*DeleteReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{11}
+}
+func (m *DeleteReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteReply.Unmarshal(m, b)
+}
+func (m *DeleteReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteReply.Marshal(b, m, deterministic)
+}
+func (dst *DeleteReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteReply.Merge(dst, src)
+}
+func (m *DeleteReply) XXX_Size() int {
+	return xxx_messageInfo_DeleteReply.Size(m)
+}
+func (m *DeleteReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteReply proto.InternalMessageInfo
+
+type FindByPlateRequest struct {
+	Plate                string   `protobuf:"bytes,1,opt,name=plate" json:"plate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindByPlateRequest) Reset()         { *m = FindByPlateRequest{} }
+func (m *FindByPlateRequest) String() string { return proto.CompactTextString(m) }
+func (*FindByPlateRequest) ProtoMessage()    {}
+func (*FindByPlateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{12}
+}
+func (m *FindByPlateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindByPlateRequest.Unmarshal(m, b)
+}
+func (m *FindByPlateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindByPlateRequest.Marshal(b, m, deterministic)
+}
+func (dst *FindByPlateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindByPlateRequest.Merge(dst, src)
+}
+func (m *FindByPlateRequest) XXX_Size() int {
+	return xxx_messageInfo_FindByPlateRequest.Size(m)
+}
+func (m *FindByPlateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindByPlateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindByPlateRequest proto.InternalMessageInfo
+
+func (m *FindByPlateRequest) GetPlate() string {
+	if m != nil {
+		return m.Plate
+	}
+	return ""
+}
+
+type ResizeRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Minutes              int32    `protobuf:"varint,2,opt,name=minutes" json:"minutes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResizeRequest) Reset()         { *m = ResizeRequest{} }
+func (m *ResizeRequest) String() string { return proto.CompactTextString(m) }
+func (*ResizeRequest) ProtoMessage()    {}
+func (*ResizeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{13}
+}
+func (m *ResizeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResizeRequest.Unmarshal(m, b)
+}
+func (m *ResizeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResizeRequest.Marshal(b, m, deterministic)
+}
+func (dst *ResizeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeRequest.Merge(dst, src)
+}
+func (m *ResizeRequest) XXX_Size() int {
+	return xxx_messageInfo_ResizeRequest.Size(m)
+}
+func (m *ResizeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResizeRequest proto.InternalMessageInfo
+
+func (m *ResizeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ResizeRequest) GetMinutes() int32 {
+	if m != nil {
+		return m.Minutes
+	}
+	return 0
+}
+
+type ResizeReply struct {
+	Booking *Booking `protobuf:"bytes,1,opt,name=booking" json:"booking,omitempty"`
+	// The change in price, negative when refunded
+	Difference           string   `protobuf:"bytes,2,opt,name=difference" json:"difference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResizeReply) Reset()         { *m = ResizeReply{} }
+func (m *ResizeReply) String() string { return proto.CompactTextString(m) }
+func (*ResizeReply) ProtoMessage()    {}
+func (*ResizeReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{14}
+}
+func (m *ResizeReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResizeReply.Unmarshal(m, b)
+}
+func (m *ResizeReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResizeReply.Marshal(b, m, deterministic)
+}
+func (dst *ResizeReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeReply.Merge(dst, src)
+}
+func (m *ResizeReply) XXX_Size() int {
+	return xxx_messageInfo_ResizeReply.Size(m)
+}
+func (m *ResizeReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResizeReply proto.InternalMessageInfo
+
+func (m *ResizeReply) GetBooking() *Booking {
+	if m != nil {
+		return m.Booking
+	}
+	return nil
+}
+
+func (m *ResizeReply) GetDifference() string {
+	if m != nil {
+		return m.Difference
+	}
+	return ""
+}
+
+type Hold struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	SpotId               int64    `protobuf:"varint,2,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	VehicleId            int64    `protobuf:"varint,3,opt,name=vehicle_id,json=vehicleId" json:"vehicle_id,omitempty"`
+	User                 string   `protobuf:"bytes,4,opt,name=user" json:"user,omitempty"`
+	StartTime            int64    `protobuf:"varint,5,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	Duration             int64    `protobuf:"varint,6,opt,name=duration" json:"duration,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,7,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	Price                string   `protobuf:"bytes,8,opt,name=price" json:"price,omitempty"`
+	BufferBefore         int64    `protobuf:"varint,9,opt,name=buffer_before,json=bufferBefore" json:"buffer_before,omitempty"`
+	BufferAfter          int64    `protobuf:"varint,10,opt,name=buffer_after,json=bufferAfter" json:"buffer_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Hold) Reset()         { *m = Hold{} }
+func (m *Hold) String() string { return proto.CompactTextString(m) }
+func (*Hold) ProtoMessage()    {}
+func (*Hold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{15}
+}
+func (m *Hold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hold.Unmarshal(m, b)
+}
+func (m *Hold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hold.Marshal(b, m, deterministic)
+}
+func (dst *Hold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hold.Merge(dst, src)
+}
+func (m *Hold) XXX_Size() int {
+	return xxx_messageInfo_Hold.Size(m)
+}
+func (m *Hold) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hold proto.InternalMessageInfo
+
+func (m *Hold) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Hold) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *Hold) GetVehicleId() int64 {
+	if m != nil {
+		return m.VehicleId
+	}
+	return 0
+}
+
+func (m *Hold) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Hold) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Hold) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *Hold) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Hold) GetPrice() string {
+	if m != nil {
+		return m.Price
+	}
+	return ""
+}
+
+func (m *Hold) GetBufferBefore() int64 {
+	if m != nil {
+		return m.BufferBefore
+	}
+	return 0
+}
+
+func (m *Hold) GetBufferAfter() int64 {
+	if m != nil {
+		return m.BufferAfter
+	}
+	return 0
+}
+
+type HoldRequest struct {
+	SpotId    string `protobuf:"bytes,1,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	VehicleId string `protobuf:"bytes,2,opt,name=vehicle_id,json=vehicleId" json:"vehicle_id,omitempty"`
+	// Seconds the spot is held, 300 when zero
+	Ttl                  int32    `protobuf:"varint,3,opt,name=ttl" json:"ttl,omitempty"`
+	Start                int64    `protobuf:"varint,4,opt,name=start" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,5,opt,name=end" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HoldRequest) Reset()         { *m = HoldRequest{} }
+func (m *HoldRequest) String() string { return proto.CompactTextString(m) }
+func (*HoldRequest) ProtoMessage()    {}
+func (*HoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{16}
+}
+func (m *HoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HoldRequest.Unmarshal(m, b)
+}
+func (m *HoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HoldRequest.Marshal(b, m, deterministic)
+}
+func (dst *HoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HoldRequest.Merge(dst, src)
+}
+func (m *HoldRequest) XXX_Size() int {
+	return xxx_messageInfo_HoldRequest.Size(m)
+}
+func (m *HoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HoldRequest proto.InternalMessageInfo
+
+func (m *HoldRequest) GetSpotId() string {
+	if m != nil {
+		return m.SpotId
+	}
+	return ""
+}
+
+func (m *HoldRequest) GetVehicleId() string {
+	if m != nil {
+		return m.VehicleId
+	}
+	return ""
+}
+
+func (m *HoldRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *HoldRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *HoldRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type HoldReply struct {
+	Hold                 *Hold    `protobuf:"bytes,1,opt,name=hold" json:"hold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HoldReply) Reset()         { *m = HoldReply{} }
+func (m *HoldReply) String() string { return proto.CompactTextString(m) }
+func (*HoldReply) ProtoMessage()    {}
+func (*HoldReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{17}
+}
+func (m *HoldReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HoldReply.Unmarshal(m, b)
+}
+func (m *HoldReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HoldReply.Marshal(b, m, deterministic)
+}
+func (dst *HoldReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HoldReply.Merge(dst, src)
+}
+func (m *HoldReply) XXX_Size() int {
+	return xxx_messageInfo_HoldReply.Size(m)
+}
+func (m *HoldReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_HoldReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HoldReply proto.InternalMessageInfo
+
+func (m *HoldReply) GetHold() *Hold {
+	if m != nil {
+		return m.Hold
+	}
+	return nil
+}
+
+type HoldIdRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HoldIdRequest) Reset()         { *m = HoldIdRequest{} }
+func (m *HoldIdRequest) String() string { return proto.CompactTextString(m) }
+func (*HoldIdRequest) ProtoMessage()    {}
+func (*HoldIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{18}
+}
+func (m *HoldIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HoldIdRequest.Unmarshal(m, b)
+}
+func (m *HoldIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HoldIdRequest.Marshal(b, m, deterministic)
+}
+func (dst *HoldIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HoldIdRequest.Merge(dst, src)
+}
+func (m *HoldIdRequest) XXX_Size() int {
+	return xxx_messageInfo_HoldIdRequest.Size(m)
+}
+func (m *HoldIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HoldIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HoldIdRequest proto.InternalMessageInfo
+
+func (m *HoldIdRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Group struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	User                 string   `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	BookingIds           []int64  `protobuf:"varint,3,rep,packed,name=booking_ids,json=bookingIds" json:"booking_ids,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Group) Reset()         { *m = Group{} }
+func (m *Group) String() string { return proto.CompactTextString(m) }
+func (*Group) ProtoMessage()    {}
+func (*Group) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{19}
+}
+func (m *Group) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Group.Unmarshal(m, b)
+}
+func (m *Group) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Group.Marshal(b, m, deterministic)
+}
+func (dst *Group) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Group.Merge(dst, src)
+}
+func (m *Group) XXX_Size() int {
+	return xxx_messageInfo_Group.Size(m)
+}
+func (m *Group) XXX_DiscardUnknown() {
+	xxx_messageInfo_Group.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Group proto.InternalMessageInfo
+
+func (m *Group) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Group) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Group) GetBookingIds() []int64 {
+	if m != nil {
+		return m.BookingIds
+	}
+	return nil
+}
+
+func (m *Group) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+// GroupRequest books a spot for each vehicle, on the spots listed or on the
+// nearest free spots within radius meters of lat, lon
+type GroupRequest struct {
+	VehicleIds           []string `protobuf:"bytes,1,rep,name=vehicle_ids,json=vehicleIds" json:"vehicle_ids,omitempty"`
+	SpotIds              []string `protobuf:"bytes,2,rep,name=spot_ids,json=spotIds" json:"spot_ids,omitempty"`
+	Lat                  string   `protobuf:"bytes,3,opt,name=lat" json:"lat,omitempty"`
+	Lon                  string   `protobuf:"bytes,4,opt,name=lon" json:"lon,omitempty"`
+	Radius               string   `protobuf:"bytes,5,opt,name=radius" json:"radius,omitempty"`
+	Start                int64    `protobuf:"varint,6,opt,name=start" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,7,opt,name=end" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupRequest) Reset()         { *m = GroupRequest{} }
+func (m *GroupRequest) String() string { return proto.CompactTextString(m) }
+func (*GroupRequest) ProtoMessage()    {}
+func (*GroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{20}
+}
+func (m *GroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupRequest.Unmarshal(m, b)
+}
+func (m *GroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupRequest.Marshal(b, m, deterministic)
+}
+func (dst *GroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupRequest.Merge(dst, src)
+}
+func (m *GroupRequest) XXX_Size() int {
+	return xxx_messageInfo_GroupRequest.Size(m)
+}
+func (m *GroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupRequest proto.InternalMessageInfo
+
+func (m *GroupRequest) GetVehicleIds() []string {
+	if m != nil {
+		return m.VehicleIds
+	}
+	return nil
+}
+
+func (m *GroupRequest) GetSpotIds() []string {
+	if m != nil {
+		return m.SpotIds
+	}
+	return nil
+}
+
+func (m *GroupRequest) GetLat() string {
+	if m != nil {
+		return m.Lat
+	}
+	return ""
+}
+
+func (m *GroupRequest) GetLon() string {
+	if m != nil {
+		return m.Lon
+	}
+	return ""
+}
+
+func (m *GroupRequest) GetRadius() string {
+	if m != nil {
+		return m.Radius
+	}
+	return ""
+}
+
+func (m *GroupRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *GroupRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type GroupReply struct {
+	Group                *Group     `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+	Bookings             []*Booking `protobuf:"bytes,2,rep,name=bookings" json:"bookings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GroupReply) Reset()         { *m = GroupReply{} }
+func (m *GroupReply) String() string { return proto.CompactTextString(m) }
+func (*GroupReply) ProtoMessage()    {}
+func (*GroupReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{21}
+}
+func (m *GroupReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupReply.Unmarshal(m, b)
+}
+func (m *GroupReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupReply.Marshal(b, m, deterministic)
+}
+func (dst *GroupReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupReply.Merge(dst, src)
+}
+func (m *GroupReply) XXX_Size() int {
+	return xxx_messageInfo_GroupReply.Size(m)
+}
+func (m *GroupReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupReply proto.InternalMessageInfo
+
+func (m *GroupReply) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+func (m *GroupReply) GetBookings() []*Booking {
+	if m != nil {
+		return m.Bookings
+	}
+	return nil
+}
+
+type GroupIdRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GroupIdRequest) Reset()         { *m = GroupIdRequest{} }
+func (m *GroupIdRequest) String() string { return proto.CompactTextString(m) }
+func (*GroupIdRequest) ProtoMessage()    {}
+func (*GroupIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{22}
+}
+func (m *GroupIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GroupIdRequest.Unmarshal(m, b)
+}
+func (m *GroupIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GroupIdRequest.Marshal(b, m, deterministic)
+}
+func (dst *GroupIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupIdRequest.Merge(dst, src)
+}
+func (m *GroupIdRequest) XXX_Size() int {
+	return xxx_messageInfo_GroupIdRequest.Size(m)
+}
+func (m *GroupIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupIdRequest proto.InternalMessageInfo
+
+func (m *GroupIdRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type NoShowPolicy struct {
+	Facility             string   `protobuf:"bytes,1,opt,name=facility" json:"facility,omitempty"`
+	Grace                int64    `protobuf:"varint,2,opt,name=grace" json:"grace,omitempty"`
+	Fee                  string   `protobuf:"bytes,3,opt,name=fee" json:"fee,omitempty"`
+	Strike               bool     `protobuf:"varint,4,opt,name=strike" json:"strike,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoShowPolicy) Reset()         { *m = NoShowPolicy{} }
+func (m *NoShowPolicy) String() string { return proto.CompactTextString(m) }
+func (*NoShowPolicy) ProtoMessage()    {}
+func (*NoShowPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{23}
+}
+func (m *NoShowPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShowPolicy.Unmarshal(m, b)
+}
+func (m *NoShowPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShowPolicy.Marshal(b, m, deterministic)
+}
+func (dst *NoShowPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShowPolicy.Merge(dst, src)
+}
+func (m *NoShowPolicy) XXX_Size() int {
+	return xxx_messageInfo_NoShowPolicy.Size(m)
+}
+func (m *NoShowPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShowPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShowPolicy proto.InternalMessageInfo
+
+func (m *NoShowPolicy) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *NoShowPolicy) GetGrace() int64 {
+	if m != nil {
+		return m.Grace
+	}
+	return 0
+}
+
+func (m *NoShowPolicy) GetFee() string {
+	if m != nil {
+		return m.Fee
+	}
+	return ""
+}
+
+func (m *NoShowPolicy) GetStrike() bool {
+	if m != nil {
+		return m.Strike
+	}
+	return false
+}
+
+type NoShowPolicyRequest struct {
+	Facility             string   `protobuf:"bytes,1,opt,name=facility" json:"facility,omitempty"`
+	GraceMinutes         int32    `protobuf:"varint,2,opt,name=grace_minutes,json=graceMinutes" json:"grace_minutes,omitempty"`
+	Fee                  string   `protobuf:"bytes,3,opt,name=fee" json:"fee,omitempty"`
+	Strike               bool     `protobuf:"varint,4,opt,name=strike" json:"strike,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoShowPolicyRequest) Reset()         { *m = NoShowPolicyRequest{} }
+func (m *NoShowPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*NoShowPolicyRequest) ProtoMessage()    {}
+func (*NoShowPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{24}
+}
+func (m *NoShowPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShowPolicyRequest.Unmarshal(m, b)
+}
+func (m *NoShowPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShowPolicyRequest.Marshal(b, m, deterministic)
+}
+func (dst *NoShowPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShowPolicyRequest.Merge(dst, src)
+}
+func (m *NoShowPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_NoShowPolicyRequest.Size(m)
+}
+func (m *NoShowPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShowPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShowPolicyRequest proto.InternalMessageInfo
+
+func (m *NoShowPolicyRequest) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *NoShowPolicyRequest) GetGraceMinutes() int32 {
+	if m != nil {
+		return m.GraceMinutes
+	}
+	return 0
+}
+
+func (m *NoShowPolicyRequest) GetFee() string {
+	if m != nil {
+		return m.Fee
+	}
+	return ""
+}
+
+func (m *NoShowPolicyRequest) GetStrike() bool {
+	if m != nil {
+		return m.Strike
+	}
+	return false
+}
+
+type NoShowPolicyReply struct {
+	Policy               *NoShowPolicy `protobuf:"bytes,1,opt,name=policy" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *NoShowPolicyReply) Reset()         { *m = NoShowPolicyReply{} }
+func (m *NoShowPolicyReply) String() string { return proto.CompactTextString(m) }
+func (*NoShowPolicyReply) ProtoMessage()    {}
+func (*NoShowPolicyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{25}
+}
+func (m *NoShowPolicyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShowPolicyReply.Unmarshal(m, b)
+}
+func (m *NoShowPolicyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShowPolicyReply.Marshal(b, m, deterministic)
+}
+func (dst *NoShowPolicyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShowPolicyReply.Merge(dst, src)
+}
+func (m *NoShowPolicyReply) XXX_Size() int {
+	return xxx_messageInfo_NoShowPolicyReply.Size(m)
+}
+func (m *NoShowPolicyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShowPolicyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShowPolicyReply proto.InternalMessageInfo
+
+func (m *NoShowPolicyReply) GetPolicy() *NoShowPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+type NoShowPoliciesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoShowPoliciesRequest) Reset()         { *m = NoShowPoliciesRequest{} }
+func (m *NoShowPoliciesRequest) String() string { return proto.CompactTextString(m) }
+func (*NoShowPoliciesRequest) ProtoMessage()    {}
+func (*NoShowPoliciesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{26}
+}
+func (m *NoShowPoliciesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShowPoliciesRequest.Unmarshal(m, b)
+}
+func (m *NoShowPoliciesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShowPoliciesRequest.Marshal(b, m, deterministic)
+}
+func (dst *NoShowPoliciesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShowPoliciesRequest.Merge(dst, src)
+}
+func (m *NoShowPoliciesRequest) XXX_Size() int {
+	return xxx_messageInfo_NoShowPoliciesRequest.Size(m)
+}
+func (m *NoShowPoliciesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShowPoliciesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShowPoliciesRequest proto.InternalMessageInfo
+
+type NoShowPoliciesReply struct {
+	Policies             []*NoShowPolicy `protobuf:"bytes,1,rep,name=policies" json:"policies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *NoShowPoliciesReply) Reset()         { *m = NoShowPoliciesReply{} }
+func (m *NoShowPoliciesReply) String() string { return proto.CompactTextString(m) }
+func (*NoShowPoliciesReply) ProtoMessage()    {}
+func (*NoShowPoliciesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{27}
+}
+func (m *NoShowPoliciesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShowPoliciesReply.Unmarshal(m, b)
+}
+func (m *NoShowPoliciesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShowPoliciesReply.Marshal(b, m, deterministic)
+}
+func (dst *NoShowPoliciesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShowPoliciesReply.Merge(dst, src)
+}
+func (m *NoShowPoliciesReply) XXX_Size() int {
+	return xxx_messageInfo_NoShowPoliciesReply.Size(m)
+}
+func (m *NoShowPoliciesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShowPoliciesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShowPoliciesReply proto.InternalMessageInfo
+
+func (m *NoShowPoliciesReply) GetPolicies() []*NoShowPolicy {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
+type NoShow struct {
+	BookingId            int64    `protobuf:"varint,1,opt,name=booking_id,json=bookingId" json:"booking_id,omitempty"`
+	User                 string   `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	SpotId               int64    `protobuf:"varint,3,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	Facility             string   `protobuf:"bytes,4,opt,name=facility" json:"facility,omitempty"`
+	Fee                  string   `protobuf:"bytes,5,opt,name=fee" json:"fee,omitempty"`
+	Strike               bool     `protobuf:"varint,6,opt,name=strike" json:"strike,omitempty"`
+	At                   int64    `protobuf:"varint,7,opt,name=at" json:"at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoShow) Reset()         { *m = NoShow{} }
+func (m *NoShow) String() string { return proto.CompactTextString(m) }
+func (*NoShow) ProtoMessage()    {}
+func (*NoShow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{28}
+}
+func (m *NoShow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShow.Unmarshal(m, b)
+}
+func (m *NoShow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShow.Marshal(b, m, deterministic)
+}
+func (dst *NoShow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShow.Merge(dst, src)
+}
+func (m *NoShow) XXX_Size() int {
+	return xxx_messageInfo_NoShow.Size(m)
+}
+func (m *NoShow) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShow proto.InternalMessageInfo
+
+func (m *NoShow) GetBookingId() int64 {
+	if m != nil {
+		return m.BookingId
+	}
+	return 0
+}
+
+func (m *NoShow) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *NoShow) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *NoShow) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *NoShow) GetFee() string {
+	if m != nil {
+		return m.Fee
+	}
+	return ""
+}
+
+func (m *NoShow) GetStrike() bool {
+	if m != nil {
+		return m.Strike
+	}
+	return false
+}
+
+func (m *NoShow) GetAt() int64 {
+	if m != nil {
+		return m.At
+	}
+	return 0
+}
+
+type NoShowsRequest struct {
+	// Everyone's when empty
+	User                 string   `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoShowsRequest) Reset()         { *m = NoShowsRequest{} }
+func (m *NoShowsRequest) String() string { return proto.CompactTextString(m) }
+func (*NoShowsRequest) ProtoMessage()    {}
+func (*NoShowsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{29}
+}
+func (m *NoShowsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShowsRequest.Unmarshal(m, b)
+}
+func (m *NoShowsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShowsRequest.Marshal(b, m, deterministic)
+}
+func (dst *NoShowsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShowsRequest.Merge(dst, src)
+}
+func (m *NoShowsRequest) XXX_Size() int {
+	return xxx_messageInfo_NoShowsRequest.Size(m)
+}
+func (m *NoShowsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShowsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShowsRequest proto.InternalMessageInfo
+
+func (m *NoShowsRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+type NoShowsReply struct {
+	NoShows              []*NoShow `protobuf:"bytes,1,rep,name=no_shows,json=noShows" json:"no_shows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *NoShowsReply) Reset()         { *m = NoShowsReply{} }
+func (m *NoShowsReply) String() string { return proto.CompactTextString(m) }
+func (*NoShowsReply) ProtoMessage()    {}
+func (*NoShowsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{30}
+}
+func (m *NoShowsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NoShowsReply.Unmarshal(m, b)
+}
+func (m *NoShowsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NoShowsReply.Marshal(b, m, deterministic)
+}
+func (dst *NoShowsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoShowsReply.Merge(dst, src)
+}
+func (m *NoShowsReply) XXX_Size() int {
+	return xxx_messageInfo_NoShowsReply.Size(m)
+}
+func (m *NoShowsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoShowsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoShowsReply proto.InternalMessageInfo
+
+func (m *NoShowsReply) GetNoShows() []*NoShow {
+	if m != nil {
+		return m.NoShows
+	}
+	return nil
+}
+
+type Buffer struct {
+	SpotId               int64    `protobuf:"varint,1,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	Facility             string   `protobuf:"bytes,2,opt,name=facility" json:"facility,omitempty"`
+	Before               int64    `protobuf:"varint,3,opt,name=before" json:"before,omitempty"`
+	After                int64    `protobuf:"varint,4,opt,name=after" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Buffer) Reset()         { *m = Buffer{} }
+func (m *Buffer) String() string { return proto.CompactTextString(m) }
+func (*Buffer) ProtoMessage()    {}
+func (*Buffer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{31}
+}
+func (m *Buffer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Buffer.Unmarshal(m, b)
+}
+func (m *Buffer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Buffer.Marshal(b, m, deterministic)
+}
+func (dst *Buffer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Buffer.Merge(dst, src)
+}
+func (m *Buffer) XXX_Size() int {
+	return xxx_messageInfo_Buffer.Size(m)
+}
+func (m *Buffer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Buffer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Buffer proto.InternalMessageInfo
+
+func (m *Buffer) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *Buffer) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *Buffer) GetBefore() int64 {
+	if m != nil {
+		return m.Before
+	}
+	return 0
+}
+
+func (m *Buffer) GetAfter() int64 {
+	if m != nil {
+		return m.After
+	}
+	return 0
+}
+
+type BufferRequest struct {
+	SpotId               int64    `protobuf:"varint,1,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	Facility             string   `protobuf:"bytes,2,opt,name=facility" json:"facility,omitempty"`
+	BeforeMinutes        int32    `protobuf:"varint,3,opt,name=before_minutes,json=beforeMinutes" json:"before_minutes,omitempty"`
+	AfterMinutes         int32    `protobuf:"varint,4,opt,name=after_minutes,json=afterMinutes" json:"after_minutes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BufferRequest) Reset()         { *m = BufferRequest{} }
+func (m *BufferRequest) String() string { return proto.CompactTextString(m) }
+func (*BufferRequest) ProtoMessage()    {}
+func (*BufferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{32}
+}
+func (m *BufferRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BufferRequest.Unmarshal(m, b)
+}
+func (m *BufferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BufferRequest.Marshal(b, m, deterministic)
+}
+func (dst *BufferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BufferRequest.Merge(dst, src)
+}
+func (m *BufferRequest) XXX_Size() int {
+	return xxx_messageInfo_BufferRequest.Size(m)
+}
+func (m *BufferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BufferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BufferRequest proto.InternalMessageInfo
+
+func (m *BufferRequest) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *BufferRequest) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *BufferRequest) GetBeforeMinutes() int32 {
+	if m != nil {
+		return m.BeforeMinutes
+	}
+	return 0
+}
+
+func (m *BufferRequest) GetAfterMinutes() int32 {
+	if m != nil {
+		return m.AfterMinutes
+	}
+	return 0
+}
+
+type BufferReply struct {
+	Buffer               *Buffer  `protobuf:"bytes,1,opt,name=buffer" json:"buffer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BufferReply) Reset()         { *m = BufferReply{} }
+func (m *BufferReply) String() string { return proto.CompactTextString(m) }
+func (*BufferReply) ProtoMessage()    {}
+func (*BufferReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{33}
+}
+func (m *BufferReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BufferReply.Unmarshal(m, b)
+}
+func (m *BufferReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BufferReply.Marshal(b, m, deterministic)
+}
+func (dst *BufferReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BufferReply.Merge(dst, src)
+}
+func (m *BufferReply) XXX_Size() int {
+	return xxx_messageInfo_BufferReply.Size(m)
+}
+func (m *BufferReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_BufferReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BufferReply proto.InternalMessageInfo
+
+func (m *BufferReply) GetBuffer() *Buffer {
+	if m != nil {
+		return m.Buffer
+	}
+	return nil
+}
+
+type BuffersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BuffersRequest) Reset()         { *m = BuffersRequest{} }
+func (m *BuffersRequest) String() string { return proto.CompactTextString(m) }
+func (*BuffersRequest) ProtoMessage()    {}
+func (*BuffersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{34}
+}
+func (m *BuffersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuffersRequest.Unmarshal(m, b)
+}
+func (m *BuffersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuffersRequest.Marshal(b, m, deterministic)
+}
+func (dst *BuffersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuffersRequest.Merge(dst, src)
+}
+func (m *BuffersRequest) XXX_Size() int {
+	return xxx_messageInfo_BuffersRequest.Size(m)
+}
+func (m *BuffersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuffersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuffersRequest proto.InternalMessageInfo
+
+type BuffersReply struct {
+	Buffers              []*Buffer `protobuf:"bytes,1,rep,name=buffers" json:"buffers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *BuffersReply) Reset()         { *m = BuffersReply{} }
+func (m *BuffersReply) String() string { return proto.CompactTextString(m) }
+func (*BuffersReply) ProtoMessage()    {}
+func (*BuffersReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{35}
+}
+func (m *BuffersReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BuffersReply.Unmarshal(m, b)
+}
+func (m *BuffersReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BuffersReply.Marshal(b, m, deterministic)
+}
+func (dst *BuffersReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BuffersReply.Merge(dst, src)
+}
+func (m *BuffersReply) XXX_Size() int {
+	return xxx_messageInfo_BuffersReply.Size(m)
+}
+func (m *BuffersReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_BuffersReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BuffersReply proto.InternalMessageInfo
+
+func (m *BuffersReply) GetBuffers() []*Buffer {
+	if m != nil {
+		return m.Buffers
+	}
+	return nil
+}
+
+type Pass struct {
+	BookingId            int64    `protobuf:"varint,1,opt,name=booking_id,json=bookingId" json:"booking_id,omitempty"`
+	SpotId               int64    `protobuf:"varint,2,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	Facility             string   `protobuf:"bytes,3,opt,name=facility" json:"facility,omitempty"`
+	NotBefore            int64    `protobuf:"varint,4,opt,name=not_before,json=notBefore" json:"not_before,omitempty"`
+	NotAfter             int64    `protobuf:"varint,5,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	Token                string   `protobuf:"bytes,6,opt,name=token" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pass) Reset()         { *m = Pass{} }
+func (m *Pass) String() string { return proto.CompactTextString(m) }
+func (*Pass) ProtoMessage()    {}
+func (*Pass) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{36}
+}
+func (m *Pass) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pass.Unmarshal(m, b)
+}
+func (m *Pass) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pass.Marshal(b, m, deterministic)
+}
+func (dst *Pass) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pass.Merge(dst, src)
+}
+func (m *Pass) XXX_Size() int {
+	return xxx_messageInfo_Pass.Size(m)
+}
+func (m *Pass) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pass.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pass proto.InternalMessageInfo
+
+func (m *Pass) GetBookingId() int64 {
+	if m != nil {
+		return m.BookingId
+	}
+	return 0
+}
+
+func (m *Pass) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *Pass) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *Pass) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
+	}
+	return 0
+}
+
+func (m *Pass) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+func (m *Pass) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type PassReply struct {
+	Pass                 *Pass    `protobuf:"bytes,1,opt,name=pass" json:"pass,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PassReply) Reset()         { *m = PassReply{} }
+func (m *PassReply) String() string { return proto.CompactTextString(m) }
+func (*PassReply) ProtoMessage()    {}
+func (*PassReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{37}
+}
+func (m *PassReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PassReply.Unmarshal(m, b)
+}
+func (m *PassReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PassReply.Marshal(b, m, deterministic)
+}
+func (dst *PassReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PassReply.Merge(dst, src)
+}
+func (m *PassReply) XXX_Size() int {
+	return xxx_messageInfo_PassReply.Size(m)
+}
+func (m *PassReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PassReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PassReply proto.InternalMessageInfo
+
+func (m *PassReply) GetPass() *Pass {
+	if m != nil {
+		return m.Pass
+	}
+	return nil
+}
+
+type GateRequest struct {
+	Token    string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	Facility string `protobuf:"bytes,2,opt,name=facility" json:"facility,omitempty"`
+	// entry or exit
+	Direction            string   `protobuf:"bytes,3,opt,name=direction" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GateRequest) Reset()         { *m = GateRequest{} }
+func (m *GateRequest) String() string { return proto.CompactTextString(m) }
+func (*GateRequest) ProtoMessage()    {}
+func (*GateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_booking_e0fa986441c94582, []int{38}
+}
+func (m *GateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GateRequest.Unmarshal(m, b)
+}
+func (m *GateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GateRequest.Marshal(b, m, deterministic)
+}
+func (dst *GateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GateRequest.Merge(dst, src)
+}
+func (m *GateRequest) XXX_Size() int {
+	return xxx_messageInfo_GateRequest.Size(m)
+}
+func (m *GateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GateRequest proto.InternalMessageInfo
+
+func (m *GateRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GateRequest) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *GateRequest) GetDirection() string {
+	if m != nil {
+		return m.Direction
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Booking)(nil), "booking.Booking")
+	proto.RegisterType((*GetAllRequest)(nil), "booking.GetAllRequest")
+	proto.RegisterType((*BookingsReply)(nil), "booking.BookingsReply")
+	proto.RegisterType((*ListRequest)(nil), "booking.ListRequest")
+	proto.RegisterType((*BookingIdRequest)(nil), "booking.BookingIdRequest")
+	proto.RegisterType((*UpdateRequest)(nil), "booking.UpdateRequest")
+	proto.RegisterType((*BookRequest)(nil), "booking.BookRequest")
+	proto.RegisterType((*BookReply)(nil), "booking.BookReply")
+	proto.RegisterType((*BookBestRequest)(nil), "booking.BookBestRequest")
+	proto.RegisterType((*BookBestReply)(nil), "booking.BookBestReply")
+	proto.RegisterType((*DeleteRequest)(nil), "booking.DeleteRequest")
+	proto.RegisterType((*DeleteReply)(nil), "booking.DeleteReply")
+	proto.RegisterType((*FindByPlateRequest)(nil), "booking.FindByPlateRequest")
+	proto.RegisterType((*ResizeRequest)(nil), "booking.ResizeRequest")
+	proto.RegisterType((*ResizeReply)(nil), "booking.ResizeReply")
+	proto.RegisterType((*Hold)(nil), "booking.Hold")
+	proto.RegisterType((*HoldRequest)(nil), "booking.HoldRequest")
+	proto.RegisterType((*HoldReply)(nil), "booking.HoldReply")
+	proto.RegisterType((*HoldIdRequest)(nil), "booking.HoldIdRequest")
+	proto.RegisterType((*Group)(nil), "booking.Group")
+	proto.RegisterType((*GroupRequest)(nil), "booking.GroupRequest")
+	proto.RegisterType((*GroupReply)(nil), "booking.GroupReply")
+	proto.RegisterType((*GroupIdRequest)(nil), "booking.GroupIdRequest")
+	proto.RegisterType((*NoShowPolicy)(nil), "booking.NoShowPolicy")
+	proto.RegisterType((*NoShowPolicyRequest)(nil), "booking.NoShowPolicyRequest")
+	proto.RegisterType((*NoShowPolicyReply)(nil), "booking.NoShowPolicyReply")
+	proto.RegisterType((*NoShowPoliciesRequest)(nil), "booking.NoShowPoliciesRequest")
+	proto.RegisterType((*NoShowPoliciesReply)(nil), "booking.NoShowPoliciesReply")
+	proto.RegisterType((*NoShow)(nil), "booking.NoShow")
+	proto.RegisterType((*NoShowsRequest)(nil), "booking.NoShowsRequest")
+	proto.RegisterType((*NoShowsReply)(nil), "booking.NoShowsReply")
+	proto.RegisterType((*Buffer)(nil), "booking.Buffer")
+	proto.RegisterType((*BufferRequest)(nil), "booking.BufferRequest")
+	proto.RegisterType((*BufferReply)(nil), "booking.BufferReply")
+	proto.RegisterType((*BuffersRequest)(nil), "booking.BuffersRequest")
+	proto.RegisterType((*BuffersReply)(nil), "booking.BuffersReply")
+	proto.RegisterType((*Pass)(nil), "booking.Pass")
+	proto.RegisterType((*PassReply)(nil), "booking.PassReply")
+	proto.RegisterType((*GateRequest)(nil), "booking.GateRequest")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for BookingService service
+
+type BookingServiceClient interface {
+	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*BookingsReply, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*BookingsReply, error)
+	Find(ctx context.Context, in *BookingIdRequest, opts ...grpc.CallOption) (*BookReply, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*BookReply, error)
+	Book(ctx context.Context, in *BookRequest, opts ...grpc.CallOption) (*BookReply, error)
+	BookBest(ctx context.Context, in *BookBestRequest, opts ...grpc.CallOption) (*BookBestReply, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error)
+	FindActiveByPlate(ctx context.Context, in *FindByPlateRequest, opts ...grpc.CallOption) (*BookingsReply, error)
+	CheckIn(ctx context.Context, in *BookingIdRequest, opts ...grpc.CallOption) (*BookReply, error)
+	Extend(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*ResizeReply, error)
+	Shorten(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*ResizeReply, error)
+	Hold(ctx context.Context, in *HoldRequest, opts ...grpc.CallOption) (*HoldReply, error)
+	Confirm(ctx context.Context, in *HoldIdRequest, opts ...grpc.CallOption) (*BookReply, error)
+	Release(ctx context.Context, in *HoldIdRequest, opts ...grpc.CallOption) (*DeleteReply, error)
+	BookGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupReply, error)
+	FindGroup(ctx context.Context, in *GroupIdRequest, opts ...grpc.CallOption) (*GroupReply, error)
+	CancelGroup(ctx context.Context, in *GroupIdRequest, opts ...grpc.CallOption) (*DeleteReply, error)
+	SetNoShowPolicy(ctx context.Context, in *NoShowPolicyRequest, opts ...grpc.CallOption) (*NoShowPolicyReply, error)
+	NoShowPolicies(ctx context.Context, in *NoShowPoliciesRequest, opts ...grpc.CallOption) (*NoShowPoliciesReply, error)
+	NoShows(ctx context.Context, in *NoShowsRequest, opts ...grpc.CallOption) (*NoShowsReply, error)
+	SetBuffer(ctx context.Context, in *BufferRequest, opts ...grpc.CallOption) (*BufferReply, error)
+	Buffers(ctx context.Context, in *BuffersRequest, opts ...grpc.CallOption) (*BuffersReply, error)
+	Pass(ctx context.Context, in *BookingIdRequest, opts ...grpc.CallOption) (*PassReply, error)
+	Gate(ctx context.Context, in *GateRequest, opts ...grpc.CallOption) (*BookReply, error)
+}
+
+type bookingServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBookingServiceClient(cc *grpc.ClientConn) BookingServiceClient {
+	return &bookingServiceClient{cc}
+}
+
+func (c *bookingServiceClient) GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*BookingsReply, error) {
+	out := new(BookingsReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/GetAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*BookingsReply, error) {
+	out := new(BookingsReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Find(ctx context.Context, in *BookingIdRequest, opts ...grpc.CallOption) (*BookReply, error) {
+	out := new(BookReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Find", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*BookReply, error) {
+	out := new(BookReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Book(ctx context.Context, in *BookRequest, opts ...grpc.CallOption) (*BookReply, error) {
+	out := new(BookReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Book", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) BookBest(ctx context.Context, in *BookBestRequest, opts ...grpc.CallOption) (*BookBestReply, error) {
+	out := new(BookBestReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/BookBest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
+	out := new(DeleteReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) FindActiveByPlate(ctx context.Context, in *FindByPlateRequest, opts ...grpc.CallOption) (*BookingsReply, error) {
+	out := new(BookingsReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/FindActiveByPlate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CheckIn(ctx context.Context, in *BookingIdRequest, opts ...grpc.CallOption) (*BookReply, error) {
+	out := new(BookReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/CheckIn", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Extend(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*ResizeReply, error) {
+	out := new(ResizeReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Extend", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Shorten(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*ResizeReply, error) {
+	out := new(ResizeReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Shorten", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Hold(ctx context.Context, in *HoldRequest, opts ...grpc.CallOption) (*HoldReply, error) {
+	out := new(HoldReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Hold", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Confirm(ctx context.Context, in *HoldIdRequest, opts ...grpc.CallOption) (*BookReply, error) {
+	out := new(BookReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Confirm", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Release(ctx context.Context, in *HoldIdRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
+	out := new(DeleteReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Release", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) BookGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupReply, error) {
+	out := new(GroupReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/BookGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) FindGroup(ctx context.Context, in *GroupIdRequest, opts ...grpc.CallOption) (*GroupReply, error) {
+	out := new(GroupReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/FindGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) CancelGroup(ctx context.Context, in *GroupIdRequest, opts ...grpc.CallOption) (*DeleteReply, error) {
+	out := new(DeleteReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/CancelGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) SetNoShowPolicy(ctx context.Context, in *NoShowPolicyRequest, opts ...grpc.CallOption) (*NoShowPolicyReply, error) {
+	out := new(NoShowPolicyReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/SetNoShowPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) NoShowPolicies(ctx context.Context, in *NoShowPoliciesRequest, opts ...grpc.CallOption) (*NoShowPoliciesReply, error) {
+	out := new(NoShowPoliciesReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/NoShowPolicies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) NoShows(ctx context.Context, in *NoShowsRequest, opts ...grpc.CallOption) (*NoShowsReply, error) {
+	out := new(NoShowsReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/NoShows", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) SetBuffer(ctx context.Context, in *BufferRequest, opts ...grpc.CallOption) (*BufferReply, error) {
+	out := new(BufferReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/SetBuffer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Buffers(ctx context.Context, in *BuffersRequest, opts ...grpc.CallOption) (*BuffersReply, error) {
+	out := new(BuffersReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Buffers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Pass(ctx context.Context, in *BookingIdRequest, opts ...grpc.CallOption) (*PassReply, error) {
+	out := new(PassReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Pass", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bookingServiceClient) Gate(ctx context.Context, in *GateRequest, opts ...grpc.CallOption) (*BookReply, error) {
+	out := new(BookReply)
+	err := grpc.Invoke(ctx, "/booking.BookingService/Gate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for BookingService service
+
+type BookingServiceServer interface {
+	GetAll(context.Context, *GetAllRequest) (*BookingsReply, error)
+	List(context.Context, *ListRequest) (*BookingsReply, error)
+	Find(context.Context, *BookingIdRequest) (*BookReply, error)
+	Update(context.Context, *UpdateRequest) (*BookReply, error)
+	Book(context.Context, *BookRequest) (*BookReply, error)
+	BookBest(context.Context, *BookBestRequest) (*BookBestReply, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteReply, error)
+	FindActiveByPlate(context.Context, *FindByPlateRequest) (*BookingsReply, error)
+	CheckIn(context.Context, *BookingIdRequest) (*BookReply, error)
+	Extend(context.Context, *ResizeRequest) (*ResizeReply, error)
+	Shorten(context.Context, *ResizeRequest) (*ResizeReply, error)
+	Hold(context.Context, *HoldRequest) (*HoldReply, error)
+	Confirm(context.Context, *HoldIdRequest) (*BookReply, error)
+	Release(context.Context, *HoldIdRequest) (*DeleteReply, error)
+	BookGroup(context.Context, *GroupRequest) (*GroupReply, error)
+	FindGroup(context.Context, *GroupIdRequest) (*GroupReply, error)
+	CancelGroup(context.Context, *GroupIdRequest) (*DeleteReply, error)
+	SetNoShowPolicy(context.Context, *NoShowPolicyRequest) (*NoShowPolicyReply, error)
+	NoShowPolicies(context.Context, *NoShowPoliciesRequest) (*NoShowPoliciesReply, error)
+	NoShows(context.Context, *NoShowsRequest) (*NoShowsReply, error)
+	SetBuffer(context.Context, *BufferRequest) (*BufferReply, error)
+	Buffers(context.Context, *BuffersRequest) (*BuffersReply, error)
+	Pass(context.Context, *BookingIdRequest) (*PassReply, error)
+	Gate(context.Context, *GateRequest) (*BookReply, error)
+}
+
+func RegisterBookingServiceServer(s *grpc.Server, srv BookingServiceServer) {
+	s.RegisterService(&_BookingService_serviceDesc, srv)
+}
+
+func _BookingService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).GetAll(ctx, req.(*GetAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Find(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Find",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Find(ctx, req.(*BookingIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Book_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Book(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Book",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Book(ctx, req.(*BookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_BookBest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookBestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).BookBest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/BookBest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).BookBest(ctx, req.(*BookBestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_FindActiveByPlate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindByPlateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).FindActiveByPlate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/FindActiveByPlate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).FindActiveByPlate(ctx, req.(*FindByPlateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/CheckIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CheckIn(ctx, req.(*BookingIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Extend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Extend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Extend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Extend(ctx, req.(*ResizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Shorten_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Shorten(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Shorten",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Shorten(ctx, req.(*ResizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Hold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Hold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Hold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Hold(ctx, req.(*HoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Confirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Confirm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Confirm(ctx, req.(*HoldIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Release",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Release(ctx, req.(*HoldIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_BookGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).BookGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/BookGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).BookGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_FindGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).FindGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/FindGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).FindGroup(ctx, req.(*GroupIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_CancelGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).CancelGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/CancelGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).CancelGroup(ctx, req.(*GroupIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_SetNoShowPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NoShowPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).SetNoShowPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/SetNoShowPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).SetNoShowPolicy(ctx, req.(*NoShowPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_NoShowPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NoShowPoliciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).NoShowPolicies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/NoShowPolicies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).NoShowPolicies(ctx, req.(*NoShowPoliciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_NoShows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NoShowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).NoShows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/NoShows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).NoShows(ctx, req.(*NoShowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_SetBuffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BufferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).SetBuffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/SetBuffer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).SetBuffer(ctx, req.(*BufferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Buffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Buffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Buffers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Buffers(ctx, req.(*BuffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Pass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookingIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Pass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Pass",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Pass(ctx, req.(*BookingIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BookingService_Gate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BookingServiceServer).Gate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/booking.BookingService/Gate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BookingServiceServer).Gate(ctx, req.(*GateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BookingService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "booking.BookingService",
+	HandlerType: (*BookingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAll",
+			Handler:    _BookingService_GetAll_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _BookingService_List_Handler,
+		},
+		{
+			MethodName: "Find",
+			Handler:    _BookingService_Find_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _BookingService_Update_Handler,
+		},
+		{
+			MethodName: "Book",
+			Handler:    _BookingService_Book_Handler,
+		},
+		{
+			MethodName: "BookBest",
+			Handler:    _BookingService_BookBest_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _BookingService_Delete_Handler,
+		},
+		{
+			MethodName: "FindActiveByPlate",
+			Handler:    _BookingService_FindActiveByPlate_Handler,
+		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _BookingService_CheckIn_Handler,
+		},
+		{
+			MethodName: "Extend",
+			Handler:    _BookingService_Extend_Handler,
+		},
+		{
+			MethodName: "Shorten",
+			Handler:    _BookingService_Shorten_Handler,
+		},
+		{
+			MethodName: "Hold",
+			Handler:    _BookingService_Hold_Handler,
+		},
+		{
+			MethodName: "Confirm",
+			Handler:    _BookingService_Confirm_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _BookingService_Release_Handler,
+		},
+		{
+			MethodName: "BookGroup",
+			Handler:    _BookingService_BookGroup_Handler,
+		},
+		{
+			MethodName: "FindGroup",
+			Handler:    _BookingService_FindGroup_Handler,
+		},
+		{
+			MethodName: "CancelGroup",
+			Handler:    _BookingService_CancelGroup_Handler,
+		},
+		{
+			MethodName: "SetNoShowPolicy",
+			Handler:    _BookingService_SetNoShowPolicy_Handler,
+		},
+		{
+			MethodName: "NoShowPolicies",
+			Handler:    _BookingService_NoShowPolicies_Handler,
+		},
+		{
+			MethodName: "NoShows",
+			Handler:    _BookingService_NoShows_Handler,
+		},
+		{
+			MethodName: "SetBuffer",
+			Handler:    _BookingService_SetBuffer_Handler,
+		},
+		{
+			MethodName: "Buffers",
+			Handler:    _BookingService_Buffers_Handler,
+		},
+		{
+			MethodName: "Pass",
+			Handler:    _BookingService_Pass_Handler,
+		},
+		{
+			MethodName: "Gate",
+			Handler:    _BookingService_Gate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "booking.proto",
+}
+
+func init() { proto.RegisterFile("booking.proto", fileDescriptor_booking_e0fa986441c94582) }
+
+var fileDescriptor_booking_e0fa986441c94582 = []byte{
+	// 1699 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x5b, 0x73, 0xdb, 0x4e,
+	0x15, 0xaf, 0x6d, 0x59, 0xb2, 0x8e, 0x23, 0x27, 0x55, 0x93, 0x54, 0x75, 0x6f, 0xa9, 0x5a, 0x86,
+	0xb4, 0x03, 0xe9, 0x10, 0x66, 0xda, 0xa6, 0x0c, 0x9d, 0xb1, 0x0b, 0xa4, 0x61, 0x28, 0x93, 0x51,
+	0xe0, 0x01, 0x66, 0x18, 0x57, 0xb1, 0xd6, 0xcd, 0x12, 0x59, 0x12, 0xd2, 0xba, 0x34, 0xbc, 0xc0,
+	0x47, 0xe0, 0x99, 0x27, 0x9e, 0x78, 0x00, 0x86, 0x6f, 0xc1, 0xf7, 0xfa, 0xcf, 0xd9, 0x3d, 0x92,
+	0x25, 0xdb, 0xca, 0x65, 0xfe, 0xff, 0xb7, 0x3d, 0x97, 0xd5, 0x9e, 0xdb, 0xfe, 0xce, 0x59, 0x81,
+	0x75, 0x1a, 0xc7, 0xe7, 0x3c, 0xfa, 0xbc, 0x97, 0xa4, 0xb1, 0x88, 0x6d, 0x83, 0xc8, 0xbe, 0x93,
+	0xf8, 0x29, 0x2e, 0x5e, 0x26, 0xa7, 0x2f, 0x69, 0xa9, 0x54, 0xdc, 0xff, 0xb4, 0xc0, 0x18, 0x2a,
+	0x2d, 0xbb, 0x07, 0x4d, 0x1e, 0x38, 0x8d, 0x9d, 0xc6, 0x6e, 0xcb, 0x6b, 0xf2, 0xc0, 0xbe, 0x0b,
+	0x46, 0x96, 0xc4, 0x62, 0xc4, 0x03, 0xa7, 0x29, 0x99, 0x3a, 0x92, 0x47, 0x81, 0xfd, 0x10, 0xe0,
+	0x0b, 0x3b, 0xe3, 0xe3, 0x90, 0xa1, 0xac, 0x25, 0x65, 0x26, 0x71, 0x94, 0x38, 0x13, 0x7e, 0x2a,
+	0x46, 0x82, 0x4f, 0x99, 0xa3, 0x29, 0xb1, 0xe4, 0xfc, 0x86, 0x4f, 0x99, 0xdd, 0x87, 0x4e, 0x30,
+	0x4b, 0x7d, 0xc1, 0xe3, 0xc8, 0x69, 0x4b, 0x61, 0x41, 0xdb, 0x36, 0x68, 0xb3, 0x8c, 0xa5, 0x8e,
+	0xbe, 0xd3, 0xd8, 0x35, 0x3d, 0xb9, 0xb6, 0xb7, 0x41, 0xcf, 0x84, 0x2f, 0x66, 0x99, 0x63, 0x48,
+	0x2e, 0x51, 0xf6, 0x26, 0xb4, 0x93, 0x94, 0x8f, 0x99, 0xd3, 0x91, 0x6c, 0x45, 0xd8, 0x0e, 0x18,
+	0x49, 0xca, 0x12, 0x9f, 0x07, 0x8e, 0xb9, 0xd3, 0xd8, 0xed, 0x78, 0x39, 0x69, 0xbb, 0x60, 0x8d,
+	0xcf, 0xd8, 0xf8, 0x9c, 0x05, 0x23, 0x1e, 0x8d, 0x7c, 0xe1, 0x80, 0x3c, 0xbc, 0x4b, 0xcc, 0xa3,
+	0x68, 0x20, 0xd0, 0x74, 0x16, 0x09, 0x96, 0xb2, 0x00, 0x15, 0xba, 0xca, 0x74, 0xe2, 0x0c, 0x84,
+	0x7d, 0x1f, 0x4c, 0xf6, 0x95, 0x0b, 0x25, 0x5d, 0x53, 0xb6, 0x2b, 0xc6, 0x40, 0xd8, 0xf7, 0xa0,
+	0xf3, 0x39, 0x8d, 0x67, 0x09, 0xc6, 0xc4, 0x92, 0x32, 0x43, 0xd2, 0x47, 0x81, 0xfd, 0x14, 0xac,
+	0xd3, 0xd9, 0x64, 0xc2, 0xd2, 0xd1, 0x29, 0x9b, 0xc4, 0x29, 0x73, 0x7a, 0x52, 0xbe, 0xa6, 0x98,
+	0x43, 0xc9, 0xb3, 0x9f, 0x00, 0xd1, 0x23, 0x7f, 0x22, 0x58, 0xea, 0xac, 0x2b, 0xf3, 0x14, 0x6f,
+	0x80, 0x2c, 0x77, 0x1d, 0xac, 0x43, 0x26, 0x06, 0x61, 0xe8, 0xb1, 0x3f, 0xcd, 0x58, 0x26, 0xdc,
+	0x9f, 0x82, 0x45, 0xd9, 0xcb, 0x3c, 0x96, 0x84, 0x17, 0xf6, 0x0f, 0xa0, 0x43, 0x49, 0xcf, 0x9c,
+	0xc6, 0x4e, 0x6b, 0xb7, 0xbb, 0xbf, 0xb1, 0x47, 0x8c, 0x3d, 0xd2, 0xf4, 0x0a, 0x0d, 0xf7, 0x9f,
+	0x0d, 0xe8, 0xfe, 0x8a, 0x67, 0x82, 0x3e, 0x57, 0xce, 0x78, 0xa3, 0x92, 0xf1, 0x3c, 0x2f, 0xcd,
+	0x95, 0x79, 0x69, 0x55, 0xf2, 0x62, 0x83, 0x36, 0x49, 0xe3, 0x29, 0x25, 0x5e, 0xae, 0xb1, 0xb4,
+	0x44, 0x4c, 0xd9, 0x6e, 0x8a, 0x18, 0x75, 0xb2, 0x38, 0x15, 0x79, 0x9e, 0x71, 0x8d, 0xf9, 0x0c,
+	0xf9, 0x94, 0x0b, 0x99, 0xe6, 0xb6, 0xa7, 0x08, 0xd7, 0x85, 0x0d, 0xb2, 0xfb, 0x28, 0xc8, 0xcd,
+	0x9c, 0x17, 0xaa, 0x89, 0x85, 0xea, 0x7e, 0x02, 0xeb, 0xb7, 0x49, 0xe0, 0x0b, 0x56, 0xa3, 0xb0,
+	0x58, 0xc9, 0x66, 0xe1, 0xd7, 0x26, 0xb4, 0x65, 0x61, 0x52, 0x11, 0x2b, 0xc2, 0xde, 0x80, 0x16,
+	0x8b, 0x02, 0x72, 0x00, 0x97, 0xee, 0x14, 0xba, 0x68, 0x45, 0x4d, 0x9c, 0xcc, 0x9a, 0x9b, 0xa1,
+	0xce, 0x2a, 0xdd, 0x8c, 0xeb, 0x1e, 0xf7, 0x1a, 0x4c, 0x75, 0x1c, 0xa6, 0xf4, 0x05, 0xe4, 0xf7,
+	0x58, 0x1e, 0xb6, 0x2a, 0xa3, 0xb9, 0x82, 0xfb, 0xaf, 0x06, 0xac, 0x23, 0x73, 0xc8, 0xe6, 0x49,
+	0xdd, 0x80, 0x56, 0xe8, 0x0b, 0x32, 0x14, 0x97, 0x92, 0x13, 0x47, 0x64, 0x1e, 0x2e, 0x91, 0x93,
+	0xfa, 0x01, 0x25, 0x12, 0x97, 0x98, 0xdd, 0x29, 0x13, 0x29, 0x1f, 0x4b, 0xbb, 0x4c, 0x8f, 0xa8,
+	0x05, 0x0f, 0xdb, 0xb5, 0x1e, 0xea, 0x2b, 0x3c, 0x34, 0xe6, 0x1e, 0x4e, 0xc0, 0x9a, 0xdb, 0x79,
+	0x43, 0x2f, 0xed, 0xe7, 0xa0, 0x61, 0xbc, 0xa5, 0x03, 0xdd, 0xfd, 0xad, 0xbd, 0x1c, 0xd2, 0x7e,
+	0xfe, 0x55, 0xb0, 0x28, 0x60, 0xc1, 0x49, 0x12, 0x0b, 0x4f, 0xaa, 0xb8, 0x8f, 0xc1, 0xfa, 0x19,
+	0x0b, 0x59, 0x6d, 0x69, 0xb8, 0x16, 0x74, 0x73, 0x85, 0x24, 0xbc, 0x70, 0x5f, 0x80, 0xfd, 0x0b,
+	0x1e, 0x05, 0xc3, 0x8b, 0xe3, 0xb0, 0x54, 0x4f, 0x08, 0x35, 0x48, 0xd3, 0x3e, 0x45, 0xb8, 0x07,
+	0x60, 0x79, 0x2c, 0xe3, 0x7f, 0xa9, 0x2d, 0x3b, 0x07, 0x8c, 0x29, 0x8f, 0x66, 0x82, 0x65, 0xd2,
+	0xd4, 0xb6, 0x97, 0x93, 0xee, 0xef, 0xa0, 0x9b, 0x6f, 0xbd, 0xa9, 0xf3, 0x8f, 0x00, 0x02, 0x8e,
+	0x90, 0xc0, 0xa2, 0x31, 0xa3, 0x1c, 0x96, 0x38, 0xee, 0x3f, 0x9a, 0xa0, 0x7d, 0x88, 0xc3, 0xe0,
+	0x3b, 0x83, 0xf3, 0xfc, 0xee, 0x6b, 0xa5, 0xbb, 0x5f, 0x85, 0xf8, 0xf6, 0x65, 0x10, 0xaf, 0x2f,
+	0x40, 0x3c, 0x42, 0xec, 0xd7, 0x84, 0xa7, 0x2c, 0x43, 0x10, 0x55, 0x25, 0x61, 0x12, 0x67, 0x20,
+	0x6a, 0x50, 0x7d, 0x09, 0x40, 0xcd, 0x6b, 0x00, 0x28, 0x2c, 0x03, 0xe8, 0x5f, 0xa1, 0x8b, 0xb1,
+	0xf9, 0xb6, 0xf7, 0x78, 0x03, 0x5a, 0x42, 0x84, 0x32, 0x54, 0x6d, 0x0f, 0x97, 0xf3, 0xba, 0xd7,
+	0x56, 0xd4, 0x7d, 0x7b, 0x5e, 0xf7, 0x7b, 0x60, 0x2a, 0x03, 0x30, 0xed, 0x4f, 0x40, 0x3b, 0x8b,
+	0xc3, 0x80, 0x72, 0x6e, 0x15, 0x39, 0x97, 0x1a, 0x52, 0x84, 0xf5, 0x8b, 0x54, 0x3d, 0xf6, 0x9d,
+	0x43, 0xfb, 0x10, 0xbb, 0xcc, 0x52, 0xba, 0x57, 0x41, 0xf6, 0x63, 0xe8, 0xd2, 0x19, 0x23, 0x1e,
+	0x20, 0x6e, 0xb7, 0x76, 0x5b, 0x1e, 0x9c, 0xe6, 0xf8, 0x9a, 0xa1, 0xdf, 0xe3, 0x94, 0xf9, 0xd4,
+	0xe1, 0xa8, 0x75, 0x13, 0x67, 0x20, 0xdc, 0xff, 0x35, 0x60, 0x4d, 0x9e, 0x96, 0x5b, 0xf3, 0x18,
+	0xba, 0xf3, 0x38, 0xa9, 0x8e, 0x63, 0x7a, 0x50, 0x04, 0x2a, 0xc3, 0xa6, 0x48, 0x11, 0xc6, 0x3b,
+	0x80, 0x52, 0x43, 0x85, 0x38, 0xcb, 0x71, 0xa9, 0xb5, 0x84, 0x4b, 0xda, 0x1c, 0x97, 0xb6, 0x41,
+	0x4f, 0xfd, 0x80, 0xcf, 0x32, 0x42, 0x1a, 0xa2, 0xae, 0x0d, 0x33, 0x9f, 0x00, 0xc8, 0x5e, 0x8c,
+	0xf7, 0x33, 0x68, 0xcb, 0x8e, 0x4c, 0x01, 0xef, 0x15, 0x01, 0x57, 0x3a, 0x4a, 0x58, 0x69, 0xa1,
+	0xcd, 0x2b, 0x5b, 0xe8, 0x0e, 0xf4, 0x0e, 0x55, 0x97, 0xaf, 0xcb, 0xd0, 0x1f, 0x61, 0xed, 0xd7,
+	0xf1, 0xc9, 0x59, 0xfc, 0xe7, 0xe3, 0x38, 0xe4, 0xe3, 0x0b, 0xbc, 0x1c, 0x13, 0x7f, 0xcc, 0x43,
+	0x2e, 0x2e, 0x48, 0xab, 0xa0, 0xd1, 0xaf, 0xcf, 0xa9, 0x4f, 0xf7, 0xba, 0xe5, 0x29, 0x02, 0xfd,
+	0x9a, 0x30, 0x96, 0x47, 0x6a, 0xc2, 0x98, 0xea, 0xbd, 0x29, 0x3f, 0x57, 0xe3, 0x55, 0xc7, 0x23,
+	0xca, 0xfd, 0x5b, 0x03, 0xee, 0x94, 0x0f, 0xcb, 0x6d, 0xba, 0xec, 0xcc, 0xa7, 0x60, 0xc9, 0x63,
+	0x46, 0x55, 0xac, 0x5a, 0x93, 0xcc, 0x8f, 0x8a, 0x77, 0x03, 0x13, 0x86, 0x70, 0xbb, 0x6a, 0x01,
+	0x46, 0xfe, 0x87, 0xa0, 0x27, 0x92, 0xa4, 0xd0, 0x6f, 0x15, 0x11, 0xad, 0xe8, 0x92, 0x92, 0x7b,
+	0x17, 0xb6, 0x4a, 0x7c, 0xce, 0xb2, 0x7c, 0xde, 0xf9, 0x00, 0x77, 0x16, 0x05, 0xf8, 0xf9, 0x1f,
+	0x41, 0x27, 0x21, 0x06, 0x4d, 0x3d, 0x35, 0x07, 0x14, 0x6a, 0xee, 0xbf, 0x1b, 0xa0, 0x2b, 0x11,
+	0x16, 0xfd, 0xfc, 0x56, 0xd0, 0x0d, 0x32, 0x8b, 0x4b, 0xb1, 0xf2, 0x22, 0x95, 0x80, 0xa3, 0x55,
+	0xc1, 0xd2, 0x72, 0xa0, 0xb5, 0x85, 0x40, 0x53, 0x0c, 0xdb, 0xab, 0x62, 0xa8, 0x97, 0x63, 0x88,
+	0x25, 0x54, 0x60, 0x63, 0xd3, 0x17, 0xee, 0x33, 0xe8, 0x29, 0x5b, 0xf3, 0x40, 0x14, 0x46, 0x35,
+	0xe6, 0x46, 0xb9, 0x6f, 0xf3, 0x42, 0xcb, 0xf2, 0xae, 0xd2, 0x89, 0xe2, 0x51, 0x86, 0x0c, 0x8a,
+	0xca, 0xfa, 0x42, 0x54, 0x3c, 0x23, 0x52, 0x1b, 0xdc, 0x73, 0xd0, 0x87, 0x12, 0x27, 0xeb, 0x67,
+	0xc0, 0xb2, 0x6b, 0xcd, 0x05, 0xd7, 0xb6, 0x41, 0x27, 0x60, 0xa6, 0x70, 0x28, 0x0a, 0xeb, 0x59,
+	0x61, 0x31, 0xc1, 0xa2, 0x24, 0xdc, 0xbf, 0x37, 0xc0, 0x52, 0xa7, 0x5d, 0x39, 0x78, 0x5e, 0x76,
+	0xe8, 0xf7, 0xa0, 0xa7, 0x8e, 0x29, 0x2a, 0x57, 0x01, 0xb2, 0xa5, 0xb8, 0x79, 0xe9, 0x3e, 0x05,
+	0x4b, 0x1e, 0x5b, 0x68, 0x69, 0xaa, 0xbe, 0x25, 0x93, 0x94, 0xdc, 0x57, 0xd0, 0xcd, 0x2d, 0xc2,
+	0xd0, 0x7d, 0x1f, 0x74, 0xd5, 0x36, 0xa8, 0x5e, 0xe7, 0x81, 0x23, 0x2d, 0x12, 0xbb, 0x1b, 0xd0,
+	0x53, 0x9c, 0xa2, 0x44, 0x0f, 0x60, 0xad, 0xe0, 0xe0, 0xa7, 0x9e, 0x83, 0xa1, 0x74, 0x97, 0x93,
+	0x40, 0xdf, 0xca, 0xe5, 0xee, 0x7f, 0x1b, 0xa0, 0x1d, 0xfb, 0x59, 0x76, 0x55, 0x45, 0xd6, 0x76,
+	0xf2, 0x72, 0xb4, 0x5a, 0x0b, 0xd1, 0x7a, 0x08, 0x10, 0xc5, 0x22, 0xef, 0x9f, 0x04, 0xed, 0x51,
+	0x2c, 0xa8, 0x79, 0xde, 0x07, 0x24, 0xa8, 0x73, 0xd2, 0xb3, 0x2c, 0x8a, 0x85, 0x6c, 0x9b, 0x98,
+	0x46, 0x11, 0x9f, 0xb3, 0x88, 0xe6, 0x75, 0x45, 0x60, 0x2f, 0x43, 0x6b, 0x8b, 0x5e, 0x96, 0xf8,
+	0x59, 0xb6, 0xd4, 0xcb, 0xa4, 0x86, 0x14, 0xb9, 0x7f, 0x80, 0xee, 0x61, 0x75, 0xa8, 0x52, 0x1f,
+	0x6d, 0x94, 0x3e, 0x7a, 0x69, 0xc2, 0x1f, 0x80, 0x19, 0xf0, 0x94, 0x8d, 0xe5, 0x5c, 0xa1, 0xfc,
+	0x9b, 0x33, 0xf6, 0xff, 0xdf, 0x85, 0x1e, 0xe1, 0xf3, 0x09, 0x4b, 0xbf, 0xe0, 0xd8, 0xf0, 0x16,
+	0x74, 0xf5, 0x5e, 0xb2, 0xb7, 0xe7, 0x58, 0x5f, 0x7e, 0x40, 0xf5, 0xb7, 0x17, 0xa1, 0x5d, 0xb9,
+	0xe3, 0xde, 0xb2, 0x5f, 0x81, 0x86, 0x4f, 0x23, 0x7b, 0xb3, 0xd0, 0x28, 0xbd, 0x94, 0x2e, 0xd9,
+	0xf7, 0x1a, 0x34, 0x9c, 0x20, 0xed, 0x7b, 0x8b, 0x1a, 0x45, 0x87, 0xe8, 0xdb, 0x15, 0xd1, 0xfc,
+	0x40, 0x5d, 0xbd, 0x62, 0x4a, 0xc6, 0x56, 0x9e, 0x35, 0x35, 0xfb, 0xf6, 0x41, 0x43, 0xb2, 0x64,
+	0x68, 0xe9, 0xa9, 0x52, 0xb3, 0xe7, 0x1d, 0x74, 0xf2, 0xf1, 0xdb, 0x76, 0x2a, 0x1a, 0x43, 0x56,
+	0xe7, 0x64, 0x31, 0xab, 0xbb, 0xb7, 0xec, 0x37, 0xa0, 0xab, 0xa9, 0xb9, 0x64, 0x6b, 0x65, 0xce,
+	0xee, 0x6f, 0x2e, 0xf1, 0xd5, 0xce, 0x5f, 0xc2, 0x6d, 0x0c, 0xcf, 0x60, 0x2c, 0xf8, 0x17, 0x46,
+	0x63, 0xb6, 0x7d, 0xbf, 0x50, 0x5e, 0x1e, 0xbe, 0x2f, 0x09, 0xf5, 0x5b, 0x30, 0xde, 0xe3, 0xe3,
+	0xfd, 0x28, 0xba, 0x79, 0xb4, 0xdf, 0x80, 0xae, 0x9e, 0x0b, 0x25, 0x0f, 0x2a, 0xd3, 0x7c, 0x7f,
+	0x73, 0x89, 0xaf, 0x76, 0x1e, 0x80, 0x71, 0x72, 0x16, 0xa7, 0x82, 0x45, 0x37, 0xde, 0xba, 0x4f,
+	0xa3, 0xf9, 0x66, 0x75, 0xd4, 0x5b, 0x32, 0xb4, 0x18, 0x11, 0x65, 0x3d, 0x19, 0xef, 0xe3, 0x68,
+	0xc2, 0xd3, 0xa9, 0xbd, 0x5d, 0x51, 0xb8, 0xca, 0xc3, 0x03, 0x30, 0x3c, 0x16, 0x32, 0x3f, 0x63,
+	0xb5, 0x1b, 0xeb, 0x92, 0x74, 0xa0, 0xde, 0x9f, 0x6a, 0xb0, 0xdc, 0x5a, 0x18, 0x93, 0x68, 0xef,
+	0x9d, 0x45, 0xb6, 0xda, 0xfa, 0x13, 0x30, 0x31, 0x87, 0x6a, 0xeb, 0xdd, 0xaa, 0xce, 0x51, 0x70,
+	0xc5, 0xe6, 0x77, 0xd0, 0x7d, 0xef, 0x47, 0x63, 0x16, 0x5e, 0xb1, 0xbd, 0xce, 0xee, 0x8f, 0xb0,
+	0x7e, 0xc2, 0x44, 0x65, 0xda, 0x7a, 0xb0, 0x7a, 0x10, 0xa0, 0x0f, 0xf5, 0x6b, 0xa4, 0xea, 0x73,
+	0xc7, 0xd0, 0x2b, 0xb1, 0x39, 0xcb, 0xec, 0x47, 0xab, 0xf4, 0xe7, 0xf3, 0x49, 0xff, 0x41, 0xad,
+	0x3c, 0x8f, 0x8e, 0xa1, 0x04, 0x59, 0xc9, 0xb9, 0x6a, 0x6b, 0xef, 0x6f, 0x2d, 0x0b, 0x8a, 0xd0,
+	0x9e, 0x30, 0x41, 0x6d, 0x7a, 0x7b, 0xb1, 0x8b, 0x2c, 0x85, 0xa6, 0xd4, 0xcf, 0xd4, 0xc9, 0x8a,
+	0x51, 0x3e, 0xb9, 0xda, 0xba, 0xfa, 0x5b, 0xcb, 0x82, 0x02, 0xd3, 0x64, 0x5f, 0xba, 0xd6, 0x2d,
+	0x2b, 0x7a, 0x82, 0x2a, 0x78, 0x84, 0xfc, 0x52, 0xc1, 0x1f, 0x5e, 0x85, 0x67, 0x43, 0xed, 0xf7,
+	0xcd, 0xe4, 0xf4, 0x54, 0x97, 0xff, 0x27, 0x7f, 0xfc, 0xcd, 0x00, 0x0e, 0xa4, 0xbc, 0x76, 0xd3,
+	0x14, 0x00, 0x00,
+}
@@ -0,0 +1,36 @@
+package booking
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+// Prices are kept to the cent, in the units of parking.Spot.Cost, which is
+// what an hour on the spot costs. A spot without a cost is free.
+
+var ErrInvalidCost = apierror.New(apierror.Internal, "invalid_spot_cost", "the cost of the spot is not a number")
+
+// price is what d costs on a spot of the hourly cost, in cents
+func price(cost string, d time.Duration) (int64, error) {
+	if cost == "" {
+		return 0, nil
+	}
+	perHour, err := strconv.ParseFloat(cost, 64)
+	if err != nil || perHour < 0 {
+		return 0, ErrInvalidCost
+	}
+	return int64(math.Round(perHour * 100 * d.Hours())), nil
+}
+
+// cents reads an amount formatted by formatCents, the empty amount is zero
+func cents(amount string) int64 {
+	v, _ := strconv.ParseFloat(amount, 64)
+	return int64(math.Round(v * 100))
+}
+
+func formatCents(c int64) string {
+	return strconv.FormatFloat(float64(c)/100, 'f', 2, 64)
+}
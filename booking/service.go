@@ -2,78 +2,1311 @@ package booking
 
 import (
 	"context"
-	"errors"
+	"sync"
 	"time"
 
 	"strconv"
 
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/event"
 	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
 )
 
 var (
-	ErrInvalidSpotId             = errors.New("invalid spotid passed in booking request")
-	ErrAlreadyReserved           = errors.New("spot already reserved")
-	ErrInvalidBookingId          = errors.New("invalid booking id  passed in delete booking request")
-	ErrInvalidSpotIdForBookingId = errors.New("invalid slot id for booking id passed in delete booking request")
-	ErrFailedToUpdate            = errors.New("Failed to update/release slot")
+	ErrInvalidSpotId = apierror.New(apierror.Unprocessable, "invalid_spot", "invalid spotid passed in booking request").
+				WithField("id", "no such spot")
+	ErrAlreadyReserved  = apierror.New(apierror.Conflict, "spot_already_reserved", "spot already reserved")
+	ErrInvalidBookingId = apierror.New(apierror.NotFound, "booking_not_found", "invalid booking id  passed in delete booking request").
+				WithField("id", "no such booking")
+	ErrInvalidSpotIdForBookingId = apierror.New(apierror.Internal, "booking_spot_missing", "invalid slot id for booking id passed in delete booking request")
+	ErrFailedToUpdate            = apierror.New(apierror.Internal, "spot_update_failed", "Failed to update/release slot")
+	ErrInvalidVehicleId          = apierror.New(apierror.Unprocessable, "invalid_vehicle", "invalid vehicle id passed in booking request").
+					WithField("vehicleId", "no such vehicle owned by the caller")
+	ErrAlreadyCheckedIn = apierror.New(apierror.Conflict, "booking_already_checked_in", "booking already checked in")
+	ErrNotActive        = apierror.New(apierror.Conflict, "booking_not_active", "booking is outside its time window")
+	ErrHoldExpired      = apierror.New(apierror.Conflict, "hold_expired", "the hold expired and its spot was released")
+	ErrGroupUnavailable = apierror.New(apierror.Conflict, "group_unavailable", "not enough free spots for the group, nothing was booked")
+	ErrNoMatch          = apierror.New(apierror.Conflict, "no_spot_available", "no free spot in the search area fits the vehicle")
+	ErrOverlap          = apierror.New(apierror.Conflict, "booking_overlap", "the spot is booked by another booking at that time")
+	ErrNoShow           = apierror.New(apierror.Conflict, "booking_no_show", "the booking was not checked in within the grace period and its spot was released")
+	ErrClosed           = apierror.New(apierror.Unprocessable, "spot_closed", "the spot is closed during the booked window")
 )
 
+const (
+	// DefaultHoldTTL is how long a hold stands when no TTL is asked for
+	DefaultHoldTTL = 5 * time.Minute
+	// MaxHoldTTL is the longest a spot may be held without a booking
+	MaxHoldTTL = 15 * time.Minute
+	// MaxGroupSize is the most bookings a group may make at once
+	MaxGroupSize = 50
+)
+
+// Change is a change of a booking asked for with Update, the zero value of
+// a field leaves the booking as it is
+type Change struct {
+	SpotId    string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// GroupSpec asks for a booking for each of VehicleIds, either on the spots
+// of SpotIds in the same order, or on the nearest free spots within Radius
+// meters of Lat, Lon
+type GroupSpec struct {
+	VehicleIds []string
+	SpotIds    []string
+	Lat        string
+	Lon        string
+	Radius     string
+	StartTime  time.Time
+	Duration   time.Duration
+}
+
 type Service interface {
 	GetAll(ctx context.Context) ([]Booking, error)
-	Book(ctx context.Context, spotId string, startTime time.Time, duration time.Duration) (Booking, error)
+	// List returns the bookings matching q. Drivers only see their own.
+	List(ctx context.Context, q Query) ([]Booking, error)
+	// Find returns a booking of the caller
+	Find(ctx context.Context, bookingId string) (Booking, error)
+	// Update moves a booking that has not ended to another time window or
+	// spot. A new spot must be free and fit the vehicle, the old one is
+	// released. Neither the spot nor the start of a checked in booking
+	// can change.
+	Update(ctx context.Context, bookingId string, c Change) (Booking, error)
+	// Book books the window on the spot for the vehicle. The spot is
+	// reserved once the window starts, until then other bookings of the
+	// window are refused as overlapping.
+	Book(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (Booking, error)
+	// BookPrepaid is Book for a window paid for elsewhere, e.g. by a
+	// monthly pass. It is not served over HTTP.
+	BookPrepaid(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (Booking, error)
+	// BookBest books the highest ranked spot of a parking search that is
+	// free and fits the vehicle, moving on to the next one when a spot is
+	// taken meanwhile
+	BookBest(ctx context.Context, lat, lon, radius string, metric parking.SearchMetric, vehicleId string, startTime time.Time, duration time.Duration) (Booking, parking.ExtendedSpot, error)
 	Delete(ctx context.Context, bookingId string) error
+	FindActiveByPlate(ctx context.Context, plate string) ([]Booking, error)
+	// CheckIn records the arrival of the vehicle during the booked window
+	CheckIn(ctx context.Context, bookingId string) (Booking, error)
+	// Expire marks the bookings whose window has ended as expired and
+	// releases their spots, as well as the spots of lapsed holds, and
+	// reserves the spots of the bookings whose window has started. Bookings
+	// not checked in within the grace period of their facility are marked
+	// no-shows instead, releasing the spot early. It is run periodically,
+	// not exposed over HTTP.
+	Expire(ctx context.Context) ([]Booking, error)
+	// Extend lengthens a booking that has not ended by d, unless another
+	// booking of the spot starts before. Its spot stays reserved
+	// throughout. It returns the booking and the price of the time added.
+	Extend(ctx context.Context, bookingId string, d time.Duration) (Booking, string, error)
+	// Shorten brings the end of a booking that has not ended forward by d,
+	// the end must stay after now. It returns the booking and the refund
+	// as a negative price.
+	Shorten(ctx context.Context, bookingId string, d time.Duration) (Booking, string, error)
+	// Hold reserves the spot for the vehicle for ttl, DefaultHoldTTL when
+	// zero, without booking it yet. A held spot is not free.
+	Hold(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration, ttl time.Duration) (Hold, error)
+	// Confirm turns a hold that has not expired into a booking
+	Confirm(ctx context.Context, holdId string) (Booking, error)
+	// Release gives up a hold and frees its spot
+	Release(ctx context.Context, holdId string) error
+	// BookGroup books a spot for every vehicle of spec, or nothing at all
+	BookGroup(ctx context.Context, spec GroupSpec) (Group, []Booking, error)
+	// FindGroup returns the group with the bookings that are left of it
+	FindGroup(ctx context.Context, groupId string) (Group, []Booking, error)
+	// CancelGroup cancels the bookings left of the group and the group
+	CancelGroup(ctx context.Context, groupId string) error
+	// SetNoShowPolicy sets the grace period and the fee or strike of a
+	// no-show at a facility
+	SetNoShowPolicy(ctx context.Context, p NoShowPolicy) (NoShowPolicy, error)
+	NoShowPolicies(ctx context.Context) ([]NoShowPolicy, error)
+	// NoShows returns the no-shows of user, of everyone when empty. Drivers
+	// only see their own.
+	NoShows(ctx context.Context, user string) ([]NoShow, error)
+	// SetBuffer sets the turnover buffers kept around the bookings of a spot
+	// or of the spots of a facility. Bookings made before keep theirs.
+	SetBuffer(ctx context.Context, b Buffer) (Buffer, error)
+	Buffers(ctx context.Context) ([]Buffer, error)
+	// Pass returns the gate pass of a booking that has not ended
+	Pass(ctx context.Context, bookingId string) (Pass, error)
+	// Gate validates a pass at a gate of facility and records the entry or
+	// exit. A pass enters once and exits once.
+	Gate(ctx context.Context, token, facility string, d Direction) (Booking, error)
 }
 
 type service struct {
 	bookingStore   BookingStore
+	holdStore      HoldStore
+	groupStore     GroupStore
+	noShowStore    NoShowStore
+	bufferStore    BufferStore
 	parkingService parking.Service
+	vehicleService vehicle.Service
+	events         event.Publisher
+	passes         *Passes
+	// gateMtx serializes the gates so a pass can't be used twice at once
+	gateMtx sync.Mutex
+	// holdMtx serializes confirming, releasing and expiring holds
+	holdMtx sync.Mutex
+	// reserveMtx makes checking and reserving a spot one step, and
+	// serializes the changes of the window and status of bookings
+	reserveMtx sync.Mutex
+	now        func() time.Time
+}
+
+// Options are the optional collaborators of the service. A nil store keeps
+// its records in memory.
+type Options struct {
+	Holds   HoldStore
+	Groups  GroupStore
+	NoShows NoShowStore
+	Buffers BufferStore
+	// Events receives the booking events, none are published when nil
+	Events event.Publisher
+	// Passes signs the gate passes, none are issued when nil
+	Passes *Passes
+	// Now tells the time, time.Now when nil
+	Now func() time.Time
 }
 
-func NewService(bookingStore BookingStore, pService parking.Service) Service {
-	return &service{bookingStore: bookingStore, parkingService: pService}
+// NewService returns the booking service
+func NewService(bookingStore BookingStore, pService parking.Service, vService vehicle.Service, o Options) Service {
+	if o.Holds == nil {
+		o.Holds, _ = NewInMemHoldStore()
+	}
+	if o.Groups == nil {
+		o.Groups, _ = NewInMemGroupStore()
+	}
+	if o.NoShows == nil {
+		o.NoShows, _ = NewInMemNoShowStore()
+	}
+	if o.Buffers == nil {
+		o.Buffers, _ = NewInMemBufferStore()
+	}
+	if o.Events == nil {
+		o.Events = event.Nop
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return &service{bookingStore: bookingStore, holdStore: o.Holds, groupStore: o.Groups, noShowStore: o.NoShows, bufferStore: o.Buffers,
+		parkingService: pService, vehicleService: vService, events: o.Events, passes: o.Passes, now: o.Now}
 }
 
 func (s *service) GetAll(ctx context.Context) ([]Booking, error) {
 	return s.bookingStore.GetAll()
 }
 
-func (s *service) Book(ctx context.Context, spotId string, startTime time.Time, duration time.Duration) (Booking, error) {
-	spot, err := s.parkingService.FindById(ctx, string(spotId))
+func (s *service) List(ctx context.Context, q Query) ([]Booking, error) {
+	if err := q.Valid(); err != nil {
+		return nil, err
+	}
+	if !auth.CanAccess(ctx, q.User) {
+		if q.User != "" {
+			return nil, auth.ErrForbidden
+		}
+		p, _ := auth.PrincipalFromContext(ctx)
+		q.User = p.Subject
+	}
+	return s.bookingStore.Query(q)
+}
+
+func (s *service) Find(ctx context.Context, bookingId string) (Booking, error) {
+	return s.find(ctx, bookingId)
+}
+
+func (s *service) Update(ctx context.Context, bookingId string, c Change) (Booking, error) {
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	b, err := s.find(ctx, bookingId)
+	if err != nil {
+		return Booking{}, err
+	}
+	now := s.now()
+	if b.Released() || !now.Before(b.EndTime()) {
+		return Booking{}, ErrNotActive
+	}
+	moveSpot := c.SpotId != "" && c.SpotId != strconv.Itoa(b.SpotId)
+	if b.Status == StatusCheckedIn && (moveSpot || !c.StartTime.IsZero() && !c.StartTime.Equal(b.StartTime)) {
+		return Booking{}, ErrAlreadyCheckedIn
+	}
+	prev := b
+	start, end := b.StartTime, b.EndTime()
+	if !c.StartTime.IsZero() {
+		start = c.StartTime
+	}
+	if !c.EndTime.IsZero() {
+		end = c.EndTime
+	}
+	if !end.After(start) {
+		return Booking{}, ErrInvalidReq.WithField("end", "must be after start")
+	}
+	if !end.After(now) {
+		return Booking{}, ErrInvalidReq.WithField("end", "must be in the future")
+	}
+	b.StartTime, b.Duration = start, end.Sub(start)
+	if !moveSpot {
+		if err := s.checkOpen(ctx, b.SpotId, start, end); err != nil {
+			return Booking{}, err
+		}
+		p, err := s.spotPrice(ctx, b, b.Duration)
+		if err != nil {
+			return Booking{}, err
+		}
+		b.Price = formatCents(p)
+		if !s.started(prev.StartTime) {
+			// the new window may start now or meet a hold
+			if _, _, err := s.reserveLocked(ctx, strconv.Itoa(b.SpotId), strconv.Itoa(b.VehicleId), b.StartTime, b.Duration); err != nil {
+				return Booking{}, err
+			}
+		}
+		if b, err = s.bookingStore.Update(b); err != nil {
+			if !s.started(prev.StartTime) {
+				s.unreserve(ctx, b.SpotId, start)
+			}
+			return Booking{}, err
+		}
+		if s.started(prev.StartTime) && !s.started(b.StartTime) {
+			// moved to a later window, the spot waits for it
+			if err := s.release(ctx, b.SpotId, b.ID); err != nil {
+				return Booking{}, err
+			}
+		}
+	} else {
+		spot, v, err := s.reserveLocked(ctx, c.SpotId, strconv.Itoa(b.VehicleId), b.StartTime, b.Duration)
+		if err != nil {
+			return Booking{}, err
+		}
+		// the booking takes the price and buffers of the new spot
+		nb, err := s.newBooking(ctx, spot, v, b.StartTime, b.Duration)
+		if err == nil {
+			b.SpotId, b.BufferBefore, b.BufferAfter = spot.ID, nb.BufferBefore, nb.BufferAfter
+			if !b.Prepaid {
+				b.Price = nb.Price
+			}
+			b, err = s.bookingStore.Update(b)
+		}
+		if err == nil && s.started(prev.StartTime) {
+			if err = s.release(ctx, prev.SpotId, prev.ID); err != nil {
+				s.bookingStore.Update(prev)
+			}
+		}
+		if err != nil {
+			s.unreserve(ctx, spot.ID, b.StartTime)
+			return Booking{}, err
+		}
+	}
+	s.publish(ctx, BookingChanged{Booking: b, Previous: prev})
+	return b, nil
+}
+
+// spotPrice is what d costs on the spot of b, in cents, nothing when b is
+// prepaid
+func (s *service) spotPrice(ctx context.Context, b Booking, d time.Duration) (int64, error) {
+	if b.Prepaid {
+		return 0, nil
+	}
+	spot, err := s.parkingService.FindById(ctx, strconv.Itoa(b.SpotId))
 	if err != nil {
-		return Booking{}, ErrInvalidSpotId
+		return 0, parkingError(err, ErrInvalidSpotIdForBookingId)
+	}
+	return price(spot.Cost, d)
+}
+
+// newBooking is the booking of the window on spot for v, priced at the cost
+// of the spot and kept apart from the others by its buffers. It fails when
+// the spot is still being turned over at startTime or closed during the
+// window.
+func (s *service) newBooking(ctx context.Context, spot parking.Spot, v vehicle.Vehicle, startTime time.Time, duration time.Duration) (Booking, error) {
+	if startTime.Before(spot.TurnoverUntil) {
+		return Booking{}, ErrOverlap.WithField("start", "the spot is taken until "+spot.TurnoverUntil.Format(time.RFC3339))
 	}
-	if spot.IsReserved == true {
-		return Booking{}, ErrAlreadyReserved
+	if err := s.checkOpen(ctx, spot.ID, startTime, startTime.Add(duration)); err != nil {
+		return Booking{}, err
 	}
-	spotIdInt, err := strconv.Atoi(spotId)
+	p, err := price(spot.Cost, duration)
+	if err != nil {
+		return Booking{}, err
+	}
+	buf, err := s.bufferStore.Buffer(spot.ID, spot.Facility)
+	if err != nil {
+		return Booking{}, err
+	}
+	return Booking{SpotId: spot.ID, VehicleId: v.ID, User: v.Owner, StartTime: startTime, Duration: duration, Price: formatCents(p),
+		BufferBefore: buf.Before, BufferAfter: buf.After}, nil
+}
+
+// checkOpen fails with ErrClosed unless the spot is open throughout the
+// window
+func (s *service) checkOpen(ctx context.Context, spotId int, start, end time.Time) error {
+	sc, err := s.parkingService.Schedule(ctx, strconv.Itoa(spotId))
+	if err != nil {
+		return parkingError(err, ErrInvalidSpotIdForBookingId)
+	}
+	if bo, ok := sc.BlackoutDuring(start, end); ok {
+		return ErrClosed.WithField("start", "the spot is closed from "+bo.From.Format(time.RFC3339)+" until "+bo.Until.Format(time.RFC3339))
+	}
+	if at, ok := sc.OpenThroughout(start, end); !ok {
+		field := "end"
+		if at.Equal(start) {
+			field = "start"
+		}
+		return ErrClosed.WithField(field, "the spot is closed at "+at.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// find returns the booking if it was made by the caller
+func (s *service) find(ctx context.Context, bookingId string) (Booking, error) {
+	id, err := strconv.Atoi(bookingId)
 	if err != nil {
 		return Booking{}, ErrInvalidReq
 	}
-	spot.IsReserved = true
-	_, err = s.parkingService.Update(ctx, spot)
+	b, err := s.bookingStore.Find(id)
+	if err != nil {
+		return Booking{}, ErrInvalidBookingId
+	}
+	if !auth.CanAccess(ctx, b.User) {
+		return Booking{}, auth.ErrForbidden
+	}
+	return b, nil
+}
+
+// Book books the spot for the vehicle. The vehicle must belong to the
+// caller and fit the spot's class and size restrictions.
+func (s *service) Book(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (Booking, error) {
+	return s.book(ctx, spotId, vehicleId, startTime, duration, false)
+}
+
+func (s *service) BookPrepaid(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (Booking, error) {
+	return s.book(ctx, spotId, vehicleId, startTime, duration, true)
+}
+
+func (s *service) book(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration, prepaid bool) (Booking, error) {
+	spot, v, err := s.reserve(ctx, spotId, vehicleId, startTime, duration)
 	if err != nil {
-		return Booking{}, ErrInternal
+		return Booking{}, err
+	}
+	b, err := s.newBooking(ctx, spot, v, startTime, duration)
+	if err == nil {
+		if prepaid {
+			b.Prepaid, b.Price = true, formatCents(0)
+		}
+		b, err = s.bookingStore.Book(b)
+	}
+	if err != nil {
+		s.unreserve(ctx, spot.ID, startTime)
+		return Booking{}, err
+	}
+	s.publish(ctx, BookingCreated{b})
+	return b, nil
+}
+
+func (s *service) BookBest(ctx context.Context, lat, lon, radius string, metric parking.SearchMetric, vehicleId string, startTime time.Time, duration time.Duration) (Booking, parking.ExtendedSpot, error) {
+	if metric != parking.COST && metric != parking.DIST {
+		return Booking{}, parking.ExtendedSpot{}, ErrInvalidReq.WithField("metric", "must be cost or dist")
+	}
+	if duration <= 0 {
+		return Booking{}, parking.ExtendedSpot{}, ErrInvalidReq.WithField("end", "must be after start")
+	}
+	found, err := s.parkingService.Search(ctx, lat, lon, radius, metric, false)
+	if err != nil {
+		return Booking{}, parking.ExtendedSpot{}, err
+	}
+	es, v, err := s.reserveFirst(ctx, found, vehicleId, startTime, duration, nil)
+	if err == errNoCandidate {
+		return Booking{}, parking.ExtendedSpot{}, ErrNoMatch
+	}
+	if err != nil {
+		return Booking{}, parking.ExtendedSpot{}, err
+	}
+	b, err := s.newBooking(ctx, es.Spot, v, startTime, duration)
+	if err == nil {
+		b, err = s.bookingStore.Book(b)
+	}
+	if err != nil {
+		s.unreserve(ctx, es.ID, startTime)
+		return Booking{}, parking.ExtendedSpot{}, err
+	}
+	s.publish(ctx, BookingCreated{b})
+	return b, es, nil
+}
+
+// errNoCandidate tells that none of the candidates could be reserved
+var errNoCandidate = apierror.New(apierror.Conflict, "no_candidate", "no candidate spot could be reserved")
+
+// reserveFirst reserves the first of candidates, in order, that is free at
+// startTime, open for duration, not taken already and fits the vehicle
+func (s *service) reserveFirst(ctx context.Context, candidates []parking.ExtendedSpot, vehicleId string, startTime time.Time, duration time.Duration, taken map[int]bool) (parking.ExtendedSpot, vehicle.Vehicle, error) {
+	for _, es := range candidates {
+		if !es.Free(startTime) || taken[es.ID] {
+			continue
+		}
+		if err := s.checkOpen(ctx, es.ID, startTime, startTime.Add(duration)); err != nil {
+			if apierror.From(err).Code == ErrClosed.Code {
+				continue
+			}
+			return parking.ExtendedSpot{}, vehicle.Vehicle{}, err
+		}
+		spot, v, err := s.reserve(ctx, strconv.Itoa(es.ID), vehicleId, startTime, duration)
+		if err == ErrAlreadyReserved || err == vehicle.ErrClassNotAllowed || err == vehicle.ErrDoesNotFit {
+			// try the next spot
+			continue
+		}
+		if err != nil {
+			return parking.ExtendedSpot{}, vehicle.Vehicle{}, err
+		}
+		es.Spot = spot
+		return es, v, nil
+	}
+	return parking.ExtendedSpot{}, vehicle.Vehicle{}, errNoCandidate
+}
+
+// started reports whether the window from start on has started. Only then
+// does a booking take its spot, the booking store keeps the windows that
+// start later apart until Expire reserves their spots.
+func (s *service) started(start time.Time) bool {
+	return !s.now().Before(start)
+}
+
+// reserve checks the vehicle belongs to the caller and fits the spot, and
+// marks the spot reserved when the window from startTime on has started. A
+// later window must not meet a hold of the spot.
+func (s *service) reserve(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (parking.Spot, vehicle.Vehicle, error) {
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	return s.reserveLocked(ctx, spotId, vehicleId, startTime, duration)
+}
+
+// reserveLocked is reserve for a caller holding reserveMtx
+func (s *service) reserveLocked(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (parking.Spot, vehicle.Vehicle, error) {
+	spot, err := s.parkingService.FindById(ctx, string(spotId))
+	if err != nil {
+		return parking.Spot{}, vehicle.Vehicle{}, parkingError(err, ErrInvalidSpotId)
+	}
+	takes := s.started(startTime)
+	if takes && spot.IsReserved {
+		return parking.Spot{}, vehicle.Vehicle{}, ErrAlreadyReserved
+	}
+	if !takes {
+		held, err := s.held(spot.ID, startTime, startTime.Add(duration))
+		if err != nil {
+			return parking.Spot{}, vehicle.Vehicle{}, err
+		}
+		if held {
+			return parking.Spot{}, vehicle.Vehicle{}, ErrAlreadyReserved
+		}
+	}
+	if _, err := strconv.Atoi(spotId); err != nil {
+		return parking.Spot{}, vehicle.Vehicle{}, ErrInvalidReq
+	}
+	v, err := s.vehicleService.Find(ctx, vehicleId)
+	if err == auth.ErrForbidden {
+		return parking.Spot{}, vehicle.Vehicle{}, err
+	}
+	if err != nil {
+		return parking.Spot{}, vehicle.Vehicle{}, ErrInvalidVehicleId
+	}
+	if err := vehicle.CheckFit(v, spot); err != nil {
+		return parking.Spot{}, vehicle.Vehicle{}, err
+	}
+	if !takes {
+		return spot, v, nil
+	}
+	if spot, err = s.parkingService.SetReserved(ctx, spotId, true, time.Time{}); err == parking.ErrAlreadyReserved {
+		// another replica took it since
+		return parking.Spot{}, vehicle.Vehicle{}, ErrAlreadyReserved
+	} else if err != nil {
+		return parking.Spot{}, vehicle.Vehicle{}, parkingError(err, ErrInternal)
+	}
+	return spot, v, nil
+}
+
+// held reports whether a live hold of the spot meets the window
+func (s *service) held(spotId int, from, until time.Time) (bool, error) {
+	hh, err := s.holdStore.GetAll()
+	if err != nil {
+		return false, err
+	}
+	now := s.now()
+	for _, h := range hh {
+		hFrom, hUntil := h.StartTime.Add(-h.BufferBefore), h.StartTime.Add(h.Duration+h.BufferAfter)
+		if h.SpotId == spotId && now.Before(h.ExpiresAt) && from.Before(hUntil) && hFrom.Before(until) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// inUse reports whether a booking of the spot other than except is within
+// its window now
+func (s *service) inUse(spotId, except int) (bool, error) {
+	bb, err := s.bookingStore.FindBySpot(spotId)
+	if err != nil {
+		return false, err
+	}
+	now := s.now()
+	for _, b := range bb {
+		if b.ID != except && !b.Released() && b.ActiveAt(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// take reserves the spot of a booking whose window has started
+func (s *service) take(ctx context.Context, b Booking) error {
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	b, err := s.bookingStore.Find(b.ID)
+	if err != nil || b.Released() || !b.ActiveAt(s.now()) {
+		// cancelled or moved meanwhile
+		return nil
+	}
+	_, err = s.parkingService.SetReserved(ctx, strconv.Itoa(b.SpotId), true, time.Time{})
+	if err != nil && err != parking.ErrAlreadyReserved && err != parking.ErrNotFound {
+		return parkingError(err, ErrFailedToUpdate)
+	}
+	return nil
+}
+
+// turnOver releases the spot of the ended booking b, unless the next
+// booking has started on it. Another booking may start once the after
+// buffer of b and the before buffer of the spot have passed.
+func (s *service) turnOver(ctx context.Context, b Booking) error {
+	spot, err := s.parkingService.FindById(ctx, strconv.Itoa(b.SpotId))
+	if err == nil && spot.IsReserved {
+		if busy, err := s.inUse(spot.ID, b.ID); err != nil || busy {
+			return err
+		}
+		var buf Buffer
+		if buf, err = s.bufferStore.Buffer(spot.ID, spot.Facility); err != nil {
+			return err
+		}
+		var until time.Time
+		if gap := b.BufferAfter + buf.Before; gap > 0 {
+			until = b.EndTime().Add(gap)
+		}
+		_, err = s.parkingService.SetReserved(ctx, strconv.Itoa(spot.ID), false, until)
+	}
+	if err != nil && err != parking.ErrNotReserved && err != parking.ErrNotFound {
+		return parkingError(err, ErrFailedToUpdate)
+	}
+	return nil
+}
+
+// release frees a reserved spot, unless a booking other than except has
+// started on it. A deleted spot has nothing to free.
+func (s *service) release(ctx context.Context, spotId, except int) error {
+	spot, err := s.parkingService.FindById(ctx, strconv.Itoa(spotId))
+	if err == nil && spot.IsReserved {
+		if busy, err := s.inUse(spotId, except); err != nil || busy {
+			return err
+		}
+		_, err = s.parkingService.SetReserved(ctx, strconv.Itoa(spotId), false, time.Time{})
+	}
+	if err != nil && err != parking.ErrNotReserved && err != parking.ErrNotFound {
+		return parkingError(err, ErrFailedToUpdate)
+	}
+	return nil
+}
+
+// unreserve undoes reserve of the window from start on, which only took
+// the spot when it had started
+func (s *service) unreserve(ctx context.Context, spotId int, start time.Time) {
+	if s.started(start) {
+		s.release(ctx, spotId, 0)
 	}
-	return s.bookingStore.Book(spotIdInt, startTime, duration)
 }
 
 func (s *service) Delete(ctx context.Context, bookingId string) error {
-	bookingIdInt, err := strconv.Atoi(bookingId)
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	b, err := s.find(ctx, bookingId)
+	if err != nil {
+		return err
+	}
+	if b.Released() {
+		// the spot was released when the booking expired or was a no-show
+		return s.bookingStore.Delete(b.ID)
+	}
+	// a booking that has not started holds no spot yet
+	if s.started(b.StartTime) {
+		if err := s.release(ctx, b.SpotId, b.ID); err != nil {
+			return err
+		}
+	}
+	if err := s.bookingStore.Delete(b.ID); err != nil {
+		return err
+	}
+	s.publish(ctx, BookingCancelled{b})
+	return nil
+}
+
+// FindActiveByPlate returns the bookings currently active for the vehicles
+// registered with plate
+func (s *service) FindActiveByPlate(ctx context.Context, plate string) ([]Booking, error) {
+	vv, err := s.vehicleService.SearchByPlate(ctx, plate)
 	if err != nil {
-		return ErrInvalidReq
+		return nil, err
 	}
-	b, err := s.bookingStore.Find(bookingIdInt)
+	now := s.now()
+	bb := make([]Booking, 0)
+	for _, v := range vv {
+		vb, err := s.bookingStore.FindByVehicle(v.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range vb {
+			if b.ActiveAt(now) {
+				bb = append(bb, b)
+			}
+		}
+	}
+	return bb, nil
+}
+
+func (s *service) CheckIn(ctx context.Context, bookingId string) (Booking, error) {
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	b, err := s.find(ctx, bookingId)
 	if err != nil {
-		return ErrInvalidBookingId
+		return Booking{}, err
+	}
+	if b.Status == StatusCheckedIn {
+		return Booking{}, ErrAlreadyCheckedIn
+	}
+	if b.Status == StatusNoShow {
+		return Booking{}, ErrNoShow
+	}
+	now := s.now()
+	if b.Status != StatusBooked || !b.ActiveAt(now) {
+		return Booking{}, ErrNotActive
+	}
+	b.Status = StatusCheckedIn
+	b.CheckedInAt = now
+	if b, err = s.bookingStore.Update(b); err != nil {
+		return Booking{}, err
+	}
+	s.publish(ctx, BookingCheckedIn{b})
+	return b, nil
+}
+
+func (s *service) Expire(ctx context.Context) ([]Booking, error) {
+	bb, err := s.bookingStore.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	if err := s.expireHolds(ctx, now); err != nil {
+		return nil, err
+	}
+	expired := make([]Booking, 0)
+	started := make([]Booking, 0)
+	for _, b := range bb {
+		if b.Released() {
+			continue
+		}
+		if b.Status == StatusBooked {
+			noShow, err := s.noShow(ctx, b, now)
+			if err != nil {
+				return expired, err
+			}
+			if noShow {
+				continue
+			}
+		}
+		if now.Before(b.EndTime()) {
+			if b.ActiveAt(now) {
+				started = append(started, b)
+			}
+			continue
+		}
+		if err := s.turnOver(ctx, b); err != nil {
+			// leave it for the next run
+			return expired, err
+		}
+		b.Status = StatusExpired
+		if b, err = s.bookingStore.Update(b); err != nil {
+			return expired, err
+		}
+		s.publish(ctx, BookingExpired{b})
+		expired = append(expired, b)
+	}
+	// the spots are taken once the bookings before gave them back
+	for _, b := range started {
+		if err := s.take(ctx, b); err != nil {
+			return expired, err
+		}
+	}
+	return expired, nil
+}
+
+// noShow marks b a no-show and releases its spot when the grace period of
+// its facility passed without a check in
+func (s *service) noShow(ctx context.Context, b Booking, now time.Time) (bool, error) {
+	if now.Before(b.StartTime) {
+		return false, nil
 	}
 	spot, err := s.parkingService.FindById(ctx, strconv.Itoa(b.SpotId))
+	if err != nil && err != parking.ErrNotFound {
+		return false, parkingError(err, ErrInvalidSpotIdForBookingId)
+	}
+	p, err := s.noShowStore.Policy(spot.Facility)
+	if err != nil || p.Grace <= 0 || now.Before(b.StartTime.Add(p.Grace)) {
+		return false, err
+	}
+
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	if b, err = s.bookingStore.Find(b.ID); err != nil || b.Status != StatusBooked {
+		// cancelled or checked in meanwhile
+		return false, nil
+	}
+	if err := s.release(ctx, b.SpotId, b.ID); err != nil {
+		return false, err
+	}
+	b.Status = StatusNoShow
+	if b, err = s.bookingStore.Update(b); err != nil {
+		return false, err
+	}
+	n, err := s.noShowStore.Record(NoShow{BookingId: b.ID, User: b.User, SpotId: b.SpotId, Facility: spot.Facility, Fee: p.Fee, Strike: p.Strike, At: now.UTC()})
 	if err != nil {
-		return ErrInvalidSpotIdForBookingId
+		return false, err
 	}
-	spot.IsReserved = false
-	_, err = s.parkingService.Update(ctx, spot)
+	s.publish(ctx, BookingNoShow{Booking: b, NoShow: n})
+	return true, nil
+}
+
+func (s *service) SetNoShowPolicy(ctx context.Context, p NoShowPolicy) (NoShowPolicy, error) {
+	if p.Grace < 0 {
+		return NoShowPolicy{}, ErrInvalidReq.WithField("grace", "must not be negative")
+	}
+	if p.Fee != "" {
+		if fee, err := strconv.ParseFloat(p.Fee, 64); err != nil || fee < 0 {
+			return NoShowPolicy{}, ErrInvalidReq.WithField("fee", "must be a non-negative number")
+		}
+	}
+	return s.noShowStore.SetPolicy(p)
+}
+
+func (s *service) NoShowPolicies(ctx context.Context) ([]NoShowPolicy, error) {
+	return s.noShowStore.Policies()
+}
+
+func (s *service) NoShows(ctx context.Context, user string) ([]NoShow, error) {
+	if !auth.CanAccess(ctx, user) {
+		if user != "" {
+			return nil, auth.ErrForbidden
+		}
+		p, _ := auth.PrincipalFromContext(ctx)
+		user = p.Subject
+	}
+	return s.noShowStore.NoShows(user)
+}
+
+func (s *service) SetBuffer(ctx context.Context, b Buffer) (Buffer, error) {
+	if b.SpotId != 0 && b.Facility != "" {
+		return Buffer{}, ErrInvalidReq.WithField("spotId", "give either spotId or facility")
+	}
+	if b.Before < 0 {
+		return Buffer{}, ErrInvalidReq.WithField("before", "must not be negative")
+	}
+	if b.After < 0 {
+		return Buffer{}, ErrInvalidReq.WithField("after", "must not be negative")
+	}
+	if b.SpotId != 0 {
+		if _, err := s.parkingService.FindById(ctx, strconv.Itoa(b.SpotId)); err != nil {
+			return Buffer{}, parkingError(err, ErrInvalidSpotId)
+		}
+	}
+	return s.bufferStore.SetBuffer(b)
+}
+
+func (s *service) Buffers(ctx context.Context) ([]Buffer, error) {
+	return s.bufferStore.Buffers()
+}
+
+func (s *service) Extend(ctx context.Context, bookingId string, d time.Duration) (Booking, string, error) {
+	if d <= 0 {
+		return Booking{}, "", ErrInvalidReq.WithField("duration", "must be positive")
+	}
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	b, err := s.find(ctx, bookingId)
+	if err != nil {
+		return Booking{}, "", err
+	}
+	if b.Released() || !s.now().Before(b.EndTime()) {
+		return Booking{}, "", ErrNotActive
+	}
+	prev := b
+	b.Duration += d
+	next, ok, err := s.next(b)
+	if err != nil {
+		return Booking{}, "", err
+	}
+	if _, until := b.Blocked(); ok {
+		if nFrom, _ := next.Blocked(); until.After(nFrom) {
+			return Booking{}, "", ErrOverlap.WithField("duration", "the spot is booked from "+nFrom.Add(-b.BufferAfter).Format(time.RFC3339))
+		}
+	}
+	if err := s.checkOpen(ctx, b.SpotId, b.StartTime, b.EndTime()); err != nil {
+		return Booking{}, "", err
+	}
+	charge, err := s.spotPrice(ctx, b, d)
+	if err != nil {
+		return Booking{}, "", err
+	}
+	b.Price = formatCents(cents(b.Price) + charge)
+	return s.resized(ctx, b, prev, charge)
+}
+
+func (s *service) Shorten(ctx context.Context, bookingId string, d time.Duration) (Booking, string, error) {
+	if d <= 0 {
+		return Booking{}, "", ErrInvalidReq.WithField("duration", "must be positive")
+	}
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	b, err := s.find(ctx, bookingId)
+	if err != nil {
+		return Booking{}, "", err
+	}
+	now := s.now()
+	if b.Released() || !now.Before(b.EndTime()) {
+		return Booking{}, "", ErrNotActive
+	}
+	prev := b
+	b.Duration -= d
+	if b.Duration <= 0 || !now.Before(b.EndTime()) {
+		return Booking{}, "", ErrInvalidReq.WithField("duration", "would end the booking before now")
+	}
+	refund, err := s.spotPrice(ctx, b, d)
+	if err != nil {
+		return Booking{}, "", err
+	}
+	if paid := cents(b.Price); refund > paid {
+		refund = paid
+	}
+	b.Price = formatCents(cents(b.Price) - refund)
+	return s.resized(ctx, b, prev, -refund)
+}
+
+// next returns the booking of the spot of b that starts next after it, no-shows
+// and expired bookings left out
+func (s *service) next(b Booking) (Booking, bool, error) {
+	bb, err := s.bookingStore.FindBySpot(b.SpotId)
+	if err != nil {
+		return Booking{}, false, err
+	}
+	var next Booking
+	ok := false
+	for _, o := range bb {
+		if o.ID == b.ID || o.Released() || o.StartTime.Before(b.StartTime) {
+			continue
+		}
+		if !ok || o.StartTime.Before(next.StartTime) {
+			next, ok = o, true
+		}
+	}
+	return next, ok, nil
+}
+
+// resized stores the booking lengthened or shortened from prev and tells
+// the change in price. The caller holds reserveMtx.
+func (s *service) resized(ctx context.Context, b, prev Booking, difference int64) (Booking, string, error) {
+	b, err := s.bookingStore.Update(b)
+	if err != nil {
+		return Booking{}, "", err
+	}
+	s.publish(ctx, BookingChanged{Booking: b, Previous: prev})
+	return b, formatCents(difference), nil
+}
+
+func (s *service) Hold(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration, ttl time.Duration) (Hold, error) {
+	if ttl == 0 {
+		ttl = DefaultHoldTTL
+	}
+	if ttl < 0 || ttl > MaxHoldTTL {
+		return Hold{}, ErrInvalidReq.WithField("ttl", "must be positive and at most "+MaxHoldTTL.String())
+	}
+	// a hold keeps its spot from others right away
+	spot, v, err := s.reserve(ctx, spotId, vehicleId, s.now(), duration)
+	if err != nil {
+		return Hold{}, err
+	}
+	// the price is quoted when the spot is held
+	b, err := s.newBooking(ctx, spot, v, startTime, duration)
+	if err == nil {
+		err = s.bookingStore.Overlap(b)
+	}
+	var h Hold
+	if err == nil {
+		h, err = s.holdStore.Create(Hold{SpotId: spot.ID, VehicleId: v.ID, User: v.Owner, StartTime: startTime, Duration: duration, Price: b.Price, ExpiresAt: s.now().Add(ttl).UTC(),
+			BufferBefore: b.BufferBefore, BufferAfter: b.BufferAfter})
+	}
+	if err != nil {
+		s.release(ctx, spot.ID, 0)
+		return Hold{}, err
+	}
+	return h, nil
+}
+
+func (s *service) Confirm(ctx context.Context, holdId string) (Booking, error) {
+	s.holdMtx.Lock()
+	defer s.holdMtx.Unlock()
+	h, err := s.findHold(ctx, holdId)
+	if err != nil {
+		return Booking{}, err
+	}
+	if !s.now().Before(h.ExpiresAt) {
+		if err := s.expireHold(ctx, h); err != nil {
+			return Booking{}, err
+		}
+		return Booking{}, ErrHoldExpired
+	}
+	b, err := s.bookingStore.Book(Booking{SpotId: h.SpotId, VehicleId: h.VehicleId, User: h.User, StartTime: h.StartTime, Duration: h.Duration, Price: h.Price,
+		BufferBefore: h.BufferBefore, BufferAfter: h.BufferAfter})
+	if err != nil {
+		return Booking{}, err
+	}
+	if err := s.holdStore.Delete(h.ID); err != nil {
+		return Booking{}, err
+	}
+	// the spot stays reserved for a booking that has started, a later one
+	// takes it when it starts
+	if !s.started(b.StartTime) {
+		if err := s.release(ctx, b.SpotId, b.ID); err != nil {
+			return Booking{}, err
+		}
+	}
+	s.publish(ctx, BookingCreated{b})
+	return b, nil
+}
+
+func (s *service) Release(ctx context.Context, holdId string) error {
+	s.holdMtx.Lock()
+	defer s.holdMtx.Unlock()
+	h, err := s.findHold(ctx, holdId)
+	if err != nil {
+		return err
+	}
+	if err := s.release(ctx, h.SpotId, 0); err != nil {
+		return err
+	}
+	if err := s.holdStore.Delete(h.ID); err != nil {
+		return err
+	}
+	s.publish(ctx, HoldReleased{h})
+	return nil
+}
+
+// findHold returns the hold if its vehicle belongs to the caller
+func (s *service) findHold(ctx context.Context, holdId string) (Hold, error) {
+	id, err := strconv.Atoi(holdId)
+	if err != nil {
+		return Hold{}, ErrInvalidReq
+	}
+	h, err := s.holdStore.Find(id)
+	if err != nil {
+		return Hold{}, err
+	}
+	if !auth.CanAccess(ctx, h.User) {
+		return Hold{}, auth.ErrForbidden
+	}
+	return h, nil
+}
+
+func (s *service) expireHolds(ctx context.Context, now time.Time) error {
+	s.holdMtx.Lock()
+	defer s.holdMtx.Unlock()
+	hh, err := s.holdStore.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, h := range hh {
+		if now.Before(h.ExpiresAt) {
+			continue
+		}
+		if err := s.expireHold(ctx, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expireHold frees the spot of a lapsed hold. The caller holds holdMtx.
+func (s *service) expireHold(ctx context.Context, h Hold) error {
+	if err := s.release(ctx, h.SpotId, 0); err != nil {
+		return err
+	}
+	if err := s.holdStore.Delete(h.ID); err != nil {
+		return err
+	}
+	s.publish(ctx, HoldExpired{h})
+	return nil
+}
+
+func (s *service) BookGroup(ctx context.Context, spec GroupSpec) (Group, []Booking, error) {
+	n := len(spec.VehicleIds)
+	if n == 0 || n > MaxGroupSize {
+		return Group{}, nil, ErrInvalidReq.WithField("vehicleIds", "must list 1 to "+strconv.Itoa(MaxGroupSize)+" vehicles")
+	}
+	near := spec.Lat != "" || spec.Lon != "" || spec.Radius != ""
+	if near == (len(spec.SpotIds) > 0) {
+		return Group{}, nil, ErrInvalidReq.WithField("spotIds", "give either spotIds or lat, lon and radius")
+	}
+	if !near && len(spec.SpotIds) != n {
+		return Group{}, nil, ErrInvalidReq.WithField("spotIds", "must list a spot for each vehicle")
+	}
+	if spec.Duration <= 0 {
+		return Group{}, nil, ErrInvalidReq.WithField("end", "must be after start")
+	}
+
+	var spots []parking.Spot
+	var vehicles []vehicle.Vehicle
+	var err error
+	if near {
+		spots, vehicles, err = s.reserveNear(ctx, spec)
+	} else {
+		spots, vehicles, err = s.reserveAll(ctx, spec)
+	}
+	if err != nil {
+		return Group{}, nil, err
+	}
+
+	bb := make([]Booking, 0, n)
+	for i, spot := range spots {
+		b, err := s.newBooking(ctx, spot, vehicles[i], spec.StartTime, spec.Duration)
+		if err != nil {
+			s.releaseAll(ctx, spots, spec.StartTime)
+			return Group{}, nil, err
+		}
+		bb = append(bb, b)
+	}
+	p, _ := auth.PrincipalFromContext(ctx)
+	g, err := s.groupStore.Create(Group{User: p.Subject, CreatedAt: s.now().UTC()})
+	if err != nil {
+		s.releaseAll(ctx, spots, spec.StartTime)
+		return Group{}, nil, err
+	}
+	for i := range bb {
+		bb[i].GroupId = g.ID
+		if bb[i], err = s.bookingStore.Book(bb[i]); err != nil {
+			s.abandonGroup(ctx, g, bb, spots)
+			return Group{}, nil, err
+		}
+		g.BookingIds = append(g.BookingIds, bb[i].ID)
+	}
+	if _, err = s.groupStore.Update(g); err != nil {
+		s.abandonGroup(ctx, g, bb, spots)
+		return Group{}, nil, err
+	}
+	for _, b := range bb {
+		s.publish(ctx, BookingCreated{b})
+	}
+	return g, bb, nil
+}
+
+// abandonGroup undoes a group that could not be stored in full: the
+// bookings stored so far, the reservations of its spots and the group
+func (s *service) abandonGroup(ctx context.Context, g Group, bb []Booking, spots []parking.Spot) {
+	for _, b := range bb {
+		if b.ID != 0 {
+			s.bookingStore.Delete(b.ID)
+		}
+	}
+	s.releaseAll(ctx, spots, bb[0].StartTime)
+	s.groupStore.Delete(g.ID)
+}
+
+// reserveAll reserves the spots of spec for its vehicles. When one fails
+// the spots reserved so far are released.
+func (s *service) reserveAll(ctx context.Context, spec GroupSpec) ([]parking.Spot, []vehicle.Vehicle, error) {
+	spots := make([]parking.Spot, 0, len(spec.SpotIds))
+	vehicles := make([]vehicle.Vehicle, 0, len(spec.SpotIds))
+	for i, spotId := range spec.SpotIds {
+		spot, v, err := s.reserve(ctx, spotId, spec.VehicleIds[i], spec.StartTime, spec.Duration)
+		if err != nil {
+			s.releaseAll(ctx, spots, spec.StartTime)
+			return nil, nil, err
+		}
+		spots, vehicles = append(spots, spot), append(vehicles, v)
+	}
+	return spots, vehicles, nil
+}
+
+// reserveNear reserves for each vehicle of spec in turn the nearest free
+// spot it fits. When one finds none the spots reserved so far are released.
+func (s *service) reserveNear(ctx context.Context, spec GroupSpec) ([]parking.Spot, []vehicle.Vehicle, error) {
+	found, err := s.parkingService.Search(ctx, spec.Lat, spec.Lon, spec.Radius, parking.DIST, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	spots := make([]parking.Spot, 0, len(spec.VehicleIds))
+	vehicles := make([]vehicle.Vehicle, 0, len(spec.VehicleIds))
+	taken := make(map[int]bool)
+	for _, vehicleId := range spec.VehicleIds {
+		es, v, err := s.reserveFirst(ctx, found, vehicleId, spec.StartTime, spec.Duration, taken)
+		if err == errNoCandidate {
+			err = ErrGroupUnavailable
+		}
+		if err != nil {
+			s.releaseAll(ctx, spots, spec.StartTime)
+			return nil, nil, err
+		}
+		taken[es.ID] = true
+		spots, vehicles = append(spots, es.Spot), append(vehicles, v)
+	}
+	return spots, vehicles, nil
+}
+
+// releaseAll frees the spots reserved for a group of the window from start
+// on that could not be booked
+func (s *service) releaseAll(ctx context.Context, spots []parking.Spot, start time.Time) {
+	for _, spot := range spots {
+		s.unreserve(ctx, spot.ID, start)
+	}
+}
+
+func (s *service) FindGroup(ctx context.Context, groupId string) (Group, []Booking, error) {
+	g, err := s.findGroup(ctx, groupId)
+	if err != nil {
+		return Group{}, nil, err
+	}
+	bb := make([]Booking, 0, len(g.BookingIds))
+	for _, id := range g.BookingIds {
+		if b, err := s.bookingStore.Find(id); err == nil {
+			bb = append(bb, b)
+		}
+	}
+	return g, bb, nil
+}
+
+func (s *service) CancelGroup(ctx context.Context, groupId string) error {
+	g, bb, err := s.FindGroup(ctx, groupId)
+	if err != nil {
+		return err
+	}
+	for _, b := range bb {
+		if err := s.Delete(ctx, strconv.Itoa(b.ID)); err != nil && err != ErrInvalidBookingId {
+			return err
+		}
+	}
+	return s.groupStore.Delete(g.ID)
+}
+
+// findGroup returns the group if it was made by the caller
+func (s *service) findGroup(ctx context.Context, groupId string) (Group, error) {
+	id, err := strconv.Atoi(groupId)
+	if err != nil {
+		return Group{}, ErrInvalidReq
+	}
+	g, err := s.groupStore.Find(id)
+	if err != nil {
+		return Group{}, err
+	}
+	if !auth.CanAccess(ctx, g.User) {
+		return Group{}, auth.ErrForbidden
+	}
+	return g, nil
+}
+
+func (s *service) Pass(ctx context.Context, bookingId string) (Pass, error) {
+	// only the owner of the booking gets the pass
+	b, err := s.find(ctx, bookingId)
 	if err != nil {
-		return ErrFailedToUpdate
+		return Pass{}, err
+	}
+	if b.Released() || !s.now().Before(b.EndTime()) {
+		return Pass{}, ErrNotActive
+	}
+	if s.passes == nil {
+		return Pass{}, ErrNoPasses
+	}
+	spot, err := s.parkingService.FindById(ctx, strconv.Itoa(b.SpotId))
+	if err != nil {
+		return Pass{}, parkingError(err, ErrInvalidSpotIdForBookingId)
+	}
+	return s.passes.Issue(b, spot.Facility), nil
+}
+
+func (s *service) Gate(ctx context.Context, token, facility string, d Direction) (Booking, error) {
+	if d != Entry && d != Exit {
+		return Booking{}, ErrInvalidReq.WithField("direction", "must be entry or exit")
+	}
+	if s.passes == nil {
+		return Booking{}, ErrNoPasses
+	}
+	p, err := s.passes.Verify(token)
+	if err != nil {
+		return Booking{}, err
+	}
+	if p.Facility != facility {
+		return Booking{}, ErrWrongFacility
+	}
+	now := s.now()
+	if now.Before(p.NotBefore) || !now.Before(p.NotAfter) {
+		return Booking{}, ErrPassNotNow
+	}
+
+	s.gateMtx.Lock()
+	defer s.gateMtx.Unlock()
+	s.reserveMtx.Lock()
+	defer s.reserveMtx.Unlock()
+	b, err := s.bookingStore.Find(p.BookingId)
+	if err != nil || b.SpotId != p.SpotId {
+		// cancelled since
+		return Booking{}, ErrInvalidPass
+	}
+	checkIn := false
+	switch d {
+	case Entry:
+		if !b.EnteredAt.IsZero() {
+			return Booking{}, ErrPassUsed
+		}
+		if b.Status == StatusExpired {
+			return Booking{}, ErrNotActive
+		}
+		if b.Status == StatusNoShow {
+			return Booking{}, ErrNoShow
+		}
+		b.EnteredAt = now
+		if b.Status == StatusBooked {
+			// entering the garage checks the booking in
+			checkIn = true
+			b.Status = StatusCheckedIn
+			b.CheckedInAt = now
+		}
+	case Exit:
+		if b.EnteredAt.IsZero() {
+			return Booking{}, ErrNotEntered
+		}
+		if !b.ExitedAt.IsZero() {
+			return Booking{}, ErrPassUsed
+		}
+		b.ExitedAt = now
+	}
+	if b, err = s.bookingStore.Update(b); err != nil {
+		return Booking{}, err
+	}
+	if checkIn {
+		s.publish(ctx, BookingCheckedIn{b})
+	}
+	return b, nil
+}
+
+func (s *service) publish(ctx context.Context, p event.Payload) {
+	s.events.Publish(ctx, event.New(p, s.now()))
+}
+
+// parkingError reports a failed call to the parking service as fallback,
+// unless parking could not be reached at all
+func parkingError(err, fallback error) error {
+	if apierror.From(err).Kind == apierror.Unavailable {
+		return err
 	}
-	return s.bookingStore.Delete(bookingIdInt)
+	return fallback
 }
@@ -1,15 +1,64 @@
 package booking
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"time"
 
 	"strconv"
 
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking/pb"
+	"github.com/atuldaemon/rct/event"
 	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+type nopCounter struct{}
+
+func (c nopCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c nopCounter) Add(delta float64)                          {}
+
+// system is the context of the background work inside the process
+var system = auth.NewContext(context.Background(), auth.System)
+
+func newVehicleService(t *testing.T) vehicle.Service {
+	vInMemStore, err := vehicle.NewInMemVehicleStore()
+
+	if err != nil {
+		t.Error("Failed to create vehicle inmem store")
+	}
+	vService := vehicle.NewService(vInMemStore)
+	_, err = vService.Register(nil, vehicle.Vehicle{
+		Plate:      "AB 123 CD",
+		Region:     "MN",
+		Class:      parking.Car,
+		Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150},
+	})
+	if err != nil {
+		t.Error("Failed to register vehicle")
+	}
+	t.Log("Created vehicle service")
+	return vService
+}
+
 func TestBook(t *testing.T) {
 
 	pInMemStore, err := parking.NewInMemParkingStore()
@@ -23,16 +72,18 @@ func TestBook(t *testing.T) {
 	t.Log("Created parking service")
 
 	bInMemStore, err := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
 
 	if err != nil {
 		t.Error("Failed to create booking inmem store")
 	}
 	t.Log("Created inmem booking store")
 
-	bService := NewService(bInMemStore, pService)
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
 	t.Log("Created booking service")
 
-	b, err := bService.Book(nil, "1", time.Now(), time.Duration(30*time.Minute))
+	b, err := bService.Book(system, "1", "1", time.Now(), time.Duration(30*time.Minute))
 
 	if err != nil {
 		t.Error("Error in booking")
@@ -56,16 +107,18 @@ func TestBookMultiple(t *testing.T) {
 	t.Log("Created parking service")
 
 	bInMemStore, err := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
 
 	if err != nil {
 		t.Error("Failed to create booking inmem store")
 	}
 	t.Log("Created inmem booking store")
 
-	bService := NewService(bInMemStore, pService)
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
 	t.Log("Created booking service")
 
-	b, err := bService.Book(nil, "1", time.Now(), time.Duration(30*time.Minute))
+	b, err := bService.Book(system, "1", "1", time.Now(), time.Duration(30*time.Minute))
 
 	if err != nil {
 		t.Error("Error in booking")
@@ -75,7 +128,7 @@ func TestBookMultiple(t *testing.T) {
 	}
 	t.Log("Booked spot")
 
-	_, err = bService.Book(nil, "1", time.Now(), time.Duration(30*time.Minute))
+	_, err = bService.Book(system, "1", "1", time.Now(), time.Duration(30*time.Minute))
 
 	if err == nil {
 		t.Error("Expecting error in booking the same spot again")
@@ -97,16 +150,18 @@ func TestDeleteBooking(t *testing.T) {
 	t.Log("Created parking service")
 
 	bInMemStore, err := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
 
 	if err != nil {
 		t.Error("Failed to create booking inmem store")
 	}
 	t.Log("Created inmem booking store")
 
-	bService := NewService(bInMemStore, pService)
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
 	t.Log("Created booking service")
 
-	b, err := bService.Book(nil, "1", time.Now(), time.Duration(30*time.Minute))
+	b, err := bService.Book(system, "1", "1", time.Now(), time.Duration(30*time.Minute))
 
 	if err != nil {
 		t.Error("Error in booking")
@@ -116,13 +171,13 @@ func TestDeleteBooking(t *testing.T) {
 	}
 	t.Log("Booked spot")
 
-	err = bService.Delete(nil, strconv.Itoa(b.ID))
+	err = bService.Delete(system, strconv.Itoa(b.ID))
 	if err != nil {
 		t.Error("Could not free spot")
 	}
 
 	// book the same spot again
-	_, err = bService.Book(nil, "1", time.Now(), time.Duration(30*time.Minute))
+	_, err = bService.Book(system, "1", "1", time.Now(), time.Duration(30*time.Minute))
 
 	if err != nil {
 		t.Error("Could not book a free spot")
@@ -130,3 +185,1238 @@ func TestDeleteBooking(t *testing.T) {
 		t.Log("booked a spot which was released")
 	}
 }
+
+// slowParking widens the windows between the calls to the parking service
+type slowParking struct {
+	parking.Service
+}
+
+func (p slowParking) FindById(ctx context.Context, spotId string) (parking.Spot, error) {
+	time.Sleep(time.Millisecond)
+	return p.Service.FindById(ctx, spotId)
+}
+
+func (p slowParking) Update(ctx context.Context, spot parking.Spot) (parking.Spot, error) {
+	time.Sleep(time.Millisecond)
+	return p.Service.Update(ctx, spot)
+}
+
+func TestDeleteRacingUpdate(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := slowParking{parking.NewService(pInMemStore)}
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+
+	// a booking moved while it is deleted must not leave either spot
+	// reserved behind
+	for i := 0; i < 20; i++ {
+		b, err := bService.Book(system, "1", "1", time.Now(), time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			bService.Update(system, strconv.Itoa(b.ID), Change{SpotId: "4"})
+		}()
+		go func(i int) {
+			defer wg.Done()
+			time.Sleep(time.Duration(i%10) * 200 * time.Microsecond)
+			if err := bService.Delete(system, strconv.Itoa(b.ID)); err != nil {
+				t.Error(err)
+			}
+		}(i)
+		wg.Wait()
+		for _, id := range []int{1, 4} {
+			if sp, _ := pInMemStore.FindById(id); sp.IsReserved {
+				t.Fatalf("Expected spot %d to be free once the booking is deleted", id)
+			}
+		}
+	}
+}
+
+func TestReserveAtStart(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	now := time.Now()
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Now: func() time.Time { return now }})
+	reserved := func() bool {
+		sp, _ := pInMemStore.FindById(1)
+		return sp.IsReserved
+	}
+
+	// the spot stays free until the evening booking starts
+	evening, err := bService.Book(system, "1", "1", now.Add(10*time.Hour), time.Hour)
+	if err != nil || reserved() {
+		t.Fatalf("Expected a later booking to leave the spot free, got %v %v", err, reserved())
+	}
+	if _, err := bService.Book(system, "1", "1", now.Add(9*time.Hour+30*time.Minute), time.Hour); err == nil || apierror.From(err).Code != "booking_overlap" {
+		t.Errorf("Expected an overlapping window to be refused, got %v", err)
+	}
+	morning, err := bService.Book(system, "1", "1", now, 2*time.Hour)
+	if err != nil || !reserved() {
+		t.Fatalf("Expected a booking starting now to reserve the spot, got %v %v", err, reserved())
+	}
+	// cancelling the evening leaves the morning's spot reserved
+	if err := bService.Delete(system, strconv.Itoa(evening.ID)); err != nil || !reserved() {
+		t.Fatalf("Expected the morning booking to keep the spot, got %v %v", err, reserved())
+	}
+	bService.Book(system, "1", "1", now.Add(10*time.Hour), time.Hour)
+
+	now = now.Add(3 * time.Hour)
+	bService.Expire(system)
+	if reserved() {
+		t.Error("Expected the spot to be free between the bookings")
+	}
+	now = now.Add(7 * time.Hour)
+	bService.Expire(system)
+	if !reserved() {
+		t.Error("Expected the spot to be reserved once the evening booking starts")
+	}
+	if b, _ := bService.Find(system, strconv.Itoa(morning.ID)); b.Status != StatusExpired {
+		t.Errorf("Expected the morning booking to have expired, got %+v", b)
+	}
+}
+
+func TestBookVehicleMustFit(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	vService := newVehicleService(t)
+	bService := NewService(bInMemStore, pService, vService, Options{Holds: hInMemStore, Groups: gInMemStore})
+
+	van, err := vService.Register(nil, vehicle.Vehicle{
+		Plate:      "VAN 1",
+		Class:      parking.Van,
+		Dimensions: parking.Dimensions{Length: 600, Width: 220, Height: 260},
+	})
+	if err != nil {
+		t.Error("Failed to register van")
+	}
+
+	// spot 2 only takes motorcycles
+	if _, err := bService.Book(system, "2", "1", time.Now(), 30*time.Minute); err != vehicle.ErrClassNotAllowed {
+		t.Error("Expecting car to be rejected from a motorcycle spot")
+	}
+	// spot 3 is limited to 200cm in height and cars
+	if _, err := bService.Book(system, "3", strconv.Itoa(van.ID), time.Now(), 30*time.Minute); err != vehicle.ErrClassNotAllowed {
+		t.Error("Expecting van to be rejected from a car spot")
+	}
+	if _, err := bService.Book(system, "1", "42", time.Now(), 30*time.Minute); err != ErrInvalidVehicleId {
+		t.Error("Expecting unknown vehicle to be rejected")
+	}
+
+	spot, _ := pService.FindById(nil, "2")
+	if spot.IsReserved {
+		t.Error("Rejected booking left the spot reserved")
+	}
+}
+
+func TestFindActiveByPlate(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+
+	b, err := bService.Book(system, "1", "1", time.Now().Add(-time.Minute), 30*time.Minute)
+	if err != nil {
+		t.Error("Error in booking")
+	}
+	if _, err := bService.Book(system, "4", "1", time.Now().Add(time.Hour), 30*time.Minute); err != nil {
+		t.Error("Error in booking")
+	}
+
+	bb, err := bService.FindActiveByPlate(system, "ab-123-cd")
+	if err != nil {
+		t.Error("Error in plate search")
+	}
+	if len(bb) != 1 || bb[0].ID != b.ID {
+		t.Error("Incorrect active bookings returned for plate")
+	}
+
+	// an hour later only the second booking is active
+	bService.(*service).now = func() time.Time { return time.Now().Add(time.Hour + time.Minute) }
+	bb, _ = bService.FindActiveByPlate(system, "ab-123-cd")
+	if len(bb) != 1 || bb[0].SpotId != 4 {
+		t.Errorf("Expecting the active bookings at the service's clock, got %+v", bb)
+	}
+}
+
+func TestBookRemoteParking(t *testing.T) {
+	pInMemStore, err := parking.NewInMemParkingStore()
+	if err != nil {
+		t.Fatal("Failed to create parking inmem store")
+	}
+	keys := auth.NewInMemKeyStore()
+	keys.Add("booking-key", auth.Principal{Subject: "booking", Roles: []auth.Role{auth.RoleService}})
+	keys.Add("operator-key", auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}})
+	a := auth.NewAuthorizer(auth.NewAuthenticator(nil, keys), log.NewNopLogger(), nil)
+	remote := httptest.NewServer(parking.MakeHTTPHandler(parking.NewService(pInMemStore), a, log.NewNopLogger()))
+	defer remote.Close()
+
+	pService, err := parking.NewHTTPClient(remote.URL, parking.ClientOptions{APIKey: "booking-key"})
+	if err != nil {
+		t.Fatal("Failed to create parking client")
+	}
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+
+	ctx := system
+	if _, err := bService.Book(ctx, "1", "1", time.Now(), 30*time.Minute); err != nil {
+		t.Fatalf("Error in booking through the remote parking service: %v", err)
+	}
+	sp, err := pInMemStore.FindById(1)
+	if err != nil || !sp.IsReserved {
+		t.Error("Spot not reserved on the remote parking service")
+	}
+	if _, err := bService.Book(ctx, "1", "1", time.Now(), 30*time.Minute); err == nil {
+		t.Error("Expected an error booking an already reserved spot")
+	}
+
+	// the restrictions of a spot an operator adds hold for booking
+	operator, _ := parking.NewHTTPClient(remote.URL, parking.ClientOptions{APIKey: "operator-key"})
+	sp, err = operator.Create(ctx, parking.Spot{Lat: "44.9", Lon: "-93.4", Cost: "20", Facility: "lakeside", Classes: []parking.VehicleClass{parking.Motorcycle}})
+	if err != nil || sp.ID != 6 || sp.Facility != "lakeside" {
+		t.Fatalf("Failed to create a spot through the API: %+v %v", sp, err)
+	}
+	if _, err := bService.Book(ctx, "6", "1", time.Now(), 30*time.Minute); err != vehicle.ErrClassNotAllowed {
+		t.Errorf("Expected the car not to be allowed on the new spot, got %v", err)
+	}
+	sp.Classes, sp.MaxSize = []parking.VehicleClass{parking.Car}, &parking.Dimensions{Length: 400}
+	if _, err := operator.Update(ctx, sp); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bService.Book(ctx, "6", "1", time.Now(), 30*time.Minute); err != vehicle.ErrDoesNotFit {
+		t.Errorf("Expected the car not to fit the updated spot, got %v", err)
+	}
+	sp.MaxSize = nil
+	operator.Update(ctx, sp)
+	if _, err := bService.Book(ctx, "6", "1", time.Now(), 30*time.Minute); err != nil {
+		t.Errorf("Expected the car to fit once the limit is lifted, got %v", err)
+	}
+	if _, err := operator.Create(ctx, parking.Spot{Classes: []parking.VehicleClass{"bus"}}); apierror.From(err).Kind != apierror.Invalid {
+		t.Errorf("Expected an unknown class to be refused, got %v", err)
+	}
+}
+
+// TestBookRacingReplicas books one spot from two booking processes sharing
+// the remote parking service, only one of them may get it
+func TestBookRacingReplicas(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	keys := auth.NewInMemKeyStore()
+	keys.Add("booking-key", auth.Principal{Subject: "booking", Roles: []auth.Role{auth.RoleService}})
+	a := auth.NewAuthorizer(auth.NewAuthenticator(nil, keys), log.NewNopLogger(), nil)
+	remote := httptest.NewServer(parking.MakeHTTPHandler(parking.NewService(pInMemStore), a, log.NewNopLogger()))
+	defer remote.Close()
+
+	replicas := make([]Service, 2)
+	for i := range replicas {
+		pService, err := parking.NewHTTPClient(remote.URL, parking.ClientOptions{APIKey: "booking-key"})
+		if err != nil {
+			t.Fatal("Failed to create parking client")
+		}
+		bInMemStore, _ := NewInMemBookingStore()
+		replicas[i] = NewService(bInMemStore, pService, newVehicleService(t), Options{})
+	}
+
+	for spot := 1; spot <= 5; spot += 2 {
+		var wg sync.WaitGroup
+		errs := make([]error, len(replicas))
+		for i, r := range replicas {
+			wg.Add(1)
+			go func(i int, r Service) {
+				defer wg.Done()
+				_, errs[i] = r.Book(system, strconv.Itoa(spot), "1", time.Now(), 30*time.Minute)
+			}(i, r)
+		}
+		wg.Wait()
+		booked := 0
+		for _, err := range errs {
+			if err == nil {
+				booked++
+			} else if err != ErrAlreadyReserved {
+				t.Errorf("Spot %d: expected the loser to find it reserved, got %v", spot, err)
+			}
+		}
+		if booked != 1 {
+			t.Errorf("Spot %d booked %d times", spot, booked)
+		}
+	}
+}
+
+type recorder struct {
+	mtx    sync.Mutex
+	events []event.Event
+}
+
+func (r *recorder) Publish(ctx context.Context, e event.Event) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) types() []string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	tt := make([]string, 0)
+	for _, e := range r.events {
+		tt = append(tt, e.Type)
+	}
+	return tt
+}
+
+func TestHolds(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	events := &recorder{}
+	vService := newVehicleService(t)
+	bService := NewService(bInMemStore, pService, vService, Options{Holds: hInMemStore, Groups: gInMemStore, Events: events})
+	ctx := system
+	now := time.Now()
+
+	h, err := bService.Hold(ctx, "1", "1", now, 30*time.Minute, 0)
+	if err != nil || !h.ExpiresAt.After(now.Add(DefaultHoldTTL-time.Second)) {
+		t.Fatalf("Failed to hold: %+v %v", h, err)
+	}
+	free, _ := pService.GetFree(ctx)
+	for _, sp := range free {
+		if sp.ID == 1 {
+			t.Error("Expected the held spot not to be free")
+		}
+	}
+	if _, err := bService.Book(ctx, "1", "1", now, 30*time.Minute); err != ErrAlreadyReserved {
+		t.Error("Expected the held spot to refuse a booking")
+	}
+	if _, err := bService.Hold(ctx, "4", "1", now, 30*time.Minute, time.Hour); err == nil {
+		t.Error("Expected a TTL above the maximum to fail")
+	}
+	if _, err := bService.Confirm(auth.NewContext(ctx, auth.Principal{Subject: "mallory", Roles: []auth.Role{auth.RoleDriver}}), strconv.Itoa(h.ID)); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver's hold to be out of reach, got %v", err)
+	}
+	b, err := bService.Confirm(ctx, strconv.Itoa(h.ID))
+	if err != nil || b.SpotId != 1 || b.Status != StatusBooked {
+		t.Fatalf("Failed to confirm: %+v %v", b, err)
+	}
+	if _, err := bService.Confirm(ctx, strconv.Itoa(h.ID)); err != ErrHoldNotFound {
+		t.Error("Expected a hold to be confirmed once")
+	}
+	if spot, _ := pService.FindById(ctx, "1"); !spot.IsReserved {
+		t.Error("Expected the confirmed spot to stay reserved")
+	}
+
+	released, _ := bService.Hold(ctx, "4", "1", now, 30*time.Minute, time.Minute)
+	if err := bService.Release(ctx, strconv.Itoa(released.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if spot, _ := pService.FindById(ctx, "4"); spot.IsReserved {
+		t.Error("Expected the released spot to be free")
+	}
+
+	lapsed, _ := bService.Hold(ctx, "5", "1", now, 30*time.Minute, time.Minute)
+	late, _ := bService.Hold(ctx, "4", "1", now, 30*time.Minute, 2*time.Minute)
+	bService.(*service).now = func() time.Time { return now.Add(90 * time.Second) }
+	if _, err := bService.Confirm(ctx, strconv.Itoa(lapsed.ID)); err != ErrHoldExpired {
+		t.Errorf("Expected a lapsed hold to fail, got %v", err)
+	}
+	bService.(*service).now = func() time.Time { return now.Add(3 * time.Minute) }
+	if _, err := bService.Expire(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"4", "5"} {
+		if spot, _ := pService.FindById(ctx, id); spot.IsReserved {
+			t.Errorf("Expected the spot %s of the expired hold to be free", id)
+		}
+	}
+	if hh, _ := hInMemStore.GetAll(); len(hh) != 0 || late.ID == 0 {
+		t.Errorf("Expected no holds left, got %+v", hh)
+	}
+
+	// the hold stays its owner's after the vehicle is gone
+	kept, _ := bService.Hold(ctx, "4", "1", now, 30*time.Minute, time.Minute)
+	if err := vService.Delete(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	mallory := auth.NewContext(ctx, auth.Principal{Subject: "mallory", Roles: []auth.Role{auth.RoleDriver}})
+	if err := bService.Release(mallory, strconv.Itoa(kept.ID)); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to release the hold, got %v", err)
+	}
+
+	want := []string{event.BookingCreated, event.HoldReleased, event.HoldExpired, event.HoldExpired}
+	if got := events.types(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected events %v, got %v", want, got)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	vService := newVehicleService(t)
+	events := &recorder{}
+	bService := NewService(bInMemStore, pService, vService, Options{Holds: hInMemStore, Groups: gInMemStore, Events: events})
+	org := auth.NewContext(context.Background(), auth.Principal{Subject: "org", Roles: []auth.Role{auth.RoleDriver}})
+	cars := make([]string, 0)
+	for i := 0; i < 2; i++ {
+		v, err := vService.Register(org, vehicle.Vehicle{Plate: "GR 00" + strconv.Itoa(i), Class: parking.Car,
+			Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cars = append(cars, strconv.Itoa(v.ID))
+	}
+	now := time.Now()
+	reserved := func(id string) bool {
+		spot, _ := pService.FindById(org, id)
+		return spot.IsReserved
+	}
+
+	// spot 2 only takes motorcycles, so spot 4 must not stay reserved
+	_, _, err := bService.BookGroup(org, GroupSpec{VehicleIds: cars, SpotIds: []string{"4", "2"}, StartTime: now, Duration: time.Hour})
+	if err != vehicle.ErrClassNotAllowed {
+		t.Errorf("Expected the group to fail on spot 2, got %v", err)
+	}
+	if reserved("4") {
+		t.Error("Expected the failed group to release the spots it reserved")
+	}
+
+	// spots 1 and 5 are within 100km of spot 1
+	near := GroupSpec{VehicleIds: cars, Lat: "44.968046", Lon: "-94.420307", Radius: "100000", StartTime: now, Duration: time.Hour}
+	g, bb, err := bService.BookGroup(org, near)
+	if err != nil || len(bb) != 2 || bb[0].SpotId != 1 || bb[1].SpotId != 5 || bb[0].GroupId != g.ID || g.User != "org" {
+		t.Fatalf("Expected the two nearest spots to be booked, got %+v %+v %v", g, bb, err)
+	}
+	near.VehicleIds = cars[:1]
+	if _, _, err := bService.BookGroup(org, near); err != ErrGroupUnavailable {
+		t.Errorf("Expected no spot left near, got %v", err)
+	}
+
+	// bookings of the group are still changed on their own
+	if err := bService.Delete(org, strconv.Itoa(bb[0].ID)); err != nil {
+		t.Fatal(err)
+	}
+	id := strconv.Itoa(g.ID)
+	if _, bb, err := bService.FindGroup(org, id); err != nil || len(bb) != 1 || bb[0].SpotId != 5 {
+		t.Errorf("Expected one booking left in the group, got %+v %v", bb, err)
+	}
+	mallory := auth.NewContext(context.Background(), auth.Principal{Subject: "mallory", Roles: []auth.Role{auth.RoleDriver}})
+	if err := bService.CancelGroup(mallory, id); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to cancel the group, got %v", err)
+	}
+	if err := bService.CancelGroup(org, id); err != nil {
+		t.Fatal(err)
+	}
+	if reserved("5") {
+		t.Error("Expected cancelling the group to release its spots")
+	}
+	if _, _, err := bService.FindGroup(org, id); err != ErrGroupNotFound {
+		t.Errorf("Expected the group to be gone, got %v", err)
+	}
+
+	for _, spec := range []GroupSpec{
+		{SpotIds: []string{"1"}, Duration: time.Hour},
+		{VehicleIds: cars, SpotIds: []string{"1"}, Duration: time.Hour},
+		{VehicleIds: cars, SpotIds: []string{"1", "4"}, Lat: "44", Lon: "-94", Radius: "10", Duration: time.Hour},
+		{VehicleIds: cars, SpotIds: []string{"1", "4"}},
+	} {
+		if _, _, err := bService.BookGroup(org, spec); err == nil || apierror.From(err).Kind != apierror.Invalid {
+			t.Errorf("Expected %+v to be invalid, got %v", spec, err)
+		}
+	}
+	want := []string{event.BookingCreated, event.BookingCreated, event.BookingCancelled, event.BookingCancelled}
+	if got := events.types(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected events %v, got %v", want, got)
+	}
+}
+
+// failingBooks is a booking store that stores the first n bookings and
+// fails the others
+type failingBooks struct {
+	BookingStore
+	n int
+}
+
+func (s *failingBooks) Book(b Booking) (Booking, error) {
+	if s.n == 0 {
+		return Booking{}, ErrOverlap
+	}
+	s.n--
+	return s.BookingStore.Book(b)
+}
+
+func TestGroupRollback(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	vService := newVehicleService(t)
+	events := &recorder{}
+	bService := NewService(&failingBooks{BookingStore: bInMemStore, n: 2}, pService, vService, Options{Holds: hInMemStore, Groups: gInMemStore, Events: events})
+	org := auth.NewContext(context.Background(), auth.Principal{Subject: "org", Roles: []auth.Role{auth.RoleDriver}})
+	cars := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		v, err := vService.Register(org, vehicle.Vehicle{Plate: "GR 00" + strconv.Itoa(i), Class: parking.Car,
+			Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cars = append(cars, strconv.Itoa(v.ID))
+	}
+
+	// the third booking fails after two were stored
+	spec := GroupSpec{VehicleIds: cars, SpotIds: []string{"1", "4", "5"}, StartTime: time.Now(), Duration: time.Hour}
+	if _, _, err := bService.BookGroup(org, spec); err != ErrOverlap {
+		t.Fatalf("Expected the group to fail on its third booking, got %v", err)
+	}
+	if bb, err := bInMemStore.GetAll(); err != nil || len(bb) != 0 {
+		t.Errorf("Expected the stored bookings to be removed, got %+v %v", bb, err)
+	}
+	for _, id := range spec.SpotIds {
+		if spot, _ := pService.FindById(org, id); spot.IsReserved {
+			t.Errorf("Expected spot %s to be released", id)
+		}
+	}
+	if _, err := gInMemStore.Find(1); err != ErrGroupNotFound {
+		t.Errorf("Expected the group to be removed, got %v", err)
+	}
+	if got := events.types(); len(got) != 0 {
+		t.Errorf("Expected no events, got %v", got)
+	}
+}
+
+// staleSearch answers searches as if every spot were free, like a search
+// made before others booked
+type staleSearch struct {
+	parking.Service
+}
+
+func (s staleSearch) Search(ctx context.Context, lat, lon, radius string, metric parking.SearchMetric, all bool) ([]parking.ExtendedSpot, error) {
+	ess, err := s.Service.Search(ctx, lat, lon, radius, metric, true)
+	for i := range ess {
+		ess[i].IsReserved = false
+	}
+	return ess, err
+}
+
+func TestBookBest(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, staleSearch{pService}, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+	ctx := system
+	now := time.Now()
+
+	// spot 1 is the nearest, but it was booked since the search
+	if _, err := bService.Book(ctx, "1", "1", now, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	b, es, err := bService.BookBest(ctx, "44.968046", "-94.420307", "100000", parking.DIST, "1", now, time.Hour)
+	if err != nil || b.SpotId != 5 || es.ID != 5 || !es.IsReserved || es.Distance == 0 {
+		t.Fatalf("Expected the next nearest spot to be booked, got %+v %+v %v", b, es, err)
+	}
+	if _, _, err := bService.BookBest(ctx, "44.968046", "-94.420307", "100000", parking.DIST, "1", now, time.Hour); err != ErrNoMatch {
+		t.Errorf("Expected no match left, got %v", err)
+	}
+	if _, _, err := bService.BookBest(ctx, "44.968046", "-94.420307", "100000", "price", "1", now, time.Hour); err == nil {
+		t.Error("Expected an unknown metric to fail")
+	}
+
+	// clients racing for spot 4, the only one near, get it once
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	won := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := bService.BookBest(ctx, "33.844843", "-116.54911", "10", parking.DIST, "1", now, time.Hour); err == nil {
+				mtx.Lock()
+				won++
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if won != 1 {
+		t.Errorf("Expected spot 4 to be booked once, got %d", won)
+	}
+}
+
+func TestQueryAndUpdate(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	vService := newVehicleService(t)
+	events := &recorder{}
+	bService := NewService(bInMemStore, pService, vService, Options{Holds: hInMemStore, Groups: gInMemStore, Events: events})
+	driver := func(name string) context.Context {
+		return auth.NewContext(context.Background(), auth.Principal{Subject: name, Roles: []auth.Role{auth.RoleDriver}})
+	}
+	alice, bob := driver("alice"), driver("bob")
+	operator := auth.NewContext(context.Background(), auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}})
+	car := func(ctx context.Context, plate string) string {
+		v, err := vService.Register(ctx, vehicle.Vehicle{Plate: plate, Class: parking.Car,
+			Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strconv.Itoa(v.ID)
+	}
+	now := time.Now()
+	first, _ := bService.Book(alice, "1", car(alice, "AL 001"), now, time.Hour)
+	second, _ := bService.Book(alice, "4", car(alice, "AL 002"), now.Add(2*time.Hour), time.Hour)
+	other, _ := bService.Book(bob, "5", car(bob, "BO 001"), now, time.Hour)
+	if first.User != "alice" || other.User != "bob" {
+		t.Fatalf("Expected the bookings to belong to the vehicle owners, got %+v %+v", first, other)
+	}
+
+	if bb, err := bService.List(alice, Query{}); err != nil || len(bb) != 2 || bb[0].ID != first.ID || bb[1].ID != second.ID {
+		t.Errorf("Expected a driver to list their own bookings, got %+v %v", bb, err)
+	}
+	if _, err := bService.List(alice, Query{User: "bob"}); err != auth.ErrForbidden {
+		t.Errorf("Expected a driver not to list the bookings of others, got %v", err)
+	}
+	for _, c := range []struct {
+		q    Query
+		want []int
+	}{
+		{Query{User: "bob"}, []int{other.ID}},
+		{Query{SpotId: 4}, []int{second.ID}},
+		{Query{Sort: "-start", Limit: 2}, []int{second.ID, first.ID}},
+		{Query{From: now.Add(90 * time.Minute)}, []int{second.ID}},
+		{Query{To: now.Add(time.Minute), Status: StatusBooked}, []int{first.ID, other.ID}},
+	} {
+		bb, err := bService.List(operator, c.q)
+		got := make([]int, 0)
+		for _, b := range bb {
+			got = append(got, b.ID)
+		}
+		if err != nil || fmt.Sprint(got) != fmt.Sprint(c.want) {
+			t.Errorf("Expected %+v to list %v, got %v %v", c.q, c.want, got, err)
+		}
+	}
+	for _, q := range []Query{{Sort: "spot"}, {Limit: -1}, {Status: "lost"}, {From: now, To: now}} {
+		if _, err := bService.List(operator, q); err == nil || apierror.From(err).Kind != apierror.Invalid {
+			t.Errorf("Expected %+v to be invalid, got %v", q, err)
+		}
+	}
+
+	id := strconv.Itoa(first.ID)
+	if _, err := bService.Find(bob, id); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to see the booking, got %v", err)
+	}
+	if b, err := bService.Find(alice, id); err != nil || b.ID != first.ID {
+		t.Errorf("Failed to find the booking: %+v %v", b, err)
+	}
+	if _, err := bService.Update(bob, id, Change{SpotId: "3"}); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to change the booking, got %v", err)
+	}
+	if _, err := bService.Update(alice, id, Change{SpotId: "5"}); err != ErrAlreadyReserved {
+		t.Errorf("Expected a taken spot to be refused, got %v", err)
+	}
+	if _, err := bService.Update(alice, id, Change{EndTime: now.Add(-time.Minute)}); err == nil || apierror.From(err).Kind != apierror.Invalid {
+		t.Errorf("Expected an end before the start to be invalid, got %v", err)
+	}
+	moved, err := bService.Update(alice, id, Change{SpotId: "3", EndTime: now.Add(2 * time.Hour)})
+	if err != nil || moved.SpotId != 3 || moved.Duration != 2*time.Hour || !moved.StartTime.Equal(first.StartTime) {
+		t.Fatalf("Failed to move the booking: %+v %v", moved, err)
+	}
+	if spot, _ := pService.FindById(alice, "1"); spot.IsReserved {
+		t.Error("Expected the old spot to be released")
+	}
+	if spot, _ := pService.FindById(alice, "3"); !spot.IsReserved {
+		t.Error("Expected the new spot to be reserved")
+	}
+	if _, err := bService.CheckIn(bob, id); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to check the booking in, got %v", err)
+	}
+	if err := bService.Delete(bob, id); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to cancel the booking, got %v", err)
+	}
+	if spot, _ := pService.FindById(alice, "3"); !spot.IsReserved {
+		t.Error("Expected the refused cancellation to keep the spot reserved")
+	}
+	if _, err := bService.CheckIn(alice, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bService.Update(alice, id, Change{SpotId: "1"}); err != ErrAlreadyCheckedIn {
+		t.Errorf("Expected a checked in booking to keep its spot, got %v", err)
+	}
+	if b, err := bService.Update(alice, id, Change{EndTime: now.Add(3 * time.Hour)}); err != nil || b.Duration != 3*time.Hour {
+		t.Errorf("Expected a checked in booking to change its end, got %+v %v", b, err)
+	}
+	if last := events.events[len(events.events)-1].Data.(BookingChanged); last.Previous.Duration != 2*time.Hour {
+		t.Errorf("Expected the change to carry the previous booking, got %+v", last)
+	}
+
+	tokens := auth.NewHMACTokens([]byte("secret"))
+	a := auth.NewAuthorizer(auth.NewAuthenticator(tokens, nil), log.NewNopLogger(), nil)
+	srv := httptest.NewServer(MakeHTTPHandler(bService, a, log.NewNopLogger()))
+	defer srv.Close()
+	token, _ := tokens.Issue(auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}}, time.Minute)
+	for query, code := range map[string]int{
+		"?user=alice&sort=-end&limit=1": http.StatusOK,
+		"?from=yesterday":               http.StatusBadRequest,
+		"?spotId=one":                   http.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest("GET", srv.URL+"/booking/v1/"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Errorf("Expected %s to answer %d, got %d", query, code, resp.StatusCode)
+		}
+	}
+}
+
+func TestBookingLifecycle(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	events := &recorder{}
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore, Events: events})
+	ctx := system
+
+	now := time.Now()
+	active, _ := bService.Book(ctx, "1", "1", now.Add(-time.Minute), 30*time.Minute)
+	ended, _ := bService.Book(ctx, "4", "1", now.Add(-time.Hour), 30*time.Minute)
+	later, _ := bService.Book(ctx, "5", "1", now.Add(time.Hour), 30*time.Minute)
+
+	b, err := bService.CheckIn(ctx, strconv.Itoa(active.ID))
+	if err != nil || b.Status != StatusCheckedIn || b.CheckedInAt.IsZero() {
+		t.Fatalf("Failed to check in: %v", err)
+	}
+	if _, err := bService.CheckIn(ctx, strconv.Itoa(active.ID)); err != ErrAlreadyCheckedIn {
+		t.Error("Expected a second check in to fail")
+	}
+	if _, err := bService.CheckIn(ctx, strconv.Itoa(later.ID)); err != ErrNotActive {
+		t.Error("Expected a check in before the window to fail")
+	}
+	if b, _, err := bService.Extend(ctx, strconv.Itoa(later.ID), time.Hour); err != nil || b.Duration != 90*time.Minute {
+		t.Errorf("Failed to extend a booking: %+v %v", b, err)
+	}
+	if _, _, err := bService.Extend(ctx, strconv.Itoa(ended.ID), time.Hour); err != ErrNotActive {
+		t.Error("Expected an ended booking not to be extended")
+	}
+
+	expired, err := bService.Expire(ctx)
+	if err != nil || len(expired) != 1 || expired[0].ID != ended.ID || expired[0].Status != StatusExpired {
+		t.Fatalf("Expected only the ended booking to expire, got %+v %v", expired, err)
+	}
+	if spot, _ := pService.FindById(ctx, "4"); spot.IsReserved {
+		t.Error("Expected the spot of the expired booking to be released")
+	}
+	if expired, _ := bService.Expire(ctx); len(expired) != 0 {
+		t.Error("Expected bookings to expire once")
+	}
+	if _, err := bService.CheckIn(ctx, strconv.Itoa(ended.ID)); err != ErrNotActive {
+		t.Error("Expected an expired booking to refuse check in")
+	}
+
+	// the spot of the expired booking went to someone else meanwhile
+	if _, err := bService.Book(ctx, "4", "1", now, 30*time.Minute); err != nil {
+		t.Fatal("Failed to book the released spot")
+	}
+	if err := bService.Delete(ctx, strconv.Itoa(ended.ID)); err != nil {
+		t.Fatal("Failed to delete the expired booking")
+	}
+	if spot, _ := pService.FindById(ctx, "4"); !spot.IsReserved {
+		t.Error("Deleting the expired booking released the new booking's spot")
+	}
+	bService.Delete(ctx, strconv.Itoa(later.ID))
+
+	want := []string{event.BookingCreated, event.BookingCreated, event.BookingCreated, event.BookingCheckedIn, event.BookingChanged,
+		event.BookingExpired, event.BookingCreated, event.BookingCancelled}
+	if got := events.types(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected events %v, got %v", want, got)
+	}
+}
+
+func TestResize(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+	ctx := system
+	now := time.Now()
+
+	// spot 5 costs 90 an hour
+	b, err := bService.Book(ctx, "5", "1", now.Add(-10*time.Minute), time.Hour)
+	if err != nil || b.Price != "90.00" {
+		t.Fatalf("Expected the hour to cost 90.00, got %+v %v", b, err)
+	}
+	id := strconv.Itoa(b.ID)
+	b, charge, err := bService.Extend(ctx, id, 30*time.Minute)
+	if err != nil || charge != "45.00" || b.Price != "135.00" || b.Duration != 90*time.Minute {
+		t.Fatalf("Expected half an hour to cost 45.00 more, got %+v %s %v", b, charge, err)
+	}
+	b, refund, err := bService.Shorten(ctx, id, 20*time.Minute)
+	if err != nil || refund != "-30.00" || b.Price != "105.00" || b.Duration != 70*time.Minute {
+		t.Fatalf("Expected 20 minutes to refund 30.00, got %+v %s %v", b, refund, err)
+	}
+	if _, _, err := bService.Shorten(ctx, id, time.Hour); err == nil || apierror.From(err).Kind != apierror.Invalid {
+		t.Errorf("Expected shortening into the past to be invalid, got %v", err)
+	}
+	mallory := auth.NewContext(ctx, auth.Principal{Subject: "mallory", Roles: []auth.Role{auth.RoleDriver}})
+	if _, _, err := bService.Extend(mallory, id, time.Minute); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to extend the booking, got %v", err)
+	}
+
+	// the next booking of the spot blocks extending into it
+	next, err := bService.Book(ctx, "5", "1", b.EndTime().Add(time.Hour), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := bService.Extend(ctx, id, 2*time.Hour); err == nil || apierror.From(err).Code != "booking_overlap" {
+		t.Errorf("Expected the next booking to block the extension, got %v", err)
+	}
+	if b, _, err := bService.Extend(ctx, id, time.Hour); err != nil || !b.EndTime().Equal(next.StartTime) {
+		t.Errorf("Expected to extend up to the next booking, got %+v %v", b, err)
+	}
+
+	// extensions of the same spot racing for the time left are atomic
+	free, _ := bService.Book(ctx, "1", "1", now, time.Hour)
+	if _, err := bService.Book(ctx, "1", "1", free.EndTime().Add(30*time.Minute), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	won := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := bService.Extend(ctx, strconv.Itoa(free.ID), 20*time.Minute); err == nil {
+				mtx.Lock()
+				won++
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if won != 1 {
+		t.Errorf("Expected one extension to fit before the next booking, got %d", won)
+	}
+}
+
+func TestNoShows(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	events := &recorder{}
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore, Events: events})
+	ctx := system
+
+	if _, err := bService.SetNoShowPolicy(ctx, NoShowPolicy{Grace: -time.Minute}); err == nil || apierror.From(err).Kind != apierror.Invalid {
+		t.Errorf("Expected a negative grace period to be invalid, got %v", err)
+	}
+	if _, err := bService.SetNoShowPolicy(ctx, NoShowPolicy{Grace: time.Minute, Fee: "ten"}); err == nil || apierror.From(err).Kind != apierror.Invalid {
+		t.Errorf("Expected a fee that is not a number to be invalid, got %v", err)
+	}
+	// spot 5 is lakeside, spot 1 is on the street without a policy
+	if _, err := bService.SetNoShowPolicy(ctx, NoShowPolicy{Facility: "lakeside", Grace: 15 * time.Minute, Fee: "5", Strike: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	late, _ := bService.Book(ctx, "5", "1", now.Add(-20*time.Minute), time.Hour)
+	street, _ := bService.Book(ctx, "1", "1", now.Add(-20*time.Minute), time.Hour)
+	expired, err := bService.Expire(ctx)
+	if err != nil || len(expired) != 0 {
+		t.Fatalf("Expected no booking to expire, got %+v %v", expired, err)
+	}
+	if b, _ := bService.Find(ctx, strconv.Itoa(late.ID)); b.Status != StatusNoShow {
+		t.Errorf("Expected the booking past its grace period to be a no-show, got %+v", b)
+	}
+	if spot, _ := pService.FindById(ctx, "5"); spot.IsReserved {
+		t.Error("Expected the spot of the no-show to be released")
+	}
+	if b, _ := bService.Find(ctx, strconv.Itoa(street.ID)); b.Status != StatusBooked {
+		t.Errorf("Expected a booking without a policy to be kept, got %+v", b)
+	}
+	if _, err := bService.CheckIn(ctx, strconv.Itoa(late.ID)); err != ErrNoShow {
+		t.Errorf("Expected a no-show to refuse check in, got %v", err)
+	}
+	nn, err := bService.NoShows(ctx, "")
+	if err != nil || len(nn) != 1 || nn[0].BookingId != late.ID || nn[0].Fee != "5" || !nn[0].Strike || nn[0].Facility != "lakeside" {
+		t.Errorf("Expected the no-show to be recorded with the policy's penalty, got %+v %v", nn, err)
+	}
+
+	// the released spot can be booked again, a checked in booking is
+	// never a no-show
+	again, err := bService.Book(ctx, "5", "1", now.Add(-20*time.Minute), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to book the released spot: %v", err)
+	}
+	bService.CheckIn(ctx, strconv.Itoa(again.ID))
+	bService.Expire(ctx)
+	if b, _ := bService.Find(ctx, strconv.Itoa(again.ID)); b.Status != StatusCheckedIn {
+		t.Errorf("Expected the checked in booking to be kept, got %+v", b)
+	}
+
+	bob := auth.NewContext(ctx, auth.Principal{Subject: "bob", Roles: []auth.Role{auth.RoleDriver}})
+	if nn, err := bService.NoShows(bob, ""); err != nil || len(nn) != 0 {
+		t.Errorf("Expected a driver to only see their own no-shows, got %+v %v", nn, err)
+	}
+	if _, err := bService.NoShows(bob, "alice"); err != auth.ErrForbidden {
+		t.Errorf("Expected a driver not to see the no-shows of others, got %v", err)
+	}
+	if got := events.types(); got[2] != event.BookingNoShow {
+		t.Errorf("Expected a no-show event, got %v", got)
+	}
+}
+
+func TestBuffers(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+	ctx := system
+
+	for _, b := range []Buffer{{SpotId: 5, Facility: "lakeside"}, {Before: -time.Minute}, {SpotId: 9}} {
+		if _, err := bService.SetBuffer(ctx, b); err == nil {
+			t.Errorf("Expected %+v to be refused", b)
+		}
+	}
+	// spot 5 is lakeside, spot 1 keeps no buffers of its own
+	bService.SetBuffer(ctx, Buffer{Facility: "lakeside", Before: 10 * time.Minute, After: 15 * time.Minute})
+	bService.SetBuffer(ctx, Buffer{SpotId: 1})
+	bService.SetBuffer(ctx, Buffer{After: time.Hour})
+	if bb, err := bService.Buffers(ctx); err != nil || len(bb) != 3 || bb[0].Facility != "" || bb[2].SpotId != 1 {
+		t.Errorf("Expected the default, facility and spot buffers, got %+v %v", bb, err)
+	}
+
+	now := time.Now()
+	ended, err := bService.Book(ctx, "5", "1", now.Add(-time.Hour), 50*time.Minute)
+	if err != nil || ended.BufferBefore != 10*time.Minute || ended.BufferAfter != 15*time.Minute {
+		t.Fatalf("Expected the booking to take the buffers of its facility, got %+v %v", ended, err)
+	}
+	if b, _ := bService.Book(ctx, "1", "1", now, time.Hour); b.BufferAfter != 0 {
+		t.Errorf("Expected the spot's own buffers to override the default, got %+v", b)
+	}
+	if _, err := bService.Expire(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the spot is turned over for 15 minutes after the booking ended and
+	// 10 minutes before the next one
+	turnover := ended.EndTime().Add(25 * time.Minute)
+	if spot, _ := pService.FindById(ctx, "5"); spot.IsReserved || !spot.TurnoverUntil.Equal(turnover) {
+		t.Errorf("Expected the spot to be turned over until %v, got %+v", turnover, spot)
+	}
+	if free, _ := pService.GetFree(ctx); containsSpot(free, 5) {
+		t.Error("Expected a spot being turned over not to be free")
+	}
+	if _, err := bService.Book(ctx, "5", "1", now, time.Hour); err == nil || apierror.From(err).Code != "booking_overlap" {
+		t.Errorf("Expected a booking during the turnover to overlap, got %v", err)
+	}
+	if spot, _ := pService.FindById(ctx, "5"); spot.IsReserved {
+		t.Error("Expected the refused booking to leave the spot free")
+	}
+	if _, err := bService.Book(ctx, "5", "1", turnover, time.Hour); err != nil {
+		t.Errorf("Failed to book after the turnover: %v", err)
+	}
+
+	// the store keeps the buffers of the bookings of a spot apart
+	if _, err := bInMemStore.Book(Booking{SpotId: 5, VehicleId: 1, StartTime: ended.EndTime().Add(20 * time.Minute), Duration: time.Minute, BufferBefore: 10 * time.Minute}); err == nil || apierror.From(err).Code != "booking_overlap" {
+		t.Errorf("Expected the store to refuse a booking within the buffers, got %v", err)
+	}
+}
+
+func containsSpot(ss []parking.Spot, id int) bool {
+	for _, sp := range ss {
+		if sp.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSchedules(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, pService, newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+	ctx := system
+
+	// 2030-01-07 is a monday, lakeside is open around the clock but for
+	// its blackout and a holiday on wednesday
+	monday := func(hour int) time.Time { return time.Date(2030, 1, 7, hour, 0, 0, 0, time.UTC) }
+	hours := []parking.OpeningHours{{Day: "monday", Open: "08:00", Close: "18:00"}, {Day: "tuesday", Open: "08:00", Close: "18:00"}}
+	if _, err := pService.SetSchedule(ctx, parking.Schedule{Hours: hours}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pService.SetSchedule(ctx, parking.Schedule{Facility: "lakeside", Holidays: []string{"2030-01-09"},
+		Blackouts: []parking.Blackout{{From: monday(10), Until: monday(12)}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, w := range []struct {
+		spot, field string
+		start       time.Time
+		d           time.Duration
+	}{
+		{"1", "start", monday(7), time.Hour},
+		// open at both ends, closed overnight
+		{"1", "end", monday(17), 16 * time.Hour},
+		{"5", "start", monday(9), 2 * time.Hour},
+		// open at both ends, wednesday is a holiday
+		{"5", "end", monday(36), 48 * time.Hour},
+	} {
+		_, err := bService.Book(ctx, w.spot, "1", w.start, w.d)
+		if e := apierror.From(err); err == nil || e.Code != ErrClosed.Code || e.Fields[0].Field != w.field {
+			t.Errorf("Expected booking spot %s at %v for %v to be refused on %s, got %v", w.spot, w.start, w.d, w.field, err)
+		}
+		if spot, _ := pService.FindById(ctx, w.spot); spot.IsReserved {
+			t.Errorf("Expected the refused booking to leave spot %s free", w.spot)
+		}
+	}
+	if _, err := bService.Book(ctx, "5", "1", monday(3), 2*time.Hour); err != nil {
+		t.Errorf("Failed to book lakeside before its blackout: %v", err)
+	}
+	b, err := bService.Book(ctx, "1", "1", monday(9), 2*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to book within the opening hours: %v", err)
+	}
+	if _, _, err := bService.Extend(ctx, strconv.Itoa(b.ID), 8*time.Hour); err == nil || apierror.From(err).Code != ErrClosed.Code {
+		t.Errorf("Expected extending past closing to be refused, got %v", err)
+	}
+	if _, _, err := bService.Extend(ctx, strconv.Itoa(b.ID), 7*time.Hour); err != nil {
+		t.Errorf("Failed to extend until closing: %v", err)
+	}
+}
+
+func TestGatePass(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	pService := parking.NewService(pInMemStore)
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	events := &recorder{}
+	vService := newVehicleService(t)
+	passes, err := NewPasses([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bService := NewService(bInMemStore, pService, vService, Options{Holds: hInMemStore, Groups: gInMemStore, Events: events, Passes: passes})
+	ctx := system
+	now := time.Now()
+
+	b, _ := bService.Book(ctx, "5", "1", now.Add(10*time.Minute), 30*time.Minute)
+	p, err := bService.Pass(ctx, strconv.Itoa(b.ID))
+	if err != nil || p.Facility != "lakeside" || p.SpotId != 5 || !p.NotBefore.Equal(b.StartTime.Add(-PassEarly).Truncate(time.Second)) {
+		t.Fatalf("Expected a pass for the lakeside facility, got %+v %v", p, err)
+	}
+	if _, err := NewService(bInMemStore, pService, vService, Options{}).Pass(ctx, strconv.Itoa(b.ID)); err != ErrNoPasses {
+		t.Errorf("Expected a service without passes to issue none, got %v", err)
+	}
+
+	if _, err := bService.Gate(ctx, p.Token, "desert-ramp", Entry); err != ErrWrongFacility {
+		t.Errorf("Expected another facility to refuse the pass, got %v", err)
+	}
+	foreign, _ := NewPasses([]byte("other"))
+	forged := foreign.Issue(b, "lakeside")
+	if _, err := bService.Gate(ctx, forged.Token, "lakeside", Entry); err != ErrInvalidPass {
+		t.Errorf("Expected a pass signed with another secret to fail, got %v", err)
+	}
+	if _, err := bService.Gate(ctx, p.Token, "lakeside", Exit); err != ErrNotEntered {
+		t.Errorf("Expected an exit before the entry to fail, got %v", err)
+	}
+	entered, err := bService.Gate(ctx, p.Token, "lakeside", Entry)
+	if err != nil || entered.EnteredAt.IsZero() || entered.Status != StatusCheckedIn {
+		t.Fatalf("Expected the entry to check the booking in, got %+v %v", entered, err)
+	}
+	if _, err := bService.Gate(ctx, p.Token, "lakeside", Entry); err != ErrPassUsed {
+		t.Errorf("Expected a second entry to fail, got %v", err)
+	}
+	if exited, err := bService.Gate(ctx, p.Token, "lakeside", Exit); err != nil || exited.ExitedAt.IsZero() {
+		t.Errorf("Expected the exit to be recorded, got %+v %v", exited, err)
+	}
+	if _, err := bService.Gate(ctx, p.Token, "lakeside", Exit); err != ErrPassUsed {
+		t.Errorf("Expected a second exit to fail, got %v", err)
+	}
+
+	other, _ := bService.Book(ctx, "1", "1", now, 30*time.Minute)
+	p, _ = bService.Pass(ctx, strconv.Itoa(other.ID))
+	bService.(*service).now = func() time.Time { return now.Add(time.Hour) }
+	if _, err := bService.Gate(ctx, p.Token, "", Entry); err != ErrPassNotNow {
+		t.Errorf("Expected a pass after its window to fail, got %v", err)
+	}
+	if _, err := bService.Pass(ctx, strconv.Itoa(other.ID)); err != ErrNotActive {
+		t.Errorf("Expected no pass for an ended booking, got %v", err)
+	}
+	if got := events.types(); len(got) != 3 || got[1] != event.BookingCheckedIn {
+		t.Errorf("Expected the entry to publish a check in, got %v", got)
+	}
+
+	// the booking stays its owner's after the vehicle is gone
+	if err := vService.Delete(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	mallory := auth.NewContext(ctx, auth.Principal{Subject: "mallory", Roles: []auth.Role{auth.RoleDriver}})
+	if _, err := bService.Pass(mallory, strconv.Itoa(other.ID)); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to get the pass, got %v", err)
+	}
+}
+
+func TestPassQRCode(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	passes, _ := NewPasses(nil)
+	bService := NewService(bInMemStore, parking.NewService(pInMemStore), newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore, Passes: passes})
+	b, _ := bService.Book(system, "5", "1", time.Now(), 30*time.Minute)
+
+	tokens := auth.NewHMACTokens([]byte("secret"))
+	a := auth.NewAuthorizer(auth.NewAuthenticator(tokens, nil), log.NewNopLogger(), nil)
+	srv := httptest.NewServer(MakeHTTPHandler(bService, a, log.NewNopLogger()))
+	defer srv.Close()
+	token, _ := tokens.Issue(auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}}, time.Minute)
+
+	req, _ := http.NewRequest("GET", srv.URL+"/booking/v1/"+strconv.Itoa(b.ID)+"/pass", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", PNGContentType)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if _, err := png.Decode(resp.Body); err != nil || resp.Header.Get("Content-Type") != PNGContentType {
+		t.Errorf("Expected a PNG, got %s %v", resp.Header.Get("Content-Type"), err)
+	}
+}
+
+// TestTransports drives the HTTP and gRPC transports against the same service
+func TestTransports(t *testing.T) {
+	pInMemStore, _ := parking.NewInMemParkingStore()
+	bInMemStore, _ := NewInMemBookingStore()
+	hInMemStore, _ := NewInMemHoldStore()
+	gInMemStore, _ := NewInMemGroupStore()
+	bService := NewService(bInMemStore, parking.NewService(pInMemStore), newVehicleService(t), Options{Holds: hInMemStore, Groups: gInMemStore})
+
+	tokens := auth.NewHMACTokens([]byte("secret"))
+	a := auth.NewAuthorizer(auth.NewAuthenticator(tokens, nil), log.NewNopLogger(), nopCounter{})
+	token, _ := tokens.Issue(auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}}, time.Minute)
+
+	httpServer := httptest.NewServer(MakeHTTPHandler(bService, a, log.NewNopLogger()))
+	defer httpServer.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Failed to listen for gRPC")
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterBookingServiceServer(grpcServer, MakeGRPCServer(bService, a, log.NewNopLogger()))
+	go grpcServer.Serve(ln)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal("Failed to dial gRPC server")
+	}
+	defer conn.Close()
+	client := pb.NewBookingServiceClient(conn)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+
+	// book a later window over gRPC, find it over HTTP
+	start := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+	booked, err := client.Book(ctx, &pb.BookRequest{SpotId: "1", VehicleId: "1", Start: start.UnixNano(), End: start.Add(time.Hour).UnixNano()})
+	if err != nil {
+		t.Fatalf("Failed to book over gRPC: %v", err)
+	}
+	if b := booked.Booking; b.StartTime != start.UnixNano() || time.Duration(b.Duration) != time.Hour || b.Status != string(StatusBooked) || b.Price == "" {
+		t.Errorf("Expecting the window, status and price of the booking, got %+v", b)
+	}
+	req, _ := http.NewRequest("GET", httpServer.URL+"/booking/v1/"+strconv.Itoa(int(booked.Booking.Id)), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal("Failed to find booking over HTTP")
+	}
+	var found bookingResponse
+	json.NewDecoder(resp.Body).Decode(&found)
+	resp.Body.Close()
+	if !found.Booking.StartTime.Equal(start) || found.Booking.Price != booked.Booking.Price {
+		t.Errorf("HTTP does not see the booking made over gRPC, got %+v", found.Booking)
+	}
+
+	// book the window after it over HTTP, list both over gRPC
+	body, _ := json.Marshal(bookingRequest{SpotId: "1", VehicleId: "1", Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)})
+	req, _ = http.NewRequest("POST", httpServer.URL+"/booking/v1/", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatal("Failed to book over HTTP")
+	}
+	resp.Body.Close()
+	listed, err := client.List(ctx, &pb.ListRequest{SpotId: 1, Sort: "start"})
+	if err != nil || len(listed.Bookings) != 2 || listed.Bookings[1].StartTime != start.Add(time.Hour).UnixNano() {
+		t.Errorf("gRPC does not see the booking made over HTTP, got %+v %v", listed, err)
+	}
+
+	_, err = client.Book(ctx, &pb.BookRequest{SpotId: "1", VehicleId: "1", Start: start.Add(30 * time.Minute).UnixNano()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expecting an overlapping window to fail with FailedPrecondition, got %v", err)
+	}
+	extended, err := client.Extend(ctx, &pb.ResizeRequest{Id: strconv.Itoa(int(listed.Bookings[1].Id)), Minutes: 30})
+	if err != nil || time.Duration(extended.Booking.Duration) != 90*time.Minute || extended.Difference == "" {
+		t.Errorf("Expecting the booking extended by 30 minutes at a price, got %+v %v", extended, err)
+	}
+
+	held, err := client.Hold(ctx, &pb.HoldRequest{SpotId: "3", VehicleId: "1", Start: start.UnixNano(), End: start.Add(time.Hour).UnixNano()})
+	if err != nil || held.Hold.StartTime != start.UnixNano() || held.Hold.ExpiresAt == 0 {
+		t.Fatalf("Expecting a hold of the window, got %+v %v", held, err)
+	}
+	confirmed, err := client.Confirm(ctx, &pb.HoldIdRequest{Id: strconv.Itoa(int(held.Hold.Id))})
+	if err != nil || confirmed.Booking.SpotId != 3 || confirmed.Booking.StartTime != start.UnixNano() {
+		t.Errorf("Expecting the hold confirmed into a booking of its window, got %+v %v", confirmed, err)
+	}
+
+	best, err := client.BookBest(ctx, &pb.BookBestRequest{Lat: "33.755787", Lon: "-116.359998", Rad: "10000", Metric: string(parking.DIST), VehicleId: "1"})
+	if err != nil || best.Spot == nil || best.Spot.Spot.Id != int64(best.Booking.SpotId) {
+		t.Errorf("Expecting the best match booked with its spot, got %+v %v", best, err)
+	}
+
+	if _, err := client.Gate(ctx, &pb.GateRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expecting a gate request without token to fail with InvalidArgument, got %v", err)
+	}
+	if _, err := client.GetAll(context.Background(), &pb.GetAllRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Error("Expecting anonymous gRPC call to be unauthenticated")
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
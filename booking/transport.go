@@ -3,33 +3,44 @@ package booking
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
-	"bytes"
-	"io/ioutil"
-
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/qr"
 	"github.com/go-kit/kit/log"
 	httptransport "github.com/go-kit/kit/transport/http"
 )
 
+const (
+	PNGContentType = "image/png"
+	// PassScale is the size of a QR code module in pixels
+	PassScale = 8
+)
+
 var (
-	ErrBadRouting = errors.New("inconsistent mapping between route and handler (programmer error)")
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
 )
 
-func MakeHTTPHandler(s Service, logger log.Logger) http.Handler {
+// MakeHTTPHandler mounts all of the service endpoints into an http.Handler.
+// The roles allowed on each route are enforced by a.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
 	r := mux.NewRouter()
-	e := MakeServerEndpoints(s)
+	e := MakeAuthorizedEndpoints(s, a)
 	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
 		httptransport.ServerErrorLogger(logger),
-		httptransport.ServerErrorEncoder(encodeError),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
 	}
 
 	r.Methods("GET").Path("/booking/v1/").Handler(httptransport.NewServer(
-		e.GetAllEndpoint,
-		decodeGetAllRequest,
+		e.ListEndpoint,
+		decodeListRequest,
 		encodeResponse,
 		options...,
 	))
@@ -45,26 +56,214 @@ func MakeHTTPHandler(s Service, logger log.Logger) http.Handler {
 		encodeResponse,
 		options...,
 	))
+	r.Methods("GET").Path("/booking/v1/noshows").Handler(httptransport.NewServer(
+		e.NoShowsEndpoint,
+		decodeNoShowsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/booking/v1/noshows/policies").Handler(httptransport.NewServer(
+		e.NoShowPoliciesEndpoint,
+		decodeNoShowPoliciesRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("PUT").Path("/booking/v1/noshows/policies").Handler(httptransport.NewServer(
+		e.SetNoShowPolicyEndpoint,
+		decodeNoShowPolicyRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/booking/v1/buffers").Handler(httptransport.NewServer(
+		e.BuffersEndpoint,
+		decodeBuffersRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("PUT").Path("/booking/v1/buffers").Handler(httptransport.NewServer(
+		e.SetBufferEndpoint,
+		decodeBufferRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/booking/v1/{id}").Handler(httptransport.NewServer(
+		e.FindEndpoint,
+		decodeDeleteRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("PATCH").Path("/booking/v1/{id}").Handler(httptransport.NewServer(
+		e.UpdateEndpoint,
+		decodeUpdateRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/booking/v1/plate/{plate}").Handler(httptransport.NewServer(
+		e.FindByPlateEndpoint,
+		decodeFindByPlateRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/{id}/checkin").Handler(httptransport.NewServer(
+		e.CheckInEndpoint,
+		decodeDeleteRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/{id}/extend").Handler(httptransport.NewServer(
+		e.ExtendEndpoint,
+		decodeResizeRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/{id}/shorten").Handler(httptransport.NewServer(
+		e.ShortenEndpoint,
+		decodeResizeRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/booking/v1/{id}/pass").Handler(httptransport.NewServer(
+		e.PassEndpoint,
+		decodePassRequest,
+		encodePassResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/gate").Handler(httptransport.NewServer(
+		e.GateEndpoint,
+		decodeGateRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/holds").Handler(httptransport.NewServer(
+		e.HoldEndpoint,
+		decodeHoldRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/holds/{id}/confirm").Handler(httptransport.NewServer(
+		e.ConfirmEndpoint,
+		decodeHoldIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/booking/v1/holds/{id}").Handler(httptransport.NewServer(
+		e.ReleaseEndpoint,
+		decodeHoldIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/best").Handler(httptransport.NewServer(
+		e.BookBestEndpoint,
+		decodeBestMatchRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/booking/v1/groups").Handler(httptransport.NewServer(
+		e.BookGroupEndpoint,
+		decodeGroupRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/booking/v1/groups/{id}").Handler(httptransport.NewServer(
+		e.FindGroupEndpoint,
+		decodeGroupIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/booking/v1/groups/{id}").Handler(httptransport.NewServer(
+		e.CancelGroupEndpoint,
+		decodeGroupIdRequest,
+		encodeResponse,
+		options...,
+	))
 	return r
 }
 
-func decodeGetAllRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
-	var req getAllRequest
+// decodeListRequest reads the filters of the query string, times are RFC
+// 3339
+func decodeListRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	v := r.URL.Query()
+	q := Query{User: v.Get("user"), Status: Status(v.Get("status")), Sort: v.Get("sort")}
+	if spotId := v.Get("spotId"); spotId != "" {
+		if q.SpotId, err = strconv.Atoi(spotId); err != nil {
+			return nil, ErrInvalidReq.WithField("spotId", "must be a spot id")
+		}
+	}
+	if limit := v.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil {
+			return nil, ErrInvalidReq.WithField("limit", "must be a number")
+		}
+	}
+	if q.From, err = queryTime(r, "from"); err != nil {
+		return nil, err
+	}
+	if q.To, err = queryTime(r, "to"); err != nil {
+		return nil, err
+	}
+	return listRequest{Query: q}, nil
+}
+
+// queryTime reads an optional RFC 3339 time from the query parameter name
+func queryTime(r *http.Request, name string) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, ErrInvalidReq.WithField(name, "must be an RFC 3339 time")
+	}
+	return t, nil
+}
+
+func decodeNoShowsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return noShowsRequest{User: r.URL.Query().Get("user")}, nil
+}
+
+func decodeNoShowPoliciesRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func decodeNoShowPolicyRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req noShowPolicyRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
 	return req, nil
 }
 
-func decodeBookingRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
-	var req bookingRequest
+func decodeBuffersRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return nil, nil
+}
+
+func decodeBufferRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req bufferRequest
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
 		return nil, e
 	}
 	return req, nil
 }
 
-func decodeBookingResponse(_ context.Context, resp *http.Response) (interface{}, error) {
-	var response bookingResponse
-	err := json.NewDecoder(resp.Body).Decode(&response)
-	return response, err
+func decodeUpdateRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	var req updateRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.BookingId = id
+	return req, nil
+}
+
+func decodeBookingRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req bookingRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
 }
 
 func decodeDeleteRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
@@ -76,6 +275,92 @@ func decodeDeleteRequest(_ context.Context, r *http.Request) (request interface{
 	return deleteRequest{BookingId: id}, nil
 }
 
+func decodeFindByPlateRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	plate, ok := vars["plate"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return findByPlateRequest{Plate: plate}, nil
+}
+
+func decodeResizeRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	var req resizeRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.BookingId = id
+	return req, nil
+}
+
+// decodePassRequest asks for the QR code when the client accepts image/png
+func decodePassRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return passRequest{BookingId: id, PNG: strings.Contains(r.Header.Get("Accept"), PNGContentType)}, nil
+}
+
+func decodeGateRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req gateRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	if req.Token == "" {
+		return nil, ErrInvalidReq.WithField("token", "is required")
+	}
+	return req, nil
+}
+
+func decodeHoldRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req holdRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeHoldIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return holdIdRequest{HoldId: id}, nil
+}
+
+func decodeBestMatchRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req bestMatchRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeGroupRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req groupRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeGroupIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return groupIdRequest{GroupId: id}, nil
+}
+
 func decodeDeleteResponse(_ context.Context, resp *http.Response) (interface{}, error) {
 	var response deleteResponse
 	err := json.NewDecoder(resp.Body).Decode(&response)
@@ -90,51 +375,31 @@ type errorer interface {
 	error() error
 }
 
-func encodeBookingRequest(ctx context.Context, req *http.Request, request interface{}) error {
-	req.URL.Path = "/booking/"
-	return encodeRequest(ctx, req, request)
-}
-
-func encodeDeleteRequest(ctx context.Context, req *http.Request, request interface{}) error {
-	req.URL.Path = "/booking/"
-	return encodeRequest(ctx, req, request)
-}
-
-func encodeRequest(_ context.Context, req *http.Request, request interface{}) error {
-	var buf bytes.Buffer
-	err := json.NewEncoder(&buf).Encode(request)
+// encodePassResponse writes the pass token as a QR code PNG, or the pass as
+// JSON
+func encodePassResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	r := response.(passResponse)
+	if r.Err != nil || !r.png {
+		return encodeResponse(ctx, w, response)
+	}
+	c, err := qr.Encode([]byte(r.Pass.Token), qr.M)
+	if err != nil {
+		return err
+	}
+	b, err := c.PNG(PassScale)
 	if err != nil {
 		return err
 	}
-	req.Body = ioutil.NopCloser(&buf)
-	return nil
+	w.Header().Set("Content-Type", PNGContentType)
+	_, err = w.Write(b)
+	return err
 }
 
 func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
 	if e, ok := response.(errorer); ok && e.error() != nil {
-		encodeError(ctx, e.error(), w)
+		apierror.EncodeError(ctx, e.error(), w)
 		return nil
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	return json.NewEncoder(w).Encode(response)
 }
-
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
-	if err == nil {
-		panic("encodeError with nil error")
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(codeFrom(err))
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": err.Error(),
-	})
-}
-
-func codeFrom(err error) int {
-	switch err {
-	case ErrNotFound:
-		return http.StatusNotFound
-	default:
-		return http.StatusInternalServerError
-	}
-}
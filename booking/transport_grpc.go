@@ -0,0 +1,554 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	oldcontext "golang.org/x/net/context"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking/pb"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/go-kit/kit/log"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+)
+
+type grpcServer struct {
+	getAll          grpctransport.Handler
+	list            grpctransport.Handler
+	find            grpctransport.Handler
+	update          grpctransport.Handler
+	book            grpctransport.Handler
+	bookBest        grpctransport.Handler
+	delete          grpctransport.Handler
+	findByPlate     grpctransport.Handler
+	checkIn         grpctransport.Handler
+	extend          grpctransport.Handler
+	shorten         grpctransport.Handler
+	hold            grpctransport.Handler
+	confirm         grpctransport.Handler
+	release         grpctransport.Handler
+	bookGroup       grpctransport.Handler
+	findGroup       grpctransport.Handler
+	cancelGroup     grpctransport.Handler
+	setNoShowPolicy grpctransport.Handler
+	noShowPolicies  grpctransport.Handler
+	noShows         grpctransport.Handler
+	setBuffer       grpctransport.Handler
+	buffers         grpctransport.Handler
+	pass            grpctransport.Handler
+	gate            grpctransport.Handler
+}
+
+// MakeGRPCServer serves the endpoints of MakeAuthorizedEndpoints over gRPC
+func MakeGRPCServer(s Service, a *auth.Authorizer, logger log.Logger) pb.BookingServiceServer {
+	e := MakeAuthorizedEndpoints(s, a)
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerBefore(auth.GRPCToContext),
+		grpctransport.ServerErrorLogger(logger),
+	}
+	return &grpcServer{
+		getAll:          grpctransport.NewServer(e.GetAllEndpoint, decodeGRPCGetAllRequest, encodeGRPCBookingsResponse, options...),
+		list:            grpctransport.NewServer(e.ListEndpoint, decodeGRPCListRequest, encodeGRPCBookingsResponse, options...),
+		find:            grpctransport.NewServer(e.FindEndpoint, decodeGRPCBookingIdRequest, encodeGRPCBookResponse, options...),
+		update:          grpctransport.NewServer(e.UpdateEndpoint, decodeGRPCUpdateRequest, encodeGRPCBookResponse, options...),
+		book:            grpctransport.NewServer(e.BookingEndpoint, decodeGRPCBookRequest, encodeGRPCBookResponse, options...),
+		bookBest:        grpctransport.NewServer(e.BookBestEndpoint, decodeGRPCBookBestRequest, encodeGRPCBookBestResponse, options...),
+		delete:          grpctransport.NewServer(e.DeleteEndpoint, decodeGRPCDeleteRequest, encodeGRPCDeleteResponse, options...),
+		findByPlate:     grpctransport.NewServer(e.FindByPlateEndpoint, decodeGRPCFindByPlateRequest, encodeGRPCBookingsResponse, options...),
+		checkIn:         grpctransport.NewServer(e.CheckInEndpoint, decodeGRPCBookingIdRequest, encodeGRPCBookResponse, options...),
+		extend:          grpctransport.NewServer(e.ExtendEndpoint, decodeGRPCResizeRequest, encodeGRPCResizeResponse, options...),
+		shorten:         grpctransport.NewServer(e.ShortenEndpoint, decodeGRPCResizeRequest, encodeGRPCResizeResponse, options...),
+		hold:            grpctransport.NewServer(e.HoldEndpoint, decodeGRPCHoldRequest, encodeGRPCHoldResponse, options...),
+		confirm:         grpctransport.NewServer(e.ConfirmEndpoint, decodeGRPCHoldIdRequest, encodeGRPCBookResponse, options...),
+		release:         grpctransport.NewServer(e.ReleaseEndpoint, decodeGRPCHoldIdRequest, encodeGRPCDeleteResponse, options...),
+		bookGroup:       grpctransport.NewServer(e.BookGroupEndpoint, decodeGRPCGroupRequest, encodeGRPCGroupResponse, options...),
+		findGroup:       grpctransport.NewServer(e.FindGroupEndpoint, decodeGRPCGroupIdRequest, encodeGRPCGroupResponse, options...),
+		cancelGroup:     grpctransport.NewServer(e.CancelGroupEndpoint, decodeGRPCGroupIdRequest, encodeGRPCDeleteResponse, options...),
+		setNoShowPolicy: grpctransport.NewServer(e.SetNoShowPolicyEndpoint, decodeGRPCNoShowPolicyRequest, encodeGRPCNoShowPolicyResponse, options...),
+		noShowPolicies:  grpctransport.NewServer(e.NoShowPoliciesEndpoint, decodeGRPCNoShowPoliciesRequest, encodeGRPCNoShowPoliciesResponse, options...),
+		noShows:         grpctransport.NewServer(e.NoShowsEndpoint, decodeGRPCNoShowsRequest, encodeGRPCNoShowsResponse, options...),
+		setBuffer:       grpctransport.NewServer(e.SetBufferEndpoint, decodeGRPCBufferRequest, encodeGRPCBufferResponse, options...),
+		buffers:         grpctransport.NewServer(e.BuffersEndpoint, decodeGRPCBuffersRequest, encodeGRPCBuffersResponse, options...),
+		pass:            grpctransport.NewServer(e.PassEndpoint, decodeGRPCPassRequest, encodeGRPCPassResponse, options...),
+		gate:            grpctransport.NewServer(e.GateEndpoint, decodeGRPCGateRequest, encodeGRPCBookResponse, options...),
+	}
+}
+
+func (s *grpcServer) GetAll(ctx oldcontext.Context, req *pb.GetAllRequest) (*pb.BookingsReply, error) {
+	_, rep, err := s.getAll.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookingsReply), nil
+}
+
+func (s *grpcServer) List(ctx oldcontext.Context, req *pb.ListRequest) (*pb.BookingsReply, error) {
+	_, rep, err := s.list.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookingsReply), nil
+}
+
+func (s *grpcServer) Find(ctx oldcontext.Context, req *pb.BookingIdRequest) (*pb.BookReply, error) {
+	_, rep, err := s.find.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookReply), nil
+}
+
+func (s *grpcServer) Update(ctx oldcontext.Context, req *pb.UpdateRequest) (*pb.BookReply, error) {
+	_, rep, err := s.update.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookReply), nil
+}
+
+func (s *grpcServer) Book(ctx oldcontext.Context, req *pb.BookRequest) (*pb.BookReply, error) {
+	_, rep, err := s.book.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookReply), nil
+}
+
+func (s *grpcServer) BookBest(ctx oldcontext.Context, req *pb.BookBestRequest) (*pb.BookBestReply, error) {
+	_, rep, err := s.bookBest.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookBestReply), nil
+}
+
+func (s *grpcServer) Delete(ctx oldcontext.Context, req *pb.DeleteRequest) (*pb.DeleteReply, error) {
+	_, rep, err := s.delete.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.DeleteReply), nil
+}
+
+func (s *grpcServer) FindActiveByPlate(ctx oldcontext.Context, req *pb.FindByPlateRequest) (*pb.BookingsReply, error) {
+	_, rep, err := s.findByPlate.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookingsReply), nil
+}
+
+func (s *grpcServer) CheckIn(ctx oldcontext.Context, req *pb.BookingIdRequest) (*pb.BookReply, error) {
+	_, rep, err := s.checkIn.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookReply), nil
+}
+
+func (s *grpcServer) Extend(ctx oldcontext.Context, req *pb.ResizeRequest) (*pb.ResizeReply, error) {
+	_, rep, err := s.extend.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.ResizeReply), nil
+}
+
+func (s *grpcServer) Shorten(ctx oldcontext.Context, req *pb.ResizeRequest) (*pb.ResizeReply, error) {
+	_, rep, err := s.shorten.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.ResizeReply), nil
+}
+
+func (s *grpcServer) Hold(ctx oldcontext.Context, req *pb.HoldRequest) (*pb.HoldReply, error) {
+	_, rep, err := s.hold.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.HoldReply), nil
+}
+
+func (s *grpcServer) Confirm(ctx oldcontext.Context, req *pb.HoldIdRequest) (*pb.BookReply, error) {
+	_, rep, err := s.confirm.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookReply), nil
+}
+
+func (s *grpcServer) Release(ctx oldcontext.Context, req *pb.HoldIdRequest) (*pb.DeleteReply, error) {
+	_, rep, err := s.release.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.DeleteReply), nil
+}
+
+func (s *grpcServer) BookGroup(ctx oldcontext.Context, req *pb.GroupRequest) (*pb.GroupReply, error) {
+	_, rep, err := s.bookGroup.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.GroupReply), nil
+}
+
+func (s *grpcServer) FindGroup(ctx oldcontext.Context, req *pb.GroupIdRequest) (*pb.GroupReply, error) {
+	_, rep, err := s.findGroup.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.GroupReply), nil
+}
+
+func (s *grpcServer) CancelGroup(ctx oldcontext.Context, req *pb.GroupIdRequest) (*pb.DeleteReply, error) {
+	_, rep, err := s.cancelGroup.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.DeleteReply), nil
+}
+
+func (s *grpcServer) SetNoShowPolicy(ctx oldcontext.Context, req *pb.NoShowPolicyRequest) (*pb.NoShowPolicyReply, error) {
+	_, rep, err := s.setNoShowPolicy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.NoShowPolicyReply), nil
+}
+
+func (s *grpcServer) NoShowPolicies(ctx oldcontext.Context, req *pb.NoShowPoliciesRequest) (*pb.NoShowPoliciesReply, error) {
+	_, rep, err := s.noShowPolicies.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.NoShowPoliciesReply), nil
+}
+
+func (s *grpcServer) NoShows(ctx oldcontext.Context, req *pb.NoShowsRequest) (*pb.NoShowsReply, error) {
+	_, rep, err := s.noShows.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.NoShowsReply), nil
+}
+
+func (s *grpcServer) SetBuffer(ctx oldcontext.Context, req *pb.BufferRequest) (*pb.BufferReply, error) {
+	_, rep, err := s.setBuffer.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BufferReply), nil
+}
+
+func (s *grpcServer) Buffers(ctx oldcontext.Context, req *pb.BuffersRequest) (*pb.BuffersReply, error) {
+	_, rep, err := s.buffers.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BuffersReply), nil
+}
+
+func (s *grpcServer) Pass(ctx oldcontext.Context, req *pb.BookingIdRequest) (*pb.PassReply, error) {
+	_, rep, err := s.pass.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.PassReply), nil
+}
+
+func (s *grpcServer) Gate(ctx oldcontext.Context, req *pb.GateRequest) (*pb.BookReply, error) {
+	_, rep, err := s.gate.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.BookReply), nil
+}
+
+func decodeGRPCGetAllRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return getAllRequest{}, nil
+}
+
+func decodeGRPCListRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ListRequest)
+	return listRequest{Query: Query{
+		SpotId: int(req.SpotId),
+		User:   req.User,
+		Status: Status(req.Status),
+		From:   fromUnixNano(req.From),
+		To:     fromUnixNano(req.To),
+		Sort:   req.Sort,
+		Limit:  int(req.Limit),
+	}}, nil
+}
+
+// decodeGRPCBookingIdRequest serves Find and CheckIn, which take the
+// request of Delete
+func decodeGRPCBookingIdRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.BookingIdRequest)
+	return deleteRequest{BookingId: req.Id}, nil
+}
+
+func decodeGRPCUpdateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateRequest)
+	return updateRequest{BookingId: req.Id, SpotId: req.SpotId, Start: fromUnixNano(req.Start), End: fromUnixNano(req.End)}, nil
+}
+
+func decodeGRPCBookRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.BookRequest)
+	return bookingRequest{SpotId: req.SpotId, VehicleId: req.VehicleId, Start: fromUnixNano(req.Start), End: fromUnixNano(req.End)}, nil
+}
+
+func decodeGRPCBookBestRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.BookBestRequest)
+	return bestMatchRequest{
+		Lat:       req.Lat,
+		Lon:       req.Lon,
+		Rad:       req.Rad,
+		Metric:    parking.SearchMetric(req.Metric),
+		VehicleId: req.VehicleId,
+		Start:     fromUnixNano(req.Start),
+		End:       fromUnixNano(req.End),
+	}, nil
+}
+
+func decodeGRPCDeleteRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.DeleteRequest)
+	return deleteRequest{BookingId: req.Id}, nil
+}
+
+func decodeGRPCFindByPlateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.FindByPlateRequest)
+	return findByPlateRequest{Plate: req.Plate}, nil
+}
+
+func decodeGRPCResizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ResizeRequest)
+	return resizeRequest{BookingId: req.Id, Minutes: int(req.Minutes)}, nil
+}
+
+func decodeGRPCHoldRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.HoldRequest)
+	return holdRequest{SpotId: req.SpotId, VehicleId: req.VehicleId, TTL: int(req.Ttl), Start: fromUnixNano(req.Start), End: fromUnixNano(req.End)}, nil
+}
+
+func decodeGRPCHoldIdRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.HoldIdRequest)
+	return holdIdRequest{HoldId: req.Id}, nil
+}
+
+func decodeGRPCGroupRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GroupRequest)
+	return groupRequest{
+		VehicleIds: req.VehicleIds,
+		SpotIds:    req.SpotIds,
+		Lat:        req.Lat,
+		Lon:        req.Lon,
+		Radius:     req.Radius,
+		Start:      fromUnixNano(req.Start),
+		End:        fromUnixNano(req.End),
+	}, nil
+}
+
+func decodeGRPCGroupIdRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GroupIdRequest)
+	return groupIdRequest{GroupId: req.Id}, nil
+}
+
+func decodeGRPCNoShowPolicyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.NoShowPolicyRequest)
+	return noShowPolicyRequest{Facility: req.Facility, GraceMinutes: int(req.GraceMinutes), Fee: req.Fee, Strike: req.Strike}, nil
+}
+
+func decodeGRPCNoShowPoliciesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeGRPCNoShowsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.NoShowsRequest)
+	return noShowsRequest{User: req.User}, nil
+}
+
+func decodeGRPCBufferRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.BufferRequest)
+	return bufferRequest{SpotId: int(req.SpotId), Facility: req.Facility, BeforeMinutes: int(req.BeforeMinutes), AfterMinutes: int(req.AfterMinutes)}, nil
+}
+
+func decodeGRPCBuffersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeGRPCPassRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.BookingIdRequest)
+	return passRequest{BookingId: req.Id}, nil
+}
+
+func decodeGRPCGateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.GateRequest)
+	if req.Token == "" {
+		return nil, ErrInvalidReq.WithField("token", "is required")
+	}
+	return gateRequest{Token: req.Token, Facility: req.Facility, Direction: Direction(req.Direction)}, nil
+}
+
+func encodeGRPCBookingsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(getAllResponse)
+	return &pb.BookingsReply{Bookings: bookingsToPB(resp.Bookings)}, nil
+}
+
+func encodeGRPCBookResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(bookingResponse)
+	return &pb.BookReply{Booking: bookingToPB(resp.Booking)}, nil
+}
+
+func encodeGRPCBookBestResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(bestMatchResponse)
+	return &pb.BookBestReply{Booking: bookingToPB(resp.Booking), Spot: parking.ExtendedSpotToPB(resp.Spot)}, nil
+}
+
+func encodeGRPCDeleteResponse(_ context.Context, response interface{}) (interface{}, error) {
+	return &pb.DeleteReply{}, nil
+}
+
+func encodeGRPCResizeResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(resizeResponse)
+	return &pb.ResizeReply{Booking: bookingToPB(resp.Booking), Difference: resp.Difference}, nil
+}
+
+func encodeGRPCHoldResponse(_ context.Context, response interface{}) (interface{}, error) {
+	h := response.(holdResponse).Hold
+	return &pb.HoldReply{Hold: &pb.Hold{
+		Id:           int64(h.ID),
+		SpotId:       int64(h.SpotId),
+		VehicleId:    int64(h.VehicleId),
+		User:         h.User,
+		StartTime:    unixNano(h.StartTime),
+		Duration:     int64(h.Duration),
+		ExpiresAt:    unixNano(h.ExpiresAt),
+		Price:        h.Price,
+		BufferBefore: int64(h.BufferBefore),
+		BufferAfter:  int64(h.BufferAfter),
+	}}, nil
+}
+
+func encodeGRPCGroupResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(groupResponse)
+	g := &pb.Group{Id: int64(resp.Group.ID), User: resp.Group.User, CreatedAt: unixNano(resp.Group.CreatedAt)}
+	for _, id := range resp.Group.BookingIds {
+		g.BookingIds = append(g.BookingIds, int64(id))
+	}
+	return &pb.GroupReply{Group: g, Bookings: bookingsToPB(resp.Bookings)}, nil
+}
+
+func encodeGRPCNoShowPolicyResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(noShowPolicyResponse)
+	return &pb.NoShowPolicyReply{Policy: noShowPolicyToPB(resp.Policy)}, nil
+}
+
+func encodeGRPCNoShowPoliciesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(noShowPoliciesResponse)
+	rep := &pb.NoShowPoliciesReply{Policies: make([]*pb.NoShowPolicy, 0, len(resp.Policies))}
+	for _, p := range resp.Policies {
+		rep.Policies = append(rep.Policies, noShowPolicyToPB(p))
+	}
+	return rep, nil
+}
+
+func encodeGRPCNoShowsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(noShowsResponse)
+	rep := &pb.NoShowsReply{NoShows: make([]*pb.NoShow, 0, len(resp.NoShows))}
+	for _, n := range resp.NoShows {
+		rep.NoShows = append(rep.NoShows, &pb.NoShow{
+			BookingId: int64(n.BookingId),
+			User:      n.User,
+			SpotId:    int64(n.SpotId),
+			Facility:  n.Facility,
+			Fee:       n.Fee,
+			Strike:    n.Strike,
+			At:        unixNano(n.At),
+		})
+	}
+	return rep, nil
+}
+
+func encodeGRPCBufferResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(bufferResponse)
+	return &pb.BufferReply{Buffer: bufferToPB(resp.Buffer)}, nil
+}
+
+func encodeGRPCBuffersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(buffersResponse)
+	rep := &pb.BuffersReply{Buffers: make([]*pb.Buffer, 0, len(resp.Buffers))}
+	for _, b := range resp.Buffers {
+		rep.Buffers = append(rep.Buffers, bufferToPB(b))
+	}
+	return rep, nil
+}
+
+func encodeGRPCPassResponse(_ context.Context, response interface{}) (interface{}, error) {
+	p := response.(passResponse).Pass
+	return &pb.PassReply{Pass: &pb.Pass{
+		BookingId: int64(p.BookingId),
+		SpotId:    int64(p.SpotId),
+		Facility:  p.Facility,
+		NotBefore: unixNano(p.NotBefore),
+		NotAfter:  unixNano(p.NotAfter),
+		Token:     p.Token,
+	}}, nil
+}
+
+func bookingToPB(b Booking) *pb.Booking {
+	return &pb.Booking{
+		Id:           int64(b.ID),
+		SpotId:       int64(b.SpotId),
+		VehicleId:    int64(b.VehicleId),
+		StartTime:    unixNano(b.StartTime),
+		Duration:     int64(b.Duration),
+		User:         b.User,
+		Status:       string(b.Status),
+		Price:        b.Price,
+		Prepaid:      b.Prepaid,
+		CheckedInAt:  unixNano(b.CheckedInAt),
+		EnteredAt:    unixNano(b.EnteredAt),
+		ExitedAt:     unixNano(b.ExitedAt),
+		GroupId:      int64(b.GroupId),
+		BufferBefore: int64(b.BufferBefore),
+		BufferAfter:  int64(b.BufferAfter),
+	}
+}
+
+func bookingsToPB(bb []Booking) []*pb.Booking {
+	pp := make([]*pb.Booking, 0, len(bb))
+	for _, b := range bb {
+		pp = append(pp, bookingToPB(b))
+	}
+	return pp
+}
+
+func noShowPolicyToPB(p NoShowPolicy) *pb.NoShowPolicy {
+	return &pb.NoShowPolicy{Facility: p.Facility, Grace: int64(p.Grace), Fee: p.Fee, Strike: p.Strike}
+}
+
+func bufferToPB(b Buffer) *pb.Buffer {
+	return &pb.Buffer{SpotId: int64(b.SpotId), Facility: b.Facility, Before: int64(b.Before), After: int64(b.After)}
+}
+
+// unixNano leaves the times a booking has not reached yet, such as its
+// check-in, at 0
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// fromUnixNano reads a time of a request, 0 leaving it to the default
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
@@ -0,0 +1,80 @@
+// Command booking runs the booking, vehicle and API key services, talking to
+// a separately deployed parking service over HTTP.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/server"
+	"github.com/go-kit/kit/log"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	var (
+		httpAddr       = flag.String("http.addr", ":8090", "HTTP listen address")
+		grpcAddr       = flag.String("grpc.addr", ":8091", "gRPC listen address")
+		parkingAddr    = flag.String("parking.addr", "http://localhost:8080", "Base URL of the parking service")
+		parkingKey     = flag.String("parking.apikey", "", "Service API key presented to the parking service, required")
+		parkingTimeout = flag.Duration("parking.timeout", parking.DefaultClientTimeout, "Timeout of a single call to the parking service")
+		parkingRetries = flag.Int("parking.retries", parking.DefaultClientRetries, "Retries of a failed call to the parking service, -1 disables them")
+		breakerCool    = flag.Duration("parking.breaker.cooldown", parking.DefaultBreakerCooldown, "How long the circuit to the parking service stays open")
+		o              server.Options
+		bo             server.BookingOptions
+	)
+	o.Flags(flag.CommandLine)
+	bo.Flags(flag.CommandLine)
+	flag.Parse()
+	if *parkingKey == "" {
+		// the background runs call parking without a caller to act for
+		fmt.Fprintln(os.Stderr, "-parking.apikey is required to reach the parking service")
+		os.Exit(2)
+	}
+
+	logger := server.NewLogger()
+
+	var p parking.Service
+	{
+		var err error
+		p, err = parking.NewHTTPClient(*parkingAddr, parking.ClientOptions{
+			APIKey:          *parkingKey,
+			Timeout:         *parkingTimeout,
+			Retries:         *parkingRetries,
+			BreakerCooldown: *breakerCool,
+		})
+		if err != nil {
+			panic(err)
+		}
+		p = parking.LoggingMiddleware(log.With(logger, "component", "parking-client"))(p)
+	}
+
+	events, err := server.NewEvents(logger, o.EventsFile)
+	if err != nil {
+		panic(err)
+	}
+	bk, err := server.NewBooking(logger, p, events, bo)
+	if err != nil {
+		panic(err)
+	}
+	keys, err := server.NewAPIKeys(logger)
+	if err != nil {
+		panic(err)
+	}
+	a := server.NewAuthorizer(logger, o, keys)
+
+	doc := openapi.New("rct booking", "v1")
+	mux := http.NewServeMux()
+	grpcServer := grpc.NewServer()
+	bk.Handle(mux, grpcServer, doc, a, logger)
+	events.Handle(mux, doc, a, logger)
+	keys.Handle(mux, doc, a, logger)
+	mux.Handle("/openapi.json", openapi.Handler(doc))
+
+	logger.Log("terminated", server.Serve(logger, *httpAddr, *grpcAddr, mux, grpcServer))
+	events.Close()
+}
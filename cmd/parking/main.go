@@ -0,0 +1,59 @@
+// Command parking runs the parking service on its own, so it can be deployed
+// independently of booking.
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/server"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	var (
+		httpAddr = flag.String("http.addr", ":8080", "HTTP listen address")
+		grpcAddr = flag.String("grpc.addr", ":8081", "gRPC listen address")
+		o        server.Options
+		so       server.SensorOptions
+	)
+	o.Flags(flag.CommandLine)
+	so.Flags(flag.CommandLine)
+	flag.Parse()
+
+	logger := server.NewLogger()
+
+	events, err := server.NewEvents(logger, o.EventsFile)
+	if err != nil {
+		panic(err)
+	}
+	p, err := server.NewParking(logger, events)
+	if err != nil {
+		panic(err)
+	}
+	// bookings live with booking, here a reserved spot counts as booked
+	sn, err := server.NewSensors(logger, p.Spots, nil, events, so)
+	if err != nil {
+		panic(err)
+	}
+	// partner keys are issued by each binary, booking's are not known here
+	keys, err := server.NewAPIKeys(logger)
+	if err != nil {
+		panic(err)
+	}
+	a := server.NewAuthorizer(logger, o, keys)
+
+	doc := openapi.New("rct parking", "v1")
+	mux := http.NewServeMux()
+	grpcServer := grpc.NewServer()
+	p.Handle(mux, grpcServer, doc, a, logger)
+	events.Handle(mux, doc, a, logger)
+	keys.Handle(mux, doc, a, logger)
+	sn.Handle(mux, doc, a, logger)
+	mux.Handle("/openapi.json", openapi.Handler(doc))
+
+	logger.Log("terminated", server.Serve(logger, *httpAddr, *grpcAddr, mux, grpcServer))
+	p.Close()
+	events.Close()
+}
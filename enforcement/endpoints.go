@@ -0,0 +1,118 @@
+package enforcement
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	ViolationsEndpoint     endpoint.Endpoint
+	PatrolEndpoint         endpoint.Endpoint
+	IssueEndpoint          endpoint.Endpoint
+	CitationsEndpoint      endpoint.Endpoint
+	UpdateCitationEndpoint endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		ViolationsEndpoint:     MakeViolationsEndpoint(s),
+		PatrolEndpoint:         MakePatrolEndpoint(s),
+		IssueEndpoint:          MakeIssueEndpoint(s),
+		CitationsEndpoint:      MakeCitationsEndpoint(s),
+		UpdateCitationEndpoint: MakeUpdateCitationEndpoint(s),
+	}
+}
+
+func MakeViolationsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		vv, e := s.Violations(ctx)
+		return violationsResponse{Violations: vv, Err: e}, e
+	}
+}
+
+func MakePatrolEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(patrolRequest)
+		ss, e := s.Patrol(ctx, req.Lat, req.Lon, req.Rad)
+		return patrolResponse{Stops: ss, Err: e}, e
+	}
+}
+
+func MakeIssueEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(issueRequest)
+		c, e := s.Issue(ctx, req.ViolationID, req.Note)
+		return citationResponse{Citation: c, Err: e}, e
+	}
+}
+
+func MakeCitationsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(citationsRequest)
+		cc, e := s.Citations(ctx, req.Status)
+		return citationsResponse{Citations: cc, Err: e}, e
+	}
+}
+
+func MakeUpdateCitationEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(updateCitationRequest)
+		c, e := s.UpdateCitation(ctx, req.ID, req.Status, req.Note)
+		return citationResponse{Citation: c, Err: e}, e
+	}
+}
+
+//
+
+type violationsRequest struct {
+}
+
+type violationsResponse struct {
+	Err        error       `json:"err,omitempty"`
+	Violations []Violation `json:"violations"`
+}
+
+func (r violationsResponse) error() error { return r.Err }
+
+type patrolRequest struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+	Rad string `json:"rad"`
+}
+
+type patrolResponse struct {
+	Err   error  `json:"err,omitempty"`
+	Stops []Stop `json:"stops"`
+}
+
+func (r patrolResponse) error() error { return r.Err }
+
+type issueRequest struct {
+	ViolationID string `json:"violationId"`
+	Note        string `json:"note,omitempty"`
+}
+
+type citationResponse struct {
+	Err      error    `json:"err,omitempty"`
+	Citation Citation `json:"citation"`
+}
+
+func (r citationResponse) error() error { return r.Err }
+
+type citationsRequest struct {
+	Status CitationStatus `json:"status"`
+}
+
+type citationsResponse struct {
+	Err       error      `json:"err,omitempty"`
+	Citations []Citation `json:"citations"`
+}
+
+func (r citationsResponse) error() error { return r.Err }
+
+type updateCitationRequest struct {
+	ID     string         `json:"-"`
+	Status CitationStatus `json:"status,omitempty"`
+	Note   string         `json:"note,omitempty"`
+}
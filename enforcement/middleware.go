@@ -0,0 +1,66 @@
+package enforcement
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Scan(ctx context.Context) (vv []Violation, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Scan", "violations", len(vv), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Scan(ctx)
+}
+
+func (mw loggingMiddleware) Violations(ctx context.Context) (vv []Violation, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Violations", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Violations(ctx)
+}
+
+func (mw loggingMiddleware) Patrol(ctx context.Context, lat, lon, radius string) (ss []Stop, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Patrol", "lat", lat, "lon", lon, "radius", radius, "stops", len(ss), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Patrol(ctx, lat, lon, radius)
+}
+
+func (mw loggingMiddleware) Issue(ctx context.Context, violationId, note string) (c Citation, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Issue", "violation", violationId, "citation", c.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Issue(ctx, violationId, note)
+}
+
+func (mw loggingMiddleware) Citations(ctx context.Context, status CitationStatus) (cc []Citation, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Citations", "status", status, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Citations(ctx, status)
+}
+
+func (mw loggingMiddleware) UpdateCitation(ctx context.Context, id string, status CitationStatus, note string) (c Citation, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "UpdateCitation", "id", id, "status", status, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.UpdateCitation(ctx, id, status, note)
+}
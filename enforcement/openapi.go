@@ -0,0 +1,41 @@
+package enforcement
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(ViolationKind(""), NoBooking, Overstay, WrongClass)
+	d.Enum(CitationStatus(""), Issued, Appealed, Paid, Voided)
+
+	d.Operation("GET", "/enforcement/v1/violations", "listViolations", "List the violations found by the latest scan").
+		Tag("enforcement").Require(requirements["ListViolations"]).
+		Returns(http.StatusOK, "Violations", violationsResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/enforcement/v1/patrol", "patrol", "List the violations around a point, nearest first").
+		Tag("enforcement").Require(requirements["Patrol"]).
+		QueryParam("lat", "Latitude of the officer").
+		QueryParam("lon", "Longitude of the officer").
+		QueryParam("rad", "Radius in meters, 5000 when left out").
+		Returns(http.StatusOK, "The stops of the patrol", patrolResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/enforcement/v1/citations", "issueCitation", "Cite a violation, the note records the evidence").
+		Tag("enforcement").Require(requirements["IssueCitation"]).
+		Body(issueRequest{}).
+		Returns(http.StatusOK, "The citation", citationResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/enforcement/v1/citations", "listCitations", "List the citations").
+		Tag("enforcement").Require(requirements["ListCitations"]).
+		QueryParam("status", "Only citations with this status").
+		Returns(http.StatusOK, "Citations", citationsResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PATCH", "/enforcement/v1/citations/{id}", "updateCitation", "Move a citation to another status or add a note").
+		Tag("enforcement").Require(requirements["UpdateCitation"]).
+		PathParam("id", "Citation id").
+		Body(updateCitationRequest{}).
+		Returns(http.StatusOK, "The citation", citationResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
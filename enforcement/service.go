@@ -0,0 +1,291 @@
+package enforcement
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+)
+
+// Enforcement service. A periodic scan compares what the sensors see with
+// the bookings and keeps the violations it finds. Officers patrol them
+// nearest first and issue citations.
+
+var (
+	ErrInvalidReq        = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrAlreadyCited      = apierror.New(apierror.Conflict, "violation_already_cited", "the violation already has an open citation")
+	ErrInvalidTransition = apierror.New(apierror.Conflict, "invalid_citation_status", "the citation can't move to this status")
+)
+
+const (
+	// DefaultGrace is how long a violation must last before it is reported
+	DefaultGrace = 10 * time.Minute
+	// DefaultPatrolRadius in meters
+	DefaultPatrolRadius = "5000"
+)
+
+// Bookings are the reservations occupancy is checked against
+type Bookings interface {
+	GetAll(ctx context.Context) ([]booking.Booking, error)
+}
+
+// Stop is a violation on the patrol list with the spot and its distance
+type Stop struct {
+	Violation Violation            `json:"violation"`
+	Spot      parking.ExtendedSpot `json:"spot"`
+}
+
+type Service interface {
+	// Scan looks for violations and replaces the stored ones with them. It
+	// is run periodically, not exposed over HTTP.
+	Scan(ctx context.Context) ([]Violation, error)
+	Violations(ctx context.Context) ([]Violation, error)
+	// Patrol lists the violations within radius meters of lat, lon,
+	// nearest first
+	Patrol(ctx context.Context, lat, lon, radius string) ([]Stop, error)
+	// Issue cites a violation, note records the evidence
+	Issue(ctx context.Context, violationId, note string) (Citation, error)
+	// Citations lists the citations with status, or all when it is empty
+	Citations(ctx context.Context, status CitationStatus) ([]Citation, error)
+	// UpdateCitation moves a citation to status and/or adds a note
+	UpdateCitation(ctx context.Context, id string, status CitationStatus, note string) (Citation, error)
+}
+
+type service struct {
+	violations ViolationStore
+	citations  CitationStore
+	spots      parking.Service
+	bookings   Bookings
+	vehicles   vehicle.Service
+	grace      time.Duration
+	now        func() time.Time
+}
+
+func NewService(violations ViolationStore, citations CitationStore, spots parking.Service, bookings Bookings, vehicles vehicle.Service, grace time.Duration) Service {
+	if grace <= 0 {
+		grace = DefaultGrace
+	}
+	return &service{
+		violations: violations,
+		citations:  citations,
+		spots:      spots,
+		bookings:   bookings,
+		vehicles:   vehicles,
+		grace:      grace,
+		now:        time.Now,
+	}
+}
+
+func (s *service) Scan(ctx context.Context) ([]Violation, error) {
+	// the scan looks at every vehicle, whoever asked for it
+	ctx = auth.NewContext(ctx, auth.System)
+	now := s.now()
+	spots, err := s.spots.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bb, err := s.bookings.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bySpot := map[int][]booking.Booking{}
+	for _, b := range bb {
+		bySpot[b.SpotId] = append(bySpot[b.SpotId], b)
+	}
+	previous, err := s.violations.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	detected := map[string]time.Time{}
+	for _, v := range previous {
+		detected[v.ID] = v.DetectedAt
+	}
+
+	found := make([]Violation, 0)
+	for _, sp := range spots {
+		if sp.Occupancy != parking.Occupied {
+			continue
+		}
+		v, ok := s.check(ctx, sp, bySpot[sp.ID], now)
+		if !ok {
+			continue
+		}
+		v.DetectedAt = now.UTC()
+		if at, ok := detected[v.ID]; ok {
+			v.DetectedAt = at
+		}
+		found = append(found, v)
+	}
+	if err := s.violations.Replace(found); err != nil {
+		return nil, err
+	}
+	return s.violations.GetAll()
+}
+
+// check looks for a violation on the occupied spot sp
+func (s *service) check(ctx context.Context, sp parking.Spot, bb []booking.Booking, now time.Time) (Violation, bool) {
+	var ended booking.Booking
+	for _, b := range bb {
+		if !b.Released() && b.ActiveAt(now) {
+			return s.checkVehicle(ctx, sp, b)
+		}
+		// the latest booking that ended while the vehicle was there
+		if !b.EndTime().After(now) && sp.OccupancyAt.Before(b.EndTime()) && b.EndTime().After(ended.EndTime()) {
+			ended = b
+		}
+	}
+	if ended.ID != 0 {
+		if now.Sub(ended.EndTime()) <= s.grace {
+			return Violation{}, false
+		}
+		v := Violation{
+			ID:        fmt.Sprintf("%s:%d:%d", Overstay, sp.ID, ended.ID),
+			Kind:      Overstay,
+			SpotId:    sp.ID,
+			BookingId: ended.ID,
+			VehicleId: ended.VehicleId,
+			Since:     ended.EndTime(),
+		}
+		if vh, err := s.vehicles.Find(ctx, strconv.Itoa(ended.VehicleId)); err == nil {
+			v.Plate = vh.Plate
+		}
+		return v, true
+	}
+	if now.Sub(sp.OccupancyAt) <= s.grace {
+		return Violation{}, false
+	}
+	// a new arrival is a new violation
+	return Violation{
+		ID:     fmt.Sprintf("%s:%d:%d", NoBooking, sp.ID, sp.OccupancyAt.Unix()),
+		Kind:   NoBooking,
+		SpotId: sp.ID,
+		Since:  sp.OccupancyAt,
+	}, true
+}
+
+// checkVehicle reports the booked vehicle when the spot doesn't take it
+func (s *service) checkVehicle(ctx context.Context, sp parking.Spot, b booking.Booking) (Violation, bool) {
+	vh, err := s.vehicles.Find(ctx, strconv.Itoa(b.VehicleId))
+	if err != nil || vehicle.CheckFit(vh, sp) == nil {
+		return Violation{}, false
+	}
+	since := b.StartTime
+	if sp.OccupancyAt.After(since) {
+		since = sp.OccupancyAt
+	}
+	return Violation{
+		ID:        fmt.Sprintf("%s:%d:%d", WrongClass, sp.ID, b.ID),
+		Kind:      WrongClass,
+		SpotId:    sp.ID,
+		BookingId: b.ID,
+		VehicleId: vh.ID,
+		Plate:     vh.Plate,
+		Since:     since,
+	}, true
+}
+
+func (s *service) Violations(ctx context.Context) ([]Violation, error) {
+	return s.violations.GetAll()
+}
+
+func (s *service) Patrol(ctx context.Context, lat, lon, radius string) ([]Stop, error) {
+	if radius == "" {
+		radius = DefaultPatrolRadius
+	}
+	vv, err := s.violations.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	bySpot := map[int][]Violation{}
+	for _, v := range vv {
+		bySpot[v.SpotId] = append(bySpot[v.SpotId], v)
+	}
+	// the spot search already orders by haversine distance, a closed spot
+	// may still be parked in
+	ess, err := s.spots.Search(ctx, lat, lon, radius, parking.DIST, true)
+	if err != nil {
+		return nil, err
+	}
+	stops := make([]Stop, 0)
+	for _, es := range ess {
+		for _, v := range bySpot[es.ID] {
+			stops = append(stops, Stop{Violation: v, Spot: es})
+		}
+	}
+	return stops, nil
+}
+
+func (s *service) Issue(ctx context.Context, violationId, note string) (Citation, error) {
+	v, err := s.violations.Find(violationId)
+	if err != nil {
+		return Citation{}, err
+	}
+	cc, err := s.citations.GetAll()
+	if err != nil {
+		return Citation{}, err
+	}
+	for _, c := range cc {
+		if c.Violation.ID == v.ID && c.Status.Open() {
+			return Citation{}, ErrAlreadyCited
+		}
+	}
+	now := s.now().UTC()
+	officer := officerOf(ctx)
+	c := Citation{Violation: v, Officer: officer, Status: Issued, Notes: make([]Note, 0), IssuedAt: now, UpdatedAt: now}
+	if note != "" {
+		c.Notes = append(c.Notes, Note{Author: officer, Text: note, At: now})
+	}
+	return s.citations.Create(c)
+}
+
+func (s *service) Citations(ctx context.Context, status CitationStatus) ([]Citation, error) {
+	cc, err := s.citations.GetAll()
+	if err != nil || status == "" {
+		return cc, err
+	}
+	res := make([]Citation, 0, len(cc))
+	for _, c := range cc {
+		if c.Status == status {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+func (s *service) UpdateCitation(ctx context.Context, id string, status CitationStatus, note string) (Citation, error) {
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		return Citation{}, ErrInvalidReq
+	}
+	if status == "" && note == "" {
+		return Citation{}, ErrInvalidReq.WithField("status", "a status or a note is required")
+	}
+	c, err := s.citations.Find(intId)
+	if err != nil {
+		return Citation{}, err
+	}
+	if status != "" && status != c.Status {
+		if !c.Status.CanMove(status) {
+			return Citation{}, ErrInvalidTransition
+		}
+		c.Status = status
+	}
+	now := s.now().UTC()
+	if note != "" {
+		// copy, the stored citation shares the backing array
+		c.Notes = append(append([]Note(nil), c.Notes...), Note{Author: officerOf(ctx), Text: note, At: now})
+	}
+	c.UpdatedAt = now
+	return s.citations.Update(c)
+}
+
+func officerOf(ctx context.Context) string {
+	p, _ := auth.PrincipalFromContext(ctx)
+	return p.Subject
+}
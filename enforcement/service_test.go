@@ -0,0 +1,140 @@
+package enforcement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+)
+
+type fakeBookings []booking.Booking
+
+func (bb fakeBookings) GetAll(ctx context.Context) ([]booking.Booking, error) { return bb, nil }
+
+var t0 = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+// newTestService sets up an occupied spot without a booking (1), a
+// motorcycle spot booked for a car (2), a spot occupied for less than the
+// grace (4) and an overstayed booking (5), and scans 15 minutes after t0
+func newTestService(t *testing.T) (Service, parking.ParkingStore) {
+	store, _ := parking.NewInMemParkingStore()
+	vehicles, _ := vehicle.NewInMemVehicleStore()
+	vs := vehicle.NewService(vehicles)
+	car, err := vs.Register(context.Background(), vehicle.Vehicle{Plate: "AB12CD", Class: parking.Car,
+		Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bookings := fakeBookings{
+		{ID: 1, SpotId: 5, VehicleId: car.ID, StartTime: t0.Add(-time.Hour), Duration: time.Hour, Status: booking.StatusCheckedIn},
+		{ID: 2, SpotId: 2, VehicleId: car.ID, StartTime: t0, Duration: time.Hour, Status: booking.StatusCheckedIn},
+	}
+	store.SetOccupancy(1, parking.Occupied, t0)
+	store.SetOccupancy(2, parking.Occupied, t0)
+	store.SetOccupancy(4, parking.Occupied, t0.Add(10*time.Minute))
+	store.SetOccupancy(5, parking.Occupied, t0.Add(-50*time.Minute))
+
+	violations, _ := NewInMemViolationStore()
+	citations, _ := NewInMemCitationStore()
+	s := NewService(violations, citations, parking.NewService(store), bookings, vs, 10*time.Minute)
+	s.(*service).now = func() time.Time { return t0.Add(15 * time.Minute) }
+	return s, store
+}
+
+func officer(name string) context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Subject: name, Roles: []auth.Role{auth.RoleOfficer}})
+}
+
+func TestScan(t *testing.T) {
+	s, store := newTestService(t)
+	ctx := context.Background()
+	vv, err := s.Scan(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int]ViolationKind{1: NoBooking, 2: WrongClass, 5: Overstay}
+	if len(vv) != len(want) {
+		t.Fatalf("Expected %d violations, got %+v", len(want), vv)
+	}
+	for _, v := range vv {
+		if want[v.SpotId] != v.Kind {
+			t.Errorf("Expected %s on spot %d, got %s", want[v.SpotId], v.SpotId, v.Kind)
+		}
+		if v.Kind == Overstay && (v.BookingId != 1 || v.Plate != "AB12CD" || !v.Since.Equal(t0)) {
+			t.Errorf("Expected the overstay to name booking 1 and the plate, got %+v", v)
+		}
+	}
+
+	// the vehicle left spot 1, a new one arrived later
+	store.SetOccupancy(1, parking.Vacant, t0.Add(time.Minute))
+	store.SetOccupancy(1, parking.Occupied, t0.Add(2*time.Minute))
+	s.(*service).now = func() time.Time { return t0.Add(20 * time.Minute) }
+	next, _ := s.Scan(ctx)
+	if len(next) != 3 || next[0].ID == vv[0].ID {
+		t.Errorf("Expected a new no_booking violation, got %+v", next)
+	}
+	if !next[2].DetectedAt.Equal(vv[2].DetectedAt) {
+		t.Error("Expected a lasting violation to keep its detection time")
+	}
+}
+
+func TestPatrol(t *testing.T) {
+	s, _ := newTestService(t)
+	ctx := context.Background()
+	s.Scan(ctx)
+
+	// from spot 5, spot 1 is about 77km away, spot 2 over 300km
+	stops, err := s.Patrol(ctx, "44.92057", "-93.44786", "200000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stops) != 2 || stops[0].Spot.ID != 5 || stops[1].Spot.ID != 1 || stops[0].Spot.Distance > stops[1].Spot.Distance {
+		t.Errorf("Expected spots 5 and 1 nearest first, got %+v", stops)
+	}
+	if stops, _ := s.Patrol(ctx, "44.92057", "-93.44786", ""); len(stops) != 1 {
+		t.Errorf("Expected the default radius to find spot 5 only, got %d stops", len(stops))
+	}
+}
+
+func TestCitations(t *testing.T) {
+	s, _ := newTestService(t)
+	vv, _ := s.Scan(context.Background())
+	ctx := officer("o1")
+
+	c, err := s.Issue(ctx, vv[0].ID, "photo 1234")
+	if err != nil || c.Status != Issued || c.Officer != "o1" || len(c.Notes) != 1 {
+		t.Fatalf("Expected an issued citation with the note, got %+v %v", c, err)
+	}
+	if _, err := s.Issue(ctx, vv[0].ID, ""); err != ErrAlreadyCited {
+		t.Errorf("Expected a second citation to be rejected, got %v", err)
+	}
+	if _, err := s.Issue(ctx, "overstay:9:9", ""); err != ErrViolationNotFound {
+		t.Errorf("Expected an unknown violation to be rejected, got %v", err)
+	}
+
+	if c, err = s.UpdateCitation(ctx, "1", Appealed, "driver disputes"); err != nil || c.Status != Appealed || len(c.Notes) != 2 {
+		t.Fatalf("Expected an appeal, got %+v %v", c, err)
+	}
+	if _, err := s.UpdateCitation(ctx, "1", Paid, ""); err != ErrInvalidTransition {
+		t.Errorf("Expected an appealed citation to stay unpaid, got %v", err)
+	}
+	if c, _ = s.UpdateCitation(ctx, "1", Voided, ""); c.Status != Voided {
+		t.Errorf("Expected the citation voided, got %s", c.Status)
+	}
+	if _, err := s.UpdateCitation(ctx, "1", Issued, ""); err != ErrInvalidTransition {
+		t.Errorf("Expected a voided citation to be final, got %v", err)
+	}
+	if _, err := s.Issue(ctx, vv[0].ID, ""); err != nil {
+		t.Errorf("Expected a voided citation to allow a new one, got %v", err)
+	}
+	if cc, _ := s.Citations(ctx, Voided); len(cc) != 1 {
+		t.Errorf("Expected one voided citation, got %d", len(cc))
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
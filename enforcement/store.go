@@ -0,0 +1,195 @@
+package enforcement
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+// ViolationStore holds the violations found by the latest scan
+type ViolationStore interface {
+	// Replace swaps the stored violations for vv
+	Replace(vv []Violation) error
+	Find(id string) (Violation, error)
+	GetAll() ([]Violation, error)
+}
+
+type CitationStore interface {
+	Create(c Citation) (Citation, error)
+	Update(c Citation) (Citation, error)
+	Find(id int) (Citation, error)
+	GetAll() ([]Citation, error)
+}
+
+type ViolationKind string
+
+const (
+	// NoBooking is an occupied spot without a booking
+	NoBooking ViolationKind = "no_booking"
+	// Overstay is a vehicle still on the spot after its booking ended
+	Overstay ViolationKind = "overstay"
+	// WrongClass is a booked vehicle the spot does not allow or fit
+	WrongClass ViolationKind = "wrong_class"
+)
+
+type Violation struct {
+	// ID names the condition, it stays the same across scans while the
+	// condition lasts
+	ID        string        `json:"id"`
+	Kind      ViolationKind `json:"kind"`
+	SpotId    int           `json:"spotId"`
+	BookingId int           `json:"bookingId,omitempty"`
+	VehicleId int           `json:"vehicleId,omitempty"`
+	Plate     string        `json:"plate,omitempty"`
+	// Since is when the violation began, e.g. the end of an overstayed
+	// booking
+	Since      time.Time `json:"since"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+type CitationStatus string
+
+const (
+	Issued   CitationStatus = "issued"
+	Appealed CitationStatus = "appealed"
+	Paid     CitationStatus = "paid"
+	Voided   CitationStatus = "voided"
+)
+
+// transitions lists the statuses a citation may move to from each status
+var transitions = map[CitationStatus][]CitationStatus{
+	Issued:   {Appealed, Paid, Voided},
+	Appealed: {Issued, Voided},
+}
+
+// CanMove reports whether a citation may go from status s to next
+func (s CitationStatus) CanMove(next CitationStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Open reports whether the citation still awaits payment or a decision
+func (s CitationStatus) Open() bool {
+	return s == Issued || s == Appealed
+}
+
+// Note is a piece of evidence or a remark recorded on a citation
+type Note struct {
+	Author string    `json:"author"`
+	Text   string    `json:"text"`
+	At     time.Time `json:"at"`
+}
+
+type Citation struct {
+	ID int `json:"id"`
+	// Violation is the violation as it was found when the citation was
+	// issued
+	Violation Violation      `json:"violation"`
+	Officer   string         `json:"officer"`
+	Status    CitationStatus `json:"status"`
+	Notes     []Note         `json:"notes"`
+	IssuedAt  time.Time      `json:"issuedAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+var (
+	ErrViolationNotFound = apierror.New(apierror.NotFound, "violation_not_found", "no such violation, it may have cleared")
+	ErrCitationNotFound  = apierror.New(apierror.NotFound, "citation_not_found", "citation not found")
+)
+
+type inMemViolationStore struct {
+	mtx sync.RWMutex
+	m   map[string]Violation
+}
+
+func NewInMemViolationStore() (ViolationStore, error) {
+	return &inMemViolationStore{m: map[string]Violation{}}, nil
+}
+
+func (s *inMemViolationStore) Replace(vv []Violation) error {
+	m := make(map[string]Violation, len(vv))
+	for _, v := range vv {
+		m[v.ID] = v
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.m = m
+	return nil
+}
+
+func (s *inMemViolationStore) Find(id string) (Violation, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	v, ok := s.m[id]
+	if !ok {
+		return Violation{}, ErrViolationNotFound
+	}
+	return v, nil
+}
+
+func (s *inMemViolationStore) GetAll() ([]Violation, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	vv := make([]Violation, 0, len(s.m))
+	for _, v := range s.m {
+		vv = append(vv, v)
+	}
+	sort.Slice(vv, func(i, j int) bool { return vv[i].ID < vv[j].ID })
+	return vv, nil
+}
+
+type inMemCitationStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Citation
+	nxtId int
+}
+
+func NewInMemCitationStore() (CitationStore, error) {
+	return &inMemCitationStore{m: map[int]Citation{}, nxtId: 1}, nil
+}
+
+func (s *inMemCitationStore) Create(c Citation) (Citation, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	c.ID = s.nxtId
+	s.nxtId++
+	s.m[c.ID] = c
+	return c, nil
+}
+
+func (s *inMemCitationStore) Update(c Citation) (Citation, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[c.ID]; !ok {
+		return Citation{}, ErrCitationNotFound
+	}
+	s.m[c.ID] = c
+	return c, nil
+}
+
+func (s *inMemCitationStore) Find(id int) (Citation, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	c, ok := s.m[id]
+	if !ok {
+		return Citation{}, ErrCitationNotFound
+	}
+	return c, nil
+}
+
+func (s *inMemCitationStore) GetAll() ([]Citation, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	cc := make([]Citation, 0, len(s.m))
+	for _, c := range s.m {
+		cc = append(cc, c)
+	}
+	sort.Slice(cc, func(i, j int) bool { return cc[i].ID < cc[j].ID })
+	return cc, nil
+}
@@ -0,0 +1,135 @@
+package enforcement
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// officers are the roles working the patrol list and the citations
+var officers = []auth.Role{auth.RoleOfficer, auth.RoleOperator, auth.RoleAdmin}
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"ListViolations": {Scope: auth.ScopeAdmin, Roles: officers},
+	"Patrol":         {Scope: auth.ScopeAdmin, Roles: officers},
+	"IssueCitation":  {Scope: auth.ScopeAdmin, Roles: officers},
+	"ListCitations":  {Scope: auth.ScopeAdmin, Roles: officers},
+	"UpdateCitation": {Scope: auth.ScopeAdmin, Roles: officers},
+}
+
+// MakeHTTPHandler mounts the enforcement endpoints into an http.Handler.
+// Officers work the patrol list and the citations, operators and admins
+// oversee them.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("GET").Path("/enforcement/v1/violations").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListViolations")(e.ViolationsEndpoint),
+		decodeViolationsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/enforcement/v1/patrol").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "Patrol")(e.PatrolEndpoint),
+		decodePatrolRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/enforcement/v1/citations").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "IssueCitation")(e.IssueEndpoint),
+		decodeIssueRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/enforcement/v1/citations").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListCitations")(e.CitationsEndpoint),
+		decodeCitationsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("PATCH").Path("/enforcement/v1/citations/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "UpdateCitation")(e.UpdateCitationEndpoint),
+		decodeUpdateCitationRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeViolationsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req violationsRequest
+	return req, nil
+}
+
+func decodePatrolRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	q := r.URL.Query()
+	req := patrolRequest{Lat: q.Get("lat"), Lon: q.Get("lon"), Rad: q.Get("rad")}
+	if req.Lat == "" || req.Lon == "" {
+		return nil, ErrInvalidReq.WithField("lat", "lat and lon are required")
+	}
+	return req, nil
+}
+
+func decodeIssueRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req issueRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	if req.ViolationID == "" {
+		return nil, ErrInvalidReq.WithField("violationId", "is required")
+	}
+	return req, nil
+}
+
+func decodeCitationsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	return citationsRequest{Status: CitationStatus(r.URL.Query().Get("status"))}, nil
+}
+
+func decodeUpdateCitationRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	var req updateCitationRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.ID = id
+	return req, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,217 @@
+package event
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+// Shards is the number of queues of a subscription. Events are spread over
+// them by aggregate, so one busy aggregate doesn't hold up the others while
+// each aggregate's events stay in order.
+const Shards = 4
+
+// Handler consumes the events of a subscription. It runs outside the request
+// that published the event.
+type Handler func(ctx context.Context, e Event)
+
+// Adapter ships events out of the process, e.g. to a file or a broker
+type Adapter interface {
+	Send(ctx context.Context, e Event) error
+}
+
+// Bus is an in-process publish/subscribe bus. Publish never blocks on
+// subscribers, every subscription has its own unbounded queues.
+type Bus struct {
+	mtx    sync.Mutex
+	seq    map[string]uint64 // last Seq per aggregate
+	subs   []*subscription
+	closed bool
+	logger log.Logger
+}
+
+func NewBus(logger log.Logger) *Bus {
+	return &Bus{seq: map[string]uint64{}, logger: logger}
+}
+
+// Publish assigns the event its sequence number within the aggregate and
+// queues it for every subscription that wants its type
+func (b *Bus) Publish(ctx context.Context, e Event) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.closed {
+		return
+	}
+	b.seq[e.Aggregate]++
+	e.Seq = b.seq[e.Aggregate]
+	for _, sub := range b.subs {
+		if sub.wants(e.Type) {
+			sub.shard(e.Aggregate).push(e)
+		}
+	}
+}
+
+// Subscribe calls h with the events of types, or all events when none are
+// given, until the returned function is called. Unsubscribing waits for the
+// queued events to be handled. Events of different aggregates may be handled
+// concurrently, h must be safe for that.
+func (b *Bus) Subscribe(name string, h Handler, types ...string) (unsubscribe func()) {
+	sub := &subscription{name: name, types: types}
+	for i := range sub.shards {
+		q := newQueue()
+		sub.shards[i] = q
+		sub.wg.Add(1)
+		go func() {
+			defer sub.wg.Done()
+			q.run(func(e Event) { b.handle(sub.name, h, e) })
+		}()
+	}
+
+	b.mtx.Lock()
+	b.subs = append(b.subs, sub)
+	b.mtx.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mtx.Lock()
+			for i, s := range b.subs {
+				if s == sub {
+					b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+					break
+				}
+			}
+			b.mtx.Unlock()
+			sub.stop()
+		})
+	}
+}
+
+// Attach subscribes an adapter. Failed sends are logged, the adapter is
+// expected to retry on its own if it needs to.
+func (b *Bus) Attach(name string, a Adapter, types ...string) (detach func()) {
+	return b.Subscribe(name, func(ctx context.Context, e Event) {
+		if err := a.Send(ctx, e); err != nil {
+			b.logger.Log("adapter", name, "event", e.ID, "type", e.Type, "err", err)
+		}
+	}, types...)
+}
+
+// Close stops accepting events and waits for the subscriptions to handle
+// the queued ones
+func (b *Bus) Close() {
+	b.mtx.Lock()
+	b.closed = true
+	subs := b.subs
+	b.subs = nil
+	b.mtx.Unlock()
+	for _, sub := range subs {
+		sub.stop()
+	}
+}
+
+// handle isolates the bus from panicking handlers
+func (b *Bus) handle(name string, h Handler, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Log("subscription", name, "event", e.ID, "type", e.Type, "panic", r)
+		}
+	}()
+	h(context.Background(), e)
+}
+
+type subscription struct {
+	name   string
+	types  []string
+	shards [Shards]*queue
+	wg     sync.WaitGroup
+}
+
+func (s *subscription) wants(t string) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	for _, want := range s.types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *subscription) shard(aggregate string) *queue {
+	h := fnv.New32a()
+	h.Write([]byte(aggregate))
+	return s.shards[h.Sum32()%Shards]
+}
+
+func (s *subscription) stop() {
+	for _, q := range s.shards {
+		q.close()
+	}
+	s.wg.Wait()
+}
+
+// queue is an unbounded FIFO drained by a single goroutine
+type queue struct {
+	mtx    sync.Mutex
+	events []Event
+	closed bool
+	wake   chan struct{}
+}
+
+func newQueue() *queue {
+	return &queue{wake: make(chan struct{}, 1)}
+}
+
+func (q *queue) push(e Event) {
+	q.mtx.Lock()
+	q.events = append(q.events, e)
+	q.mtx.Unlock()
+	q.signal()
+}
+
+func (q *queue) close() {
+	q.mtx.Lock()
+	q.closed = true
+	q.mtx.Unlock()
+	q.signal()
+}
+
+func (q *queue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run handles the events until the queue is closed and empty
+func (q *queue) run(handle func(Event)) {
+	for {
+		q.mtx.Lock()
+		if len(q.events) == 0 {
+			closed := q.closed
+			q.mtx.Unlock()
+			if closed {
+				return
+			}
+			<-q.wake
+			continue
+		}
+		e := q.events[0]
+		q.events[0] = Event{}
+		q.events = q.events[1:]
+		q.mtx.Unlock()
+		handle(e)
+	}
+}
+
+// Counting returns a handler counting the events by type
+func Counting(c metrics.Counter) Handler {
+	return func(_ context.Context, e Event) {
+		c.With("type", e.Type).Add(1)
+	}
+}
@@ -0,0 +1,126 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type testPayload struct {
+	Type string `json:"-"`
+	ID   int    `json:"id"`
+	N    int    `json:"n"`
+}
+
+func (p testPayload) EventType() string   { return p.Type }
+func (p testPayload) AggregateID() string { return fmt.Sprintf("spot/%d", p.ID) }
+
+func TestBusOrdering(t *testing.T) {
+	bus := NewBus(log.NewNopLogger())
+	var mtx sync.Mutex
+	got := map[string][]Event{}
+	bus.Subscribe("test", func(_ context.Context, e Event) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		got[e.Aggregate] = append(got[e.Aggregate], e)
+	})
+	reserved := 0
+	bus.Subscribe("reserved", func(context.Context, Event) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		reserved++
+	}, SpotReserved)
+
+	for n := 0; n < 100; n++ {
+		for id := 1; id <= 8; id++ {
+			bus.Publish(context.Background(), New(testPayload{Type: SpotReserved, ID: id, N: n}, time.Now()))
+		}
+	}
+	bus.Publish(context.Background(), New(testPayload{Type: SpotReleased, ID: 1}, time.Now()))
+	bus.Close()
+	bus.Publish(context.Background(), New(testPayload{Type: SpotReserved, ID: 1}, time.Now()))
+
+	if len(got) != 8 || len(got["spot/1"]) != 101 {
+		t.Fatalf("Expected every event before Close to be handled, got %d aggregates", len(got))
+	}
+	for agg, ee := range got {
+		for i, e := range ee[:100] {
+			if e.Seq != uint64(i+1) || e.Data.(testPayload).N != i {
+				t.Fatalf("Expected %s events in order, got seq %d with n %d at %d", agg, e.Seq, e.Data.(testPayload).N, i)
+			}
+		}
+	}
+	if reserved != 800 {
+		t.Errorf("Expected the filtered subscription to see 800 events, got %d", reserved)
+	}
+}
+
+func TestBusRecoversPanics(t *testing.T) {
+	bus := NewBus(log.NewNopLogger())
+	n := 0
+	unsubscribe := bus.Subscribe("flaky", func(_ context.Context, e Event) {
+		if n++; n == 1 {
+			panic("boom")
+		}
+	})
+	bus.Publish(context.Background(), New(testPayload{Type: SpotCreated, ID: 1}, time.Now()))
+	bus.Publish(context.Background(), New(testPayload{Type: SpotDeleted, ID: 1}, time.Now()))
+	unsubscribe()
+	bus.Publish(context.Background(), New(testPayload{Type: SpotCreated, ID: 1}, time.Now()))
+	unsubscribe()
+	bus.Close()
+	if n != 2 {
+		t.Errorf("Expected the handler to survive its panic and stop after unsubscribing, got %d calls", n)
+	}
+}
+
+func TestFileAdapter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.jsonl")
+
+	a, err := NewFileAdapter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus := NewBus(log.NewNopLogger())
+	bus.Attach("file", a, SpotReserved)
+	bus.Publish(context.Background(), New(testPayload{Type: SpotReserved, ID: 3, N: 1}, time.Now()))
+	bus.Publish(context.Background(), New(testPayload{Type: SpotReleased, ID: 3}, time.Now()))
+	bus.Publish(context.Background(), New(testPayload{Type: SpotReserved, ID: 3, N: 2}, time.Now()))
+	bus.Close()
+	a.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var lines []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("Expected a JSON event per line, got %s", scanner.Text())
+		}
+		lines = append(lines, e)
+	}
+	if len(lines) != 2 || lines[0].Seq != 1 || lines[1].Seq != 3 || lines[1].Aggregate != "spot/3" {
+		t.Errorf("Expected the two reservations of spot 3, got %+v", lines)
+	}
+	if data, _ := lines[1].Data.(map[string]interface{}); data["n"] != 2.0 {
+		t.Errorf("Expected the payload in data, got %v", lines[1].Data)
+	}
+}
@@ -0,0 +1,109 @@
+// Package event carries the domain events of the services, such as a spot
+// being reserved or a booking being cancelled, to the consumers interested
+// in them. The services publish to a Bus, which hands every event to its
+// subscribers, like the webhook dispatcher, and to outbound adapters.
+package event
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Event types, named <aggregate>.<what happened>
+const (
+	SpotCreated      = "spot.created"
+	SpotReserved     = "spot.reserved"
+	SpotReleased     = "spot.released"
+	SpotDeleted      = "spot.deleted"
+	SpotOccupied     = "spot.occupied"
+	SpotVacated      = "spot.vacated"
+	SpotReset        = "spot.reset"
+	BookingCreated   = "booking.created"
+	BookingCancelled = "booking.cancelled"
+	BookingCheckedIn = "booking.checked_in"
+	BookingExpired   = "booking.expired"
+	BookingChanged   = "booking.changed"
+	BookingNoShow    = "booking.no_show"
+	HoldReleased     = "hold.released"
+	HoldExpired      = "hold.expired"
+	AlertRaised      = "alert.raised"
+	AlertResolved    = "alert.resolved"
+	WaitlistOffered  = "waitlist.offered"
+	WaitlistBooked   = "waitlist.booked"
+	// SubscriptionPaymentDue asks the payments provider to collect the fee
+	// of a pass
+	SubscriptionPaymentDue = "subscription.payment_due"
+	SubscriptionActivated  = "subscription.activated"
+	SubscriptionRenewed    = "subscription.renewed"
+	SubscriptionLapsed     = "subscription.lapsed"
+)
+
+// Types lists every event type published
+var Types = []string{
+	SpotCreated, SpotReserved, SpotReleased, SpotDeleted, SpotOccupied, SpotVacated, SpotReset,
+	BookingCreated, BookingCancelled, BookingCheckedIn, BookingExpired, BookingChanged, BookingNoShow,
+	HoldReleased, HoldExpired,
+	AlertRaised, AlertResolved,
+	WaitlistOffered, WaitlistBooked,
+	SubscriptionPaymentDue, SubscriptionActivated, SubscriptionRenewed, SubscriptionLapsed,
+}
+
+// Known reports whether t is one of Types
+func Known(t string) bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is a typed domain event, such as parking.SpotReserved. The
+// package owning the aggregate declares it.
+type Payload interface {
+	EventType() string
+	// AggregateID names the spot or booking changed, e.g. "spot/3".
+	// Events of one aggregate are delivered in order.
+	AggregateID() string
+}
+
+// Event is the envelope every payload travels in
+type Event struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Aggregate string `json:"aggregate"`
+	// Seq numbers the events of the aggregate from 1, it is assigned by
+	// the Bus
+	Seq uint64    `json:"seq,omitempty"`
+	At  time.Time `json:"at"`
+	// Data is the Payload, consumers decoding the JSON see an object
+	Data interface{} `json:"data"`
+}
+
+// New wraps p in an event which happened at at, with a random ID
+func New(p Payload, at time.Time) Event {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return Event{
+		ID:        hex.EncodeToString(b),
+		Type:      p.EventType(),
+		Aggregate: p.AggregateID(),
+		At:        at.UTC(),
+		Data:      p,
+	}
+}
+
+// Publisher hands events to their consumers. Publish must not block on
+// slow consumers, services call it on their request path.
+type Publisher interface {
+	Publish(ctx context.Context, e Event)
+}
+
+// Nop discards every event
+var Nop Publisher = nop{}
+
+type nop struct{}
+
+func (nop) Publish(context.Context, Event) {}
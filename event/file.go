@@ -0,0 +1,36 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileAdapter appends every event it is sent to a file as a line of JSON
+type FileAdapter struct {
+	mtx sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// NewFileAdapter opens path for appending, creating it if needed
+func NewFileAdapter(path string) (*FileAdapter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileAdapter{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+func (a *FileAdapter) Send(ctx context.Context, e Event) error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.enc.Encode(e)
+}
+
+func (a *FileAdapter) Close() error {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.f.Close()
+}
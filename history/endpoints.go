@@ -0,0 +1,101 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/atuldaemon/rct/event"
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	SpotEndpoint    endpoint.Endpoint
+	SpotsEndpoint   endpoint.Endpoint
+	BookingEndpoint endpoint.Endpoint
+	EventsEndpoint  endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		SpotEndpoint:    MakeSpotEndpoint(s),
+		SpotsEndpoint:   MakeSpotsEndpoint(s),
+		BookingEndpoint: MakeBookingEndpoint(s),
+		EventsEndpoint:  MakeEventsEndpoint(s),
+	}
+}
+
+func MakeSpotEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		st, e := s.Spot(ctx, req.ID, req.At)
+		return spotResponse{State: st, Err: e}, e
+	}
+}
+
+func MakeSpotsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(spotsRequest)
+		ss, e := s.Spots(ctx, req.At)
+		return spotsResponse{Spots: ss, Err: e}, e
+	}
+}
+
+func MakeBookingEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		st, e := s.Booking(ctx, req.ID, req.At)
+		return bookingResponse{State: st, Err: e}, e
+	}
+}
+
+func MakeEventsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(eventsRequest)
+		ee, e := s.Events(ctx, req.Aggregate, req.Until)
+		return eventsResponse{Events: ee, Err: e}, e
+	}
+}
+
+//
+
+type idRequest struct {
+	ID string    `json:"id"`
+	At time.Time `json:"at"`
+}
+
+type spotResponse struct {
+	Err   error     `json:"err,omitempty"`
+	State SpotState `json:"state"`
+}
+
+func (r spotResponse) error() error { return r.Err }
+
+type spotsRequest struct {
+	At time.Time `json:"at"`
+}
+
+type spotsResponse struct {
+	Err   error       `json:"err,omitempty"`
+	Spots []SpotState `json:"spots"`
+}
+
+func (r spotsResponse) error() error { return r.Err }
+
+type bookingResponse struct {
+	Err   error        `json:"err,omitempty"`
+	State BookingState `json:"state"`
+}
+
+func (r bookingResponse) error() error { return r.Err }
+
+type eventsRequest struct {
+	Aggregate string    `json:"aggregate"`
+	Until     time.Time `json:"until"`
+}
+
+type eventsResponse struct {
+	Err    error         `json:"err,omitempty"`
+	Events []event.Event `json:"events"`
+}
+
+func (r eventsResponse) error() error { return r.Err }
@@ -0,0 +1,80 @@
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/go-kit/kit/log"
+)
+
+// The event log keeps every domain event in the order it was recorded. Events
+// are only ever appended, the state of a spot or booking at any time is
+// rebuilt from them.
+
+type EventLog interface {
+	Append(e event.Event) error
+	// Events returns the events of aggregate, or of every aggregate when it
+	// is empty, that happened at or before until, oldest first
+	Events(aggregate string, until time.Time) ([]event.Event, error)
+}
+
+type InMemLog struct {
+	mtx         sync.RWMutex
+	events      []event.Event
+	byAggregate map[string][]int // positions in events
+}
+
+func NewInMemEventLog() (EventLog, error) {
+	return &InMemLog{byAggregate: map[string][]int{}}, nil
+}
+
+func (l *InMemLog) Append(e event.Event) error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.byAggregate[e.Aggregate] = append(l.byAggregate[e.Aggregate], len(l.events))
+	l.events = append(l.events, e)
+	return nil
+}
+
+func (l *InMemLog) Events(aggregate string, until time.Time) ([]event.Event, error) {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	ee := make([]event.Event, 0)
+	if aggregate == "" {
+		for _, e := range l.events {
+			if !e.At.After(until) {
+				ee = append(ee, e)
+			}
+		}
+		return ee, nil
+	}
+	for _, i := range l.byAggregate[aggregate] {
+		if e := l.events[i]; !e.At.After(until) {
+			ee = append(ee, e)
+		}
+	}
+	return ee, nil
+}
+
+// Record returns the bus handler appending every event to l
+func Record(l EventLog, logger log.Logger) event.Handler {
+	return func(_ context.Context, e event.Event) {
+		if err := l.Append(e); err != nil {
+			logger.Log("event", e.ID, "type", e.Type, "err", err)
+		}
+	}
+}
+
+// Snapshot records spots as created at at. Stores that start out with spots,
+// like the in-memory one, call it before publishing their first change.
+func Snapshot(l EventLog, spots []parking.Spot, at time.Time) error {
+	for _, sp := range spots {
+		if err := l.Append(event.New(parking.SpotCreated{Spot: sp}, at)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
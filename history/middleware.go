@@ -0,0 +1,53 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/atuldaemon/rct/event"
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Spot(ctx context.Context, id string, at time.Time) (st SpotState, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Spot", "id", id, "at", at, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Spot(ctx, id, at)
+}
+
+func (mw loggingMiddleware) Spots(ctx context.Context, at time.Time) (ss []SpotState, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Spots", "at", at, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Spots(ctx, at)
+}
+
+func (mw loggingMiddleware) Booking(ctx context.Context, id string, at time.Time) (st BookingState, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Booking", "id", id, "at", at, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Booking(ctx, id, at)
+}
+
+func (mw loggingMiddleware) Events(ctx context.Context, aggregate string, until time.Time) (ee []event.Event, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Events", "aggregate", aggregate, "until", until, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Events(ctx, aggregate, until)
+}
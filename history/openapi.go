@@ -0,0 +1,34 @@
+package history
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Operation("GET", "/history/v1/spots", "spotsHistory", "List every spot as it was at a time").
+		Tag("history").Require(requirements["SpotsHistory"]).
+		QueryParam("at", "RFC 3339 time, now when left out").
+		Returns(http.StatusOK, "Spot states", spotsResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/history/v1/spots/{id}", "spotHistory", "Get a spot as it was at a time").
+		Tag("history").Require(requirements["SpotHistory"]).
+		PathParam("id", "Spot id").
+		QueryParam("at", "RFC 3339 time, now when left out").
+		Returns(http.StatusOK, "Spot state", spotResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/history/v1/bookings/{id}", "bookingHistory", "Get a booking as it was at a time, cancelled ones included").
+		Tag("history").Require(requirements["BookingHistory"]).
+		PathParam("id", "Booking id").
+		QueryParam("at", "RFC 3339 time, now when left out").
+		Returns(http.StatusOK, "Booking state", bookingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/history/v1/events", "eventHistory", "List the recorded events, oldest first").
+		Tag("history").Require(requirements["EventHistory"]).
+		QueryParam("aggregate", "Only the events of this aggregate, e.g. spot/3 or booking/1").
+		QueryParam("until", "RFC 3339 time, now when left out").
+		Returns(http.StatusOK, "Events", eventsResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
@@ -0,0 +1,96 @@
+package history
+
+import (
+	"time"
+
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+)
+
+// SpotState is a spot as its events left it
+type SpotState struct {
+	Spot    parking.Spot `json:"spot"`
+	Deleted bool         `json:"deleted,omitempty"`
+	// LastEvent is the type of the latest event applied, Since when it
+	// happened and Version the number of events applied
+	LastEvent string    `json:"lastEvent"`
+	Since     time.Time `json:"since"`
+	Version   int       `json:"version"`
+}
+
+// BookingState is a booking as its events left it. Cancelled bookings keep
+// the state they had when they were cancelled.
+type BookingState struct {
+	Booking   booking.Booking `json:"booking"`
+	Cancelled bool            `json:"cancelled,omitempty"`
+	LastEvent string          `json:"lastEvent"`
+	Since     time.Time       `json:"since"`
+	Version   int             `json:"version"`
+}
+
+// Projection folds events into the current state of the spots and bookings
+type Projection struct {
+	Spots    map[int]SpotState
+	Bookings map[int]BookingState
+}
+
+func NewProjection() *Projection {
+	return &Projection{Spots: map[int]SpotState{}, Bookings: map[int]BookingState{}}
+}
+
+// Replay rebuilds the projection of the events of aggregate, or all of them
+// when it is empty, as of at
+func Replay(l EventLog, aggregate string, at time.Time) (*Projection, error) {
+	ee, err := l.Events(aggregate, at)
+	if err != nil {
+		return nil, err
+	}
+	p := NewProjection()
+	for _, e := range ee {
+		p.Apply(e)
+	}
+	return p, nil
+}
+
+// Apply folds e into the projection. Events of other aggregates are ignored.
+func (p *Projection) Apply(e event.Event) {
+	switch d := e.Data.(type) {
+	case parking.SpotCreated:
+		p.applySpot(e, d.Spot, false)
+	case parking.SpotReserved:
+		p.applySpot(e, d.Spot, false)
+	case parking.SpotReleased:
+		p.applySpot(e, d.Spot, false)
+	case parking.SpotOccupied:
+		p.applySpot(e, d.Spot, false)
+	case parking.SpotVacated:
+		p.applySpot(e, d.Spot, false)
+	case parking.SpotReset:
+		p.applySpot(e, d.Spot, false)
+	case parking.SpotDeleted:
+		p.applySpot(e, d.Spot, true)
+	case booking.BookingCreated:
+		p.applyBooking(e, d.Booking, false)
+	case booking.BookingCheckedIn:
+		p.applyBooking(e, d.Booking, false)
+	case booking.BookingExpired:
+		p.applyBooking(e, d.Booking, false)
+	case booking.BookingChanged:
+		p.applyBooking(e, d.Booking, false)
+	case booking.BookingNoShow:
+		p.applyBooking(e, d.Booking, false)
+	case booking.BookingCancelled:
+		p.applyBooking(e, d.Booking, true)
+	}
+}
+
+func (p *Projection) applySpot(e event.Event, sp parking.Spot, deleted bool) {
+	st := p.Spots[sp.ID]
+	p.Spots[sp.ID] = SpotState{Spot: sp, Deleted: deleted, LastEvent: e.Type, Since: e.At, Version: st.Version + 1}
+}
+
+func (p *Projection) applyBooking(e event.Event, b booking.Booking, cancelled bool) {
+	st := p.Bookings[b.ID]
+	p.Bookings[b.ID] = BookingState{Booking: b, Cancelled: cancelled, LastEvent: e.Type, Since: e.At, Version: st.Version + 1}
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+)
+
+// History service, answers what state a spot or booking was in at a given
+// time from the event log
+
+var (
+	ErrNotFound   = apierror.New(apierror.NotFound, "history_not_found", "no events recorded up to the given time")
+	ErrInvalidReq = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+)
+
+type Service interface {
+	// Spot returns spot id as of at
+	Spot(ctx context.Context, id string, at time.Time) (SpotState, error)
+	// Spots returns every spot known as of at, deleted ones included
+	Spots(ctx context.Context, at time.Time) ([]SpotState, error)
+	// Booking returns booking id as of at
+	Booking(ctx context.Context, id string, at time.Time) (BookingState, error)
+	// Events returns the recorded events of aggregate, e.g. "spot/3", or
+	// of all aggregates when it is empty, up to until
+	Events(ctx context.Context, aggregate string, until time.Time) ([]event.Event, error)
+}
+
+type service struct {
+	log EventLog
+	now func() time.Time
+}
+
+func NewService(l EventLog) Service {
+	return &service{log: l, now: time.Now}
+}
+
+func (s *service) Spot(ctx context.Context, id string, at time.Time) (SpotState, error) {
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		return SpotState{}, ErrInvalidReq
+	}
+	p, err := Replay(s.log, parking.SpotAggregate(intId), s.asOf(at))
+	if err != nil {
+		return SpotState{}, err
+	}
+	st, ok := p.Spots[intId]
+	if !ok {
+		return SpotState{}, ErrNotFound
+	}
+	return st, nil
+}
+
+func (s *service) Spots(ctx context.Context, at time.Time) ([]SpotState, error) {
+	p, err := Replay(s.log, "", s.asOf(at))
+	if err != nil {
+		return nil, err
+	}
+	ss := make([]SpotState, 0, len(p.Spots))
+	for _, st := range p.Spots {
+		ss = append(ss, st)
+	}
+	sort.Slice(ss, func(i, j int) bool { return ss[i].Spot.ID < ss[j].Spot.ID })
+	return ss, nil
+}
+
+func (s *service) Booking(ctx context.Context, id string, at time.Time) (BookingState, error) {
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		return BookingState{}, ErrInvalidReq
+	}
+	p, err := Replay(s.log, booking.BookingAggregate(intId), s.asOf(at))
+	if err != nil {
+		return BookingState{}, err
+	}
+	st, ok := p.Bookings[intId]
+	if !ok {
+		return BookingState{}, ErrNotFound
+	}
+	return st, nil
+}
+
+func (s *service) Events(ctx context.Context, aggregate string, until time.Time) ([]event.Event, error) {
+	return s.log.Events(aggregate, s.asOf(until))
+}
+
+// asOf defaults a zero time to now
+func (s *service) asOf(t time.Time) time.Time {
+	if t.IsZero() {
+		return s.now()
+	}
+	return t
+}
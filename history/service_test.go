@@ -0,0 +1,126 @@
+package history
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+type nopCounter struct{}
+
+func (c nopCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c nopCounter) Add(delta float64)                          {}
+
+func TestPointInTime(t *testing.T) {
+	l, _ := NewInMemEventLog()
+	s := NewService(l)
+	ctx := context.Background()
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	Snapshot(l, []parking.Spot{{ID: 3}, {ID: 4}}, t0)
+	l.Append(event.New(parking.SpotReserved{Spot: parking.Spot{ID: 3, IsReserved: true}}, t0.Add(10*time.Minute)))
+	l.Append(event.New(booking.BookingCreated{Booking: booking.Booking{ID: 1, SpotId: 3}}, t0.Add(10*time.Minute)))
+	l.Append(event.New(parking.SpotReleased{Spot: parking.Spot{ID: 3}}, t0.Add(30*time.Minute)))
+	l.Append(event.New(booking.BookingCancelled{Booking: booking.Booking{ID: 1, SpotId: 3}}, t0.Add(30*time.Minute)))
+	l.Append(event.New(parking.SpotDeleted{Spot: parking.Spot{ID: 4}}, t0.Add(time.Hour)))
+
+	if _, err := s.Spot(ctx, "3", t0.Add(-time.Minute)); err != ErrNotFound {
+		t.Errorf("Expected no state before the spot existed, got %v", err)
+	}
+	st, err := s.Spot(ctx, "3", t0.Add(15*time.Minute))
+	if err != nil || !st.Spot.IsReserved || st.Version != 2 || st.LastEvent != event.SpotReserved {
+		t.Errorf("Expected spot 3 reserved at 10:15, got %+v %v", st, err)
+	}
+	if st, _ := s.Spot(ctx, "3", time.Time{}); st.Spot.IsReserved {
+		t.Error("Expected spot 3 free now")
+	}
+
+	b, err := s.Booking(ctx, "1", t0.Add(15*time.Minute))
+	if err != nil || b.Cancelled || b.Booking.SpotId != 3 {
+		t.Errorf("Expected booking 1 live at 10:15, got %+v %v", b, err)
+	}
+	if b, _ := s.Booking(ctx, "1", t0.Add(time.Hour)); !b.Cancelled {
+		t.Error("Expected the cancelled booking to stay queryable")
+	}
+	if _, err := s.Booking(ctx, "x", t0); err != ErrInvalidReq {
+		t.Error("Expected an invalid id to fail")
+	}
+
+	ss, _ := s.Spots(ctx, t0.Add(2*time.Hour))
+	if len(ss) != 2 || ss[0].Spot.ID != 3 || !ss[1].Deleted {
+		t.Errorf("Expected spot 3 and the deleted spot 4, got %+v", ss)
+	}
+	ee, _ := s.Events(ctx, parking.SpotAggregate(3), t0.Add(20*time.Minute))
+	if len(ee) != 2 {
+		t.Errorf("Expected the first two events of spot 3, got %d", len(ee))
+	}
+}
+
+func TestRecordFromBus(t *testing.T) {
+	l, _ := NewInMemEventLog()
+	bus := event.NewBus(log.NewNopLogger())
+	bus.Subscribe("history", Record(l, log.NewNopLogger()))
+	store, _ := parking.NewInMemParkingStore()
+	stop := parking.PublishChanges(store, bus, log.NewNopLogger(), nopCounter{})
+
+	store.Update(parking.Spot{ID: 2, IsReserved: true})
+	store.Update(parking.Spot{ID: 2})
+	// the relay publishes asynchronously, wait for it before closing the bus
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if ee, _ := l.Events(parking.SpotAggregate(2), time.Now()); len(ee) == 2 {
+			break
+		}
+	}
+	stop()
+	bus.Close()
+
+	ee, _ := l.Events(parking.SpotAggregate(2), time.Now())
+	if len(ee) != 2 || ee[0].Type != event.SpotReserved || ee[1].Seq != 2 {
+		t.Fatalf("Expected the reservation and release of spot 2 in the log, got %+v", ee)
+	}
+	p, _ := Replay(l, "", time.Now())
+	if st := p.Spots[2]; st.Spot.IsReserved || st.Version != 2 {
+		t.Errorf("Expected the projection to end with spot 2 free, got %+v", st)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	l, _ := NewInMemEventLog()
+	Snapshot(l, []parking.Spot{{ID: 3}}, time.Now().Add(-time.Hour))
+	tokens := auth.NewHMACTokens([]byte("secret"))
+	a := auth.NewAuthorizer(auth.NewAuthenticator(tokens, nil), log.NewNopLogger(), nopCounter{})
+	h := MakeHTTPHandler(NewService(l), a, log.NewNopLogger())
+	operator, _ := tokens.Issue(auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}}, time.Minute)
+	driver, _ := tokens.Issue(auth.Principal{Subject: "driver", Roles: []auth.Role{auth.RoleDriver}}, time.Minute)
+
+	for _, tc := range []struct {
+		path, token string
+		code        int
+	}{
+		{"/history/v1/spots/3", operator, http.StatusOK},
+		{"/history/v1/spots/3?at=" + time.Now().Add(-2*time.Hour).Format(time.RFC3339), operator, http.StatusNotFound},
+		{"/history/v1/spots/3?at=yesterday", operator, http.StatusBadRequest},
+		{"/history/v1/events?aggregate=spot/3", operator, http.StatusOK},
+		{"/history/v1/spots", driver, http.StatusForbidden},
+	} {
+		req := httptest.NewRequest("GET", tc.path, nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tc.code {
+			t.Errorf("GET %s: expected %d, got %d %s", tc.path, tc.code, rec.Code, rec.Body)
+		}
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
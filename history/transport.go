@@ -0,0 +1,124 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"SpotsHistory":   {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"SpotHistory":    {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"BookingHistory": {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"EventHistory":   {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+}
+
+// MakeHTTPHandler mounts the history endpoints into an http.Handler. They are
+// restricted to operators and admins, bookings carry the vehicles of drivers.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("GET").Path("/history/v1/spots").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "SpotsHistory")(e.SpotsEndpoint),
+		decodeSpotsRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/history/v1/spots/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "SpotHistory")(e.SpotEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/history/v1/bookings/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "BookingHistory")(e.BookingEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/history/v1/events").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "EventHistory")(e.EventsEndpoint),
+		decodeEventsRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeSpotsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	at, err := queryTime(r, "at")
+	if err != nil {
+		return nil, err
+	}
+	return spotsRequest{At: at}, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	at, err := queryTime(r, "at")
+	if err != nil {
+		return nil, err
+	}
+	return idRequest{ID: id, At: at}, nil
+}
+
+func decodeEventsRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	until, err := queryTime(r, "until")
+	if err != nil {
+		return nil, err
+	}
+	return eventsRequest{Aggregate: r.URL.Query().Get("aggregate"), Until: until}, nil
+}
+
+// queryTime reads an optional RFC 3339 time from the query parameter name
+func queryTime(r *http.Request, name string) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, ErrInvalidReq.WithField(name, "must be an RFC 3339 time")
+	}
+	return t, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
@@ -2,118 +2,61 @@ package main
 
 import (
 	"flag"
-	"fmt"
 	"net/http"
-	"os"
 
-	"os/signal"
-	"syscall"
-
-	"github.com/atuldaemon/rct/booking"
-	"github.com/atuldaemon/rct/parking"
-	"github.com/go-kit/kit/log"
-	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
-	stdprometheus "github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/server"
+	"google.golang.org/grpc"
 )
 
 func main() {
 	var (
 		httpAddr = flag.String("http.addr", ":8080", "HTTP listen address")
+		grpcAddr = flag.String("grpc.addr", ":8081", "gRPC listen address")
+		o        server.Options
+		so       server.SensorOptions
+		bo       server.BookingOptions
 	)
+	o.Flags(flag.CommandLine)
+	so.Flags(flag.CommandLine)
+	bo.Flags(flag.CommandLine)
 	flag.Parse()
 
-	var logger log.Logger
-	{
-		logger = log.NewLogfmtLogger(os.Stderr)
-		logger = log.With(logger, "ts", log.DefaultTimestampUTC)
-		logger = log.With(logger, "caller", log.DefaultCaller)
-	}
-
-	fieldKeys := []string{"method"}
+	logger := server.NewLogger()
 
-	parkingStore, err := parking.NewInMemParkingStore()
+	events, err := server.NewEvents(logger, o.EventsFile)
 	if err != nil {
 		panic(err)
 	}
-	var p parking.Service
-	{
-		p = parking.NewService(parkingStore)
-		p = parking.LoggingMiddleware(logger)(p)
-		p = parking.NewInstrumentingService(
-			kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
-				Namespace: "api",
-				Subsystem: "parking_service",
-				Name:      "request_count",
-				Help:      "Number of requests received.",
-			}, fieldKeys),
-			kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
-				Namespace: "api",
-				Subsystem: "parking_service",
-				Name:      "request_latency_microseconds",
-				Help:      "Total duration of requests in microseconds.",
-			}, fieldKeys),
-			p)
+	p, err := server.NewParking(logger, events)
+	if err != nil {
+		panic(err)
 	}
-
-	bookingStore, err := booking.NewInMemBookingStore()
+	bk, err := server.NewBooking(logger, p.Spots, events, bo)
 	if err != nil {
 		panic(err)
 	}
-	var b booking.Service
-	{
-		b = booking.NewService(bookingStore, p)
-		b = booking.LoggingMiddleware(logger)(b)
-		b = booking.NewInstrumentingService(
-			kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
-				Namespace: "api",
-				Subsystem: "booking_service",
-				Name:      "request_count",
-				Help:      "Number of requests received.",
-			}, fieldKeys),
-			kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
-				Namespace: "api",
-				Subsystem: "booking_service",
-				Name:      "request_latency_microseconds",
-				Help:      "Total duration of requests in microseconds.",
-			}, fieldKeys),
-			b)
+	sn, err := server.NewSensors(logger, p.Spots, bk.Bookings, events, so)
+	if err != nil {
+		panic(err)
+	}
+	keys, err := server.NewAPIKeys(logger)
+	if err != nil {
+		panic(err)
 	}
+	a := server.NewAuthorizer(logger, o, keys)
 
+	doc := openapi.New("rct", "v1")
 	mux := http.NewServeMux()
-
-	mux.Handle("/parking/v1/", parking.MakeHTTPHandler(p, log.With(logger, "component", "HTTP")))
-	mux.Handle("/booking/v1/", booking.MakeHTTPHandler(b, log.With(logger, "component", "HTTP")))
-
-	http.Handle("/", accessControl(mux))
-	http.Handle("/metrics", promhttp.Handler())
-
-	errs := make(chan error, 2)
-
-	go func() {
-		logger.Log("transport", "http", "address", *httpAddr, "msg", "listening")
-		errs <- http.ListenAndServe(*httpAddr, nil)
-	}()
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, syscall.SIGINT)
-		errs <- fmt.Errorf("%s", <-c)
-	}()
-
-	logger.Log("terminated", <-errs)
-
-}
-
-func accessControl(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type")
-
-		if r.Method == "OPTIONS" {
-			return
-		}
-
-		h.ServeHTTP(w, r)
-	})
+	grpcServer := grpc.NewServer()
+	p.Handle(mux, grpcServer, doc, a, logger)
+	bk.Handle(mux, grpcServer, doc, a, logger)
+	events.Handle(mux, doc, a, logger)
+	keys.Handle(mux, doc, a, logger)
+	sn.Handle(mux, doc, a, logger)
+	mux.Handle("/openapi.json", openapi.Handler(doc))
+
+	logger.Log("terminated", server.Serve(logger, *httpAddr, *grpcAddr, mux, grpcServer))
+	p.Close()
+	events.Close()
 }
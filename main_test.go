@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/atuldaemon/rct/apikey"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/enforcement"
+	"github.com/atuldaemon/rct/history"
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/recurring"
+	"github.com/atuldaemon/rct/sensor"
+	"github.com/atuldaemon/rct/subscriptions"
+	"github.com/atuldaemon/rct/vehicle"
+	"github.com/atuldaemon/rct/waitlist"
+	"github.com/atuldaemon/rct/webhook"
+	"github.com/go-kit/kit/log"
+)
+
+func TestOpenAPI(t *testing.T) {
+	logger := log.NewNopLogger()
+	a := auth.NewAuthorizer(auth.NewAuthenticator(nil, nil), logger, nil)
+	for _, tt := range []struct {
+		name    string
+		add     func(*openapi.Document)
+		handler http.Handler
+	}{
+		{"parking", parking.AddOpenAPI, parking.MakeHTTPHandler(nil, a, logger)},
+		{"booking", booking.AddOpenAPI, booking.MakeHTTPHandler(nil, a, logger)},
+		{"vehicle", vehicle.AddOpenAPI, vehicle.MakeHTTPHandler(nil, a, logger)},
+		{"apikey", apikey.AddOpenAPI, apikey.MakeHTTPHandler(nil, a, logger)},
+		{"webhook", webhook.AddOpenAPI, webhook.MakeHTTPHandler(nil, a, logger)},
+		{"history", history.AddOpenAPI, history.MakeHTTPHandler(nil, a, logger)},
+		{"sensor", sensor.AddOpenAPI, sensor.MakeHTTPHandler(nil, a, logger)},
+		{"enforcement", enforcement.AddOpenAPI, enforcement.MakeHTTPHandler(nil, a, logger)},
+		{"waitlist", waitlist.AddOpenAPI, waitlist.MakeHTTPHandler(nil, a, logger)},
+		{"recurring", recurring.AddOpenAPI, recurring.MakeHTTPHandler(nil, a, logger)},
+		{"subscriptions", subscriptions.AddOpenAPI, subscriptions.MakeHTTPHandler(nil, a, logger)},
+	} {
+		d := openapi.New("rct", "test")
+		tt.add(d)
+		for _, problem := range openapi.Verify(d, tt.handler) {
+			t.Errorf("%s: %s", tt.name, problem)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Package openapi builds the OpenAPI 3 description of the HTTP API. Each
+// service adds its routes to a shared Document, schemas are derived from the
+// Go types that travel over the wire, and Verify checks a handler's routes
+// against the description so the two can't drift apart.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+)
+
+const Version = "3.0.0"
+
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+
+	enums map[reflect.Type][]interface{}
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem holds the operations of a path keyed by lower case HTTP method
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+	// Scope and Roles mirror the auth.Requirement the route enforces
+	Scope string   `json:"x-scope,omitempty"`
+	Roles []string `json:"x-roles,omitempty"`
+
+	doc *Document
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+type SecurityRequirement map[string][]string
+
+// New returns an empty document. Every operation accepts either a bearer
+// token or an API key, matching auth.HTTPToContext.
+func New(title, version string) *Document {
+	d := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Security: []SecurityRequirement{
+			{"bearerAuth": {}},
+			{"apiKeyAuth": {}},
+		},
+		Paths: map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+				"apiKeyAuth": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			},
+		},
+		enums: map[reflect.Type][]interface{}{},
+	}
+	d.Schema(apierror.Problem{})
+	return d
+}
+
+// Enum records the allowed values of a named type, v being any value of it
+func (d *Document) Enum(v interface{}, values ...interface{}) {
+	d.enums[reflect.TypeOf(v)] = values
+}
+
+// Operation adds the operation for method and path, using the mux path
+// template syntax, and returns it for further description
+func (d *Document) Operation(method, path, id, summary string) *Operation {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = PathItem{}
+		d.Paths[path] = item
+	}
+	op := &Operation{
+		OperationID: id,
+		Summary:     summary,
+		Responses:   map[string]Response{},
+		doc:         d,
+	}
+	item[strings.ToLower(method)] = op
+	return op
+}
+
+// Tag groups the operation
+func (op *Operation) Tag(tags ...string) *Operation {
+	op.Tags = append(op.Tags, tags...)
+	return op
+}
+
+// Require documents the scope and roles allowed to call the operation
+func (op *Operation) Require(req auth.Requirement) *Operation {
+	op.Scope = string(req.Scope)
+	op.Roles = op.Roles[:0]
+	for _, r := range req.Roles {
+		op.Roles = append(op.Roles, string(r))
+	}
+	return op
+}
+
+// PathParam documents a {name} segment of the path
+func (op *Operation) PathParam(name, description string) *Operation {
+	op.Parameters = append(op.Parameters, Parameter{
+		Name:        name,
+		In:          "path",
+		Description: description,
+		Required:    true,
+		Schema:      &Schema{Type: "string"},
+	})
+	return op
+}
+
+// QueryParam documents an optional query parameter
+func (op *Operation) QueryParam(name, description string) *Operation {
+	return op.param("query", name, description)
+}
+
+// HeaderParam documents an optional request header
+func (op *Operation) HeaderParam(name, description string) *Operation {
+	return op.param("header", name, description)
+}
+
+func (op *Operation) param(in, name, description string) *Operation {
+	op.Parameters = append(op.Parameters, Parameter{
+		Name:        name,
+		In:          in,
+		Description: description,
+		Schema:      &Schema{Type: "string"},
+	})
+	return op
+}
+
+// Body documents a JSON request body shaped like v
+func (op *Operation) Body(v interface{}) *Operation {
+	op.RequestBody = &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{"application/json": {Schema: op.doc.Schema(v)}},
+	}
+	return op
+}
+
+// Returns documents a successful JSON response shaped like v
+func (op *Operation) Returns(code int, description string, v interface{}) *Operation {
+	return op.ReturnsAs(code, description, "application/json", v)
+}
+
+// ReturnsAs documents a successful response of another media type, whose
+// items are shaped like v. A nil v is a binary body. Calling it again for
+// the same code adds a media type the client may ask for.
+func (op *Operation) ReturnsAs(code int, description, mediaType string, v interface{}) *Operation {
+	schema := &Schema{Type: "string", Format: "binary"}
+	if v != nil {
+		schema = op.doc.Schema(v)
+	}
+	r, ok := op.Responses[strconv.Itoa(code)]
+	if !ok {
+		r = Response{Description: description, Content: map[string]MediaType{}}
+	}
+	r.Content[mediaType] = MediaType{Schema: schema}
+	op.Responses[strconv.Itoa(code)] = r
+	return op
+}
+
+// Fails documents the error statuses the operation may answer with, each
+// as a problem+json body
+func (op *Operation) Fails(codes ...int) *Operation {
+	for _, code := range codes {
+		op.Responses[strconv.Itoa(code)] = Response{
+			Description: http.StatusText(code),
+			Content:     map[string]MediaType{apierror.ProblemContentType: {Schema: op.doc.Schema(apierror.Problem{})}},
+		}
+	}
+	return op
+}
+
+// Handler serves the document as JSON
+func Handler(d *Document) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(d)
+	})
+}
+
+// Verify compares the routes of h, which must be a *mux.Router, with the
+// operations of d and describes every route without an operation, every
+// operation without a route and every operation no role may call
+func Verify(d *Document, h http.Handler) []string {
+	r, ok := h.(*mux.Router)
+	if !ok {
+		return []string{fmt.Sprintf("%T is not a *mux.Router", h)}
+	}
+	var problems []string
+	routed := map[string]bool{}
+	r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{"GET"}
+		}
+		for _, m := range methods {
+			routed[m+" "+path] = true
+			if _, ok := d.Paths[path][strings.ToLower(m)]; !ok {
+				problems = append(problems, m+" "+path+" is not documented")
+			}
+		}
+		return nil
+	})
+	for path, item := range d.Paths {
+		for m, op := range item {
+			key := strings.ToUpper(m) + " " + path
+			if !routed[key] {
+				problems = append(problems, key+" is documented but not routed")
+			}
+			if len(op.Roles) == 0 {
+				problems = append(problems, key+" documents no roles")
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
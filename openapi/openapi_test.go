@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/gorilla/mux"
+)
+
+type Kind string
+
+type Item struct {
+	ID      int           `json:"id"`
+	Kind    Kind          `json:"kind"`
+	Tags    []string      `json:"tags,omitempty"`
+	At      time.Time     `json:"at"`
+	For     time.Duration `json:"for"`
+	Secret  string        `json:"-"`
+	Parent  *Item         `json:"parent,omitempty"`
+	private int
+}
+
+type itemResponse struct {
+	Err  error `json:"err,omitempty"`
+	Item Item  `json:"item"`
+}
+
+func TestSchema(t *testing.T) {
+	d := New("test", "1")
+	d.Enum(Kind(""), "a", "b")
+
+	s := d.Schema(itemResponse{})
+	if s.Ref != "" || s.Type != "object" {
+		t.Fatal("Expected unexported types to be inlined")
+	}
+	if _, ok := s.Properties["err"]; ok {
+		t.Error("Expected error fields to be left out")
+	}
+	if s.Properties["item"].Ref != "#/components/schemas/Item" {
+		t.Error("Expected exported structs to be referenced")
+	}
+
+	item := d.Components.Schemas["Item"]
+	if item == nil {
+		t.Fatal("Item not registered")
+	}
+	if _, ok := item.Properties["Secret"]; ok {
+		t.Error("Expected json:\"-\" fields to be left out")
+	}
+	if len(item.Properties) != 6 {
+		t.Errorf("Expected 6 properties, got %d", len(item.Properties))
+	}
+	if item.Properties["at"].Format != "date-time" || item.Properties["for"].Type != "integer" {
+		t.Error("Wrong time schemas")
+	}
+	if item.Properties["parent"].Ref != "#/components/schemas/Item" {
+		t.Error("Expected the recursive field to reference Item")
+	}
+	if kind := d.Components.Schemas["Kind"]; kind == nil || len(kind.Enum) != 2 {
+		t.Error("Expected Kind to be an enum component")
+	}
+	required := map[string]bool{}
+	for _, r := range item.Required {
+		required[r] = true
+	}
+	if !required["id"] || required["tags"] || required["parent"] {
+		t.Error("Expected omitempty fields to be optional and the rest required")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	r := mux.NewRouter()
+	r.Methods("GET").Path("/items/{id}").Handler(http.NotFoundHandler())
+	r.Methods("PUT").Path("/items/").Handler(http.NotFoundHandler())
+
+	read := auth.Requirement{Scope: auth.ScopeParkingRead, Roles: auth.AllRoles}
+	d := New("test", "1")
+	d.Operation("GET", "/items/{id}", "getItem", "Get an item").Require(read).PathParam("id", "Item id").Returns(http.StatusOK, "The item", Item{})
+	d.Operation("POST", "/items/", "addItem", "Add an item").Require(read).Body(Item{})
+
+	problems := Verify(d, r)
+	if len(problems) != 2 ||
+		problems[0] != "POST /items/ is documented but not routed" ||
+		problems[1] != "PUT /items/ is not documented" {
+		t.Errorf("Unexpected problems %q", problems)
+	}
+
+	d.Operation("PUT", "/items/", "updateItem", "Update an item").Require(auth.Requirement{}).Body(Item{})
+	delete(d.Paths["/items/"], "post")
+	if problems := Verify(d, r); len(problems) != 1 || problems[0] != "PUT /items/ documents no roles" {
+		t.Errorf("Unexpected problems %q", problems)
+	}
+
+	d.Paths["/items/"]["put"].Require(auth.Requirement{Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleOperator}})
+	if problems := Verify(d, r); len(problems) != 0 {
+		t.Errorf("Unexpected problems %q", problems)
+	}
+
+	srv := httptest.NewServer(Handler(d))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal("Failed to fetch the document")
+	}
+	defer resp.Body.Close()
+	var doc map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil || doc["openapi"] != Version {
+		t.Error("Document not served as OpenAPI JSON")
+	}
+}
@@ -0,0 +1,242 @@
+package parking
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/event"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+// The change feed records every spot mutation of a ParkingStore, in order,
+// and fans it out to subscribers such as the events stream
+
+type ChangeType string
+
+const (
+	ChangeCreated  ChangeType = "created"
+	ChangeReserved ChangeType = "reserved"
+	ChangeReleased ChangeType = "released"
+	ChangeDeleted  ChangeType = "deleted"
+	ChangeOccupied ChangeType = "occupied"
+	ChangeVacated  ChangeType = "vacated"
+)
+
+const (
+	// DefaultFeedHistory is the number of changes kept for resuming clients
+	DefaultFeedHistory = 1024
+	// subscriberBuffer is how far a subscriber may fall behind before it is
+	// dropped and has to resume
+	subscriberBuffer = 64
+)
+
+// Change is a single spot mutation. IDs increase by one with every change.
+type Change struct {
+	ID   uint64     `json:"id"`
+	Type ChangeType `json:"type"`
+	Spot Spot       `json:"spot"`
+	At   time.Time  `json:"at"`
+}
+
+// BBox is a bounding box in degrees
+type BBox struct {
+	MinLat, MinLon, MaxLat, MaxLon float64
+}
+
+func (b BBox) Contains(sp Spot) bool {
+	lat, err := strconv.ParseFloat(sp.Lat, 64)
+	if err != nil {
+		return false
+	}
+	lon, err := strconv.ParseFloat(sp.Lon, 64)
+	if err != nil {
+		return false
+	}
+	return lat >= b.MinLat && lat <= b.MaxLat && lon >= b.MinLon && lon <= b.MaxLon
+}
+
+// ChangeFilter selects changes by spot. An empty filter matches everything,
+// otherwise a spot must match both the IDs and the box that are set.
+type ChangeFilter struct {
+	IDs  []int
+	BBox *BBox
+}
+
+func (f ChangeFilter) Match(sp Spot) bool {
+	if len(f.IDs) > 0 {
+		found := false
+		for _, id := range f.IDs {
+			if id == sp.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return f.BBox == nil || f.BBox.Contains(sp)
+}
+
+type ChangeFeed struct {
+	mtx     sync.Mutex
+	next    uint64
+	history []Change // oldest first, at most limit long
+	limit   int
+	subs    map[*Subscription]struct{}
+	now     func() time.Time
+}
+
+func NewChangeFeed(history int) *ChangeFeed {
+	return &ChangeFeed{next: 1, limit: history, subs: map[*Subscription]struct{}{}, now: time.Now}
+}
+
+// Publish records a change of sp. Stores call it while still holding their
+// lock so the feed order is the mutation order. Subscribers that can't keep
+// up are dropped rather than blocking the store.
+func (f *ChangeFeed) Publish(t ChangeType, sp Spot) Change {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	c := Change{ID: f.next, Type: t, Spot: sp, At: f.now().UTC()}
+	f.next++
+	f.history = append(f.history, c)
+	if len(f.history) > f.limit {
+		f.history = f.history[len(f.history)-f.limit:]
+	}
+	for sub := range f.subs {
+		if !sub.filter.Match(sp) {
+			continue
+		}
+		select {
+		case sub.c <- c:
+		default:
+			f.drop(sub)
+		}
+	}
+	return c
+}
+
+// Subscription delivers the changes matching its filter on C. C is closed
+// when the subscription is closed or falls behind.
+type Subscription struct {
+	C <-chan Change
+	// Backlog holds the retained changes after the resume point
+	Backlog []Change
+	// Truncated is set when changes after the resume point were already
+	// discarded, or the resume point is unknown. The client should reload
+	// the spots before applying further changes.
+	Truncated bool
+
+	c      chan Change
+	feed   *ChangeFeed
+	filter ChangeFilter
+}
+
+// Subscribe starts delivering the changes matching filter. A non-zero after
+// resumes after the change with that ID.
+func (f *ChangeFeed) Subscribe(after uint64, filter ChangeFilter) *Subscription {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	c := make(chan Change, subscriberBuffer)
+	sub := &Subscription{C: c, c: c, feed: f, filter: filter}
+	if after > 0 {
+		if after >= f.next || len(f.history) == 0 || after+1 < f.history[0].ID {
+			sub.Truncated = true
+		}
+		for _, ch := range f.history {
+			if (sub.Truncated || ch.ID > after) && filter.Match(ch.Spot) {
+				sub.Backlog = append(sub.Backlog, ch)
+			}
+		}
+	}
+	f.subs[sub] = struct{}{}
+	return sub
+}
+
+// Close stops the subscription, it is safe to call more than once
+func (s *Subscription) Close() {
+	s.feed.mtx.Lock()
+	defer s.feed.mtx.Unlock()
+	s.feed.drop(s)
+}
+
+func (f *ChangeFeed) drop(sub *Subscription) {
+	if _, ok := f.subs[sub]; ok {
+		delete(f.subs, sub)
+		close(sub.c)
+	}
+}
+
+// PublishChanges relays every change of the store to pub as a typed spot
+// event until stop is called. When the relay falls behind it resumes after
+// the last change it published. Changes discarded meanwhile are lost, the
+// gap is logged and counted and every spot is published as a SpotReset
+// instead.
+func PublishChanges(store ParkingStore, pub event.Publisher, logger log.Logger, gaps metrics.Counter) (stop func()) {
+	feed := store.Changes()
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	// subscribe right away so no change made after the call is missed
+	sub := feed.Subscribe(0, ChangeFilter{})
+	go func() {
+		defer close(done)
+		var last uint64
+		publish := func(c Change) {
+			pub.Publish(context.Background(), event.New(c.Payload(), c.At))
+			last = c.ID
+		}
+		for {
+			if sub.Truncated {
+				last = resetSpots(store, sub, last, pub, logger, gaps)
+			}
+			for _, c := range sub.Backlog {
+				if c.ID > last {
+					publish(c)
+				}
+			}
+		relay:
+			for {
+				select {
+				case c, ok := <-sub.C:
+					if !ok {
+						break relay
+					}
+					publish(c)
+				case <-quit:
+					sub.Close()
+					return
+				}
+			}
+			sub = feed.Subscribe(last, ChangeFilter{})
+		}
+	}()
+	return func() {
+		close(quit)
+		<-done
+	}
+}
+
+// resetSpots publishes every spot of the store as it is now, once the
+// changes after last were discarded before sub resumed. The retained
+// changes are older than the spots and are skipped, the ID of the newest is
+// returned.
+func resetSpots(store ParkingStore, sub *Subscription, last uint64, pub event.Publisher, logger log.Logger, gaps metrics.Counter) uint64 {
+	missed, newest := "unknown", last
+	if len(sub.Backlog) > 0 {
+		missed = strconv.FormatUint(sub.Backlog[0].ID-last-1, 10)
+		newest = sub.Backlog[len(sub.Backlog)-1].ID
+	}
+	gaps.Add(1)
+	ss, err := store.Get(all)
+	logger.Log("after", last, "missed", missed, "spots", len(ss), "err", err)
+	now := time.Now().UTC()
+	for _, sp := range ss {
+		pub.Publish(context.Background(), event.New(SpotReset{sp}, now))
+	}
+	return newest
+}
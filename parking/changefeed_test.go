@@ -0,0 +1,203 @@
+package parking
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/event"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+func TestStoreChanges(t *testing.T) {
+	store, _ := NewInMemParkingStore()
+	sub := store.Changes().Subscribe(0, ChangeFilter{})
+	defer sub.Close()
+
+	sp, err := store.Create(Spot{Lat: "44.9", Lon: "-93.4", Cost: "20"})
+	if err != nil || sp.ID != 6 {
+		t.Fatalf("Expected the next free ID 6, got %d %v", sp.ID, err)
+	}
+	store.Update(Spot{ID: 6, IsReserved: true})
+	store.Update(Spot{ID: 6, IsReserved: true})
+	store.Update(Spot{ID: 6})
+	if err := store.Delete(6); err != nil {
+		t.Fatal("Failed to delete the spot")
+	}
+	if store.Delete(6) != ErrNotFound {
+		t.Error("Expected a second delete to fail")
+	}
+
+	want := []ChangeType{ChangeCreated, ChangeReserved, ChangeReleased, ChangeDeleted}
+	for i, typ := range want {
+		c := <-sub.C
+		if c.Type != typ || c.ID != uint64(i+1) || c.Spot.ID != 6 {
+			t.Errorf("Expected change %d to be %s of spot 6, got %+v", i+1, typ, c)
+		}
+	}
+	select {
+	case c := <-sub.C:
+		t.Errorf("Unexpected change %+v, updates that change nothing are not published", c)
+	default:
+	}
+}
+
+func TestChangeFeedResume(t *testing.T) {
+	feed := NewChangeFeed(3)
+	for id := 1; id <= 5; id++ {
+		feed.Publish(ChangeReserved, Spot{ID: id})
+	}
+
+	sub := feed.Subscribe(3, ChangeFilter{})
+	if sub.Truncated || len(sub.Backlog) != 2 || sub.Backlog[0].ID != 4 {
+		t.Errorf("Expected changes 4 and 5, got %+v", sub.Backlog)
+	}
+	sub.Close()
+	sub.Close()
+
+	sub = feed.Subscribe(1, ChangeFilter{})
+	if !sub.Truncated || len(sub.Backlog) != 3 {
+		t.Error("Expected a resume before the retained history to be truncated")
+	}
+	sub = feed.Subscribe(9, ChangeFilter{})
+	if !sub.Truncated {
+		t.Error("Expected an unknown event id to be truncated")
+	}
+	sub = feed.Subscribe(5, ChangeFilter{})
+	if sub.Truncated || len(sub.Backlog) != 0 {
+		t.Error("Expected nothing to replay after the latest change")
+	}
+}
+
+func TestChangeFeedFilter(t *testing.T) {
+	feed := NewChangeFeed(DefaultFeedHistory)
+	box := &BBox{MinLat: 44, MinLon: -95, MaxLat: 45, MaxLon: -93}
+	byID := feed.Subscribe(0, ChangeFilter{IDs: []int{2}})
+	inBox := feed.Subscribe(0, ChangeFilter{BBox: box})
+
+	feed.Publish(ChangeReserved, Spot{ID: 1, Lat: "44.968046", Lon: "-94.420307"})
+	feed.Publish(ChangeReserved, Spot{ID: 2, Lat: "44.33328", Lon: "-89.132008"})
+
+	if c := <-byID.C; c.Spot.ID != 2 {
+		t.Errorf("Expected spot 2 by id, got %d", c.Spot.ID)
+	}
+	if c := <-inBox.C; c.Spot.ID != 1 {
+		t.Errorf("Expected spot 1 in the box, got %d", c.Spot.ID)
+	}
+	if len(byID.C) != 0 || len(inBox.C) != 0 {
+		t.Error("Filters let through other spots")
+	}
+
+	back := feed.Subscribe(1, ChangeFilter{BBox: box})
+	if len(back.Backlog) != 0 {
+		t.Error("Expected the backlog to be filtered too")
+	}
+}
+
+func TestChangeFeedDropsSlowSubscribers(t *testing.T) {
+	feed := NewChangeFeed(DefaultFeedHistory)
+	sub := feed.Subscribe(0, ChangeFilter{})
+	for i := 0; i <= subscriberBuffer; i++ {
+		feed.Publish(ChangeReserved, Spot{ID: 1})
+	}
+	n := 0
+	for range sub.C {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("Expected %d buffered changes before the drop, got %d", subscriberBuffer, n)
+	}
+}
+
+// stalled holds up the first event published to it until released
+type stalled struct {
+	release chan struct{}
+	once    sync.Once
+	mtx     sync.Mutex
+	events  []event.Event
+}
+
+func (p *stalled) Publish(ctx context.Context, e event.Event) {
+	p.once.Do(func() { <-p.release })
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.events = append(p.events, e)
+}
+
+type gapCounter struct {
+	mtx sync.Mutex
+	n   float64
+}
+
+func (c *gapCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c *gapCounter) Add(delta float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.n += delta
+}
+
+func TestPublishChangesGap(t *testing.T) {
+	store, _ := NewInMemParkingStore()
+	pub := &stalled{release: make(chan struct{})}
+	gaps := &gapCounter{}
+	stop := PublishChanges(store, pub, log.NewNopLogger(), gaps)
+
+	// the relay falls behind further than the feed remembers
+	for i := 0; i < DefaultFeedHistory+2*subscriberBuffer; i++ {
+		store.Update(Spot{ID: 1, IsReserved: i%2 == 0})
+	}
+	close(pub.release)
+	store.Update(Spot{ID: 2, IsReserved: true})
+	resets := func() map[int]Spot {
+		pub.mtx.Lock()
+		defer pub.mtx.Unlock()
+		ss := map[int]Spot{}
+		for _, e := range pub.events {
+			if r, ok := e.Data.(SpotReset); ok {
+				ss[r.Spot.ID] = r.Spot
+			}
+		}
+		return ss
+	}
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline) && len(resets()) < 5; time.Sleep(10 * time.Millisecond) {
+	}
+	stop()
+
+	ss := resets()
+	if len(ss) != 5 || gaps.n != 1 {
+		t.Fatalf("Expected the gap to be counted once and every spot reset, got %d resets and %v gaps", len(ss), gaps.n)
+	}
+	if !ss[2].IsReserved {
+		t.Error("Expected the reset to carry the spots as they are now")
+	}
+}
+
+func TestStoreOccupancy(t *testing.T) {
+	store, _ := NewInMemParkingStore()
+	sub := store.Changes().Subscribe(0, ChangeFilter{})
+	defer sub.Close()
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	sp, err := store.SetOccupancy(1, Occupied, at)
+	if err != nil || sp.Occupancy != Occupied || !sp.OccupancyAt.Equal(at) || sp.IsReserved {
+		t.Fatalf("Expected spot 1 occupied and not reserved, got %+v %v", sp, err)
+	}
+	store.SetOccupancy(1, Occupied, at.Add(time.Minute))
+	store.SetOccupancy(1, Vacant, at.Add(-time.Minute))
+	if sp, _ := store.FindById(1); sp.Occupancy != Occupied || !sp.OccupancyAt.Equal(at) {
+		t.Errorf("Expected repeated and older readings to be ignored, got %+v", sp)
+	}
+	store.Update(Spot{ID: 1, IsReserved: true})
+	store.SetOccupancy(1, Vacant, at.Add(time.Hour))
+
+	for _, typ := range []ChangeType{ChangeOccupied, ChangeReserved, ChangeVacated} {
+		if c := <-sub.C; c.Type != typ {
+			t.Errorf("Expected %s, got %s", typ, c.Type)
+		}
+	}
+	if _, err := store.SetOccupancy(99, Vacant, at); err != ErrNotFound {
+		t.Error("Expected an unknown spot to fail")
+	}
+}
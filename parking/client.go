@@ -0,0 +1,493 @@
+package parking
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrUnavailable = apierror.New(apierror.Unavailable, "parking_unavailable", "parking service unavailable")
+	ErrCircuitOpen = apierror.New(apierror.Unavailable, "parking_circuit_open", "parking service circuit open")
+	// ErrNoRemoteEvents is returned by Subscribe on the client, the events
+	// stream is only served over HTTP
+	ErrNoRemoteEvents = apierror.New(apierror.Internal, "no_remote_events", "spot changes can't be followed through the client")
+)
+
+// ClientOptions tune the remote client. Zero values take the defaults below.
+type ClientOptions struct {
+	// APIKey identifies the calling service. When empty the credentials of
+	// the incoming request are forwarded instead.
+	APIKey string
+	// Timeout bounds a single attempt
+	Timeout time.Duration
+	// Retries is the number of extra attempts after a transport failure,
+	// negative disables them
+	Retries int
+	// BreakerFailures consecutive failures open the circuit for BreakerCooldown
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+const (
+	DefaultClientTimeout   = 2 * time.Second
+	DefaultClientRetries   = 2
+	DefaultBreakerFailures = 5
+	DefaultBreakerCooldown = 10 * time.Second
+)
+
+// NewHTTPClient returns a Service backed by the parking HTTP API at instance
+func NewHTTPClient(instance string, o ClientOptions) (Service, error) {
+	return MakeClientEndpoints(instance, o)
+}
+
+// MakeClientEndpoints returns Endpoints that invoke the remote parking
+// service at instance. Every endpoint is guarded by a per-attempt timeout,
+// retries and a circuit breaker shared by all of them. Business errors
+// (4xx) are returned as is and neither retried nor counted as failures.
+func MakeClientEndpoints(instance string, o ClientOptions) (Endpoints, error) {
+	if !strings.HasPrefix(instance, "http") {
+		instance = "http://" + instance
+	}
+	tgt, err := url.Parse(instance)
+	if err != nil {
+		return Endpoints{}, err
+	}
+	tgt.Path = ""
+
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultClientTimeout
+	}
+	if o.Retries < 0 {
+		o.Retries = 0
+	} else if o.Retries == 0 {
+		o.Retries = DefaultClientRetries
+	}
+	if o.BreakerFailures <= 0 {
+		o.BreakerFailures = DefaultBreakerFailures
+	}
+	if o.BreakerCooldown <= 0 {
+		o.BreakerCooldown = DefaultBreakerCooldown
+	}
+
+	options := []httptransport.ClientOption{
+		httptransport.SetClient(&http.Client{Timeout: o.Timeout}),
+	}
+	if o.APIKey != "" {
+		options = append(options, httptransport.ClientBefore(httptransport.SetRequestHeader(auth.APIKeyHeader, o.APIKey)))
+	} else {
+		options = append(options, httptransport.ClientBefore(auth.ContextToHTTP))
+	}
+
+	cb := newBreaker(o.BreakerFailures, o.BreakerCooldown)
+	guard := func(e endpoint.Endpoint) endpoint.Endpoint {
+		return cb.middleware()(retry(o.Retries, o.Timeout)(e))
+	}
+	// creating is not retried, a lost reply would add the spot twice
+	create := cb.middleware()(httptransport.NewClient("POST", tgt, encodeUpdateRequest, decodeUpdateResponse, options...).Endpoint())
+	// neither is reserving, the retry of a reservation whose reply was lost
+	// would find the spot reserved
+	setReserved := cb.middleware()(httptransport.NewClient("PUT", tgt, encodeSetReservedRequest, decodeUpdateResponse, options...).Endpoint())
+
+	return Endpoints{
+		GetAllParkingEndpoint:      guard(httptransport.NewClient("GET", tgt, encodeGetAllRequest, decodeSpotsResponse, options...).Endpoint()),
+		GetFreeParkingEndpoint:     guard(httptransport.NewClient("GET", tgt, encodeGetFreeRequest, decodeSpotsResponse, options...).Endpoint()),
+		GetReservedParkingEndpoint: guard(httptransport.NewClient("GET", tgt, encodeGetReservedRequest, decodeSpotsResponse, options...).Endpoint()),
+		SearchParkingEndpoint:      guard(httptransport.NewClient("POST", tgt, encodeSearchRequest, decodeSearchResponse, options...).Endpoint()),
+		FindByIdParkingEndpoint:    guard(httptransport.NewClient("GET", tgt, encodeFindRequest, decodeSpotsResponse, options...).Endpoint()),
+		CreateParkingEndpoint:      create,
+		UpdateParkingEndpoint:      guard(httptransport.NewClient("PUT", tgt, encodeUpdateRequest, decodeUpdateResponse, options...).Endpoint()),
+		SetReservedEndpoint:        setReserved,
+		SetOccupancyEndpoint:       guard(httptransport.NewClient("PUT", tgt, encodeSetOccupancyRequest, decodeUpdateResponse, options...).Endpoint()),
+		SetScheduleEndpoint:        guard(httptransport.NewClient("PUT", tgt, encodeSetScheduleRequest, decodeScheduleResponse, options...).Endpoint()),
+		SchedulesEndpoint:          guard(httptransport.NewClient("GET", tgt, encodeSchedulesRequest, decodeSchedulesResponse, options...).Endpoint()),
+		ScheduleEndpoint:           guard(httptransport.NewClient("GET", tgt, encodeScheduleRequest, decodeScheduleResponse, options...).Endpoint()),
+	}, nil
+}
+
+// GetAll implements Service. Primarily useful in a client.
+func (e Endpoints) GetAll(ctx context.Context) ([]Spot, error) {
+	resp, err := e.GetAllParkingEndpoint(ctx, getAllParkingRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return spotsFrom(resp)
+}
+
+// GetFree implements Service. Primarily useful in a client.
+func (e Endpoints) GetFree(ctx context.Context) ([]Spot, error) {
+	resp, err := e.GetFreeParkingEndpoint(ctx, getAllParkingRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return spotsFrom(resp)
+}
+
+// GetReserved implements Service. Primarily useful in a client.
+func (e Endpoints) GetReserved(ctx context.Context) ([]Spot, error) {
+	resp, err := e.GetReservedParkingEndpoint(ctx, getAllParkingRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return spotsFrom(resp)
+}
+
+// Search implements Service. Primarily useful in a client.
+func (e Endpoints) Search(ctx context.Context, lat, lon, radius string, metric SearchMetric, all bool) ([]ExtendedSpot, error) {
+	resp, err := e.SearchParkingEndpoint(ctx, searchParkingRequest{Lat: lat, Lon: lon, Rad: radius, Metric: metric, IncludeClosed: all})
+	if err != nil {
+		return nil, err
+	}
+	r := resp.(getSearchParkingResponse)
+	return r.Spots, r.Err
+}
+
+// FindById implements Service. Primarily useful in a client.
+func (e Endpoints) FindById(ctx context.Context, id string) (Spot, error) {
+	resp, err := e.FindByIdParkingEndpoint(ctx, findByIdParkingRequest{ID: id})
+	if err != nil {
+		return Spot{}, err
+	}
+	r := resp.(getAllParkingResponse)
+	if r.Err != nil {
+		return Spot{}, r.Err
+	}
+	if len(r.Spots) != 1 {
+		return Spot{}, ErrNotFound
+	}
+	return r.Spots[0], nil
+}
+
+// Create implements Service. Primarily useful in a client.
+func (e Endpoints) Create(ctx context.Context, sp Spot) (Spot, error) {
+	resp, err := e.CreateParkingEndpoint(ctx, updateParkingRequest{Spot: sp})
+	if err != nil {
+		return Spot{}, err
+	}
+	r := resp.(updateParkingResponse)
+	return r.Spot, r.Err
+}
+
+// Update implements Service. Primarily useful in a client.
+func (e Endpoints) Update(ctx context.Context, sp Spot) (Spot, error) {
+	resp, err := e.UpdateParkingEndpoint(ctx, updateParkingRequest{Spot: sp})
+	if err != nil {
+		return Spot{}, err
+	}
+	r := resp.(updateParkingResponse)
+	return r.Spot, r.Err
+}
+
+// SetReserved implements Service. Primarily useful in a client.
+func (e Endpoints) SetReserved(ctx context.Context, id string, reserved bool, turnoverUntil time.Time) (Spot, error) {
+	resp, err := e.SetReservedEndpoint(ctx, setReservedRequest{ID: id, Reserved: reserved, TurnoverUntil: turnoverUntil})
+	if err != nil {
+		return Spot{}, err
+	}
+	r := resp.(updateParkingResponse)
+	return r.Spot, r.Err
+}
+
+// SetOccupancy implements Service. Primarily useful in a client.
+func (e Endpoints) SetOccupancy(ctx context.Context, id string, o Occupancy, at time.Time) (Spot, error) {
+	resp, err := e.SetOccupancyEndpoint(ctx, setOccupancyRequest{ID: id, Occupancy: o, At: at})
+	if err != nil {
+		return Spot{}, err
+	}
+	r := resp.(updateParkingResponse)
+	return r.Spot, r.Err
+}
+
+// Subscribe implements Service. It always fails, remote consumers read
+// /parking/v1/events instead.
+func (e Endpoints) Subscribe(ctx context.Context, after uint64, filter ChangeFilter) (*Subscription, error) {
+	return nil, ErrNoRemoteEvents
+}
+
+// SetSchedule implements Service. Primarily useful in a client.
+func (e Endpoints) SetSchedule(ctx context.Context, sc Schedule) (Schedule, error) {
+	resp, err := e.SetScheduleEndpoint(ctx, sc)
+	if err != nil {
+		return Schedule{}, err
+	}
+	r := resp.(scheduleResponse)
+	return r.Schedule, r.Err
+}
+
+// Schedules implements Service. Primarily useful in a client.
+func (e Endpoints) Schedules(ctx context.Context) ([]Schedule, error) {
+	resp, err := e.SchedulesEndpoint(ctx, getAllParkingRequest{})
+	if err != nil {
+		return nil, err
+	}
+	r := resp.(schedulesResponse)
+	return r.Schedules, r.Err
+}
+
+// Schedule implements Service. Primarily useful in a client.
+func (e Endpoints) Schedule(ctx context.Context, id string) (Schedule, error) {
+	resp, err := e.ScheduleEndpoint(ctx, findByIdParkingRequest{ID: id})
+	if err != nil {
+		return Schedule{}, err
+	}
+	r := resp.(scheduleResponse)
+	return r.Schedule, r.Err
+}
+
+// spotsFrom unpacks any of the spot list responses, whether decoded by the
+// client or returned by a server endpoint.
+func spotsFrom(response interface{}) ([]Spot, error) {
+	switch r := response.(type) {
+	case getAllParkingResponse:
+		return r.Spots, r.Err
+	case getFreeParkingResponse:
+		return r.Spots, r.Err
+	case getReservedParkingResponse:
+		return r.Spots, r.Err
+	}
+	return nil, ErrInternal
+}
+
+func encodeGetAllRequest(_ context.Context, req *http.Request, request interface{}) error {
+	req.URL.Path = "/parking/v1/getAll/"
+	return nil
+}
+
+func encodeGetFreeRequest(_ context.Context, req *http.Request, request interface{}) error {
+	req.URL.Path = "/parking/v1/getFree/"
+	return nil
+}
+
+func encodeGetReservedRequest(_ context.Context, req *http.Request, request interface{}) error {
+	req.URL.Path = "/parking/v1/getReserved/"
+	return nil
+}
+
+func encodeSearchRequest(ctx context.Context, req *http.Request, request interface{}) error {
+	req.URL.Path = "/parking/v1/search/"
+	return encodeRequest(ctx, req, request)
+}
+
+func encodeFindRequest(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(findByIdParkingRequest)
+	req.URL.Path = "/parking/v1/find/" + r.ID
+	return nil
+}
+
+func encodeUpdateRequest(ctx context.Context, req *http.Request, request interface{}) error {
+	req.URL.Path = "/parking/v1/"
+	return encodeRequest(ctx, req, request)
+}
+
+func encodeSetReservedRequest(ctx context.Context, req *http.Request, request interface{}) error {
+	r := request.(setReservedRequest)
+	req.URL.Path = "/parking/v1/" + url.PathEscape(r.ID) + "/reserved"
+	return encodeRequest(ctx, req, request)
+}
+
+func encodeSetOccupancyRequest(ctx context.Context, req *http.Request, request interface{}) error {
+	r := request.(setOccupancyRequest)
+	req.URL.Path = "/parking/v1/" + url.PathEscape(r.ID) + "/occupancy"
+	return encodeRequest(ctx, req, request)
+}
+
+func encodeSetScheduleRequest(ctx context.Context, req *http.Request, request interface{}) error {
+	req.URL.Path = "/parking/v1/schedules"
+	return encodeRequest(ctx, req, request)
+}
+
+func encodeSchedulesRequest(_ context.Context, req *http.Request, request interface{}) error {
+	req.URL.Path = "/parking/v1/schedules"
+	return nil
+}
+
+func encodeScheduleRequest(_ context.Context, req *http.Request, request interface{}) error {
+	r := request.(findByIdParkingRequest)
+	req.URL.Path = "/parking/v1/" + url.PathEscape(r.ID) + "/schedule"
+	return nil
+}
+
+func encodeRequest(_ context.Context, req *http.Request, request interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(request); err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.ContentLength = int64(buf.Len())
+	req.Body = ioutil.NopCloser(&buf)
+	return nil
+}
+
+// The spot list routes share a payload, so they all decode into
+// getAllParkingResponse.
+func decodeSpotsResponse(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return getAllParkingResponse{Err: err}, errorIfTransient(err)
+	}
+	var response getAllParkingResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func decodeSearchResponse(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return getSearchParkingResponse{Err: err}, errorIfTransient(err)
+	}
+	var response getSearchParkingResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func decodeUpdateResponse(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return updateParkingResponse{Err: err}, errorIfTransient(err)
+	}
+	var response updateParkingResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func decodeScheduleResponse(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return scheduleResponse{Err: err}, errorIfTransient(err)
+	}
+	var response scheduleResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+func decodeSchedulesResponse(_ context.Context, resp *http.Response) (interface{}, error) {
+	if err := errorFromResponse(resp); err != nil {
+		return schedulesResponse{Err: err}, errorIfTransient(err)
+	}
+	var response schedulesResponse
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	return response, err
+}
+
+// remoteErrors are the errors the server may report, matched by code so
+// callers can compare against the usual sentinels.
+var remoteErrors = []*apierror.Error{
+	ErrNotFound, ErrInvalidReq, ErrInvalidParam, ErrInconsistentIDs, ErrAlreadyReserved, ErrNotReserved,
+	auth.ErrUnauthenticated, auth.ErrForbidden, auth.ErrRateLimited,
+}
+
+// errorFromResponse turns a non-2xx response into an error. Server side
+// failures become ErrUnavailable so they are retried.
+func errorFromResponse(resp *http.Response) error {
+	if resp.StatusCode >= 500 {
+		return ErrUnavailable
+	}
+	e := apierror.DecodeError(resp)
+	if e == nil {
+		return nil
+	}
+	for _, known := range remoteErrors {
+		if known.Code == e.Code {
+			return known
+		}
+	}
+	return e
+}
+
+// errorIfTransient keeps business errors inside the response, where the
+// retry and breaker middlewares don't see them.
+func errorIfTransient(err error) error {
+	if err == ErrUnavailable {
+		return err
+	}
+	return nil
+}
+
+// retry calls next up to retries+1 times, each attempt bounded by timeout,
+// backing off exponentially between attempts. It gives up early once the
+// caller's context is done.
+func retry(retries int, timeout time.Duration) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			backoff := 50 * time.Millisecond
+			for attempt := 0; ; attempt++ {
+				actx, cancel := context.WithTimeout(ctx, timeout)
+				response, err = next(actx, request)
+				cancel()
+				if err == nil || attempt >= retries {
+					return response, err
+				}
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(backoff):
+				}
+				backoff *= 2
+			}
+		}
+	}
+}
+
+// breaker is a consecutive-failure circuit breaker. Once open it rejects
+// calls with ErrCircuitOpen until the cooldown passes, then lets a single
+// trial call through: success closes it, failure opens it again.
+type breaker struct {
+	mtx       sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	trial     bool
+	now       func() time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+func (b *breaker) allow() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *breaker) record(err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.trial = false
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+func (b *breaker) middleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if !b.allow() {
+				return nil, ErrCircuitOpen
+			}
+			response, err := next(ctx, request)
+			b.record(err)
+			return response, err
+		}
+	}
+}
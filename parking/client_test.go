@@ -0,0 +1,188 @@
+package parking
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+)
+
+func newRemoteParking(t *testing.T) (*httptest.Server, string) {
+	inMemStore, err := NewInMemParkingStore()
+	if err != nil {
+		t.Fatal("Failed to create parking inmem store")
+	}
+	keys := auth.NewInMemKeyStore()
+	keys.Add("booking-key", auth.Principal{Subject: "booking", Roles: []auth.Role{auth.RoleService}})
+	a := auth.NewAuthorizer(auth.NewAuthenticator(nil, keys), log.NewNopLogger(), nopCounter{})
+	return httptest.NewServer(MakeHTTPHandler(NewService(inMemStore), a, log.NewNopLogger())), "booking-key"
+}
+
+func TestHTTPClient(t *testing.T) {
+	srv, key := newRemoteParking(t)
+	defer srv.Close()
+
+	client, err := NewHTTPClient(srv.URL, ClientOptions{APIKey: key})
+	if err != nil {
+		t.Fatal("Failed to create client")
+	}
+	ctx := context.Background()
+
+	ss, err := client.GetAll(ctx)
+	if err != nil || len(ss) != 5 {
+		t.Fatal("Failed to get all spots through the client")
+	}
+
+	sp, err := client.FindById(ctx, "2")
+	if err != nil || sp.ID != 2 {
+		t.Fatal("Failed to find spot 2 through the client")
+	}
+	if !sp.Allows(Motorcycle) || sp.Allows(Car) {
+		t.Error("Spot classes lost in transit")
+	}
+
+	sp.IsReserved = true
+	if _, err := client.Update(ctx, sp); err != nil {
+		t.Fatal("Failed to reserve spot through the client")
+	}
+	reserved, err := client.GetReserved(ctx)
+	if err != nil || len(reserved) != 1 || reserved[0].ID != 2 {
+		t.Error("Reserved spot not visible through the client")
+	}
+	free, err := client.GetFree(ctx)
+	if err != nil || len(free) != 4 {
+		t.Error("Expected 4 free spots through the client")
+	}
+
+	es, err := client.Search(ctx, "44.968046", "-94.420307", "100000", DIST, false)
+	if err != nil || len(es) == 0 {
+		t.Error("Failed to search through the client")
+	}
+
+	if sc, err := client.Schedule(ctx, "1"); err != nil || !sc.OpenAt(time.Now()) {
+		t.Errorf("Expected spot 1 to be open around the clock through the client, got %+v %v", sc, err)
+	}
+	if _, err := client.Schedule(ctx, "99"); err != ErrNotFound {
+		t.Errorf("Expected the schedule of a missing spot to be ErrNotFound, got %v", err)
+	}
+
+	if _, err := client.FindById(ctx, "99"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := client.FindById(ctx, "abc"); err != ErrInvalidReq {
+		t.Errorf("Expected ErrInvalidReq, got %v", err)
+	}
+
+	anonymous, _ := NewHTTPClient(srv.URL, ClientOptions{})
+	if _, err := anonymous.GetAll(ctx); err != auth.ErrUnauthenticated {
+		t.Errorf("Expected ErrUnauthenticated without credentials, got %v", err)
+	}
+}
+
+func TestHTTPClientRetries(t *testing.T) {
+	remote, key := newRemoteParking(t)
+	defer remote.Close()
+
+	// fail the first two attempts, then hand over to the real service
+	var calls int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		remote.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer flaky.Close()
+
+	client, _ := NewHTTPClient(flaky.URL, ClientOptions{APIKey: key, Retries: 2})
+	if _, err := client.GetAll(context.Background()); err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("Expected 3 attempts, got %d", n)
+	}
+
+	// business errors are not retried
+	atomic.StoreInt32(&calls, 2)
+	if _, err := client.FindById(context.Background(), "99"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("Expected a single attempt for a business error, got %d", n-2)
+	}
+}
+
+func TestHTTPClientTimeout(t *testing.T) {
+	var calls int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer slow.Close()
+
+	client, _ := NewHTTPClient(slow.URL, ClientOptions{Timeout: 20 * time.Millisecond, Retries: 1})
+	begin := time.Now()
+	if _, err := client.GetAll(context.Background()); err == nil {
+		t.Fatal("Expected a timeout")
+	}
+	if time.Since(begin) > 500*time.Millisecond {
+		t.Error("Timeout not enforced")
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("Expected 2 attempts, got %d", n)
+	}
+}
+
+func TestHTTPClientCircuitBreaker(t *testing.T) {
+	var calls int32
+	var healthy int32
+	remote, key := newRemoteParking(t)
+	defer remote.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		remote.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer down.Close()
+
+	client, _ := NewHTTPClient(down.URL, ClientOptions{
+		APIKey:          key,
+		Retries:         -1,
+		BreakerFailures: 2,
+		BreakerCooldown: 50 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetAll(ctx); err != ErrUnavailable {
+			t.Fatalf("Expected ErrUnavailable, got %v", err)
+		}
+	}
+	if _, err := client.GetFree(ctx); err != ErrCircuitOpen {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("Open circuit still reached the server, %d calls", n)
+	}
+
+	// after the cooldown a trial call goes through and closes the circuit
+	atomic.StoreInt32(&healthy, 1)
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.GetAll(ctx); err != nil {
+		t.Fatalf("Expected the trial call to succeed, got %v", err)
+	}
+	if _, err := client.GetAll(ctx); err != nil {
+		t.Errorf("Expected the circuit to be closed, got %v", err)
+	}
+}
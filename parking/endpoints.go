@@ -2,7 +2,9 @@ package parking
 
 import (
 	"context"
+	"time"
 
+	"github.com/atuldaemon/rct/auth"
 	"github.com/go-kit/kit/endpoint"
 )
 
@@ -12,7 +14,14 @@ type Endpoints struct {
 	GetReservedParkingEndpoint endpoint.Endpoint
 	SearchParkingEndpoint      endpoint.Endpoint
 	FindByIdParkingEndpoint    endpoint.Endpoint
+	CreateParkingEndpoint      endpoint.Endpoint
 	UpdateParkingEndpoint      endpoint.Endpoint
+	SetReservedEndpoint        endpoint.Endpoint
+	SetOccupancyEndpoint       endpoint.Endpoint
+	SubscribeEndpoint          endpoint.Endpoint
+	SetScheduleEndpoint        endpoint.Endpoint
+	SchedulesEndpoint          endpoint.Endpoint
+	ScheduleEndpoint           endpoint.Endpoint
 }
 
 func MakeServerEndpoints(s Service) Endpoints {
@@ -22,7 +31,53 @@ func MakeServerEndpoints(s Service) Endpoints {
 		GetReservedParkingEndpoint: MakeGetReservedEndpoint(s),
 		SearchParkingEndpoint:      MakeSearchEndpoint(s),
 		FindByIdParkingEndpoint:    MakeFindByIdEndpoint(s),
+		CreateParkingEndpoint:      MakeCreateEndpoint(s),
 		UpdateParkingEndpoint:      MakeUpdateEndpoint(s),
+		SetReservedEndpoint:        MakeSetReservedEndpoint(s),
+		SetOccupancyEndpoint:       MakeSetOccupancyEndpoint(s),
+		SubscribeEndpoint:          MakeSubscribeEndpoint(s),
+		SetScheduleEndpoint:        MakeSetScheduleEndpoint(s),
+		SchedulesEndpoint:          MakeSchedulesEndpoint(s),
+		ScheduleEndpoint:           MakeScheduleEndpoint(s),
+	}
+}
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeAuthorizedEndpoints and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"GetAll":       {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+	"GetFree":      {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+	"GetReserved":  {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+	"Search":       {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+	"FindById":     {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+	"Create":       {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"Update":       {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin, auth.RoleService}},
+	"SetReserved":  {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin, auth.RoleService}},
+	"SetOccupancy": {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin, auth.RoleService}},
+	"Subscribe":    {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+	"SetSchedule":  {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"Schedules":    {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+	"Schedule":     {Scope: auth.ScopeParkingRead, Roles: auth.AllRoles},
+}
+
+// MakeAuthorizedEndpoints returns the server endpoints, each wrapped with the
+// scope and roles allowed to call it. Every transport serves these.
+func MakeAuthorizedEndpoints(s Service, a *auth.Authorizer) Endpoints {
+	e := MakeServerEndpoints(s)
+	return Endpoints{
+		GetAllParkingEndpoint:      a.Enforce(requirements, "GetAll")(e.GetAllParkingEndpoint),
+		GetFreeParkingEndpoint:     a.Enforce(requirements, "GetFree")(e.GetFreeParkingEndpoint),
+		GetReservedParkingEndpoint: a.Enforce(requirements, "GetReserved")(e.GetReservedParkingEndpoint),
+		SearchParkingEndpoint:      a.Enforce(requirements, "Search")(e.SearchParkingEndpoint),
+		FindByIdParkingEndpoint:    a.Enforce(requirements, "FindById")(e.FindByIdParkingEndpoint),
+		CreateParkingEndpoint:      a.Enforce(requirements, "Create")(e.CreateParkingEndpoint),
+		UpdateParkingEndpoint:      a.Enforce(requirements, "Update")(e.UpdateParkingEndpoint),
+		SetReservedEndpoint:        a.Enforce(requirements, "SetReserved")(e.SetReservedEndpoint),
+		SetOccupancyEndpoint:       a.Enforce(requirements, "SetOccupancy")(e.SetOccupancyEndpoint),
+		SubscribeEndpoint:          a.Enforce(requirements, "Subscribe")(e.SubscribeEndpoint),
+		SetScheduleEndpoint:        a.Enforce(requirements, "SetSchedule")(e.SetScheduleEndpoint),
+		SchedulesEndpoint:          a.Enforce(requirements, "Schedules")(e.SchedulesEndpoint),
+		ScheduleEndpoint:           a.Enforce(requirements, "Schedule")(e.ScheduleEndpoint),
 	}
 }
 
@@ -50,6 +105,14 @@ func MakeGetReservedEndpoint(s Service) endpoint.Endpoint {
 	}
 }
 
+func MakeCreateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(updateParkingRequest)
+		sp, e := s.Create(ctx, req.Spot)
+		return updateParkingResponse{Spot: sp, Err: e}, e
+	}
+}
+
 func MakeUpdateEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(updateParkingRequest)
@@ -58,10 +121,26 @@ func MakeUpdateEndpoint(s Service) endpoint.Endpoint {
 	}
 }
 
+func MakeSetReservedEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(setReservedRequest)
+		sp, e := s.SetReserved(ctx, req.ID, req.Reserved, req.TurnoverUntil)
+		return updateParkingResponse{Spot: sp, Err: e}, e
+	}
+}
+
+func MakeSetOccupancyEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(setOccupancyRequest)
+		sp, e := s.SetOccupancy(ctx, req.ID, req.Occupancy, req.At)
+		return updateParkingResponse{Spot: sp, Err: e}, e
+	}
+}
+
 func MakeSearchEndpoint(s Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(searchParkingRequest)
-		ss, e := s.Search(ctx, req.Lat, req.Lon, req.Rad, req.Metric)
+		ss, e := s.Search(ctx, req.Lat, req.Lon, req.Rad, req.Metric, req.IncludeClosed)
 		return getSearchParkingResponse{Spots: ss, Err: e}, e
 	}
 }
@@ -74,6 +153,38 @@ func MakeFindByIdEndpoint(s Service) endpoint.Endpoint {
 	}
 }
 
+// MakeSubscribeEndpoint only opens the subscription, the transport streams it
+func MakeSubscribeEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(subscribeRequest)
+		sub, e := s.Subscribe(ctx, req.After, req.Filter)
+		return subscribeResponse{Sub: sub, Err: e}, e
+	}
+}
+
+func MakeSetScheduleEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(Schedule)
+		sc, e := s.SetSchedule(ctx, req)
+		return scheduleResponse{Schedule: sc, Err: e}, e
+	}
+}
+
+func MakeSchedulesEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		ss, e := s.Schedules(ctx)
+		return schedulesResponse{Schedules: ss, Err: e}, e
+	}
+}
+
+func MakeScheduleEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(findByIdParkingRequest)
+		sc, e := s.Schedule(ctx, req.ID)
+		return scheduleResponse{Schedule: sc, Err: e}, e
+	}
+}
+
 //
 
 type updateParkingResponse struct {
@@ -90,6 +201,20 @@ type updateParkingRequest struct {
 	Spot Spot `json:"spot"`
 }
 
+type setReservedRequest struct {
+	ID       string `json:"-"`
+	Reserved bool   `json:"reserved"`
+	// TurnoverUntil keeps a freed spot from being booked until then
+	TurnoverUntil time.Time `json:"turnoverUntil,omitempty"`
+}
+
+type setOccupancyRequest struct {
+	ID        string    `json:"-"`
+	Occupancy Occupancy `json:"occupancy"`
+	// At is when the sensor saw it, now when left out
+	At time.Time `json:"at,omitempty"`
+}
+
 type SearchMetric string
 
 const (
@@ -102,6 +227,9 @@ type searchParkingRequest struct {
 	Lon    string       `json:"lon"`
 	Rad    string       `json:"rad"`
 	Metric SearchMetric `json:"metric"`
+	// IncludeClosed keeps the spots closed or taken now, flagged open false
+	// or with isReserved and turnoverUntil
+	IncludeClosed bool `json:"includeClosed,omitempty"`
 }
 
 type getAllParkingRequest struct {
@@ -134,3 +262,29 @@ type getReservedParkingResponse struct {
 }
 
 func (r getReservedParkingResponse) error() error { return r.Err }
+
+type subscribeRequest struct {
+	After  uint64
+	Filter ChangeFilter
+}
+
+type subscribeResponse struct {
+	Err error
+	Sub *Subscription
+}
+
+func (r subscribeResponse) error() error { return r.Err }
+
+type scheduleResponse struct {
+	Err      error    `json:"err,omitempty"`
+	Schedule Schedule `json:"schedule"`
+}
+
+func (r scheduleResponse) error() error { return r.Err }
+
+type schedulesResponse struct {
+	Err       error      `json:"err,omitempty"`
+	Schedules []Schedule `json:"schedules"`
+}
+
+func (r schedulesResponse) error() error { return r.Err }
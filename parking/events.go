@@ -0,0 +1,81 @@
+package parking
+
+import (
+	"strconv"
+
+	"github.com/atuldaemon/rct/event"
+)
+
+// The typed spot events published on the event bus. Each carries the spot as
+// it is after the change.
+
+type SpotCreated struct {
+	Spot Spot `json:"spot"`
+}
+
+type SpotReserved struct {
+	Spot Spot `json:"spot"`
+}
+
+type SpotReleased struct {
+	Spot Spot `json:"spot"`
+}
+
+type SpotDeleted struct {
+	Spot Spot `json:"spot"`
+}
+
+// SpotOccupied and SpotVacated report what the sensors see, they don't
+// change the reservation
+type SpotOccupied struct {
+	Spot Spot `json:"spot"`
+}
+
+type SpotVacated struct {
+	Spot Spot `json:"spot"`
+}
+
+// SpotReset replaces what is known of the spot when the relay of the
+// changes fell behind and some of them were lost
+type SpotReset struct {
+	Spot Spot `json:"spot"`
+}
+
+func (SpotCreated) EventType() string  { return event.SpotCreated }
+func (SpotReserved) EventType() string { return event.SpotReserved }
+func (SpotReleased) EventType() string { return event.SpotReleased }
+func (SpotDeleted) EventType() string  { return event.SpotDeleted }
+func (SpotOccupied) EventType() string { return event.SpotOccupied }
+func (SpotVacated) EventType() string  { return event.SpotVacated }
+func (SpotReset) EventType() string    { return event.SpotReset }
+
+func (e SpotCreated) AggregateID() string  { return SpotAggregate(e.Spot.ID) }
+func (e SpotReserved) AggregateID() string { return SpotAggregate(e.Spot.ID) }
+func (e SpotReleased) AggregateID() string { return SpotAggregate(e.Spot.ID) }
+func (e SpotDeleted) AggregateID() string  { return SpotAggregate(e.Spot.ID) }
+func (e SpotOccupied) AggregateID() string { return SpotAggregate(e.Spot.ID) }
+func (e SpotVacated) AggregateID() string  { return SpotAggregate(e.Spot.ID) }
+func (e SpotReset) AggregateID() string    { return SpotAggregate(e.Spot.ID) }
+
+// SpotAggregate is the aggregate ID of the events of spot id
+func SpotAggregate(id int) string {
+	return "spot/" + strconv.Itoa(id)
+}
+
+// Payload returns the typed event of the change
+func (c Change) Payload() event.Payload {
+	switch c.Type {
+	case ChangeCreated:
+		return SpotCreated{c.Spot}
+	case ChangeReserved:
+		return SpotReserved{c.Spot}
+	case ChangeReleased:
+		return SpotReleased{c.Spot}
+	case ChangeOccupied:
+		return SpotOccupied{c.Spot}
+	case ChangeVacated:
+		return SpotVacated{c.Spot}
+	default:
+		return SpotDeleted{c.Spot}
+	}
+}
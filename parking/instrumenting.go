@@ -51,13 +51,13 @@ func (s *instrumentingService) GetReserved(ctx context.Context) ([]Spot, error)
 	return s.Service.GetReserved(ctx)
 }
 
-func (s *instrumentingService) Search(ctx context.Context, lat, lon, radius string, metric SearchMetric) ([]ExtendedSpot, error) {
+func (s *instrumentingService) Search(ctx context.Context, lat, lon, radius string, metric SearchMetric, all bool) ([]ExtendedSpot, error) {
 	defer func(begin time.Time) {
 		s.requestCount.With("method", "Search").Add(1)
 		s.requestLatency.With("method", "Search").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return s.Service.Search(ctx, lat, lon, radius, metric)
+	return s.Service.Search(ctx, lat, lon, radius, metric, all)
 }
 
 func (s *instrumentingService) FindById(ctx context.Context, id string) (Spot, error) {
@@ -69,6 +69,15 @@ func (s *instrumentingService) FindById(ctx context.Context, id string) (Spot, e
 	return s.Service.FindById(ctx, id)
 }
 
+func (s *instrumentingService) Create(ctx context.Context, sp Spot) (Spot, error) {
+	defer func(begin time.Time) {
+		s.requestCount.With("method", "Create").Add(1)
+		s.requestLatency.With("method", "Create").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return s.Service.Create(ctx, sp)
+}
+
 func (s *instrumentingService) Update(ctx context.Context, sp Spot) (Spot, error) {
 	defer func(begin time.Time) {
 		s.requestCount.With("method", "Update").Add(1)
@@ -77,3 +86,12 @@ func (s *instrumentingService) Update(ctx context.Context, sp Spot) (Spot, error
 
 	return s.Service.Update(ctx, sp)
 }
+
+func (s *instrumentingService) SetReserved(ctx context.Context, id string, reserved bool, turnoverUntil time.Time) (Spot, error) {
+	defer func(begin time.Time) {
+		s.requestCount.With("method", "SetReserved").Add(1)
+		s.requestLatency.With("method", "SetReserved").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return s.Service.SetReserved(ctx, id, reserved, turnoverUntil)
+}
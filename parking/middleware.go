@@ -45,11 +45,11 @@ func (mw loggingMiddleware) GetReserved(ctx context.Context) (sp []Spot, err err
 	return mw.next.GetReserved(ctx)
 }
 
-func (mw loggingMiddleware) Search(ctx context.Context, lat, lon, rad string, metric SearchMetric) (sp []ExtendedSpot, err error) {
+func (mw loggingMiddleware) Search(ctx context.Context, lat, lon, rad string, metric SearchMetric, all bool) (sp []ExtendedSpot, err error) {
 	defer func(begin time.Time) {
 		mw.logger.Log("method", "Search", "lat", lat, "lon", lon, "radius", rad, "metric", metric, "took", time.Since(begin), "err", err)
 	}(time.Now())
-	return mw.next.Search(ctx, lat, lon, rad, metric)
+	return mw.next.Search(ctx, lat, lon, rad, metric, all)
 }
 
 func (mw loggingMiddleware) FindById(ctx context.Context, id string) (sp Spot, err error) {
@@ -59,9 +59,58 @@ func (mw loggingMiddleware) FindById(ctx context.Context, id string) (sp Spot, e
 	return mw.next.FindById(ctx, id)
 }
 
+func (mw loggingMiddleware) Create(ctx context.Context, s Spot) (sp Spot, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Create", "id", sp.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Create(ctx, s)
+}
+
 func (mw loggingMiddleware) Update(ctx context.Context, s Spot) (sp Spot, err error) {
 	defer func(begin time.Time) {
 		mw.logger.Log("method", "Update", "id", s.ID, "took", time.Since(begin), "err", err)
 	}(time.Now())
 	return mw.next.Update(ctx, s)
 }
+
+func (mw loggingMiddleware) SetReserved(ctx context.Context, id string, reserved bool, turnoverUntil time.Time) (sp Spot, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "SetReserved", "id", id, "reserved", reserved, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.SetReserved(ctx, id, reserved, turnoverUntil)
+}
+
+func (mw loggingMiddleware) SetOccupancy(ctx context.Context, id string, o Occupancy, at time.Time) (sp Spot, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "SetOccupancy", "id", id, "occupancy", o, "at", at, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.SetOccupancy(ctx, id, o, at)
+}
+
+func (mw loggingMiddleware) Subscribe(ctx context.Context, after uint64, filter ChangeFilter) (sub *Subscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Subscribe", "after", after, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Subscribe(ctx, after, filter)
+}
+
+func (mw loggingMiddleware) SetSchedule(ctx context.Context, sc Schedule) (_ Schedule, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "SetSchedule", "spotId", sc.SpotId, "facility", sc.Facility, "hours", len(sc.Hours), "holidays", len(sc.Holidays), "blackouts", len(sc.Blackouts), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.SetSchedule(ctx, sc)
+}
+
+func (mw loggingMiddleware) Schedules(ctx context.Context) (ss []Schedule, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Schedules", "schedules", len(ss), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Schedules(ctx)
+}
+
+func (mw loggingMiddleware) Schedule(ctx context.Context, id string) (sc Schedule, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Schedule", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Schedule(ctx, id)
+}
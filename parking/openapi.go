@@ -0,0 +1,82 @@
+package parking
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(VehicleClass(""), Motorcycle, Car, Van, Truck)
+	d.Enum(SearchMetric(""), COST, DIST)
+	d.Enum(ChangeType(""), ChangeCreated, ChangeReserved, ChangeReleased, ChangeDeleted, ChangeOccupied, ChangeVacated)
+	d.Enum(Occupancy(""), OccupancyUnknown, Occupied, Vacant)
+
+	d.Operation("GET", "/parking/v1/getAll/", "getAllSpots", "List all spots").
+		Tag("parking").Require(requirements["GetAll"]).
+		Returns(http.StatusOK, "All spots", getAllParkingResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/parking/v1/getFree/", "getFreeSpots", "List the spots that are open and neither reserved nor being turned over after a booking").
+		Tag("parking").Require(requirements["GetFree"]).
+		Returns(http.StatusOK, "Free spots", getFreeParkingResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/parking/v1/getReserved/", "getReservedSpots", "List the reserved spots").
+		Tag("parking").Require(requirements["GetReserved"]).
+		Returns(http.StatusOK, "Reserved spots", getReservedParkingResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/parking/v1/search/", "searchSpots", "Search spots within a radius in meters, ordered by cost or distance. Spots closed, reserved, held or being turned over now are left out unless includeClosed.").
+		Tag("parking").Require(requirements["Search"]).
+		Body(searchParkingRequest{}).
+		Returns(http.StatusOK, "Matching spots with their distance", getSearchParkingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/parking/v1/find/{id}", "findSpot", "Find a spot by id").
+		Tag("parking").Require(requirements["FindById"]).
+		PathParam("id", "Spot id").
+		Returns(http.StatusOK, "A list holding the spot", getAllParkingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/parking/v1/", "createSpot", "Add a spot, the next free id is assigned when id is left out").
+		Tag("parking").Require(requirements["Create"]).
+		Body(updateParkingRequest{}).
+		Returns(http.StatusOK, "The created spot", updateParkingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PUT", "/parking/v1/", "updateSpot", "Replace the reservation, turnover, facility, classes and maximum size of a spot").
+		Tag("parking").Require(requirements["Update"]).
+		Body(updateParkingRequest{}).
+		Returns(http.StatusOK, "The updated spot", updateParkingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PUT", "/parking/v1/{id}/reserved", "setReserved", "Reserve or free a spot, failing with a conflict when it already is so that only one of two callers racing for it gets it").
+		Tag("parking").Require(requirements["SetReserved"]).
+		PathParam("id", "Spot id").
+		Body(setReservedRequest{}).
+		Returns(http.StatusOK, "The spot", updateParkingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PUT", "/parking/v1/{id}/occupancy", "setOccupancy", "Record what a sensor saw on a spot, readings older than the current state are ignored").
+		Tag("parking").Require(requirements["SetOccupancy"]).
+		PathParam("id", "Spot id").
+		Body(setOccupancyRequest{}).
+		Returns(http.StatusOK, "The spot", updateParkingResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/parking/v1/schedules", "listSchedules", "List the opening hours, holidays and blackouts of spots and facilities").
+		Tag("parking").Require(requirements["Schedules"]).
+		Returns(http.StatusOK, "The schedules, the empty facility is the default", schedulesResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("PUT", "/parking/v1/schedules", "setSchedule", "Set the schedule of a spot or of the spots of a facility, spots without one are open around the clock").
+		Tag("parking").Require(requirements["SetSchedule"]).
+		Body(Schedule{}).
+		Returns(http.StatusOK, "The schedule", scheduleResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/parking/v1/{id}/schedule", "findSchedule", "Get the schedule that applies to a spot").
+		Tag("parking").Require(requirements["Schedule"]).
+		PathParam("id", "Spot id").
+		Returns(http.StatusOK, "The schedule", scheduleResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/parking/v1/events", "spotEvents", "Stream spot changes as server-sent events").
+		Tag("parking").Require(requirements["Subscribe"]).
+		QueryParam("ids", "Comma separated spot ids to follow").
+		QueryParam("bbox", "Only follow spots within minLat,minLon,maxLat,maxLon").
+		QueryParam("lastEventId", "Resume after this event, for clients that can't set Last-Event-ID").
+		HeaderParam(LastEventIDHeader, "Resume after this event").
+		ReturnsAs(http.StatusOK, "An event per change, named by its type and identified by its id. A reset event means changes were missed.", "text/event-stream", Change{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
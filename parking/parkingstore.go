@@ -1,14 +1,16 @@
 package parking
 
 import (
-	"errors"
 	"sync"
+	"time"
 
 	"strconv"
 
 	"sort"
 
 	"github.com/umahmood/haversine"
+
+	"github.com/atuldaemon/rct/apierror"
 )
 
 // The parking store which stores the information about the spots
@@ -25,9 +27,27 @@ type ParkingStore interface {
 	Get(t SpotType) ([]Spot, error)
 	Create(Spot) (Spot, error)
 	Update(Spot) (Spot, error)
+	// SetReserved reserves or frees spot id in one step, failing with
+	// ErrAlreadyReserved or ErrNotReserved when it already is. Freeing it
+	// turns it over until turnoverUntil.
+	SetReserved(id int, reserved bool, turnoverUntil time.Time) (Spot, error)
+	// SetOccupancy records what a sensor saw on spot id at at. Readings
+	// older than the current state are ignored.
+	SetOccupancy(id int, o Occupancy, at time.Time) (Spot, error)
 	Delete(id int) error
-	Search(lat, lon, radius string, metric SearchMetric) ([]ExtendedSpot, error)
+	// Search leaves out the spots closed, reserved or being turned over now
+	// unless all
+	Search(lat, lon, radius string, metric SearchMetric, all bool) ([]ExtendedSpot, error)
 	FindById(id int) (Spot, error)
+	// Changes is the feed every mutation of the store is published to
+	Changes() *ChangeFeed
+	// SetSchedule adds or replaces the schedule of its spot or facility
+	SetSchedule(sc Schedule) (Schedule, error)
+	// Schedule returns the schedule of the spot, falling back to the
+	// schedule of its facility and then to the schedule of the empty
+	// facility
+	Schedule(sp Spot) (Schedule, error)
+	Schedules() ([]Schedule, error)
 }
 
 type Spot struct {
@@ -37,6 +57,88 @@ type Spot struct {
 	Cost       string `json:"cost"`
 	IsReserved bool   `json:"isReserved"`
 	Address    string `json:"address,omitempty"`
+	// Facility names the garage or lot the spot belongs to, empty for
+	// street parking
+	Facility string `json:"facility,omitempty"`
+	// Classes lists the vehicle classes allowed to park, empty allows any
+	Classes []VehicleClass `json:"classes,omitempty"`
+	// MaxSize is the largest vehicle that fits, nil means unlimited
+	MaxSize *Dimensions `json:"maxSize,omitempty"`
+	// Occupancy is what the sensors last saw on the spot, independent of
+	// the reservation, since OccupancyAt
+	Occupancy   Occupancy `json:"occupancy,omitempty"`
+	OccupancyAt time.Time `json:"occupancyAt,omitempty"`
+	// TurnoverUntil is the earliest a booking may start after the buffers
+	// kept around the last booking, a released spot is not free before
+	TurnoverUntil time.Time `json:"turnoverUntil,omitempty"`
+}
+
+type Occupancy string
+
+const (
+	// OccupancyUnknown is the state of spots without a sensor reading
+	OccupancyUnknown Occupancy = ""
+	Occupied         Occupancy = "occupied"
+	Vacant           Occupancy = "vacant"
+)
+
+func (o Occupancy) Valid() bool {
+	return o == Occupied || o == Vacant
+}
+
+type VehicleClass string
+
+const (
+	Motorcycle VehicleClass = "motorcycle"
+	Car        VehicleClass = "car"
+	Van        VehicleClass = "van"
+	Truck      VehicleClass = "truck"
+)
+
+func (c VehicleClass) Valid() bool {
+	switch c {
+	case Motorcycle, Car, Van, Truck:
+		return true
+	}
+	return false
+}
+
+// Dimensions of a vehicle or a spot in centimetres. A zero value on a spot
+// means the dimension is not restricted.
+type Dimensions struct {
+	Length int `json:"length"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Allows reports whether a vehicle of class c may use the spot
+func (sp Spot) Allows(c VehicleClass) bool {
+	if len(sp.Classes) == 0 {
+		return true
+	}
+	for _, allowed := range sp.Classes {
+		if allowed == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Free reports whether the spot is neither reserved nor being turned over
+// at at
+func (sp Spot) Free(at time.Time) bool {
+	return !sp.IsReserved && !at.Before(sp.TurnoverUntil)
+}
+
+// Fits reports whether a vehicle of size d fits in the spot
+func (sp Spot) Fits(d Dimensions) bool {
+	if sp.MaxSize == nil {
+		return true
+	}
+	max := sp.MaxSize
+	return (max.Length == 0 || d.Length <= max.Length) &&
+		(max.Width == 0 || d.Width <= max.Width) &&
+		(max.Height == 0 || d.Height <= max.Height)
 }
 
 // ExtendedSpot stores the distance of the spot from the searched location
@@ -44,6 +146,8 @@ type ExtendedSpot struct {
 	Spot
 	// Distance in meters
 	Distance float64 `json:"distance"`
+	// Open tells whether the spot was open at the time of the search
+	Open bool `json:"open"`
 }
 
 func MakeNewExtendedSpot(spot Spot, distanceKM float64) ExtendedSpot {
@@ -53,25 +157,41 @@ func MakeNewExtendedSpot(spot Spot, distanceKM float64) ExtendedSpot {
 	esp.Lat = spot.Lat
 	esp.Lon = spot.Lon
 	esp.Address = spot.Address
+	esp.Facility = spot.Facility
 	esp.Cost = spot.Cost
+	esp.Classes = spot.Classes
+	esp.MaxSize = spot.MaxSize
+	esp.Occupancy = spot.Occupancy
+	esp.OccupancyAt = spot.OccupancyAt
+	esp.TurnoverUntil = spot.TurnoverUntil
 	return esp
 }
 
 var (
-	ErrInconsistentIDs = errors.New("inconsistent IDs")
-	ErrNotFound        = errors.New("not found")
-	ErrInvalidReq      = errors.New("invalid request")
-	ErrInternal        = errors.New("internal data error")
+	ErrInconsistentIDs = apierror.New(apierror.Conflict, "inconsistent_ids", "inconsistent IDs")
+	ErrNotFound        = apierror.New(apierror.NotFound, "spot_not_found", "not found")
+	ErrInvalidReq      = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInternal        = apierror.New(apierror.Internal, "internal", "internal data error")
+	ErrAlreadyReserved = apierror.New(apierror.Conflict, "spot_already_reserved", "spot already reserved")
+	ErrNotReserved     = apierror.New(apierror.Conflict, "spot_not_reserved", "spot not reserved")
 )
 
 // In memory store that stores the parking database in memory
 type InMemStore struct {
-	mtx sync.RWMutex // controls access to the map m
-	m   map[int]Spot
+	mtx  sync.RWMutex // controls access to the map m
+	m    map[int]Spot
+	feed *ChangeFeed
+	// schedules are keyed by spot or by facility
+	schedules map[scheduleKey]Schedule
+}
+
+type scheduleKey struct {
+	spotId   int
+	facility string
 }
 
 func NewInMemParkingStore() (ParkingStore, error) {
-	s := &InMemStore{m: make(map[int]Spot, 0)}
+	s := &InMemStore{m: make(map[int]Spot, 0), feed: NewChangeFeed(DefaultFeedHistory), schedules: make(map[scheduleKey]Schedule)}
 	ss := createDefaultSpots()
 	for _, sp := range ss {
 		s.m[sp.ID] = sp
@@ -80,6 +200,9 @@ func NewInMemParkingStore() (ParkingStore, error) {
 }
 
 func (s *InMemStore) Get(t SpotType) ([]Spot, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
 	switch t {
 	case all:
 		return s.getAll()
@@ -89,16 +212,30 @@ func (s *InMemStore) Get(t SpotType) ([]Spot, error) {
 		return s.getReserved()
 	default:
 		return nil, ErrInvalidReq
-
 	}
-	return nil, nil
 }
 
 // CRUD ops on Parking store
 
+// Create adds st, assigning the next free ID when st.ID is zero
 func (s *InMemStore) Create(st Spot) (Spot, error) {
-	// TODO: implement this - not required for the test since we will be only using the dummy data
-	return Spot{}, nil
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if st.ID == 0 {
+		for id := range s.m {
+			if id > st.ID {
+				st.ID = id
+			}
+		}
+		st.ID++
+	}
+	if _, ok := s.m[st.ID]; ok {
+		return Spot{}, ErrInconsistentIDs
+	}
+	s.m[st.ID] = st
+	s.feed.Publish(ChangeCreated, st)
+	return st, nil
 }
 
 func (s *InMemStore) Update(st Spot) (Spot, error) {
@@ -109,17 +246,88 @@ func (s *InMemStore) Update(st Spot) (Spot, error) {
 	if !ok {
 		return Spot{}, ErrInconsistentIDs
 	}
+	changed := sp.IsReserved != st.IsReserved
 	sp.IsReserved = st.IsReserved
+	sp.TurnoverUntil = st.TurnoverUntil
+	sp.Facility, sp.Classes, sp.MaxSize = st.Facility, st.Classes, st.MaxSize
 	s.m[sp.ID] = sp
 
+	if changed {
+		if sp.IsReserved {
+			s.feed.Publish(ChangeReserved, sp)
+		} else {
+			s.feed.Publish(ChangeReleased, sp)
+		}
+	}
+	return sp, nil
+}
+
+func (s *InMemStore) SetReserved(id int, reserved bool, turnoverUntil time.Time) (Spot, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	sp, ok := s.m[id]
+	if !ok {
+		return Spot{}, ErrNotFound
+	}
+	if sp.IsReserved == reserved {
+		if reserved {
+			return Spot{}, ErrAlreadyReserved
+		}
+		return Spot{}, ErrNotReserved
+	}
+	sp.IsReserved = reserved
+	if !reserved {
+		sp.TurnoverUntil = turnoverUntil
+	}
+	s.m[id] = sp
+
+	if reserved {
+		s.feed.Publish(ChangeReserved, sp)
+	} else {
+		s.feed.Publish(ChangeReleased, sp)
+	}
+	return sp, nil
+}
+
+func (s *InMemStore) SetOccupancy(id int, o Occupancy, at time.Time) (Spot, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	sp, ok := s.m[id]
+	if !ok {
+		return Spot{}, ErrNotFound
+	}
+	if at.Before(sp.OccupancyAt) || sp.Occupancy == o {
+		return sp, nil
+	}
+	sp.Occupancy, sp.OccupancyAt = o, at.UTC()
+	s.m[id] = sp
+	if o == Occupied {
+		s.feed.Publish(ChangeOccupied, sp)
+	} else {
+		s.feed.Publish(ChangeVacated, sp)
+	}
 	return sp, nil
 }
 
 func (s *InMemStore) Delete(id int) error {
-	// TODO: implement this - perhaps not required... Definitely not required for the test
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	sp, ok := s.m[id]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.m, id)
+	s.feed.Publish(ChangeDeleted, sp)
 	return nil
 }
 
+func (s *InMemStore) Changes() *ChangeFeed {
+	return s.feed
+}
+
 func (s *InMemStore) getAll() ([]Spot, error) {
 	ss := make([]Spot, 0)
 	for _, sp := range s.m {
@@ -130,8 +338,9 @@ func (s *InMemStore) getAll() ([]Spot, error) {
 
 func (s *InMemStore) getFree() ([]Spot, error) {
 	ss := make([]Spot, 0)
+	now := time.Now()
 	for _, sp := range s.m {
-		if sp.IsReserved == false {
+		if sp.Free(now) && s.schedule(sp).OpenAt(now) {
 			ss = append(ss, sp)
 		}
 	}
@@ -149,6 +358,9 @@ func (s *InMemStore) getReserved() ([]Spot, error) {
 }
 
 func (s *InMemStore) FindById(id int) (Spot, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
 	if sp, ok := s.m[id]; ok {
 		return sp, nil
 	}
@@ -158,23 +370,27 @@ func (s *InMemStore) FindById(id int) (Spot, error) {
 // Search searches for the neighbouring spots based on the searchmetric
 // SearchMetric can be one of cost and distance
 // The search results will be ordered based on the metric
-func (s *InMemStore) Search(lat, lon, radius string, metric SearchMetric) ([]ExtendedSpot, error) {
+func (s *InMemStore) Search(lat, lon, radius string, metric SearchMetric, all bool) ([]ExtendedSpot, error) {
 	ess := make([]ExtendedSpot, 0)
 	latFloat, err := strconv.ParseFloat(lat, 64)
 	if err != nil {
-		return nil, ErrInvalidReq
+		return nil, ErrInvalidReq.WithField("lat", "must be a number")
 	}
 	lonFloat, err := strconv.ParseFloat(lon, 64)
 	if err != nil {
-		return nil, ErrInvalidReq
+		return nil, ErrInvalidReq.WithField("lon", "must be a number")
 	}
 	radFloat, err := strconv.ParseFloat(radius, 64)
 	if err != nil {
-		return nil, ErrInvalidReq
+		return nil, ErrInvalidReq.WithField("rad", "must be a number")
 	}
 
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
 	// Make use of the third party haversine library for computing the distance between two spots
 	p1 := haversine.Coord{Lat: latFloat, Lon: lonFloat}
+	now := time.Now()
 	for _, sp := range s.m {
 		p2LatFloat, err := strconv.ParseFloat(sp.Lat, 64)
 		if err != nil {
@@ -185,12 +401,55 @@ func (s *InMemStore) Search(lat, lon, radius string, metric SearchMetric) ([]Ext
 		_, km := haversine.Distance(p1, p2)
 		if km < radFloat/1000 {
 			esp := MakeNewExtendedSpot(sp, km)
-			ess = append(ess, esp)
+			esp.Open = s.schedule(sp).OpenAt(now)
+			if esp.Open && sp.Free(now) || all {
+				ess = append(ess, esp)
+			}
 		}
 	}
 	return SortSpots(ess, metric)
 }
 
+func (s *InMemStore) SetSchedule(sc Schedule) (Schedule, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.schedules[scheduleKey{sc.SpotId, sc.Facility}] = sc
+	return sc, nil
+}
+
+func (s *InMemStore) Schedule(sp Spot) (Schedule, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.schedule(sp), nil
+}
+
+// schedule is the schedule that applies to sp. The caller holds mtx.
+func (s *InMemStore) schedule(sp Spot) Schedule {
+	if sc, ok := s.schedules[scheduleKey{spotId: sp.ID}]; ok {
+		return sc
+	}
+	if sc, ok := s.schedules[scheduleKey{facility: sp.Facility}]; ok {
+		return sc
+	}
+	return s.schedules[scheduleKey{}]
+}
+
+func (s *InMemStore) Schedules() ([]Schedule, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	ss := make([]Schedule, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		ss = append(ss, sc)
+	}
+	sort.Slice(ss, func(i, j int) bool {
+		if ss[i].SpotId != ss[j].SpotId {
+			return ss[i].SpotId < ss[j].SpotId
+		}
+		return ss[i].Facility < ss[j].Facility
+	})
+	return ss, nil
+}
+
 func SortSpots(ess []ExtendedSpot, metric SearchMetric) ([]ExtendedSpot, error) {
 	switch metric {
 	case "dist":
@@ -210,11 +469,14 @@ func SortSpots(ess []ExtendedSpot, metric SearchMetric) ([]ExtendedSpot, error)
 // Dummy data for testing
 func createDefaultSpots() []Spot {
 	ss := []Spot{
-		Spot{1, "44.968046", "-94.420307", "100", false, "address 1"},
-		Spot{2, "44.33328", "-89.132008", "10", false, "address 2"},
-		Spot{3, "33.755787", "-116.359998", "80", false, "address 3"},
-		Spot{4, "33.844843", "-116.54911", "70", false, "address 4"},
-		Spot{5, "44.92057", "-93.44786", "90", false, "address 5"},
+		Spot{ID: 1, Lat: "44.968046", Lon: "-94.420307", Cost: "100", Address: "address 1"},
+		Spot{ID: 2, Lat: "44.33328", Lon: "-89.132008", Cost: "10", Address: "address 2",
+			Classes: []VehicleClass{Motorcycle}},
+		Spot{ID: 3, Lat: "33.755787", Lon: "-116.359998", Cost: "80", Address: "address 3", Facility: "desert-ramp",
+			Classes: []VehicleClass{Motorcycle, Car}, MaxSize: &Dimensions{Height: 200}},
+		Spot{ID: 4, Lat: "33.844843", Lon: "-116.54911", Cost: "70", Address: "address 4"},
+		Spot{ID: 5, Lat: "44.92057", Lon: "-93.44786", Cost: "90", Address: "address 5", Facility: "lakeside",
+			Classes: []VehicleClass{Car, Van, Truck}, MaxSize: &Dimensions{Length: 1200, Height: 400}},
 	}
 	return ss
 }
@@ -0,0 +1,1969 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: parking.proto
+
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Dimensions in centimetres, zero means unrestricted.
+type Dimensions struct {
+	Length               int32    `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
+	Width                int32    `protobuf:"varint,2,opt,name=width" json:"width,omitempty"`
+	Height               int32    `protobuf:"varint,3,opt,name=height" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Dimensions) Reset()         { *m = Dimensions{} }
+func (m *Dimensions) String() string { return proto.CompactTextString(m) }
+func (*Dimensions) ProtoMessage()    {}
+func (*Dimensions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{0}
+}
+func (m *Dimensions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dimensions.Unmarshal(m, b)
+}
+func (m *Dimensions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dimensions.Marshal(b, m, deterministic)
+}
+func (dst *Dimensions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dimensions.Merge(dst, src)
+}
+func (m *Dimensions) XXX_Size() int {
+	return xxx_messageInfo_Dimensions.Size(m)
+}
+func (m *Dimensions) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dimensions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dimensions proto.InternalMessageInfo
+
+func (m *Dimensions) GetLength() int32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *Dimensions) GetWidth() int32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *Dimensions) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// Times are Unix times in nanoseconds, zero when unset.
+type Spot struct {
+	Id                   int64       `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Lat                  string      `protobuf:"bytes,2,opt,name=lat" json:"lat,omitempty"`
+	Lon                  string      `protobuf:"bytes,3,opt,name=lon" json:"lon,omitempty"`
+	Cost                 string      `protobuf:"bytes,4,opt,name=cost" json:"cost,omitempty"`
+	IsReserved           bool        `protobuf:"varint,5,opt,name=is_reserved,json=isReserved" json:"is_reserved,omitempty"`
+	Address              string      `protobuf:"bytes,6,opt,name=address" json:"address,omitempty"`
+	Classes              []string    `protobuf:"bytes,7,rep,name=classes" json:"classes,omitempty"`
+	MaxSize              *Dimensions `protobuf:"bytes,8,opt,name=max_size,json=maxSize" json:"max_size,omitempty"`
+	Facility             string      `protobuf:"bytes,9,opt,name=facility" json:"facility,omitempty"`
+	Occupancy            string      `protobuf:"bytes,10,opt,name=occupancy" json:"occupancy,omitempty"`
+	OccupancyAt          int64       `protobuf:"varint,11,opt,name=occupancy_at,json=occupancyAt" json:"occupancy_at,omitempty"`
+	TurnoverUntil        int64       `protobuf:"varint,12,opt,name=turnover_until,json=turnoverUntil" json:"turnover_until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Spot) Reset()         { *m = Spot{} }
+func (m *Spot) String() string { return proto.CompactTextString(m) }
+func (*Spot) ProtoMessage()    {}
+func (*Spot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{1}
+}
+func (m *Spot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Spot.Unmarshal(m, b)
+}
+func (m *Spot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Spot.Marshal(b, m, deterministic)
+}
+func (dst *Spot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Spot.Merge(dst, src)
+}
+func (m *Spot) XXX_Size() int {
+	return xxx_messageInfo_Spot.Size(m)
+}
+func (m *Spot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Spot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Spot proto.InternalMessageInfo
+
+func (m *Spot) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Spot) GetLat() string {
+	if m != nil {
+		return m.Lat
+	}
+	return ""
+}
+
+func (m *Spot) GetLon() string {
+	if m != nil {
+		return m.Lon
+	}
+	return ""
+}
+
+func (m *Spot) GetCost() string {
+	if m != nil {
+		return m.Cost
+	}
+	return ""
+}
+
+func (m *Spot) GetIsReserved() bool {
+	if m != nil {
+		return m.IsReserved
+	}
+	return false
+}
+
+func (m *Spot) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Spot) GetClasses() []string {
+	if m != nil {
+		return m.Classes
+	}
+	return nil
+}
+
+func (m *Spot) GetMaxSize() *Dimensions {
+	if m != nil {
+		return m.MaxSize
+	}
+	return nil
+}
+
+func (m *Spot) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *Spot) GetOccupancy() string {
+	if m != nil {
+		return m.Occupancy
+	}
+	return ""
+}
+
+func (m *Spot) GetOccupancyAt() int64 {
+	if m != nil {
+		return m.OccupancyAt
+	}
+	return 0
+}
+
+func (m *Spot) GetTurnoverUntil() int64 {
+	if m != nil {
+		return m.TurnoverUntil
+	}
+	return 0
+}
+
+type ExtendedSpot struct {
+	Spot *Spot `protobuf:"bytes,1,opt,name=spot" json:"spot,omitempty"`
+	// Distance in meters
+	Distance             float64  `protobuf:"fixed64,2,opt,name=distance" json:"distance,omitempty"`
+	Open                 bool     `protobuf:"varint,3,opt,name=open" json:"open,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExtendedSpot) Reset()         { *m = ExtendedSpot{} }
+func (m *ExtendedSpot) String() string { return proto.CompactTextString(m) }
+func (*ExtendedSpot) ProtoMessage()    {}
+func (*ExtendedSpot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{2}
+}
+func (m *ExtendedSpot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtendedSpot.Unmarshal(m, b)
+}
+func (m *ExtendedSpot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtendedSpot.Marshal(b, m, deterministic)
+}
+func (dst *ExtendedSpot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtendedSpot.Merge(dst, src)
+}
+func (m *ExtendedSpot) XXX_Size() int {
+	return xxx_messageInfo_ExtendedSpot.Size(m)
+}
+func (m *ExtendedSpot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtendedSpot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtendedSpot proto.InternalMessageInfo
+
+func (m *ExtendedSpot) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+func (m *ExtendedSpot) GetDistance() float64 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+func (m *ExtendedSpot) GetOpen() bool {
+	if m != nil {
+		return m.Open
+	}
+	return false
+}
+
+type GetSpotsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSpotsRequest) Reset()         { *m = GetSpotsRequest{} }
+func (m *GetSpotsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSpotsRequest) ProtoMessage()    {}
+func (*GetSpotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{3}
+}
+func (m *GetSpotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSpotsRequest.Unmarshal(m, b)
+}
+func (m *GetSpotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSpotsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetSpotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSpotsRequest.Merge(dst, src)
+}
+func (m *GetSpotsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSpotsRequest.Size(m)
+}
+func (m *GetSpotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSpotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSpotsRequest proto.InternalMessageInfo
+
+type SpotsReply struct {
+	Spots                []*Spot  `protobuf:"bytes,1,rep,name=spots" json:"spots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SpotsReply) Reset()         { *m = SpotsReply{} }
+func (m *SpotsReply) String() string { return proto.CompactTextString(m) }
+func (*SpotsReply) ProtoMessage()    {}
+func (*SpotsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{4}
+}
+func (m *SpotsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SpotsReply.Unmarshal(m, b)
+}
+func (m *SpotsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SpotsReply.Marshal(b, m, deterministic)
+}
+func (dst *SpotsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpotsReply.Merge(dst, src)
+}
+func (m *SpotsReply) XXX_Size() int {
+	return xxx_messageInfo_SpotsReply.Size(m)
+}
+func (m *SpotsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpotsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpotsReply proto.InternalMessageInfo
+
+func (m *SpotsReply) GetSpots() []*Spot {
+	if m != nil {
+		return m.Spots
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	Lat    string `protobuf:"bytes,1,opt,name=lat" json:"lat,omitempty"`
+	Lon    string `protobuf:"bytes,2,opt,name=lon" json:"lon,omitempty"`
+	Rad    string `protobuf:"bytes,3,opt,name=rad" json:"rad,omitempty"`
+	Metric string `protobuf:"bytes,4,opt,name=metric" json:"metric,omitempty"`
+	// Keeps the spots closed or taken now
+	IncludeClosed        bool     `protobuf:"varint,5,opt,name=include_closed,json=includeClosed" json:"include_closed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{5}
+}
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchRequest.Unmarshal(m, b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+}
+func (dst *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(dst, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return xxx_messageInfo_SearchRequest.Size(m)
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetLat() string {
+	if m != nil {
+		return m.Lat
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetLon() string {
+	if m != nil {
+		return m.Lon
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetRad() string {
+	if m != nil {
+		return m.Rad
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetMetric() string {
+	if m != nil {
+		return m.Metric
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetIncludeClosed() bool {
+	if m != nil {
+		return m.IncludeClosed
+	}
+	return false
+}
+
+type SearchReply struct {
+	Spots                []*ExtendedSpot `protobuf:"bytes,1,rep,name=spots" json:"spots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SearchReply) Reset()         { *m = SearchReply{} }
+func (m *SearchReply) String() string { return proto.CompactTextString(m) }
+func (*SearchReply) ProtoMessage()    {}
+func (*SearchReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{6}
+}
+func (m *SearchReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SearchReply.Unmarshal(m, b)
+}
+func (m *SearchReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SearchReply.Marshal(b, m, deterministic)
+}
+func (dst *SearchReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchReply.Merge(dst, src)
+}
+func (m *SearchReply) XXX_Size() int {
+	return xxx_messageInfo_SearchReply.Size(m)
+}
+func (m *SearchReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchReply proto.InternalMessageInfo
+
+func (m *SearchReply) GetSpots() []*ExtendedSpot {
+	if m != nil {
+		return m.Spots
+	}
+	return nil
+}
+
+type FindByIdRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindByIdRequest) Reset()         { *m = FindByIdRequest{} }
+func (m *FindByIdRequest) String() string { return proto.CompactTextString(m) }
+func (*FindByIdRequest) ProtoMessage()    {}
+func (*FindByIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{7}
+}
+func (m *FindByIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindByIdRequest.Unmarshal(m, b)
+}
+func (m *FindByIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindByIdRequest.Marshal(b, m, deterministic)
+}
+func (dst *FindByIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindByIdRequest.Merge(dst, src)
+}
+func (m *FindByIdRequest) XXX_Size() int {
+	return xxx_messageInfo_FindByIdRequest.Size(m)
+}
+func (m *FindByIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindByIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindByIdRequest proto.InternalMessageInfo
+
+func (m *FindByIdRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CreateRequest struct {
+	Spot                 *Spot    `protobuf:"bytes,1,opt,name=spot" json:"spot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{8}
+}
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(dst, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+type CreateReply struct {
+	Spot                 *Spot    `protobuf:"bytes,1,opt,name=spot" json:"spot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateReply) Reset()         { *m = CreateReply{} }
+func (m *CreateReply) String() string { return proto.CompactTextString(m) }
+func (*CreateReply) ProtoMessage()    {}
+func (*CreateReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{9}
+}
+func (m *CreateReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateReply.Unmarshal(m, b)
+}
+func (m *CreateReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateReply.Marshal(b, m, deterministic)
+}
+func (dst *CreateReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateReply.Merge(dst, src)
+}
+func (m *CreateReply) XXX_Size() int {
+	return xxx_messageInfo_CreateReply.Size(m)
+}
+func (m *CreateReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateReply proto.InternalMessageInfo
+
+func (m *CreateReply) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+type UpdateRequest struct {
+	Spot                 *Spot    `protobuf:"bytes,1,opt,name=spot" json:"spot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
+func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRequest) ProtoMessage()    {}
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{10}
+}
+func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
+}
+func (m *UpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRequest.Merge(dst, src)
+}
+func (m *UpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRequest.Size(m)
+}
+func (m *UpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRequest proto.InternalMessageInfo
+
+func (m *UpdateRequest) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+type UpdateReply struct {
+	Spot                 *Spot    `protobuf:"bytes,1,opt,name=spot" json:"spot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateReply) Reset()         { *m = UpdateReply{} }
+func (m *UpdateReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateReply) ProtoMessage()    {}
+func (*UpdateReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{11}
+}
+func (m *UpdateReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateReply.Unmarshal(m, b)
+}
+func (m *UpdateReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateReply.Marshal(b, m, deterministic)
+}
+func (dst *UpdateReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateReply.Merge(dst, src)
+}
+func (m *UpdateReply) XXX_Size() int {
+	return xxx_messageInfo_UpdateReply.Size(m)
+}
+func (m *UpdateReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateReply proto.InternalMessageInfo
+
+func (m *UpdateReply) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+type SetReservedRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Reserved             bool     `protobuf:"varint,2,opt,name=reserved" json:"reserved,omitempty"`
+	TurnoverUntil        int64    `protobuf:"varint,3,opt,name=turnover_until,json=turnoverUntil" json:"turnover_until,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetReservedRequest) Reset()         { *m = SetReservedRequest{} }
+func (m *SetReservedRequest) String() string { return proto.CompactTextString(m) }
+func (*SetReservedRequest) ProtoMessage()    {}
+func (*SetReservedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{12}
+}
+func (m *SetReservedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetReservedRequest.Unmarshal(m, b)
+}
+func (m *SetReservedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetReservedRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetReservedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReservedRequest.Merge(dst, src)
+}
+func (m *SetReservedRequest) XXX_Size() int {
+	return xxx_messageInfo_SetReservedRequest.Size(m)
+}
+func (m *SetReservedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReservedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReservedRequest proto.InternalMessageInfo
+
+func (m *SetReservedRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SetReservedRequest) GetReserved() bool {
+	if m != nil {
+		return m.Reserved
+	}
+	return false
+}
+
+func (m *SetReservedRequest) GetTurnoverUntil() int64 {
+	if m != nil {
+		return m.TurnoverUntil
+	}
+	return 0
+}
+
+type SetReservedReply struct {
+	Spot                 *Spot    `protobuf:"bytes,1,opt,name=spot" json:"spot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetReservedReply) Reset()         { *m = SetReservedReply{} }
+func (m *SetReservedReply) String() string { return proto.CompactTextString(m) }
+func (*SetReservedReply) ProtoMessage()    {}
+func (*SetReservedReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{13}
+}
+func (m *SetReservedReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetReservedReply.Unmarshal(m, b)
+}
+func (m *SetReservedReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetReservedReply.Marshal(b, m, deterministic)
+}
+func (dst *SetReservedReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReservedReply.Merge(dst, src)
+}
+func (m *SetReservedReply) XXX_Size() int {
+	return xxx_messageInfo_SetReservedReply.Size(m)
+}
+func (m *SetReservedReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReservedReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReservedReply proto.InternalMessageInfo
+
+func (m *SetReservedReply) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+type SetOccupancyRequest struct {
+	Id        string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Occupancy string `protobuf:"bytes,2,opt,name=occupancy" json:"occupancy,omitempty"`
+	// Now when zero
+	At                   int64    `protobuf:"varint,3,opt,name=at" json:"at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetOccupancyRequest) Reset()         { *m = SetOccupancyRequest{} }
+func (m *SetOccupancyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetOccupancyRequest) ProtoMessage()    {}
+func (*SetOccupancyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{14}
+}
+func (m *SetOccupancyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetOccupancyRequest.Unmarshal(m, b)
+}
+func (m *SetOccupancyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetOccupancyRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetOccupancyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetOccupancyRequest.Merge(dst, src)
+}
+func (m *SetOccupancyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetOccupancyRequest.Size(m)
+}
+func (m *SetOccupancyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetOccupancyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetOccupancyRequest proto.InternalMessageInfo
+
+func (m *SetOccupancyRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SetOccupancyRequest) GetOccupancy() string {
+	if m != nil {
+		return m.Occupancy
+	}
+	return ""
+}
+
+func (m *SetOccupancyRequest) GetAt() int64 {
+	if m != nil {
+		return m.At
+	}
+	return 0
+}
+
+type SetOccupancyReply struct {
+	Spot                 *Spot    `protobuf:"bytes,1,opt,name=spot" json:"spot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetOccupancyReply) Reset()         { *m = SetOccupancyReply{} }
+func (m *SetOccupancyReply) String() string { return proto.CompactTextString(m) }
+func (*SetOccupancyReply) ProtoMessage()    {}
+func (*SetOccupancyReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{15}
+}
+func (m *SetOccupancyReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetOccupancyReply.Unmarshal(m, b)
+}
+func (m *SetOccupancyReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetOccupancyReply.Marshal(b, m, deterministic)
+}
+func (dst *SetOccupancyReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetOccupancyReply.Merge(dst, src)
+}
+func (m *SetOccupancyReply) XXX_Size() int {
+	return xxx_messageInfo_SetOccupancyReply.Size(m)
+}
+func (m *SetOccupancyReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetOccupancyReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetOccupancyReply proto.InternalMessageInfo
+
+func (m *SetOccupancyReply) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+// BBox is a bounding box in degrees
+type BBox struct {
+	MinLat               float64  `protobuf:"fixed64,1,opt,name=min_lat,json=minLat" json:"min_lat,omitempty"`
+	MinLon               float64  `protobuf:"fixed64,2,opt,name=min_lon,json=minLon" json:"min_lon,omitempty"`
+	MaxLat               float64  `protobuf:"fixed64,3,opt,name=max_lat,json=maxLat" json:"max_lat,omitempty"`
+	MaxLon               float64  `protobuf:"fixed64,4,opt,name=max_lon,json=maxLon" json:"max_lon,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BBox) Reset()         { *m = BBox{} }
+func (m *BBox) String() string { return proto.CompactTextString(m) }
+func (*BBox) ProtoMessage()    {}
+func (*BBox) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{16}
+}
+func (m *BBox) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BBox.Unmarshal(m, b)
+}
+func (m *BBox) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BBox.Marshal(b, m, deterministic)
+}
+func (dst *BBox) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BBox.Merge(dst, src)
+}
+func (m *BBox) XXX_Size() int {
+	return xxx_messageInfo_BBox.Size(m)
+}
+func (m *BBox) XXX_DiscardUnknown() {
+	xxx_messageInfo_BBox.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BBox proto.InternalMessageInfo
+
+func (m *BBox) GetMinLat() float64 {
+	if m != nil {
+		return m.MinLat
+	}
+	return 0
+}
+
+func (m *BBox) GetMinLon() float64 {
+	if m != nil {
+		return m.MinLon
+	}
+	return 0
+}
+
+func (m *BBox) GetMaxLat() float64 {
+	if m != nil {
+		return m.MaxLat
+	}
+	return 0
+}
+
+func (m *BBox) GetMaxLon() float64 {
+	if m != nil {
+		return m.MaxLon
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	// Resumes after the change with this id when non-zero
+	After                uint64   `protobuf:"varint,1,opt,name=after" json:"after,omitempty"`
+	Ids                  []int64  `protobuf:"varint,2,rep,packed,name=ids" json:"ids,omitempty"`
+	Bbox                 *BBox    `protobuf:"bytes,3,opt,name=bbox" json:"bbox,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{17}
+}
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (dst *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(dst, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetAfter() uint64 {
+	if m != nil {
+		return m.After
+	}
+	return 0
+}
+
+func (m *SubscribeRequest) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetBbox() *BBox {
+	if m != nil {
+		return m.Bbox
+	}
+	return nil
+}
+
+type Change struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Spot                 *Spot    `protobuf:"bytes,3,opt,name=spot" json:"spot,omitempty"`
+	At                   int64    `protobuf:"varint,4,opt,name=at" json:"at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Change) Reset()         { *m = Change{} }
+func (m *Change) String() string { return proto.CompactTextString(m) }
+func (*Change) ProtoMessage()    {}
+func (*Change) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{18}
+}
+func (m *Change) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Change.Unmarshal(m, b)
+}
+func (m *Change) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Change.Marshal(b, m, deterministic)
+}
+func (dst *Change) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Change.Merge(dst, src)
+}
+func (m *Change) XXX_Size() int {
+	return xxx_messageInfo_Change.Size(m)
+}
+func (m *Change) XXX_DiscardUnknown() {
+	xxx_messageInfo_Change.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Change proto.InternalMessageInfo
+
+func (m *Change) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Change) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Change) GetSpot() *Spot {
+	if m != nil {
+		return m.Spot
+	}
+	return nil
+}
+
+func (m *Change) GetAt() int64 {
+	if m != nil {
+		return m.At
+	}
+	return 0
+}
+
+type OpeningHours struct {
+	Day                  string   `protobuf:"bytes,1,opt,name=day" json:"day,omitempty"`
+	Open                 string   `protobuf:"bytes,2,opt,name=open" json:"open,omitempty"`
+	Close                string   `protobuf:"bytes,3,opt,name=close" json:"close,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpeningHours) Reset()         { *m = OpeningHours{} }
+func (m *OpeningHours) String() string { return proto.CompactTextString(m) }
+func (*OpeningHours) ProtoMessage()    {}
+func (*OpeningHours) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{19}
+}
+func (m *OpeningHours) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpeningHours.Unmarshal(m, b)
+}
+func (m *OpeningHours) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpeningHours.Marshal(b, m, deterministic)
+}
+func (dst *OpeningHours) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpeningHours.Merge(dst, src)
+}
+func (m *OpeningHours) XXX_Size() int {
+	return xxx_messageInfo_OpeningHours.Size(m)
+}
+func (m *OpeningHours) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpeningHours.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpeningHours proto.InternalMessageInfo
+
+func (m *OpeningHours) GetDay() string {
+	if m != nil {
+		return m.Day
+	}
+	return ""
+}
+
+func (m *OpeningHours) GetOpen() string {
+	if m != nil {
+		return m.Open
+	}
+	return ""
+}
+
+func (m *OpeningHours) GetClose() string {
+	if m != nil {
+		return m.Close
+	}
+	return ""
+}
+
+type Blackout struct {
+	From                 int64    `protobuf:"varint,1,opt,name=from" json:"from,omitempty"`
+	Until                int64    `protobuf:"varint,2,opt,name=until" json:"until,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Blackout) Reset()         { *m = Blackout{} }
+func (m *Blackout) String() string { return proto.CompactTextString(m) }
+func (*Blackout) ProtoMessage()    {}
+func (*Blackout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{20}
+}
+func (m *Blackout) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Blackout.Unmarshal(m, b)
+}
+func (m *Blackout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Blackout.Marshal(b, m, deterministic)
+}
+func (dst *Blackout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Blackout.Merge(dst, src)
+}
+func (m *Blackout) XXX_Size() int {
+	return xxx_messageInfo_Blackout.Size(m)
+}
+func (m *Blackout) XXX_DiscardUnknown() {
+	xxx_messageInfo_Blackout.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Blackout proto.InternalMessageInfo
+
+func (m *Blackout) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *Blackout) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *Blackout) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type Schedule struct {
+	SpotId               int64           `protobuf:"varint,1,opt,name=spot_id,json=spotId" json:"spot_id,omitempty"`
+	Facility             string          `protobuf:"bytes,2,opt,name=facility" json:"facility,omitempty"`
+	TimeZone             string          `protobuf:"bytes,3,opt,name=time_zone,json=timeZone" json:"time_zone,omitempty"`
+	Hours                []*OpeningHours `protobuf:"bytes,4,rep,name=hours" json:"hours,omitempty"`
+	Holidays             []string        `protobuf:"bytes,5,rep,name=holidays" json:"holidays,omitempty"`
+	Blackouts            []*Blackout     `protobuf:"bytes,6,rep,name=blackouts" json:"blackouts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{21}
+}
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schedule.Unmarshal(m, b)
+}
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
+}
+func (dst *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(dst, src)
+}
+func (m *Schedule) XXX_Size() int {
+	return xxx_messageInfo_Schedule.Size(m)
+}
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
+
+func (m *Schedule) GetSpotId() int64 {
+	if m != nil {
+		return m.SpotId
+	}
+	return 0
+}
+
+func (m *Schedule) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *Schedule) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *Schedule) GetHours() []*OpeningHours {
+	if m != nil {
+		return m.Hours
+	}
+	return nil
+}
+
+func (m *Schedule) GetHolidays() []string {
+	if m != nil {
+		return m.Holidays
+	}
+	return nil
+}
+
+func (m *Schedule) GetBlackouts() []*Blackout {
+	if m != nil {
+		return m.Blackouts
+	}
+	return nil
+}
+
+type SetScheduleRequest struct {
+	Schedule             *Schedule `protobuf:"bytes,1,opt,name=schedule" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SetScheduleRequest) Reset()         { *m = SetScheduleRequest{} }
+func (m *SetScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetScheduleRequest) ProtoMessage()    {}
+func (*SetScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{22}
+}
+func (m *SetScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetScheduleRequest.Unmarshal(m, b)
+}
+func (m *SetScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetScheduleRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetScheduleRequest.Merge(dst, src)
+}
+func (m *SetScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_SetScheduleRequest.Size(m)
+}
+func (m *SetScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetScheduleRequest proto.InternalMessageInfo
+
+func (m *SetScheduleRequest) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+type SchedulesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SchedulesRequest) Reset()         { *m = SchedulesRequest{} }
+func (m *SchedulesRequest) String() string { return proto.CompactTextString(m) }
+func (*SchedulesRequest) ProtoMessage()    {}
+func (*SchedulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{23}
+}
+func (m *SchedulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchedulesRequest.Unmarshal(m, b)
+}
+func (m *SchedulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchedulesRequest.Marshal(b, m, deterministic)
+}
+func (dst *SchedulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulesRequest.Merge(dst, src)
+}
+func (m *SchedulesRequest) XXX_Size() int {
+	return xxx_messageInfo_SchedulesRequest.Size(m)
+}
+func (m *SchedulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulesRequest proto.InternalMessageInfo
+
+type SchedulesReply struct {
+	Schedules            []*Schedule `protobuf:"bytes,1,rep,name=schedules" json:"schedules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SchedulesReply) Reset()         { *m = SchedulesReply{} }
+func (m *SchedulesReply) String() string { return proto.CompactTextString(m) }
+func (*SchedulesReply) ProtoMessage()    {}
+func (*SchedulesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{24}
+}
+func (m *SchedulesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchedulesReply.Unmarshal(m, b)
+}
+func (m *SchedulesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchedulesReply.Marshal(b, m, deterministic)
+}
+func (dst *SchedulesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulesReply.Merge(dst, src)
+}
+func (m *SchedulesReply) XXX_Size() int {
+	return xxx_messageInfo_SchedulesReply.Size(m)
+}
+func (m *SchedulesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulesReply proto.InternalMessageInfo
+
+func (m *SchedulesReply) GetSchedules() []*Schedule {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
+type ScheduleRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScheduleRequest) Reset()         { *m = ScheduleRequest{} }
+func (m *ScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*ScheduleRequest) ProtoMessage()    {}
+func (*ScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{25}
+}
+func (m *ScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleRequest.Unmarshal(m, b)
+}
+func (m *ScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleRequest.Marshal(b, m, deterministic)
+}
+func (dst *ScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleRequest.Merge(dst, src)
+}
+func (m *ScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_ScheduleRequest.Size(m)
+}
+func (m *ScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleRequest proto.InternalMessageInfo
+
+func (m *ScheduleRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ScheduleReply struct {
+	Schedule             *Schedule `protobuf:"bytes,1,opt,name=schedule" json:"schedule,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ScheduleReply) Reset()         { *m = ScheduleReply{} }
+func (m *ScheduleReply) String() string { return proto.CompactTextString(m) }
+func (*ScheduleReply) ProtoMessage()    {}
+func (*ScheduleReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_parking_132875bae9754439, []int{26}
+}
+func (m *ScheduleReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleReply.Unmarshal(m, b)
+}
+func (m *ScheduleReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleReply.Marshal(b, m, deterministic)
+}
+func (dst *ScheduleReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleReply.Merge(dst, src)
+}
+func (m *ScheduleReply) XXX_Size() int {
+	return xxx_messageInfo_ScheduleReply.Size(m)
+}
+func (m *ScheduleReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleReply proto.InternalMessageInfo
+
+func (m *ScheduleReply) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Dimensions)(nil), "parking.Dimensions")
+	proto.RegisterType((*Spot)(nil), "parking.Spot")
+	proto.RegisterType((*ExtendedSpot)(nil), "parking.ExtendedSpot")
+	proto.RegisterType((*GetSpotsRequest)(nil), "parking.GetSpotsRequest")
+	proto.RegisterType((*SpotsReply)(nil), "parking.SpotsReply")
+	proto.RegisterType((*SearchRequest)(nil), "parking.SearchRequest")
+	proto.RegisterType((*SearchReply)(nil), "parking.SearchReply")
+	proto.RegisterType((*FindByIdRequest)(nil), "parking.FindByIdRequest")
+	proto.RegisterType((*CreateRequest)(nil), "parking.CreateRequest")
+	proto.RegisterType((*CreateReply)(nil), "parking.CreateReply")
+	proto.RegisterType((*UpdateRequest)(nil), "parking.UpdateRequest")
+	proto.RegisterType((*UpdateReply)(nil), "parking.UpdateReply")
+	proto.RegisterType((*SetReservedRequest)(nil), "parking.SetReservedRequest")
+	proto.RegisterType((*SetReservedReply)(nil), "parking.SetReservedReply")
+	proto.RegisterType((*SetOccupancyRequest)(nil), "parking.SetOccupancyRequest")
+	proto.RegisterType((*SetOccupancyReply)(nil), "parking.SetOccupancyReply")
+	proto.RegisterType((*BBox)(nil), "parking.BBox")
+	proto.RegisterType((*SubscribeRequest)(nil), "parking.SubscribeRequest")
+	proto.RegisterType((*Change)(nil), "parking.Change")
+	proto.RegisterType((*OpeningHours)(nil), "parking.OpeningHours")
+	proto.RegisterType((*Blackout)(nil), "parking.Blackout")
+	proto.RegisterType((*Schedule)(nil), "parking.Schedule")
+	proto.RegisterType((*SetScheduleRequest)(nil), "parking.SetScheduleRequest")
+	proto.RegisterType((*SchedulesRequest)(nil), "parking.SchedulesRequest")
+	proto.RegisterType((*SchedulesReply)(nil), "parking.SchedulesReply")
+	proto.RegisterType((*ScheduleRequest)(nil), "parking.ScheduleRequest")
+	proto.RegisterType((*ScheduleReply)(nil), "parking.ScheduleReply")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for ParkingService service
+
+type ParkingServiceClient interface {
+	GetAll(ctx context.Context, in *GetSpotsRequest, opts ...grpc.CallOption) (*SpotsReply, error)
+	GetFree(ctx context.Context, in *GetSpotsRequest, opts ...grpc.CallOption) (*SpotsReply, error)
+	GetReserved(ctx context.Context, in *GetSpotsRequest, opts ...grpc.CallOption) (*SpotsReply, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchReply, error)
+	FindById(ctx context.Context, in *FindByIdRequest, opts ...grpc.CallOption) (*SpotsReply, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateReply, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateReply, error)
+	SetReserved(ctx context.Context, in *SetReservedRequest, opts ...grpc.CallOption) (*SetReservedReply, error)
+	SetOccupancy(ctx context.Context, in *SetOccupancyRequest, opts ...grpc.CallOption) (*SetOccupancyReply, error)
+	// Subscribe streams the spot changes, starting with a change of type
+	// reset when changes after the resume point were missed.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ParkingService_SubscribeClient, error)
+	SetSchedule(ctx context.Context, in *SetScheduleRequest, opts ...grpc.CallOption) (*ScheduleReply, error)
+	Schedules(ctx context.Context, in *SchedulesRequest, opts ...grpc.CallOption) (*SchedulesReply, error)
+	Schedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*ScheduleReply, error)
+}
+
+type parkingServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewParkingServiceClient(cc *grpc.ClientConn) ParkingServiceClient {
+	return &parkingServiceClient{cc}
+}
+
+func (c *parkingServiceClient) GetAll(ctx context.Context, in *GetSpotsRequest, opts ...grpc.CallOption) (*SpotsReply, error) {
+	out := new(SpotsReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/GetAll", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) GetFree(ctx context.Context, in *GetSpotsRequest, opts ...grpc.CallOption) (*SpotsReply, error) {
+	out := new(SpotsReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/GetFree", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) GetReserved(ctx context.Context, in *GetSpotsRequest, opts ...grpc.CallOption) (*SpotsReply, error) {
+	out := new(SpotsReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/GetReserved", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchReply, error) {
+	out := new(SearchReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/Search", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) FindById(ctx context.Context, in *FindByIdRequest, opts ...grpc.CallOption) (*SpotsReply, error) {
+	out := new(SpotsReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/FindById", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateReply, error) {
+	out := new(CreateReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateReply, error) {
+	out := new(UpdateReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) SetReserved(ctx context.Context, in *SetReservedRequest, opts ...grpc.CallOption) (*SetReservedReply, error) {
+	out := new(SetReservedReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/SetReserved", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) SetOccupancy(ctx context.Context, in *SetOccupancyRequest, opts ...grpc.CallOption) (*SetOccupancyReply, error) {
+	out := new(SetOccupancyReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/SetOccupancy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ParkingService_SubscribeClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ParkingService_serviceDesc.Streams[0], c.cc, "/parking.ParkingService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &parkingServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ParkingService_SubscribeClient interface {
+	Recv() (*Change, error)
+	grpc.ClientStream
+}
+
+type parkingServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *parkingServiceSubscribeClient) Recv() (*Change, error) {
+	m := new(Change)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *parkingServiceClient) SetSchedule(ctx context.Context, in *SetScheduleRequest, opts ...grpc.CallOption) (*ScheduleReply, error) {
+	out := new(ScheduleReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/SetSchedule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) Schedules(ctx context.Context, in *SchedulesRequest, opts ...grpc.CallOption) (*SchedulesReply, error) {
+	out := new(SchedulesReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/Schedules", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *parkingServiceClient) Schedule(ctx context.Context, in *ScheduleRequest, opts ...grpc.CallOption) (*ScheduleReply, error) {
+	out := new(ScheduleReply)
+	err := grpc.Invoke(ctx, "/parking.ParkingService/Schedule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ParkingService service
+
+type ParkingServiceServer interface {
+	GetAll(context.Context, *GetSpotsRequest) (*SpotsReply, error)
+	GetFree(context.Context, *GetSpotsRequest) (*SpotsReply, error)
+	GetReserved(context.Context, *GetSpotsRequest) (*SpotsReply, error)
+	Search(context.Context, *SearchRequest) (*SearchReply, error)
+	FindById(context.Context, *FindByIdRequest) (*SpotsReply, error)
+	Create(context.Context, *CreateRequest) (*CreateReply, error)
+	Update(context.Context, *UpdateRequest) (*UpdateReply, error)
+	SetReserved(context.Context, *SetReservedRequest) (*SetReservedReply, error)
+	SetOccupancy(context.Context, *SetOccupancyRequest) (*SetOccupancyReply, error)
+	// Subscribe streams the spot changes, starting with a change of type
+	// reset when changes after the resume point were missed.
+	Subscribe(*SubscribeRequest, ParkingService_SubscribeServer) error
+	SetSchedule(context.Context, *SetScheduleRequest) (*ScheduleReply, error)
+	Schedules(context.Context, *SchedulesRequest) (*SchedulesReply, error)
+	Schedule(context.Context, *ScheduleRequest) (*ScheduleReply, error)
+}
+
+func RegisterParkingServiceServer(s *grpc.Server, srv ParkingServiceServer) {
+	s.RegisterService(&_ParkingService_serviceDesc, srv)
+}
+
+func _ParkingService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).GetAll(ctx, req.(*GetSpotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_GetFree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).GetFree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/GetFree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).GetFree(ctx, req.(*GetSpotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_GetReserved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).GetReserved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/GetReserved",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).GetReserved(ctx, req.(*GetSpotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_FindById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindByIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).FindById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/FindById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).FindById(ctx, req.(*FindByIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_SetReserved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReservedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).SetReserved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/SetReserved",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).SetReserved(ctx, req.(*SetReservedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_SetOccupancy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOccupancyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).SetOccupancy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/SetOccupancy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).SetOccupancy(ctx, req.(*SetOccupancyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ParkingServiceServer).Subscribe(m, &parkingServiceSubscribeServer{stream})
+}
+
+type ParkingService_SubscribeServer interface {
+	Send(*Change) error
+	grpc.ServerStream
+}
+
+type parkingServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *parkingServiceSubscribeServer) Send(m *Change) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ParkingService_SetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).SetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/SetSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).SetSchedule(ctx, req.(*SetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_Schedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).Schedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/Schedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).Schedules(ctx, req.(*SchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ParkingService_Schedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ParkingServiceServer).Schedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/parking.ParkingService/Schedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ParkingServiceServer).Schedule(ctx, req.(*ScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ParkingService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "parking.ParkingService",
+	HandlerType: (*ParkingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAll",
+			Handler:    _ParkingService_GetAll_Handler,
+		},
+		{
+			MethodName: "GetFree",
+			Handler:    _ParkingService_GetFree_Handler,
+		},
+		{
+			MethodName: "GetReserved",
+			Handler:    _ParkingService_GetReserved_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _ParkingService_Search_Handler,
+		},
+		{
+			MethodName: "FindById",
+			Handler:    _ParkingService_FindById_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _ParkingService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ParkingService_Update_Handler,
+		},
+		{
+			MethodName: "SetReserved",
+			Handler:    _ParkingService_SetReserved_Handler,
+		},
+		{
+			MethodName: "SetOccupancy",
+			Handler:    _ParkingService_SetOccupancy_Handler,
+		},
+		{
+			MethodName: "SetSchedule",
+			Handler:    _ParkingService_SetSchedule_Handler,
+		},
+		{
+			MethodName: "Schedules",
+			Handler:    _ParkingService_Schedules_Handler,
+		},
+		{
+			MethodName: "Schedule",
+			Handler:    _ParkingService_Schedule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _ParkingService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "parking.proto",
+}
+
+func init() { proto.RegisterFile("parking.proto", fileDescriptor_parking_132875bae9754439) }
+
+var fileDescriptor_parking_132875bae9754439 = []byte{
+	// 1152 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xdd, 0x6f, 0xdb, 0x36,
+	0x10, 0xaf, 0x25, 0xf9, 0xeb, 0x1c, 0xe7, 0x83, 0xc9, 0x52, 0xcd, 0x2d, 0x30, 0x47, 0xc3, 0x80,
+	0x00, 0xc3, 0xd2, 0x2e, 0xc3, 0x86, 0xad, 0x03, 0x0a, 0x24, 0xe9, 0x9a, 0xb5, 0x28, 0xd0, 0x41,
+	0x46, 0x5f, 0x0a, 0x0c, 0x06, 0x2d, 0x31, 0x36, 0x57, 0x59, 0xf4, 0x44, 0x3a, 0xb3, 0xf3, 0xbc,
+	0x7f, 0x6f, 0xaf, 0xdb, 0xbf, 0x33, 0x90, 0x22, 0x29, 0x59, 0x76, 0xda, 0xe4, 0x8d, 0xf7, 0xc5,
+	0xfb, 0xdd, 0x4f, 0xc7, 0x3b, 0x08, 0xba, 0x33, 0x9c, 0x7d, 0xa0, 0xe9, 0xf8, 0x64, 0x96, 0x31,
+	0xc1, 0x50, 0x53, 0x8b, 0x41, 0x08, 0xf0, 0x82, 0x4e, 0x49, 0xca, 0x29, 0x4b, 0x39, 0x3a, 0x84,
+	0x46, 0x42, 0xd2, 0xb1, 0x98, 0xf8, 0xb5, 0x7e, 0xed, 0xb8, 0x1e, 0x6a, 0x09, 0x1d, 0x40, 0xfd,
+	0x2f, 0x1a, 0x8b, 0x89, 0xef, 0x28, 0x75, 0x2e, 0x48, 0xef, 0x09, 0xa1, 0xe3, 0x89, 0xf0, 0xdd,
+	0xdc, 0x3b, 0x97, 0x82, 0xff, 0x1c, 0xf0, 0x06, 0x33, 0x26, 0xd0, 0x36, 0x38, 0x34, 0x56, 0x57,
+	0xb9, 0xa1, 0x43, 0x63, 0xb4, 0x0b, 0x6e, 0x82, 0x85, 0xba, 0xa4, 0x1d, 0xca, 0xa3, 0xd2, 0xb0,
+	0xd4, 0x77, 0xb5, 0x86, 0xa5, 0x08, 0x81, 0x17, 0x31, 0x2e, 0x7c, 0x4f, 0xa9, 0xd4, 0x19, 0x7d,
+	0x01, 0x1d, 0xca, 0x87, 0x19, 0xe1, 0x24, 0xbb, 0x26, 0xb1, 0x5f, 0xef, 0xd7, 0x8e, 0x5b, 0x21,
+	0x50, 0x1e, 0x6a, 0x0d, 0xf2, 0xa1, 0x89, 0xe3, 0x38, 0x23, 0x9c, 0xfb, 0x0d, 0x15, 0x67, 0x44,
+	0x69, 0x89, 0x12, 0xcc, 0x39, 0xe1, 0x7e, 0xb3, 0xef, 0x4a, 0x8b, 0x16, 0xd1, 0x09, 0xb4, 0xa6,
+	0x78, 0x31, 0xe4, 0xf4, 0x86, 0xf8, 0xad, 0x7e, 0xed, 0xb8, 0x73, 0xba, 0x7f, 0x62, 0x48, 0x2a,
+	0x28, 0x09, 0x9b, 0x53, 0xbc, 0x18, 0xd0, 0x1b, 0x82, 0x7a, 0xd0, 0xba, 0xc2, 0x11, 0x4d, 0xa8,
+	0x58, 0xfa, 0x6d, 0x95, 0xc4, 0xca, 0xe8, 0x31, 0xb4, 0x59, 0x14, 0xcd, 0x67, 0x38, 0x8d, 0x96,
+	0x3e, 0x28, 0x63, 0xa1, 0x40, 0x47, 0xb0, 0x65, 0x85, 0x21, 0x16, 0x7e, 0x47, 0x11, 0xd2, 0xb1,
+	0xba, 0x33, 0x81, 0xbe, 0x82, 0x6d, 0x31, 0xcf, 0x52, 0x76, 0x4d, 0xb2, 0xe1, 0x3c, 0x15, 0x34,
+	0xf1, 0xb7, 0x94, 0x53, 0xd7, 0x68, 0xdf, 0x49, 0x65, 0x80, 0x61, 0xeb, 0x97, 0x85, 0x20, 0x69,
+	0x4c, 0x62, 0x45, 0xf0, 0x11, 0x78, 0x7c, 0xc6, 0x84, 0xa2, 0xb8, 0x73, 0xda, 0xb5, 0xf8, 0xa5,
+	0x31, 0x54, 0x26, 0x09, 0x3b, 0xa6, 0x5c, 0xe0, 0x34, 0x22, 0x8a, 0xf8, 0x5a, 0x68, 0x65, 0xc9,
+	0x35, 0x9b, 0x91, 0x9c, 0xfe, 0x56, 0xa8, 0xce, 0xc1, 0x1e, 0xec, 0x5c, 0x12, 0x21, 0x2f, 0xe0,
+	0x21, 0xf9, 0x73, 0x4e, 0xb8, 0x08, 0xbe, 0x05, 0xd0, 0xf2, 0x2c, 0x59, 0xa2, 0x2f, 0xa1, 0x2e,
+	0x2f, 0xe6, 0x7e, 0xad, 0xef, 0xae, 0x27, 0xcd, 0x6d, 0xc1, 0xdf, 0x35, 0xe8, 0x0e, 0x08, 0xce,
+	0xa2, 0x89, 0xbe, 0xc4, 0x7c, 0xfb, 0xda, 0xda, 0xb7, 0x77, 0x8a, 0x6f, 0xbf, 0x0b, 0x6e, 0x86,
+	0x63, 0xd3, 0x0d, 0x19, 0x8e, 0x65, 0x8b, 0x4d, 0x89, 0xc8, 0x68, 0xa4, 0xfb, 0x41, 0x4b, 0x92,
+	0x2f, 0x9a, 0x46, 0xc9, 0x3c, 0x26, 0xc3, 0x28, 0x61, 0xdc, 0x36, 0x45, 0x57, 0x6b, 0x2f, 0x94,
+	0x32, 0x78, 0x06, 0x1d, 0x83, 0x42, 0x42, 0xff, 0x7a, 0x15, 0xfa, 0x67, 0x16, 0x7a, 0x99, 0x54,
+	0x53, 0xc2, 0x11, 0xec, 0xbc, 0xa4, 0x69, 0x7c, 0xbe, 0x7c, 0x15, 0x9b, 0x1a, 0x8a, 0x7e, 0x6e,
+	0xcb, 0x7e, 0x0e, 0x4e, 0xa1, 0x7b, 0x91, 0x11, 0x2c, 0x88, 0x71, 0xf8, 0xf4, 0xf7, 0x08, 0x9e,
+	0x42, 0xc7, 0xc4, 0x48, 0x48, 0x77, 0x88, 0x38, 0x85, 0xee, 0xbb, 0x59, 0x7c, 0xef, 0x2c, 0x26,
+	0xe6, 0x8e, 0x59, 0xc6, 0x80, 0x06, 0x44, 0x98, 0x17, 0x75, 0x4b, 0xc5, 0xb2, 0x9b, 0xec, 0x33,
+	0x74, 0x14, 0xe3, 0x56, 0xde, 0xd0, 0xc3, 0xee, 0xa6, 0x1e, 0xfe, 0x1e, 0x76, 0x57, 0x12, 0xdd,
+	0x11, 0xdf, 0x00, 0xf6, 0x07, 0x44, 0xbc, 0x35, 0x6f, 0xe6, 0x36, 0x80, 0x2b, 0x2f, 0xd1, 0xa9,
+	0xbe, 0xc4, 0x6d, 0x70, 0xb0, 0xd0, 0xb0, 0x1c, 0x2c, 0x82, 0x1f, 0x60, 0x6f, 0xf5, 0xd2, 0x3b,
+	0x82, 0xf9, 0x03, 0xbc, 0xf3, 0x73, 0xb6, 0x40, 0x0f, 0xa1, 0x39, 0xa5, 0xe9, 0xd0, 0x34, 0x76,
+	0x2d, 0x6c, 0x4c, 0x69, 0xfa, 0x06, 0x0b, 0x6b, 0xd0, 0xfd, 0xad, 0x0d, 0x2c, 0x55, 0x06, 0xbc,
+	0x18, 0x26, 0x1a, 0x86, 0x34, 0xe0, 0x85, 0x89, 0x90, 0x06, 0x96, 0xfa, 0x5e, 0x61, 0x60, 0x69,
+	0xf0, 0x3b, 0xec, 0x0e, 0xe6, 0x23, 0x1e, 0x65, 0x74, 0x64, 0x3b, 0xe0, 0x00, 0xea, 0xf8, 0x4a,
+	0x90, 0x4c, 0x65, 0xf5, 0xc2, 0x5c, 0x90, 0xcf, 0x87, 0xc6, 0xdc, 0x77, 0xfa, 0xee, 0xb1, 0x1b,
+	0xca, 0xa3, 0x2c, 0x65, 0x34, 0x62, 0x0b, 0xdf, 0xad, 0x94, 0x22, 0xc1, 0x87, 0xca, 0x14, 0x0c,
+	0xa1, 0x71, 0x31, 0xc1, 0xe9, 0x98, 0x94, 0xa8, 0xf4, 0x14, 0x95, 0x08, 0x3c, 0xb1, 0x9c, 0x11,
+	0xcd, 0xa2, 0x3a, 0x5b, 0x6e, 0xdc, 0xdb, 0x07, 0x4e, 0xce, 0xb1, 0x67, 0x39, 0x7e, 0x0d, 0x5b,
+	0x6f, 0x67, 0x24, 0xa5, 0xe9, 0xf8, 0x57, 0x36, 0xcf, 0xb8, 0x44, 0x19, 0xe3, 0xa5, 0x19, 0x04,
+	0x31, 0x5e, 0xda, 0x31, 0xa4, 0x13, 0xc9, 0xb3, 0xac, 0x50, 0x3d, 0x6c, 0x3d, 0x0c, 0x72, 0x21,
+	0x78, 0x03, 0xad, 0xf3, 0x04, 0x47, 0x1f, 0xd8, 0x5c, 0xc8, 0xa8, 0xab, 0x8c, 0x4d, 0xf5, 0x7a,
+	0x51, 0x67, 0x19, 0x95, 0x77, 0x9e, 0xa3, 0x94, 0xb9, 0x20, 0x87, 0x48, 0x46, 0x30, 0xb7, 0x7b,
+	0x46, 0x4b, 0xc1, 0xbf, 0x35, 0x68, 0x0d, 0xa2, 0x09, 0x89, 0xe7, 0x09, 0x91, 0xfc, 0x4b, 0xf8,
+	0x43, 0xbb, 0xb0, 0x1a, 0x52, 0x7c, 0x15, 0xaf, 0xcc, 0x7d, 0xa7, 0x32, 0xf7, 0x1f, 0x41, 0x5b,
+	0xd0, 0x29, 0x19, 0xde, 0xb0, 0xd4, 0x20, 0x6d, 0x49, 0xc5, 0x7b, 0x96, 0x12, 0x39, 0x6d, 0x26,
+	0xb2, 0x62, 0xdf, 0xab, 0x4c, 0x9b, 0x32, 0x1d, 0x61, 0xee, 0x23, 0xb3, 0x4c, 0x58, 0x42, 0x63,
+	0xbc, 0xe4, 0x7e, 0x5d, 0x2d, 0x2a, 0x2b, 0xa3, 0x27, 0xd0, 0x1e, 0xe9, 0xaa, 0xe5, 0x7e, 0x93,
+	0x97, 0xed, 0x15, 0x9f, 0x52, 0x5b, 0xc2, 0xc2, 0x27, 0xb8, 0x50, 0x6f, 0xd9, 0x94, 0x66, 0x9a,
+	0xe6, 0x1b, 0x68, 0x71, 0xad, 0xd2, 0xbd, 0x5d, 0xdc, 0x62, 0x7d, 0xad, 0x4b, 0x80, 0x60, 0xd7,
+	0x68, 0xed, 0x26, 0x38, 0x83, 0xed, 0x92, 0x4e, 0x3e, 0x96, 0x27, 0xd0, 0x36, 0x11, 0x66, 0xac,
+	0x6e, 0xb8, 0xb5, 0xf0, 0x91, 0x63, 0xb5, 0x0a, 0xac, 0x3a, 0x56, 0x9f, 0x43, 0xb7, 0x70, 0x91,
+	0x49, 0xee, 0x87, 0xfc, 0xf4, 0x9f, 0x06, 0x6c, 0xff, 0x96, 0x9b, 0x07, 0x24, 0xbb, 0xa6, 0x11,
+	0x41, 0x3f, 0x41, 0xe3, 0x92, 0x88, 0xb3, 0x24, 0x41, 0xbe, 0x8d, 0xac, 0xac, 0xb9, 0xde, 0xfe,
+	0x4a, 0x37, 0xe7, 0xf5, 0x05, 0x0f, 0xd0, 0x33, 0x68, 0x5e, 0x12, 0xf1, 0x32, 0x23, 0xe4, 0xfe,
+	0xb1, 0xcf, 0xa1, 0x73, 0x59, 0xcc, 0xba, 0xfb, 0xc7, 0xff, 0x08, 0x8d, 0x7c, 0x7f, 0xa1, 0xc3,
+	0xc2, 0xa1, 0xbc, 0x56, 0x7b, 0x07, 0x6b, 0xfa, 0x3c, 0xf2, 0x67, 0x68, 0x99, 0xed, 0x55, 0x4a,
+	0x5b, 0x59, 0x68, 0x1f, 0x49, 0x9b, 0xef, 0xa8, 0x52, 0xda, 0x95, 0x45, 0xd7, 0x3b, 0x58, 0xd3,
+	0xdb, 0xc8, 0x7c, 0xef, 0x94, 0x22, 0x57, 0x96, 0x57, 0xef, 0x60, 0x4d, 0x9f, 0x47, 0x5e, 0xca,
+	0x55, 0x5d, 0x50, 0xf5, 0xa8, 0x54, 0x57, 0x75, 0x2b, 0xf5, 0x3e, 0xdf, 0x6c, 0xcc, 0x2f, 0x7a,
+	0x0d, 0x5b, 0xe5, 0x99, 0x8e, 0x1e, 0x97, 0x9d, 0xab, 0xfb, 0xa3, 0xd7, 0xbb, 0xc5, 0x6a, 0x58,
+	0x6c, 0xdb, 0xd9, 0x8b, 0x4a, 0x59, 0x2b, 0xf3, 0xb8, 0xb7, 0x53, 0xd0, 0xa1, 0x66, 0x69, 0xf0,
+	0xe0, 0x69, 0x0d, 0xbd, 0x50, 0x15, 0xd9, 0x01, 0xb3, 0x52, 0x51, 0xe5, 0x09, 0xf4, 0x0e, 0xd7,
+	0xfb, 0x59, 0x43, 0x38, 0x83, 0xb6, 0x51, 0xf1, 0x32, 0x84, 0xca, 0xd3, 0xec, 0x3d, 0xdc, 0x64,
+	0x32, 0x5d, 0x58, 0x8c, 0x39, 0x7f, 0x43, 0xa2, 0x4f, 0x40, 0x38, 0xf7, 0xde, 0x3b, 0xb3, 0xd1,
+	0xa8, 0xa1, 0xfe, 0x1c, 0xbe, 0xfb, 0x7f, 0x00, 0xb7, 0x66, 0x0d, 0x53, 0x4a, 0x0c, 0x00, 0x00,
+}
@@ -0,0 +1,187 @@
+package parking
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is when a spot, or the spots of a facility, can be used: the
+// weekly opening hours less the holidays and the blackouts. A schedule of
+// SpotId overrides the schedule of the spot's facility, the schedule of the
+// empty facility applies to every other spot. A spot without a schedule is
+// open around the clock.
+type Schedule struct {
+	SpotId   int    `json:"spotId,omitempty"`
+	Facility string `json:"facility,omitempty"`
+	// TimeZone is the IANA zone of the hours and holidays, UTC when empty
+	TimeZone string `json:"timeZone,omitempty"`
+	// Hours are the weekly opening hours, open around the clock when empty
+	Hours []OpeningHours `json:"hours,omitempty"`
+	// Holidays are dates, YYYY-MM-DD, closed all day
+	Holidays []string `json:"holidays,omitempty"`
+	// Blackouts close the spot for a while, e.g. for maintenance
+	Blackouts []Blackout `json:"blackouts,omitempty"`
+}
+
+// OpeningHours open the spot on Day from Open until Close, both HH:MM.
+// Close may be 24:00, hours past midnight are given on the next day.
+type OpeningHours struct {
+	// Day is the name of the weekday, e.g. monday
+	Day   string `json:"day"`
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+type Blackout struct {
+	From   time.Time `json:"from"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+const dateLayout = "2006-01-02"
+
+// Valid returns ErrInvalidReq naming the first field that can't be read
+func (sc Schedule) Valid() error {
+	if sc.SpotId != 0 && sc.Facility != "" {
+		return ErrInvalidReq.WithField("spotId", "give either spotId or facility")
+	}
+	if _, err := time.LoadLocation(sc.TimeZone); err != nil {
+		return ErrInvalidReq.WithField("timeZone", "must be an IANA time zone")
+	}
+	for _, h := range sc.Hours {
+		if _, _, _, err := h.parse(); err != nil {
+			return err
+		}
+	}
+	for _, d := range sc.Holidays {
+		if _, err := time.Parse(dateLayout, d); err != nil {
+			return ErrInvalidReq.WithField("holidays", "must be dates, YYYY-MM-DD")
+		}
+	}
+	for _, b := range sc.Blackouts {
+		if !b.Until.After(b.From) {
+			return ErrInvalidReq.WithField("blackouts", "must end after they start")
+		}
+	}
+	return nil
+}
+
+// OpenAt reports whether the spot is open at t
+func (sc Schedule) OpenAt(t time.Time) bool {
+	if _, ok := sc.BlackoutDuring(t, t.Add(time.Nanosecond)); ok {
+		return false
+	}
+	local := t.In(sc.location())
+	date := local.Format(dateLayout)
+	for _, d := range sc.Holidays {
+		if d == date {
+			return false
+		}
+	}
+	if len(sc.Hours) == 0 {
+		return true
+	}
+	sec := local.Hour()*3600 + local.Minute()*60 + local.Second()
+	for _, h := range sc.Hours {
+		day, open, close, err := h.parse()
+		if err == nil && day == local.Weekday() && open <= sec && sec < close {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenThroughout reports whether the spot is open all the time from from
+// until until, and when not the first moment it is closed
+func (sc Schedule) OpenThroughout(from, until time.Time) (time.Time, bool) {
+	if b, ok := sc.BlackoutDuring(from, until); ok {
+		if b.From.After(from) {
+			return b.From, false
+		}
+		return from, false
+	}
+	loc := sc.location()
+	// walk the window a day, or an opening, at a time
+	for t := from.In(loc); t.Before(until); {
+		y, m, d := t.Date()
+		date := t.Format(dateLayout)
+		for _, h := range sc.Holidays {
+			if h == date {
+				return t, false
+			}
+		}
+		if len(sc.Hours) == 0 {
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		sec := t.Hour()*3600 + t.Minute()*60 + t.Second()
+		next := t
+		for _, h := range sc.Hours {
+			day, open, close, err := h.parse()
+			if err == nil && day == t.Weekday() && open <= sec && sec < close {
+				if c := time.Date(y, m, d, 0, 0, close, 0, loc); c.After(next) {
+					next = c
+				}
+			}
+		}
+		if !next.After(t) {
+			return t, false
+		}
+		t = next
+	}
+	return time.Time{}, true
+}
+
+// BlackoutDuring returns the first blackout overlapping from until until
+func (sc Schedule) BlackoutDuring(from, until time.Time) (Blackout, bool) {
+	for _, b := range sc.Blackouts {
+		if from.Before(b.Until) && b.From.Before(until) {
+			return b, true
+		}
+	}
+	return Blackout{}, false
+}
+
+func (sc Schedule) location() *time.Location {
+	if loc, err := time.LoadLocation(sc.TimeZone); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// parse returns the weekday and the opening and closing second of the day
+func (h OpeningHours) parse() (time.Weekday, int, int, error) {
+	day, ok := weekdays[strings.ToLower(h.Day)]
+	if !ok {
+		return 0, 0, 0, ErrInvalidReq.WithField("hours", "day must be the name of a weekday")
+	}
+	open, ok := secondOfDay(h.Open)
+	if !ok {
+		return 0, 0, 0, ErrInvalidReq.WithField("hours", "open must be HH:MM")
+	}
+	close, ok := secondOfDay(h.Close)
+	if !ok || close <= open {
+		return 0, 0, 0, ErrInvalidReq.WithField("hours", "close must be HH:MM after open")
+	}
+	return day, open, close, nil
+}
+
+// secondOfDay reads HH:MM, up to 24:00
+func secondOfDay(hhmm string) (int, bool) {
+	parts := strings.Split(hhmm, ":")
+	if len(parts) != 2 || len(parts[1]) != 2 {
+		return 0, false
+	}
+	h, err1 := strconv.Atoi(parts[0])
+	m, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, false
+	}
+	return h*3600 + m*60, true
+}
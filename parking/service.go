@@ -3,6 +3,7 @@ package parking
 import (
 	"context"
 	"strconv"
+	"time"
 )
 
 // Parking service
@@ -11,9 +12,33 @@ type Service interface {
 	GetAll(ctx context.Context) ([]Spot, error)
 	GetFree(ctx context.Context) ([]Spot, error)
 	GetReserved(ctx context.Context) ([]Spot, error)
-	Search(ctx context.Context, lat, lon, radius string, metric SearchMetric) ([]ExtendedSpot, error)
+	// Search lists the spots within radius meters, leaving out the spots
+	// closed, reserved or being turned over now unless all
+	Search(ctx context.Context, lat, lon, radius string, metric SearchMetric, all bool) ([]ExtendedSpot, error)
 	FindById(ctx context.Context, id string) (Spot, error)
+	// Create adds a spot, assigning the next free id when sp.ID is zero
+	Create(ctx context.Context, sp Spot) (Spot, error)
+	// Update replaces the reservation, turnover, facility, classes and
+	// maximum size of a spot
 	Update(ctx context.Context, sp Spot) (Spot, error)
+	// SetReserved reserves or frees spot id, failing with
+	// ErrAlreadyReserved or ErrNotReserved when it already is, so that of
+	// two callers racing for a spot only one gets it. Freeing it turns it
+	// over until turnoverUntil when that is set.
+	SetReserved(ctx context.Context, id string, reserved bool, turnoverUntil time.Time) (Spot, error)
+	// SetOccupancy records what a sensor saw on spot id at at, it leaves the
+	// reservation alone
+	SetOccupancy(ctx context.Context, id string, o Occupancy, at time.Time) (Spot, error)
+	// Subscribe follows the spot changes matching filter, resuming after
+	// the change with ID after when it is non-zero. The caller must Close
+	// the subscription.
+	Subscribe(ctx context.Context, after uint64, filter ChangeFilter) (*Subscription, error)
+	// SetSchedule sets the opening hours, holidays and blackouts of a spot
+	// or of the spots of a facility
+	SetSchedule(ctx context.Context, sc Schedule) (Schedule, error)
+	Schedules(ctx context.Context) ([]Schedule, error)
+	// Schedule returns the schedule that applies to spot id
+	Schedule(ctx context.Context, id string) (Schedule, error)
 }
 
 type service struct {
@@ -36,8 +61,8 @@ func (s *service) GetReserved(ctx context.Context) ([]Spot, error) {
 	return s.parkingStore.Get(reserved)
 }
 
-func (s *service) Search(ctx context.Context, lat, lon, radius string, metric SearchMetric) ([]ExtendedSpot, error) {
-	return s.parkingStore.Search(lat, lon, radius, metric)
+func (s *service) Search(ctx context.Context, lat, lon, radius string, metric SearchMetric, all bool) ([]ExtendedSpot, error) {
+	return s.parkingStore.Search(lat, lon, radius, metric, all)
 }
 
 func (s *service) FindById(ctx context.Context, id string) (Spot, error) {
@@ -48,6 +73,82 @@ func (s *service) FindById(ctx context.Context, id string) (Spot, error) {
 	return s.parkingStore.FindById(int(intId))
 }
 
+func (s *service) Create(ctx context.Context, sp Spot) (Spot, error) {
+	if err := validate(sp); err != nil {
+		return Spot{}, err
+	}
+	return s.parkingStore.Create(sp)
+}
+
 func (s *service) Update(ctx context.Context, sp Spot) (Spot, error) {
+	if err := validate(sp); err != nil {
+		return Spot{}, err
+	}
 	return s.parkingStore.Update(sp)
 }
+
+// validate checks the vehicle restrictions of sp
+func validate(sp Spot) error {
+	for _, c := range sp.Classes {
+		if !c.Valid() {
+			return ErrInvalidReq.WithField("classes", "must be motorcycle, car, van or truck")
+		}
+	}
+	if m := sp.MaxSize; m != nil && (m.Length < 0 || m.Width < 0 || m.Height < 0) {
+		return ErrInvalidReq.WithField("maxSize", "must not be negative")
+	}
+	return nil
+}
+
+func (s *service) SetReserved(ctx context.Context, id string, reserved bool, turnoverUntil time.Time) (Spot, error) {
+	intId, err := strconv.ParseInt(id, 0, 32)
+	if err != nil {
+		return Spot{}, ErrInvalidReq
+	}
+	if !turnoverUntil.IsZero() {
+		turnoverUntil = turnoverUntil.UTC()
+	}
+	return s.parkingStore.SetReserved(int(intId), reserved, turnoverUntil)
+}
+
+func (s *service) SetOccupancy(ctx context.Context, id string, o Occupancy, at time.Time) (Spot, error) {
+	intId, err := strconv.ParseInt(id, 0, 32)
+	if err != nil {
+		return Spot{}, ErrInvalidReq
+	}
+	if !o.Valid() {
+		return Spot{}, ErrInvalidReq.WithField("occupancy", "must be occupied or vacant")
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return s.parkingStore.SetOccupancy(int(intId), o, at)
+}
+
+func (s *service) Subscribe(ctx context.Context, after uint64, filter ChangeFilter) (*Subscription, error) {
+	return s.parkingStore.Changes().Subscribe(after, filter), nil
+}
+
+func (s *service) SetSchedule(ctx context.Context, sc Schedule) (Schedule, error) {
+	if err := sc.Valid(); err != nil {
+		return Schedule{}, err
+	}
+	if sc.SpotId != 0 {
+		if _, err := s.parkingStore.FindById(sc.SpotId); err != nil {
+			return Schedule{}, err
+		}
+	}
+	return s.parkingStore.SetSchedule(sc)
+}
+
+func (s *service) Schedules(ctx context.Context) ([]Schedule, error) {
+	return s.parkingStore.Schedules()
+}
+
+func (s *service) Schedule(ctx context.Context, id string) (Schedule, error) {
+	sp, err := s.FindById(ctx, id)
+	if err != nil {
+		return Schedule{}, err
+	}
+	return s.parkingStore.Schedule(sp)
+}
@@ -2,6 +2,7 @@ package parking
 
 import (
 	"testing"
+	"time"
 )
 
 func TestFindById(t *testing.T) {
@@ -61,7 +62,7 @@ func TestSearchByCost(t *testing.T) {
 	curLat := "33.755787"
 	curLon := "-116.359998"
 
-	ss, err := service.Search(nil, curLat, curLon, "10000", "cost")
+	ss, err := service.Search(nil, curLat, curLon, "10000", "cost", false)
 	if err != nil {
 		t.Error("Error in Search")
 	}
@@ -85,7 +86,7 @@ func TestSearchByDist(t *testing.T) {
 	curLat := "33.755787"
 	curLon := "-116.359998"
 
-	ss, err := service.Search(nil, curLat, curLon, "10000", "dist")
+	ss, err := service.Search(nil, curLat, curLon, "10000", "dist", false)
 	if err != nil {
 		t.Error("Error in Search")
 	}
@@ -94,3 +95,159 @@ func TestSearchByDist(t *testing.T) {
 	}
 	t.Log("Search spot by dist")
 }
+
+func TestGetFreeTurnover(t *testing.T) {
+	inMemStore, _ := NewInMemParkingStore()
+	service := NewService(inMemStore)
+
+	sp, _ := service.FindById(nil, "1")
+	sp.TurnoverUntil = time.Now().Add(time.Hour)
+	if _, err := service.Update(nil, sp); err != nil {
+		t.Fatal(err)
+	}
+	ss, err := service.GetFree(nil)
+	if err != nil || len(ss) != 4 {
+		t.Errorf("Expected the spot being turned over not to be free, got %v %v", ss, err)
+	}
+	if !sp.Free(sp.TurnoverUntil) || sp.Free(time.Now()) {
+		t.Error("Expected the spot to be free once turned over")
+	}
+}
+
+func TestSearchLeavesOutTaken(t *testing.T) {
+	inMemStore, _ := NewInMemParkingStore()
+	service := NewService(inMemStore)
+
+	// spot 3 is reserved, spot 4 is being turned over
+	sp, _ := service.FindById(nil, "3")
+	sp.IsReserved = true
+	service.Update(nil, sp)
+	sp, _ = service.FindById(nil, "4")
+	sp.TurnoverUntil = time.Now().Add(time.Hour)
+	service.Update(nil, sp)
+
+	if ss, err := service.Search(nil, "33.755787", "-116.359998", "100000", "dist", false); err != nil || len(ss) != 0 {
+		t.Errorf("Expected search to leave out the taken spots, got %+v %v", ss, err)
+	}
+	ss, err := service.Search(nil, "33.755787", "-116.359998", "100000", "dist", true)
+	if err != nil || len(ss) != 2 || !ss[0].IsReserved || ss[1].TurnoverUntil.IsZero() {
+		t.Errorf("Expected every spot in range to be flagged, got %+v %v", ss, err)
+	}
+}
+
+func TestSetReserved(t *testing.T) {
+	inMemStore, _ := NewInMemParkingStore()
+	service := NewService(inMemStore)
+
+	if sp, err := service.SetReserved(nil, "1", true, time.Time{}); err != nil || !sp.IsReserved {
+		t.Fatalf("Failed to reserve a free spot: %+v %v", sp, err)
+	}
+	if _, err := service.SetReserved(nil, "1", true, time.Time{}); err != ErrAlreadyReserved {
+		t.Errorf("Expected reserving a reserved spot to conflict, got %v", err)
+	}
+	until := time.Now().Add(time.Hour)
+	if sp, err := service.SetReserved(nil, "1", false, until); err != nil || sp.IsReserved || !sp.TurnoverUntil.Equal(until) {
+		t.Errorf("Failed to free the spot into a turnover: %+v %v", sp, err)
+	}
+	if _, err := service.SetReserved(nil, "1", false, time.Time{}); err != ErrNotReserved {
+		t.Errorf("Expected freeing a free spot to conflict, got %v", err)
+	}
+	if _, err := service.SetReserved(nil, "42", true, time.Time{}); err != ErrNotFound {
+		t.Errorf("Expected an unknown spot not to be found, got %v", err)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	inMemStore, _ := NewInMemParkingStore()
+	service := NewService(inMemStore)
+
+	for _, sc := range []Schedule{
+		{SpotId: 1, Facility: "lakeside"},
+		{TimeZone: "Mars/Olympus"},
+		{Hours: []OpeningHours{{Day: "someday", Open: "08:00", Close: "18:00"}}},
+		{Hours: []OpeningHours{{Day: "monday", Open: "18:00", Close: "08:00"}}},
+		{Holidays: []string{"01/01/2030"}},
+		{SpotId: 9},
+	} {
+		if _, err := service.SetSchedule(nil, sc); err == nil {
+			t.Errorf("Expected %+v to be refused", sc)
+		}
+	}
+
+	// 2030-01-07 is a monday, 2030-01-01 a tuesday
+	sc := Schedule{
+		TimeZone: "America/Los_Angeles",
+		Hours:    []OpeningHours{{Day: "monday", Open: "08:00", Close: "18:00"}, {Day: "tuesday", Open: "00:00", Close: "24:00"}},
+		Holidays: []string{"2030-01-01"},
+		Blackouts: []Blackout{{
+			From:  time.Date(2030, 1, 7, 20, 0, 0, 0, time.UTC),
+			Until: time.Date(2030, 1, 7, 22, 0, 0, 0, time.UTC),
+		}},
+	}
+	la, _ := time.LoadLocation(sc.TimeZone)
+	for at, open := range map[time.Time]bool{
+		time.Date(2030, 1, 7, 7, 59, 0, 0, la):  false,
+		time.Date(2030, 1, 7, 8, 0, 0, 0, la):   true,
+		time.Date(2030, 1, 7, 12, 30, 0, 0, la): false, // 20:30 UTC, blacked out
+		time.Date(2030, 1, 7, 18, 0, 0, 0, la):  false,
+		time.Date(2030, 1, 8, 23, 59, 0, 0, la): true,
+		time.Date(2030, 1, 1, 12, 0, 0, 0, la):  false, // holiday
+		time.Date(2030, 1, 9, 12, 0, 0, 0, la):  false,
+	} {
+		if sc.OpenAt(at) != open {
+			t.Errorf("Expected open at %v to be %v", at, open)
+		}
+	}
+
+	for _, w := range []struct {
+		from, until time.Time
+		closedAt    time.Time
+	}{
+		{time.Date(2030, 1, 7, 9, 0, 0, 0, la), time.Date(2030, 1, 7, 12, 0, 0, 0, la), time.Time{}},
+		// closed overnight from monday to tuesday
+		{time.Date(2030, 1, 7, 17, 0, 0, 0, la), time.Date(2030, 1, 8, 9, 0, 0, 0, la), time.Date(2030, 1, 7, 18, 0, 0, 0, la)},
+		// tuesday runs into a closed wednesday
+		{time.Date(2030, 1, 8, 9, 0, 0, 0, la), time.Date(2030, 1, 9, 0, 0, 0, 0, la), time.Time{}},
+		{time.Date(2030, 1, 8, 9, 0, 0, 0, la), time.Date(2030, 1, 9, 1, 0, 0, 0, la), time.Date(2030, 1, 9, 0, 0, 0, 0, la)},
+		// the blackout
+		{time.Date(2030, 1, 7, 11, 0, 0, 0, la), time.Date(2030, 1, 7, 13, 0, 0, 0, la), time.Date(2030, 1, 7, 20, 0, 0, 0, time.UTC)},
+	} {
+		at, ok := sc.OpenThroughout(w.from, w.until)
+		if ok != w.closedAt.IsZero() || !at.Equal(w.closedAt) {
+			t.Errorf("Expected %v until %v to close at %v, got %v %v", w.from, w.until, w.closedAt, at, ok)
+		}
+	}
+	holiday := Schedule{Holidays: []string{"2030-01-08"}}
+	if at, ok := holiday.OpenThroughout(time.Date(2030, 1, 7, 12, 0, 0, 0, time.UTC), time.Date(2030, 1, 9, 12, 0, 0, 0, time.UTC)); ok || !at.Equal(time.Date(2030, 1, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected a window across a holiday to close on it, got %v %v", at, ok)
+	}
+
+	// spot 1 is closed now, spot 3 closed by its facility
+	now := time.Now()
+	closed := []Blackout{{From: now.Add(-time.Hour), Until: now.Add(time.Hour), Reason: "resurfacing"}}
+	if _, err := service.SetSchedule(nil, Schedule{SpotId: 1, Blackouts: closed}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.SetSchedule(nil, Schedule{Facility: "desert-ramp", Blackouts: closed}); err != nil {
+		t.Fatal(err)
+	}
+	if ss, err := service.Schedules(nil); err != nil || len(ss) != 2 {
+		t.Errorf("Expected two schedules, got %+v %v", ss, err)
+	}
+	if got, err := service.Schedule(nil, "3"); err != nil || got.Facility != "desert-ramp" {
+		t.Errorf("Expected spot 3 to fall back to the schedule of its facility, got %+v %v", got, err)
+	}
+	if got, err := service.Schedule(nil, "4"); err != nil || !got.OpenAt(now) {
+		t.Errorf("Expected spot 4 to be open around the clock, got %+v %v", got, err)
+	}
+	if ss, err := service.GetFree(nil); err != nil || len(ss) != 3 {
+		t.Errorf("Expected the closed spots not to be free, got %v %v", ss, err)
+	}
+	if ss, err := service.Search(nil, "33.755787", "-116.359998", "10000", "cost", false); err != nil || len(ss) != 0 {
+		t.Errorf("Expected search to leave out the closed spot 3, got %+v %v", ss, err)
+	}
+	ss, err := service.Search(nil, "33.755787", "-116.359998", "10000", "cost", true)
+	if err != nil || len(ss) != 1 || ss[0].ID != 3 || ss[0].Open {
+		t.Errorf("Expected search to flag spot 3 closed, got %+v %v", ss, err)
+	}
+}
@@ -3,27 +3,31 @@ package parking
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
 	"github.com/go-kit/kit/log"
 	httptransport "github.com/go-kit/kit/transport/http"
 )
 
 var (
-	ErrBadRouting   = errors.New("inconsistent mapping between route and handler (programmer error)")
-	ErrInvalidParam = errors.New("invalid param")
+	ErrBadRouting   = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+	ErrInvalidParam = apierror.New(apierror.Invalid, "invalid_param", "invalid param").
+			WithField("metric", "must be cost or dist")
 )
 
 // MakeHTTPHandler mounts all of the service endpoints into an http.Handler.
-func MakeHTTPHandler(s Service, logger log.Logger) http.Handler {
+// The roles allowed on each route are enforced by a.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
 	r := mux.NewRouter()
-	e := MakeServerEndpoints(s)
+	e := MakeAuthorizedEndpoints(s, a)
 	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
 		httptransport.ServerErrorLogger(logger),
-		httptransport.ServerErrorEncoder(encodeError),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
 	}
 
 	r.Methods("GET").Path("/parking/v1/getAll/").Handler(httptransport.NewServer(
@@ -56,12 +60,52 @@ func MakeHTTPHandler(s Service, logger log.Logger) http.Handler {
 		encodeResponse,
 		options...,
 	))
+	r.Methods("POST").Path("/parking/v1/").Handler(httptransport.NewServer(
+		e.CreateParkingEndpoint,
+		decodeUpdateRequest,
+		encodeResponse,
+		options...,
+	))
 	r.Methods("PUT").Path("/parking/v1/").Handler(httptransport.NewServer(
 		e.UpdateParkingEndpoint,
 		decodeUpdateRequest,
 		encodeResponse,
 		options...,
 	))
+	r.Methods("PUT").Path("/parking/v1/{id}/reserved").Handler(httptransport.NewServer(
+		e.SetReservedEndpoint,
+		decodeSetReservedRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("PUT").Path("/parking/v1/{id}/occupancy").Handler(httptransport.NewServer(
+		e.SetOccupancyEndpoint,
+		decodeSetOccupancyRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/parking/v1/schedules").Handler(httptransport.NewServer(
+		e.SchedulesEndpoint,
+		decodeGetRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("PUT").Path("/parking/v1/schedules").Handler(httptransport.NewServer(
+		e.SetScheduleEndpoint,
+		decodeSetScheduleRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/parking/v1/{id}/schedule").Handler(httptransport.NewServer(
+		e.ScheduleEndpoint,
+		decodeFindRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/parking/v1/events").Handler(eventsHandler{
+		subscribe: e.SubscribeEndpoint,
+		logger:    logger,
+	})
 	return r
 }
 
@@ -73,6 +117,40 @@ func decodeUpdateRequest(_ context.Context, r *http.Request) (request interface{
 	return req, nil
 }
 
+func decodeSetReservedRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	var req setReservedRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.ID = id
+	return req, nil
+}
+
+func decodeSetOccupancyRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	id, ok := mux.Vars(r)["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	var req setOccupancyRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.ID = id
+	return req, nil
+}
+
+func decodeSetScheduleRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req Schedule
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
 func decodeSearchRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
 	var req searchParkingRequest
 	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
@@ -114,29 +192,9 @@ func encodeResponse(ctx context.Context, w http.ResponseWriter, response interfa
 	if e, ok := response.(errorer); ok && e.error() != nil {
 		// Not a Go kit transport error, but a business-logic error.
 		// Provide those as HTTP errors.
-		encodeError(ctx, e.error(), w)
+		apierror.EncodeError(ctx, e.error(), w)
 		return nil
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	return json.NewEncoder(w).Encode(response)
 }
-
-func encodeError(_ context.Context, err error, w http.ResponseWriter) {
-	if err == nil {
-		panic("encodeError with nil error")
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(codeFrom(err))
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": err.Error(),
-	})
-}
-
-func codeFrom(err error) int {
-	switch err {
-	case ErrNotFound:
-		return http.StatusNotFound
-	default:
-		return http.StatusInternalServerError
-	}
-}
@@ -0,0 +1,150 @@
+package parking
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+)
+
+// The spot changes are streamed as server-sent events. Every event carries
+// the change ID, which browsers send back as Last-Event-ID on reconnect.
+
+const (
+	LastEventIDHeader = "Last-Event-ID"
+	// eventsKeepAlive is the interval of the comments that keep idle
+	// connections open through proxies
+	eventsKeepAlive = 15 * time.Second
+	// ResetEvent tells the client that changes were missed and it has to
+	// reload the spots
+	ResetEvent = "reset"
+)
+
+type eventsHandler struct {
+	subscribe endpoint.Endpoint
+	logger    log.Logger
+}
+
+func (h eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := auth.HTTPToContext(r.Context(), r)
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		apierror.EncodeError(ctx, ErrInternal, w)
+		return
+	}
+	req, err := decodeSubscribeRequest(r)
+	if err != nil {
+		apierror.EncodeError(ctx, err, w)
+		return
+	}
+	resp, err := h.subscribe(ctx, req)
+	if err != nil {
+		h.logger.Log("err", err)
+		apierror.EncodeError(ctx, err, w)
+		return
+	}
+	sub := resp.(subscribeResponse).Sub
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if sub.Truncated {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", ResetEvent)
+	}
+	for _, c := range sub.Backlog {
+		if err := writeEvent(w, c); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case c, ok := <-sub.C:
+			if !ok {
+				// dropped for falling behind, the client resumes from the
+				// last ID it saw
+				return
+			}
+			if err := writeEvent(w, c); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, c Change) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", c.ID, c.Type, data)
+	return err
+}
+
+// decodeSubscribeRequest reads the resume point from the Last-Event-ID
+// header, or the lastEventId parameter for the first connection, and the
+// filter from the ids and bbox parameters
+func decodeSubscribeRequest(r *http.Request) (subscribeRequest, error) {
+	var req subscribeRequest
+	q := r.URL.Query()
+
+	last := r.Header.Get(LastEventIDHeader)
+	if last == "" {
+		last = q.Get("lastEventId")
+	}
+	if last != "" {
+		after, err := strconv.ParseUint(last, 10, 64)
+		if err != nil {
+			return req, ErrInvalidReq.WithField("lastEventId", "must be an event id")
+		}
+		req.After = after
+	}
+
+	if ids := q.Get("ids"); ids != "" {
+		for _, s := range strings.Split(ids, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return req, ErrInvalidReq.WithField("ids", "must be comma separated spot ids")
+			}
+			req.Filter.IDs = append(req.Filter.IDs, id)
+		}
+	}
+
+	if bbox := q.Get("bbox"); bbox != "" {
+		parts := strings.Split(bbox, ",")
+		if len(parts) != 4 {
+			return req, ErrInvalidReq.WithField("bbox", "must be minLat,minLon,maxLat,maxLon")
+		}
+		var v [4]float64
+		for i, s := range parts {
+			f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return req, ErrInvalidReq.WithField("bbox", "must be minLat,minLon,maxLat,maxLon")
+			}
+			v[i] = f
+		}
+		if v[0] > v[2] || v[1] > v[3] {
+			return req, ErrInvalidReq.WithField("bbox", "minimum exceeds maximum")
+		}
+		req.Filter.BBox = &BBox{MinLat: v[0], MinLon: v[1], MaxLat: v[2], MaxLon: v[3]}
+	}
+	return req, nil
+}
@@ -0,0 +1,448 @@
+package parking
+
+import (
+	"context"
+	"time"
+
+	oldcontext "golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/parking/pb"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+)
+
+type grpcServer struct {
+	getAll       grpctransport.Handler
+	getFree      grpctransport.Handler
+	getReserved  grpctransport.Handler
+	search       grpctransport.Handler
+	findById     grpctransport.Handler
+	create       grpctransport.Handler
+	update       grpctransport.Handler
+	setReserved  grpctransport.Handler
+	setOccupancy grpctransport.Handler
+	setSchedule  grpctransport.Handler
+	schedules    grpctransport.Handler
+	schedule     grpctransport.Handler
+
+	// subscribe streams, which go-kit's gRPC server doesn't, so Subscribe
+	// calls the endpoint itself
+	subscribe endpoint.Endpoint
+	logger    log.Logger
+}
+
+// MakeGRPCServer serves the endpoints of MakeAuthorizedEndpoints over gRPC
+func MakeGRPCServer(s Service, a *auth.Authorizer, logger log.Logger) pb.ParkingServiceServer {
+	e := MakeAuthorizedEndpoints(s, a)
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerBefore(auth.GRPCToContext),
+		grpctransport.ServerErrorLogger(logger),
+	}
+	return &grpcServer{
+		getAll:       grpctransport.NewServer(e.GetAllParkingEndpoint, decodeGRPCGetRequest, encodeGRPCSpotsResponse, options...),
+		getFree:      grpctransport.NewServer(e.GetFreeParkingEndpoint, decodeGRPCGetRequest, encodeGRPCSpotsResponse, options...),
+		getReserved:  grpctransport.NewServer(e.GetReservedParkingEndpoint, decodeGRPCGetRequest, encodeGRPCSpotsResponse, options...),
+		search:       grpctransport.NewServer(e.SearchParkingEndpoint, decodeGRPCSearchRequest, encodeGRPCSearchResponse, options...),
+		findById:     grpctransport.NewServer(e.FindByIdParkingEndpoint, decodeGRPCFindRequest, encodeGRPCSpotsResponse, options...),
+		create:       grpctransport.NewServer(e.CreateParkingEndpoint, decodeGRPCCreateRequest, encodeGRPCCreateResponse, options...),
+		update:       grpctransport.NewServer(e.UpdateParkingEndpoint, decodeGRPCUpdateRequest, encodeGRPCUpdateResponse, options...),
+		setReserved:  grpctransport.NewServer(e.SetReservedEndpoint, decodeGRPCSetReservedRequest, encodeGRPCSetReservedResponse, options...),
+		setOccupancy: grpctransport.NewServer(e.SetOccupancyEndpoint, decodeGRPCSetOccupancyRequest, encodeGRPCSetOccupancyResponse, options...),
+		setSchedule:  grpctransport.NewServer(e.SetScheduleEndpoint, decodeGRPCSetScheduleRequest, encodeGRPCScheduleResponse, options...),
+		schedules:    grpctransport.NewServer(e.SchedulesEndpoint, decodeGRPCSchedulesRequest, encodeGRPCSchedulesResponse, options...),
+		schedule:     grpctransport.NewServer(e.ScheduleEndpoint, decodeGRPCScheduleRequest, encodeGRPCScheduleResponse, options...),
+		subscribe:    e.SubscribeEndpoint,
+		logger:       logger,
+	}
+}
+
+func (s *grpcServer) GetAll(ctx oldcontext.Context, req *pb.GetSpotsRequest) (*pb.SpotsReply, error) {
+	_, rep, err := s.getAll.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SpotsReply), nil
+}
+
+func (s *grpcServer) GetFree(ctx oldcontext.Context, req *pb.GetSpotsRequest) (*pb.SpotsReply, error) {
+	_, rep, err := s.getFree.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SpotsReply), nil
+}
+
+func (s *grpcServer) GetReserved(ctx oldcontext.Context, req *pb.GetSpotsRequest) (*pb.SpotsReply, error) {
+	_, rep, err := s.getReserved.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SpotsReply), nil
+}
+
+func (s *grpcServer) Search(ctx oldcontext.Context, req *pb.SearchRequest) (*pb.SearchReply, error) {
+	_, rep, err := s.search.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SearchReply), nil
+}
+
+func (s *grpcServer) FindById(ctx oldcontext.Context, req *pb.FindByIdRequest) (*pb.SpotsReply, error) {
+	_, rep, err := s.findById.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SpotsReply), nil
+}
+
+func (s *grpcServer) Create(ctx oldcontext.Context, req *pb.CreateRequest) (*pb.CreateReply, error) {
+	_, rep, err := s.create.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.CreateReply), nil
+}
+
+func (s *grpcServer) Update(ctx oldcontext.Context, req *pb.UpdateRequest) (*pb.UpdateReply, error) {
+	_, rep, err := s.update.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.UpdateReply), nil
+}
+
+func (s *grpcServer) SetReserved(ctx oldcontext.Context, req *pb.SetReservedRequest) (*pb.SetReservedReply, error) {
+	_, rep, err := s.setReserved.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SetReservedReply), nil
+}
+
+func (s *grpcServer) SetOccupancy(ctx oldcontext.Context, req *pb.SetOccupancyRequest) (*pb.SetOccupancyReply, error) {
+	_, rep, err := s.setOccupancy.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SetOccupancyReply), nil
+}
+
+func (s *grpcServer) SetSchedule(ctx oldcontext.Context, req *pb.SetScheduleRequest) (*pb.ScheduleReply, error) {
+	_, rep, err := s.setSchedule.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.ScheduleReply), nil
+}
+
+func (s *grpcServer) Schedules(ctx oldcontext.Context, req *pb.SchedulesRequest) (*pb.SchedulesReply, error) {
+	_, rep, err := s.schedules.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.SchedulesReply), nil
+}
+
+func (s *grpcServer) Schedule(ctx oldcontext.Context, req *pb.ScheduleRequest) (*pb.ScheduleReply, error) {
+	_, rep, err := s.schedule.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.ScheduleReply), nil
+}
+
+// Subscribe sends the changes like the events stream of MakeHTTPHandler: a
+// reset first when changes were missed, then the backlog and the live
+// changes until the client goes away or falls behind.
+func (s *grpcServer) Subscribe(req *pb.SubscribeRequest, stream pb.ParkingService_SubscribeServer) error {
+	ctx := stream.Context()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = auth.GRPCToContext(ctx, md)
+	}
+	r, err := decodeGRPCSubscribeRequest(ctx, req)
+	if err != nil {
+		return apierror.GRPCError(err)
+	}
+	resp, err := s.subscribe(ctx, r)
+	if err != nil {
+		s.logger.Log("err", err)
+		return apierror.GRPCError(err)
+	}
+	sub := resp.(subscribeResponse).Sub
+	defer sub.Close()
+
+	if sub.Truncated {
+		if err := stream.Send(&pb.Change{Type: ResetEvent}); err != nil {
+			return err
+		}
+	}
+	for _, c := range sub.Backlog {
+		if err := stream.Send(changeToPB(c)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case c, ok := <-sub.C:
+			if !ok {
+				// dropped for falling behind, the client resumes after the
+				// last ID it saw
+				return nil
+			}
+			if err := stream.Send(changeToPB(c)); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func decodeGRPCGetRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return getAllParkingRequest{}, nil
+}
+
+func decodeGRPCSearchRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SearchRequest)
+	sr := searchParkingRequest{Lat: req.Lat, Lon: req.Lon, Rad: req.Rad, Metric: SearchMetric(req.Metric), IncludeClosed: req.IncludeClosed}
+	if sr.Metric != COST && sr.Metric != DIST {
+		return nil, ErrInvalidParam
+	}
+	return sr, nil
+}
+
+func decodeGRPCFindRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.FindByIdRequest)
+	return findByIdParkingRequest{ID: req.Id}, nil
+}
+
+func decodeGRPCCreateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.CreateRequest)
+	return updateParkingRequest{Spot: spotFromPB(req.Spot)}, nil
+}
+
+func decodeGRPCUpdateRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.UpdateRequest)
+	return updateParkingRequest{Spot: spotFromPB(req.Spot)}, nil
+}
+
+func decodeGRPCSetReservedRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SetReservedRequest)
+	return setReservedRequest{ID: req.Id, Reserved: req.Reserved, TurnoverUntil: fromUnixNano(req.TurnoverUntil)}, nil
+}
+
+func decodeGRPCSetOccupancyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SetOccupancyRequest)
+	return setOccupancyRequest{ID: req.Id, Occupancy: Occupancy(req.Occupancy), At: fromUnixNano(req.At)}, nil
+}
+
+func decodeGRPCSetScheduleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.SetScheduleRequest)
+	return scheduleFromPB(req.Schedule), nil
+}
+
+func decodeGRPCSchedulesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeGRPCScheduleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*pb.ScheduleRequest)
+	return findByIdParkingRequest{ID: req.Id}, nil
+}
+
+func decodeGRPCSubscribeRequest(_ context.Context, req *pb.SubscribeRequest) (subscribeRequest, error) {
+	r := subscribeRequest{After: req.After}
+	for _, id := range req.Ids {
+		r.Filter.IDs = append(r.Filter.IDs, int(id))
+	}
+	if b := req.Bbox; b != nil {
+		if b.MinLat > b.MaxLat || b.MinLon > b.MaxLon {
+			return r, ErrInvalidReq.WithField("bbox", "minimum exceeds maximum")
+		}
+		r.Filter.BBox = &BBox{MinLat: b.MinLat, MinLon: b.MinLon, MaxLat: b.MaxLat, MaxLon: b.MaxLon}
+	}
+	return r, nil
+}
+
+// The GetAll, GetFree, GetReserved and FindById endpoints all return a list
+// of spots, each with its own response type.
+func encodeGRPCSpotsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	var ss []Spot
+	switch r := response.(type) {
+	case getAllParkingResponse:
+		ss = r.Spots
+	case getFreeParkingResponse:
+		ss = r.Spots
+	case getReservedParkingResponse:
+		ss = r.Spots
+	}
+	rep := &pb.SpotsReply{Spots: make([]*pb.Spot, 0, len(ss))}
+	for _, sp := range ss {
+		rep.Spots = append(rep.Spots, spotToPB(sp))
+	}
+	return rep, nil
+}
+
+func encodeGRPCSearchResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(getSearchParkingResponse)
+	rep := &pb.SearchReply{Spots: make([]*pb.ExtendedSpot, 0, len(resp.Spots))}
+	for _, esp := range resp.Spots {
+		rep.Spots = append(rep.Spots, ExtendedSpotToPB(esp))
+	}
+	return rep, nil
+}
+
+func encodeGRPCCreateResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(updateParkingResponse)
+	return &pb.CreateReply{Spot: spotToPB(resp.Spot)}, nil
+}
+
+func encodeGRPCUpdateResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(updateParkingResponse)
+	return &pb.UpdateReply{Spot: spotToPB(resp.Spot)}, nil
+}
+
+func encodeGRPCSetReservedResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(updateParkingResponse)
+	return &pb.SetReservedReply{Spot: spotToPB(resp.Spot)}, nil
+}
+
+func encodeGRPCSetOccupancyResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(updateParkingResponse)
+	return &pb.SetOccupancyReply{Spot: spotToPB(resp.Spot)}, nil
+}
+
+func encodeGRPCScheduleResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(scheduleResponse)
+	return &pb.ScheduleReply{Schedule: scheduleToPB(resp.Schedule)}, nil
+}
+
+func encodeGRPCSchedulesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(schedulesResponse)
+	rep := &pb.SchedulesReply{Schedules: make([]*pb.Schedule, 0, len(resp.Schedules))}
+	for _, sc := range resp.Schedules {
+		rep.Schedules = append(rep.Schedules, scheduleToPB(sc))
+	}
+	return rep, nil
+}
+
+// ExtendedSpotToPB converts a search result for the gRPC servers of the
+// services that return one
+func ExtendedSpotToPB(esp ExtendedSpot) *pb.ExtendedSpot {
+	return &pb.ExtendedSpot{Spot: spotToPB(esp.Spot), Distance: esp.Distance, Open: esp.Open}
+}
+
+func changeToPB(c Change) *pb.Change {
+	return &pb.Change{Id: c.ID, Type: string(c.Type), Spot: spotToPB(c.Spot), At: unixNano(c.At)}
+}
+
+func spotToPB(sp Spot) *pb.Spot {
+	p := &pb.Spot{
+		Id:            int64(sp.ID),
+		Lat:           sp.Lat,
+		Lon:           sp.Lon,
+		Cost:          sp.Cost,
+		IsReserved:    sp.IsReserved,
+		Address:       sp.Address,
+		Facility:      sp.Facility,
+		Occupancy:     string(sp.Occupancy),
+		OccupancyAt:   unixNano(sp.OccupancyAt),
+		TurnoverUntil: unixNano(sp.TurnoverUntil),
+	}
+	for _, c := range sp.Classes {
+		p.Classes = append(p.Classes, string(c))
+	}
+	if sp.MaxSize != nil {
+		p.MaxSize = &pb.Dimensions{
+			Length: int32(sp.MaxSize.Length),
+			Width:  int32(sp.MaxSize.Width),
+			Height: int32(sp.MaxSize.Height),
+		}
+	}
+	return p
+}
+
+func spotFromPB(p *pb.Spot) Spot {
+	if p == nil {
+		return Spot{}
+	}
+	sp := Spot{
+		ID:            int(p.Id),
+		Lat:           p.Lat,
+		Lon:           p.Lon,
+		Cost:          p.Cost,
+		IsReserved:    p.IsReserved,
+		Address:       p.Address,
+		Facility:      p.Facility,
+		Occupancy:     Occupancy(p.Occupancy),
+		OccupancyAt:   fromUnixNano(p.OccupancyAt),
+		TurnoverUntil: fromUnixNano(p.TurnoverUntil),
+	}
+	for _, c := range p.Classes {
+		sp.Classes = append(sp.Classes, VehicleClass(c))
+	}
+	if p.MaxSize != nil {
+		sp.MaxSize = &Dimensions{
+			Length: int(p.MaxSize.Length),
+			Width:  int(p.MaxSize.Width),
+			Height: int(p.MaxSize.Height),
+		}
+	}
+	return sp
+}
+
+func scheduleToPB(sc Schedule) *pb.Schedule {
+	p := &pb.Schedule{
+		SpotId:   int64(sc.SpotId),
+		Facility: sc.Facility,
+		TimeZone: sc.TimeZone,
+		Holidays: sc.Holidays,
+	}
+	for _, h := range sc.Hours {
+		p.Hours = append(p.Hours, &pb.OpeningHours{Day: h.Day, Open: h.Open, Close: h.Close})
+	}
+	for _, b := range sc.Blackouts {
+		p.Blackouts = append(p.Blackouts, &pb.Blackout{From: unixNano(b.From), Until: unixNano(b.Until), Reason: b.Reason})
+	}
+	return p
+}
+
+func scheduleFromPB(p *pb.Schedule) Schedule {
+	if p == nil {
+		return Schedule{}
+	}
+	sc := Schedule{
+		SpotId:   int(p.SpotId),
+		Facility: p.Facility,
+		TimeZone: p.TimeZone,
+		Holidays: p.Holidays,
+	}
+	for _, h := range p.Hours {
+		sc.Hours = append(sc.Hours, OpeningHours{Day: h.Day, Open: h.Open, Close: h.Close})
+	}
+	for _, b := range p.Blackouts {
+		sc.Blackouts = append(sc.Blackouts, Blackout{From: fromUnixNano(b.From), Until: fromUnixNano(b.Until), Reason: b.Reason})
+	}
+	return sc
+}
+
+// unixNano and fromUnixNano map the zero time to 0 and back, the times of
+// the messages being Unix nanoseconds
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func fromUnixNano(n int64) time.Time {
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
@@ -0,0 +1,312 @@
+package parking
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/parking/pb"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+type nopCounter struct{}
+
+func (c nopCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c nopCounter) Add(delta float64)                          {}
+
+// TestTransports drives the HTTP and gRPC transports against the same service
+func TestTransports(t *testing.T) {
+	inMemStore, _ := NewInMemParkingStore()
+	service := NewService(inMemStore)
+
+	tokens := auth.NewHMACTokens([]byte("secret"))
+	a := auth.NewAuthorizer(auth.NewAuthenticator(tokens, nil), log.NewNopLogger(), nopCounter{})
+	token, _ := tokens.Issue(auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}}, time.Minute)
+
+	httpServer := httptest.NewServer(MakeHTTPHandler(service, a, log.NewNopLogger()))
+	defer httpServer.Close()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Failed to listen for gRPC")
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterParkingServiceServer(grpcServer, MakeGRPCServer(service, a, log.NewNopLogger()))
+	go grpcServer.Serve(ln)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal("Failed to dial gRPC server")
+	}
+	defer conn.Close()
+	client := pb.NewParkingServiceClient(conn)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+
+	// reserve over HTTP, observe over gRPC
+	body, _ := json.Marshal(updateParkingRequest{Spot: Spot{ID: 2, IsReserved: true}})
+	req, _ := http.NewRequest("PUT", httpServer.URL+"/parking/v1/", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatal("Failed to update spot over HTTP")
+	}
+	resp.Body.Close()
+
+	reserved, err := client.GetReserved(ctx, &pb.GetSpotsRequest{})
+	if err != nil {
+		t.Fatal("Failed to get reserved spots over gRPC")
+	}
+	if len(reserved.Spots) != 1 || reserved.Spots[0].Id != 2 {
+		t.Error("gRPC does not see the spot reserved over HTTP")
+	}
+
+	// reserve over gRPC, observe over HTTP
+	if _, err := client.Update(ctx, &pb.UpdateRequest{Spot: &pb.Spot{Id: 3, IsReserved: true}}); err != nil {
+		t.Fatal("Failed to update spot over gRPC")
+	}
+	req, _ = http.NewRequest("GET", httpServer.URL+"/parking/v1/getReserved/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal("Failed to get reserved spots over HTTP")
+	}
+	var got getReservedParkingResponse
+	json.NewDecoder(resp.Body).Decode(&got)
+	resp.Body.Close()
+	if len(got.Spots) != 2 {
+		t.Error("HTTP does not see the spot reserved over gRPC")
+	}
+
+	_, err = client.FindById(ctx, &pb.FindByIdRequest{Id: "10"})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Expecting NotFound for an unknown spot over gRPC")
+	}
+	_, err = client.GetAll(context.Background(), &pb.GetSpotsRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Error("Expecting anonymous gRPC call to be unauthenticated")
+	}
+
+	// a spot added over gRPC keeps its facility and turnover over HTTP
+	turnover := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	created, err := client.Create(ctx, &pb.CreateRequest{Spot: &pb.Spot{Lat: "44.9", Lon: "-93.2", Cost: "100", Facility: "garage", TurnoverUntil: turnover.UnixNano()}})
+	if err != nil {
+		t.Fatal("Failed to create spot over gRPC")
+	}
+	req, _ = http.NewRequest("GET", httpServer.URL+"/parking/v1/find/"+strconv.Itoa(int(created.Spot.Id)), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal("Failed to find spot over HTTP")
+	}
+	var found getAllParkingResponse
+	json.NewDecoder(resp.Body).Decode(&found)
+	resp.Body.Close()
+	if len(found.Spots) != 1 || found.Spots[0].Facility != "garage" || !found.Spots[0].TurnoverUntil.Equal(turnover) {
+		t.Errorf("HTTP does not see the spot created over gRPC, got %+v", found.Spots)
+	}
+
+	id := strconv.Itoa(int(created.Spot.Id))
+	if _, err := client.SetReserved(ctx, &pb.SetReservedRequest{Id: id, Reserved: true}); err != nil {
+		t.Fatal("Failed to reserve spot over gRPC")
+	}
+	if _, err := client.SetReserved(ctx, &pb.SetReservedRequest{Id: id, Reserved: true}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expecting a reserved spot to fail with FailedPrecondition, got %v", err)
+	}
+
+	sc, err := client.SetSchedule(ctx, &pb.SetScheduleRequest{Schedule: &pb.Schedule{Facility: "garage", TimeZone: "UTC", Hours: []*pb.OpeningHours{{Day: "monday", Open: "08:00", Close: "18:00"}}}})
+	if err != nil {
+		t.Fatalf("Failed to set schedule over gRPC: %v", err)
+	}
+	if got, err := client.Schedule(ctx, &pb.ScheduleRequest{Id: id}); err != nil || got.Schedule.Facility != "garage" || len(got.Schedule.Hours) != 1 {
+		t.Errorf("Expecting the schedule of the facility for the spot, got %+v %v", got, err)
+	}
+	if _, err := client.SetSchedule(ctx, &pb.SetScheduleRequest{Schedule: &pb.Schedule{TimeZone: "Nowhere/City"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expecting an invalid schedule to fail with InvalidArgument, got %v", err)
+	}
+	schedules, err := client.Schedules(ctx, &pb.SchedulesRequest{})
+	if err != nil || len(schedules.Schedules) != 1 || schedules.Schedules[0].TimeZone != sc.Schedule.TimeZone {
+		t.Errorf("Expecting the schedule set over gRPC, got %+v %v", schedules, err)
+	}
+
+	// resume after the reservation made over HTTP: the backlog replays the
+	// one made over gRPC, then changes are streamed as they happen
+	stream, err := client.Subscribe(ctx, &pb.SubscribeRequest{After: 1, Ids: []int64{3}})
+	if err != nil {
+		t.Fatal("Failed to subscribe over gRPC")
+	}
+	if c, err := stream.Recv(); err != nil || c.Id != 2 || c.Type != string(ChangeReserved) || c.Spot.Id != 3 {
+		t.Fatalf("Expecting reserved change 2 of spot 3, got %+v %v", c, err)
+	}
+	if _, err := client.SetOccupancy(ctx, &pb.SetOccupancyRequest{Id: "3", Occupancy: string(Occupied)}); err != nil {
+		t.Fatal("Failed to set occupancy over gRPC")
+	}
+	if c, err := stream.Recv(); err != nil || c.Type != string(ChangeOccupied) || c.Spot.Occupancy != string(Occupied) || c.Spot.OccupancyAt == 0 {
+		t.Errorf("Expecting spot 3 occupied, got %+v %v", c, err)
+	}
+	reset, err := client.Subscribe(ctx, &pb.SubscribeRequest{After: 100})
+	if err != nil {
+		t.Fatal("Failed to subscribe over gRPC")
+	}
+	if c, err := reset.Recv(); err != nil || c.Type != ResetEvent {
+		t.Errorf("Expecting a reset for an unknown change id, got %+v %v", c, err)
+	}
+	bad, err := client.Subscribe(ctx, &pb.SubscribeRequest{Bbox: &pb.BBox{MinLat: 45, MaxLat: 44}})
+	if err == nil {
+		_, err = bad.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expecting an inverted box to fail with InvalidArgument, got %v", err)
+	}
+
+	// the spots taken are only found when asked for
+	search := &pb.SearchRequest{Lat: "44.968046", Lon: "-94.420307", Rad: "1000000", Metric: string(DIST)}
+	open, err := client.Search(ctx, search)
+	if err != nil {
+		t.Fatal("Failed to search over gRPC")
+	}
+	search.IncludeClosed = true
+	all, err := client.Search(ctx, search)
+	if err != nil || len(all.Spots) <= len(open.Spots) {
+		t.Errorf("Expecting the taken spots to be included, got %d of %d", len(all.Spots), len(open.Spots))
+	}
+
+	// errors come back as problem+json with their field details
+	for _, tc := range []struct {
+		body   string
+		status int
+		code   string
+		field  string
+	}{
+		{`{"lat":"x","lon":"1","rad":"1","metric":"cost"}`, http.StatusBadRequest, "invalid_request", "lat"},
+		{`{"lat":"1","lon":"1","rad":"1","metric":"price"}`, http.StatusBadRequest, "invalid_param", "metric"},
+		{`{"lat":1}`, http.StatusBadRequest, "malformed_body", "lat"},
+	} {
+		req, _ = http.NewRequest("POST", httpServer.URL+"/parking/v1/search/", strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal("Failed to search over HTTP")
+		}
+		var p apierror.Problem
+		json.NewDecoder(resp.Body).Decode(&p)
+		resp.Body.Close()
+		if resp.StatusCode != tc.status || resp.Header.Get("Content-Type") != apierror.ProblemContentType {
+			t.Errorf("%s: expected a %d problem, got %d %s", tc.body, tc.status, resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		if p.Code != tc.code || len(p.Fields) != 1 || p.Fields[0].Field != tc.field {
+			t.Errorf("%s: expected %s on field %s, got %+v", tc.body, tc.code, tc.field, p)
+		}
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
+func TestEvents(t *testing.T) {
+	inMemStore, _ := NewInMemParkingStore()
+	service := NewService(inMemStore)
+
+	tokens := auth.NewHMACTokens([]byte("secret"))
+	a := auth.NewAuthorizer(auth.NewAuthenticator(tokens, nil), log.NewNopLogger(), nopCounter{})
+	token, _ := tokens.Issue(auth.Principal{Subject: "driver", Roles: []auth.Role{auth.RoleDriver}}, time.Minute)
+
+	srv := httptest.NewServer(MakeHTTPHandler(service, a, log.NewNopLogger()))
+	defer srv.Close()
+
+	stream := func(query, lastEventID string) *http.Response {
+		req, _ := http.NewRequest("GET", srv.URL+"/parking/v1/events"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if lastEventID != "" {
+			req.Header.Set(LastEventIDHeader, lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal("Failed to open the events stream")
+		}
+		return resp
+	}
+	// next reads an event as its id, event and data lines
+	next := func(r *bufio.Reader) []string {
+		var lines []string
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatal("Stream ended early")
+			}
+			if line == "\n" {
+				return lines
+			}
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+	}
+
+	resp := stream("?ids=2,3", "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	service.Update(context.Background(), Spot{ID: 1, IsReserved: true})
+	service.Update(context.Background(), Spot{ID: 2, IsReserved: true})
+
+	ev := next(bufio.NewReader(resp.Body))
+	if len(ev) != 3 || ev[0] != "id: 2" || ev[1] != "event: reserved" {
+		t.Fatalf("Expected reserved event 2, got %q", ev)
+	}
+	var c Change
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(ev[2], "data: ")), &c); err != nil || c.Spot.ID != 2 || !c.Spot.IsReserved {
+		t.Errorf("Unexpected event data %s", ev[2])
+	}
+
+	// resume after the first change within a box around spot 2
+	resumed := stream("?bbox=44,-90,45,-89", "1")
+	defer resumed.Body.Close()
+	if ev := next(bufio.NewReader(resumed.Body)); len(ev) != 3 || ev[0] != "id: 2" {
+		t.Errorf("Expected the missed event 2 to be replayed, got %q", ev)
+	}
+
+	reset := stream("", "100")
+	defer reset.Body.Close()
+	if ev := next(bufio.NewReader(reset.Body)); len(ev) != 2 || ev[0] != "event: "+ResetEvent {
+		t.Errorf("Expected a reset for an unknown event id, got %q", ev)
+	}
+
+	for query, field := range map[string]string{
+		"?bbox=1,2,3":          "bbox",
+		"?bbox=45,-90,44,-89":  "bbox",
+		"?ids=1,x":             "ids",
+		"?lastEventId=nothing": "lastEventId",
+	} {
+		resp := stream(query, "")
+		var p apierror.Problem
+		json.NewDecoder(resp.Body).Decode(&p)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest || len(p.Fields) != 1 || p.Fields[0].Field != field {
+			t.Errorf("%s: expected a 400 on field %s, got %d %+v", query, field, resp.StatusCode, p)
+		}
+	}
+
+	anon, err := http.Get(srv.URL + "/parking/v1/events")
+	if err != nil {
+		t.Fatal("Failed to call the events stream")
+	}
+	anon.Body.Close()
+	if anon.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without credentials, got %d", anon.StatusCode)
+	}
+}
@@ -0,0 +1,256 @@
+package qr
+
+// builder lays out a symbol, function marks the finder, timing, alignment,
+// format and version modules which hold no data and are never masked
+type builder struct {
+	*Code
+	function []bool
+}
+
+func newCode(version int, level Level) *builder {
+	size := version*4 + 17
+	b := &builder{
+		Code:     &Code{Version: version, Level: level, Size: size, modules: make([]bool, size*size)},
+		function: make([]bool, size*size),
+	}
+	for i := 0; i < size; i++ {
+		b.set(6, i, i%2 == 0)
+		b.set(i, 6, i%2 == 0)
+	}
+	b.finder(3, 3)
+	b.finder(size-4, 3)
+	b.finder(3, size-4)
+	pos := alignmentPositions(version)
+	for i, x := range pos {
+		for j, y := range pos {
+			// the corners hold the finder patterns
+			if i == 0 && j == 0 || i == 0 && j == len(pos)-1 || i == len(pos)-1 && j == 0 {
+				continue
+			}
+			b.alignment(x, y)
+		}
+	}
+	// reserve the format modules, they are drawn once the mask is known
+	b.drawFormat(0)
+	b.drawVersion()
+	return b
+}
+
+func (b *builder) set(x, y int, dark bool) {
+	b.modules[y*b.Size+x] = dark
+	b.function[y*b.Size+x] = true
+}
+
+func (b *builder) finder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= b.Size || y >= b.Size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			b.set(x, y, d != 2 && d != 4)
+		}
+	}
+}
+
+func (b *builder) alignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			b.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// alignmentPositions returns the centre coordinates of the alignment
+// patterns, on both axes
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	n := version/7 + 2
+	step := (version*4 + n*2 + 1) / (n*2 - 2) * 2
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, version*4+10; i > 0; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// drawFormat writes the level and mask, BCH protected, in both copies
+func (b *builder) drawFormat(mask int) {
+	data := formatLevel[b.Level]<<3 | uint(mask)
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>uint(i)&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		b.set(8, i, bit(i))
+	}
+	b.set(8, 7, bit(6))
+	b.set(8, 8, bit(7))
+	b.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		b.set(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		b.set(b.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		b.set(8, b.Size-15+i, bit(i))
+	}
+	b.set(8, b.Size-8, true)
+}
+
+// drawVersion writes the version, Golay protected, from version 7 on
+func (b *builder) drawVersion() {
+	if b.Version < 7 {
+		return
+	}
+	rem := uint(b.Version)
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	bits := uint(b.Version)<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 != 0
+		x, y := b.Size-11+i%3, i/3
+		b.set(x, y, dark)
+		b.set(y, x, dark)
+	}
+}
+
+// place fills the data modules with codewords in the zigzag order, two
+// columns at a time from the bottom right
+func (b *builder) place(codewords []byte) {
+	i := 0
+	for right := b.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// skip the vertical timing pattern
+			right = 5
+		}
+		for vert := 0; vert < b.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = b.Size - 1 - vert
+				}
+				if b.function[y*b.Size+x] || i >= len(codewords)*8 {
+					continue
+				}
+				b.modules[y*b.Size+x] = codewords[i/8]>>uint(7-i%8)&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+func (b *builder) applyMask(mask int) {
+	for y := 0; y < b.Size; y++ {
+		for x := 0; x < b.Size; x++ {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !b.function[y*b.Size+x] {
+				b.modules[y*b.Size+x] = !b.modules[y*b.Size+x]
+			}
+		}
+	}
+}
+
+// penalty scores the masked symbol by the rules of the standard: runs of
+// one color, 2x2 blocks, finder-like patterns and the dark/light balance
+func (c *Code) penalty() int {
+	p := 0
+	// rows and columns
+	for _, column := range []bool{false, true} {
+		for i := 0; i < c.Size; i++ {
+			at := func(j int) bool {
+				if column {
+					return c.Black(i, j)
+				}
+				return c.Black(j, i)
+			}
+			run := 1
+			for j := 1; j < c.Size; j++ {
+				if at(j) == at(j-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					p += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				p += run - 2
+			}
+			// 1:1:3:1:1 with four light modules on either side; outside
+			// the symbol counts as light
+			for j := -4; j < c.Size; j++ {
+				core := at(j+4) && !at(j+5) && at(j+6) && at(j+7) && at(j+8) && !at(j+9) && at(j+10)
+				if !core || j+10 >= c.Size {
+					continue
+				}
+				before := !at(j) && !at(j+1) && !at(j+2) && !at(j+3)
+				after := !at(j+11) && !at(j+12) && !at(j+13) && !at(j+14)
+				if before || after {
+					p += 40
+				}
+			}
+		}
+	}
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Black(x, y) {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size {
+				v := c.Black(x, y)
+				if v == c.Black(x+1, y) && v == c.Black(x, y+1) && v == c.Black(x+1, y+1) {
+					p += 3
+				}
+			}
+		}
+	}
+	total := c.Size * c.Size
+	// every 5% away from half dark
+	p += abs(dark*20-total*10) / total * 10
+	return p
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
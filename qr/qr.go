@@ -0,0 +1,190 @@
+// Package qr encodes QR codes (ISO/IEC 18004) in byte mode, versions 1 to
+// 10, which hold up to 271 bytes at level L.
+package qr
+
+import (
+	"errors"
+)
+
+type Level int
+
+const (
+	// L recovers about 7% of the codewords, M 15%, Q 25% and H 30%
+	L Level = iota
+	M
+	Q
+	H
+)
+
+// MaxVersion is the largest symbol supported, 57 modules wide
+const MaxVersion = 10
+
+var ErrTooLong = errors.New("qr: data too long")
+
+// Code is an encoded symbol. Modules are read row by row, true is dark.
+type Code struct {
+	Version int
+	Level   Level
+	Mask    int
+	Size    int
+	modules []bool
+}
+
+// Black reports whether the module in column x of row y is dark
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y*c.Size+x]
+}
+
+// blockGroup is a run of error correction blocks with the same data length
+type blockGroup struct {
+	blocks, data int
+}
+
+// ecTable holds, per version and level, the error correction codewords of
+// every block and the block groups
+var ecTable = [MaxVersion + 1][4]struct {
+	ec     int
+	groups []blockGroup
+}{
+	1:  {{7, []blockGroup{{1, 19}}}, {10, []blockGroup{{1, 16}}}, {13, []blockGroup{{1, 13}}}, {17, []blockGroup{{1, 9}}}},
+	2:  {{10, []blockGroup{{1, 34}}}, {16, []blockGroup{{1, 28}}}, {22, []blockGroup{{1, 22}}}, {28, []blockGroup{{1, 16}}}},
+	3:  {{15, []blockGroup{{1, 55}}}, {26, []blockGroup{{1, 44}}}, {18, []blockGroup{{2, 17}}}, {22, []blockGroup{{2, 13}}}},
+	4:  {{20, []blockGroup{{1, 80}}}, {18, []blockGroup{{2, 32}}}, {26, []blockGroup{{2, 24}}}, {16, []blockGroup{{4, 9}}}},
+	5:  {{26, []blockGroup{{1, 108}}}, {24, []blockGroup{{2, 43}}}, {18, []blockGroup{{2, 15}, {2, 16}}}, {22, []blockGroup{{2, 11}, {2, 12}}}},
+	6:  {{18, []blockGroup{{2, 68}}}, {16, []blockGroup{{4, 27}}}, {24, []blockGroup{{4, 19}}}, {28, []blockGroup{{4, 15}}}},
+	7:  {{20, []blockGroup{{2, 78}}}, {18, []blockGroup{{4, 31}}}, {18, []blockGroup{{2, 14}, {4, 15}}}, {26, []blockGroup{{4, 13}, {1, 14}}}},
+	8:  {{24, []blockGroup{{2, 97}}}, {22, []blockGroup{{2, 38}, {2, 39}}}, {22, []blockGroup{{4, 18}, {2, 19}}}, {26, []blockGroup{{4, 14}, {2, 15}}}},
+	9:  {{30, []blockGroup{{2, 116}}}, {22, []blockGroup{{3, 36}, {2, 37}}}, {20, []blockGroup{{4, 16}, {4, 17}}}, {24, []blockGroup{{4, 12}, {4, 13}}}},
+	10: {{18, []blockGroup{{2, 68}, {2, 69}}}, {26, []blockGroup{{4, 43}, {1, 44}}}, {24, []blockGroup{{6, 19}, {2, 20}}}, {28, []blockGroup{{6, 15}, {2, 16}}}},
+}
+
+// formatLevel is the level as written in the format information
+var formatLevel = [4]uint{L: 1, M: 0, Q: 3, H: 2}
+
+func dataCapacity(version int, level Level) int {
+	n := 0
+	for _, g := range ecTable[version][level].groups {
+		n += g.blocks * g.data
+	}
+	return n
+}
+
+// Encode returns the smallest symbol holding data at level, with the mask
+// of the lowest penalty
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < L || level > H {
+		return nil, errors.New("qr: invalid level")
+	}
+	version := 1
+	for ; version <= MaxVersion; version++ {
+		if bitLength(len(data), version) <= dataCapacity(version, level)*8 {
+			break
+		}
+	}
+	if version > MaxVersion {
+		return nil, ErrTooLong
+	}
+	codewords := addErrorCorrection(encodeData(data, version, level), version, level)
+
+	var best *Code
+	bestPenalty := 0
+	for mask := 0; mask < 8; mask++ {
+		c := newCode(version, level)
+		c.place(codewords)
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		if p := c.penalty(); best == nil || p < bestPenalty {
+			best, bestPenalty = c.Code, p
+			best.Mask = mask
+		}
+	}
+	return best, nil
+}
+
+// bitLength is the size of the byte mode segment of n bytes
+func bitLength(n, version int) int {
+	return 4 + countBits(version) + 8*n
+}
+
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData returns the data codewords: the byte mode segment, the
+// terminator and the padding
+func encodeData(data []byte, version int, level Level) []byte {
+	var b bitBuffer
+	b.append(4, 4) // byte mode
+	b.append(uint(len(data)), countBits(version))
+	for _, d := range data {
+		b.append(uint(d), 8)
+	}
+	capacity := dataCapacity(version, level) * 8
+	term := capacity - b.n
+	if term > 4 {
+		term = 4
+	}
+	b.append(0, term)
+	b.append(0, (8-b.n%8)%8)
+	for pad := uint(0xEC); b.n < capacity; pad ^= 0xEC ^ 0x11 {
+		b.append(pad, 8)
+	}
+	return b.bytes
+}
+
+type bitBuffer struct {
+	bytes []byte
+	n     int
+}
+
+func (b *bitBuffer) append(v uint, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		if b.n%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if v>>uint(i)&1 == 1 {
+			b.bytes[b.n/8] |= 0x80 >> uint(b.n%8)
+		}
+		b.n++
+	}
+}
+
+// addErrorCorrection splits data into blocks, adds the error correction
+// codewords of each and interleaves them
+func addErrorCorrection(data []byte, version int, level Level) []byte {
+	e := ecTable[version][level]
+	gen := generator(e.ec)
+	var blocks, ecs [][]byte
+	for _, g := range e.groups {
+		for i := 0; i < g.blocks; i++ {
+			blocks = append(blocks, data[:g.data])
+			ecs = append(ecs, remainder(data[:g.data], gen))
+			data = data[g.data:]
+		}
+	}
+	var out []byte
+	for i := 0; ; i++ {
+		n := 0
+		for _, b := range blocks {
+			if i < len(b) {
+				out = append(out, b[i])
+				n++
+			}
+		}
+		if n == 0 {
+			break
+		}
+	}
+	for i := 0; i < e.ec; i++ {
+		for _, ec := range ecs {
+			out = append(out, ec[i])
+		}
+	}
+	return out
+}
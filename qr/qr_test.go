@@ -0,0 +1,77 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestErrorCorrection(t *testing.T) {
+	// "HELLO WORLD" as 1-M, from the worked example of the standard
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := remainder(data, generator(10)); !bytes.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestFormatAndVersion(t *testing.T) {
+	readFormat := func(c *Code) (bits uint) {
+		for i := 0; i <= 5; i++ {
+			if c.Black(8, i) {
+				bits |= 1 << uint(i)
+			}
+		}
+		return bits
+	}
+	b := newCode(1, M)
+	b.drawFormat(0)
+	// the low 6 bits of 101010000010010
+	if got := readFormat(b.Code); got != 0x12 {
+		t.Errorf("Expected the format bits of M and mask 0, got %06b", got)
+	}
+
+	b = newCode(7, L)
+	var bits uint
+	for i := 0; i < 18; i++ {
+		if b.Black(b.Size-11+i%3, i/3) {
+			bits |= 1 << uint(i)
+		}
+	}
+	if bits != 0x07C94 {
+		t.Errorf("Expected the version bits 0x07C94, got %#05x", bits)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, tc := range []struct {
+		n, version int
+	}{{14, 1}, {15, 2}, {180, 9}, {213, 10}} {
+		c, err := Encode([]byte(strings.Repeat("a", tc.n)), M)
+		if err != nil || c.Version != tc.version || c.Size != tc.version*4+17 {
+			t.Errorf("Expected %d bytes to take version %d, got %+v %v", tc.n, tc.version, c, err)
+		}
+	}
+	if _, err := Encode(make([]byte, 272), L); err != ErrTooLong {
+		t.Errorf("Expected too long data to fail, got %v", err)
+	}
+
+	c, _ := Encode([]byte("hello"), M)
+	for _, corner := range [][2]int{{0, 0}, {c.Size - 7, 0}, {0, c.Size - 7}} {
+		if !c.Black(corner[0], corner[1]) || !c.Black(corner[0]+3, corner[1]+3) || c.Black(corner[0]+1, corner[1]+1) {
+			t.Errorf("Expected a finder pattern at %v", corner)
+		}
+	}
+	b, err := c.PNG(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if side := (c.Size + 2*QuietZone) * 4; img.Bounds().Dx() != side {
+		t.Errorf("Expected a %dpx image, got %v", side, img.Bounds())
+	}
+}
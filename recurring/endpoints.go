@@ -0,0 +1,108 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	CreateEndpoint endpoint.Endpoint
+	ListEndpoint   endpoint.Endpoint
+	FindEndpoint   endpoint.Endpoint
+	CancelEndpoint endpoint.Endpoint
+	SkipEndpoint   endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		CreateEndpoint: MakeCreateEndpoint(s),
+		ListEndpoint:   MakeListEndpoint(s),
+		FindEndpoint:   MakeFindEndpoint(s),
+		CancelEndpoint: MakeCancelEndpoint(s),
+		SkipEndpoint:   MakeSkipEndpoint(s),
+	}
+}
+
+func MakeCreateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(createRequest)
+		ser, err := s.Create(ctx, Series{
+			SpotId:    req.SpotId,
+			VehicleId: req.VehicleId,
+			Start:     req.Start,
+			Duration:  req.End.Sub(req.Start),
+			RRule:     req.RRule,
+		})
+		return seriesResponse{Series: ser, Err: err}, err
+	}
+}
+
+func MakeListEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		ss, e := s.List(ctx)
+		return listResponse{Series: ss, Err: e}, e
+	}
+}
+
+func MakeFindEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		ser, err := s.Find(ctx, req.ID)
+		return seriesResponse{Series: ser, Err: err}, err
+	}
+}
+
+func MakeCancelEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		ser, err := s.Cancel(ctx, req.ID)
+		return seriesResponse{Series: ser, Err: err}, err
+	}
+}
+
+func MakeSkipEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(skipRequest)
+		ser, err := s.Skip(ctx, req.ID, req.Date)
+		return seriesResponse{Series: ser, Err: err}, err
+	}
+}
+
+//
+
+// createRequest gives the first occurrence as start and end
+type createRequest struct {
+	SpotId    int       `json:"spotId"`
+	VehicleId int       `json:"vehicleId"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	RRule     string    `json:"rrule"`
+}
+
+type seriesResponse struct {
+	Err    error  `json:"err,omitempty"`
+	Series Series `json:"series"`
+}
+
+func (r seriesResponse) error() error { return r.Err }
+
+type listRequest struct {
+}
+
+type listResponse struct {
+	Err    error    `json:"err,omitempty"`
+	Series []Series `json:"series"`
+}
+
+func (r listResponse) error() error { return r.Err }
+
+type idRequest struct {
+	ID string `json:"id"`
+}
+
+type skipRequest struct {
+	ID   string `json:"id"`
+	Date string `json:"date"`
+}
@@ -0,0 +1,66 @@
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Create(ctx context.Context, s Series) (res Series, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Create", "spotId", s.SpotId, "vehicleId", s.VehicleId, "rrule", s.RRule, "id", res.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Create(ctx, s)
+}
+
+func (mw loggingMiddleware) List(ctx context.Context) (ss []Series, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "List", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.List(ctx)
+}
+
+func (mw loggingMiddleware) Find(ctx context.Context, id string) (s Series, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Find(ctx, id)
+}
+
+func (mw loggingMiddleware) Cancel(ctx context.Context, id string) (s Series, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Cancel", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Cancel(ctx, id)
+}
+
+func (mw loggingMiddleware) Skip(ctx context.Context, id, date string) (s Series, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Skip", "id", id, "date", date, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Skip(ctx, id, date)
+}
+
+func (mw loggingMiddleware) Materialise(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Materialise", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Materialise(ctx)
+}
@@ -0,0 +1,39 @@
+package recurring
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(Status(""), Active, Ended, Cancelled)
+	d.Enum(OccurrenceStatus(""), Booked, Conflict, Skipped, Withdrawn)
+
+	d.Operation("POST", "/recurring/v1/", "createSeries", "Book a spot at every occurrence of a recurrence rule, e.g. FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=20").
+		Tag("recurring").Require(requirements["CreateSeries"]).
+		Body(createRequest{}).
+		Returns(http.StatusOK, "The series with the occurrences booked so far", seriesResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/recurring/v1/", "listSeries", "List the series of the caller, or all for operators").
+		Tag("recurring").Require(requirements["ListSeries"]).
+		Returns(http.StatusOK, "Series", listResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/recurring/v1/{id}", "findSeries", "Get a series with its booked, conflicting and skipped occurrences").
+		Tag("recurring").Require(requirements["FindSeries"]).
+		PathParam("id", "Series id").
+		Returns(http.StatusOK, "The series", seriesResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/recurring/v1/{id}", "cancelSeries", "Cancel the series and the bookings of the occurrences that have not started").
+		Tag("recurring").Require(requirements["CancelSeries"]).
+		PathParam("id", "Series id").
+		Returns(http.StatusOK, "The cancelled series", seriesResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/recurring/v1/{id}/occurrences/{date}", "skipOccurrence", "Skip a single occurrence, cancelling its booking when made already").
+		Tag("recurring").Require(requirements["SkipOccurrence"]).
+		PathParam("id", "Series id").
+		PathParam("date", "Day of the occurrence, YYYY-MM-DD").
+		Returns(http.StatusOK, "The series", seriesResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
@@ -0,0 +1,159 @@
+package recurring
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+// Recurrence rules follow the RRULE of RFC 5545, limited to what commuters
+// need: FREQ of DAILY or WEEKLY, INTERVAL, BYDAY, and an end given by COUNT
+// or UNTIL, e.g. FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20261231
+
+type Freq string
+
+const (
+	Daily  Freq = "DAILY"
+	Weekly Freq = "WEEKLY"
+)
+
+// MaxSpan is how far after its start a series may run
+const MaxSpan = 2 * 366 * 24 * time.Hour
+
+var ErrInvalidRule = apierror.New(apierror.Unprocessable, "invalid_rrule", "invalid recurrence rule").
+	WithField("rrule", "FREQ=DAILY or WEEKLY, optional INTERVAL and BYDAY, and COUNT or UNTIL")
+
+type Rule struct {
+	Freq     Freq
+	Interval int
+	// ByDay limits the occurrences to these weekdays. Weekly rules without
+	// it recur on the weekday of the start.
+	ByDay []time.Weekday
+	// Count or Until ends the series, Until includes the occurrences
+	// starting on that day
+	Count int
+	Until time.Time
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRule parses s, with or without the RRULE: prefix. UNTIL dates
+// without a time are read in loc.
+func ParseRule(s string, loc *time.Location) (Rule, error) {
+	r := Rule{Interval: 1}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return Rule{}, ErrInvalidRule
+		}
+		val := strings.ToUpper(kv[1])
+		switch strings.ToUpper(kv[0]) {
+		case "FREQ":
+			r.Freq = Freq(val)
+			if r.Freq != Daily && r.Freq != Weekly {
+				return Rule{}, ErrInvalidRule.WithField("rrule", "FREQ must be DAILY or WEEKLY")
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return Rule{}, ErrInvalidRule.WithField("rrule", "INTERVAL must be a positive number")
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				wd, ok := weekdays[d]
+				if !ok {
+					return Rule{}, ErrInvalidRule.WithField("rrule", "BYDAY takes MO, TU, WE, TH, FR, SA and SU")
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return Rule{}, ErrInvalidRule.WithField("rrule", "COUNT must be a positive number")
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseUntil(val, loc)
+			if err != nil {
+				return Rule{}, ErrInvalidRule.WithField("rrule", "UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+			}
+			r.Until = t
+		default:
+			return Rule{}, ErrInvalidRule.WithField("rrule", "unsupported part "+kv[0])
+		}
+	}
+	if r.Freq == "" {
+		return Rule{}, ErrInvalidRule.WithField("rrule", "FREQ is required")
+	}
+	if (r.Count == 0) == r.Until.IsZero() {
+		return Rule{}, ErrInvalidRule.WithField("rrule", "one of COUNT and UNTIL is required")
+	}
+	return r, nil
+}
+
+func parseUntil(s string, loc *time.Location) (time.Time, error) {
+	if len(s) == len("20060102") {
+		d, err := time.ParseInLocation("20060102", s, loc)
+		// the whole day
+		return d.AddDate(0, 0, 1).Add(-time.Nanosecond), err
+	}
+	return time.Parse("20060102T150405Z", s)
+}
+
+// Starts returns the starts of the occurrences of a series which begins at
+// start, up to and excluding limit. Occurrences keep the wall clock time of
+// start.
+func (r Rule) Starts(start, limit time.Time) []time.Time {
+	end := start.Add(MaxSpan)
+	if !r.Until.IsZero() && r.Until.Before(end) {
+		end = r.Until.Add(time.Nanosecond)
+	}
+	if limit.Before(end) {
+		end = limit
+	}
+	// weeks count from the monday of the start's week
+	monday := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	tt := make([]time.Time, 0)
+	n := 0
+	for k := 0; ; k++ {
+		t := start.AddDate(0, 0, k)
+		if !t.Before(end) {
+			return tt
+		}
+		if !r.on(t, k, monday, start.Weekday()) {
+			continue
+		}
+		tt = append(tt, t)
+		if n++; r.Count > 0 && n == r.Count {
+			return tt
+		}
+	}
+}
+
+// on reports whether the day t, k days after the start, is an occurrence
+func (r Rule) on(t time.Time, k int, monday time.Time, startDay time.Weekday) bool {
+	if r.Freq == Daily {
+		return k%r.Interval == 0 && r.onDay(t.Weekday(), t.Weekday())
+	}
+	week := int(t.Sub(monday).Hours()/24+0.5) / 7
+	return week%r.Interval == 0 && r.onDay(t.Weekday(), startDay)
+}
+
+func (r Rule) onDay(d, fallback time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return d == fallback
+	}
+	for _, wd := range r.ByDay {
+		if wd == d {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,307 @@
+package recurring
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+)
+
+// Recurring booking series. A series books the same spot at every
+// occurrence of its rule that starts within the window. A booking only
+// reserves its spot once it starts, so the spot is free between the
+// occurrences.
+
+var (
+	ErrInvalidReq     = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInvalidSpot    = apierror.New(apierror.Unprocessable, "invalid_spot", "no such spot").WithField("spotId", "no such spot")
+	ErrInvalidVehicle = apierror.New(apierror.Unprocessable, "invalid_vehicle", "no such vehicle owned by the caller").
+				WithField("vehicleId", "no such vehicle owned by the caller")
+	ErrInvalidTime = apierror.New(apierror.Unprocessable, "invalid_series_time", "end must be after start and at most a day later").
+			WithField("end", "must be after start and at most a day later")
+	ErrInvalidDate       = apierror.New(apierror.Invalid, "invalid_date", "invalid date").WithField("date", "must be YYYY-MM-DD")
+	ErrNoOccurrence      = apierror.New(apierror.NotFound, "occurrence_not_found", "the series has no occurrence on that date")
+	ErrOccurrenceStarted = apierror.New(apierror.Conflict, "occurrence_started", "the occurrence has already started")
+	ErrClosed            = apierror.New(apierror.Conflict, "series_closed", "the series has ended or was cancelled")
+)
+
+// DefaultWindow is how long before it starts an occurrence of a series is
+// booked at most
+const DefaultWindow = 7 * 24 * time.Hour
+
+// Bookings books and cancels the occurrences
+type Bookings interface {
+	Book(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (booking.Booking, error)
+	Delete(ctx context.Context, bookingId string) error
+}
+
+type Service interface {
+	Create(ctx context.Context, s Series) (Series, error)
+	// List returns the series the caller may see
+	List(ctx context.Context) ([]Series, error)
+	Find(ctx context.Context, id string) (Series, error)
+	// Cancel ends the series and cancels the bookings of the occurrences
+	// that have not started
+	Cancel(ctx context.Context, id string) (Series, error)
+	// Skip cancels the occurrence on date, its booking too when it was
+	// made already
+	Skip(ctx context.Context, id, date string) (Series, error)
+	// Materialise books the occurrences of each series that start within
+	// the window and retries them on conflict. It is run periodically, not
+	// exposed over HTTP.
+	Materialise(ctx context.Context) error
+}
+
+type service struct {
+	// mtx serializes the changes to the series
+	mtx      sync.Mutex
+	series   SeriesStore
+	spots    parking.Service
+	bookings Bookings
+	vehicles vehicle.Service
+	window   time.Duration
+	now      func() time.Time
+}
+
+// NewService returns the recurring booking service. Occurrences are booked
+// window ahead of their start, DefaultWindow when zero.
+func NewService(series SeriesStore, spots parking.Service, bookings Bookings, vehicles vehicle.Service, window time.Duration) Service {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &service{series: series, spots: spots, bookings: bookings, vehicles: vehicles, window: window, now: time.Now}
+}
+
+func (s *service) Create(ctx context.Context, ser Series) (Series, error) {
+	if ser.Start.IsZero() {
+		return Series{}, ErrInvalidReq.WithField("start", "is required")
+	}
+	if ser.Duration <= 0 || ser.Duration > 24*time.Hour {
+		return Series{}, ErrInvalidTime
+	}
+	rule, err := ParseRule(ser.RRule, ser.Start.Location())
+	if err != nil {
+		return Series{}, err
+	}
+	if len(rule.Starts(ser.Start, ser.Start.Add(MaxSpan))) == 0 {
+		return Series{}, ErrInvalidRule.WithField("rrule", "has no occurrences")
+	}
+	sp, err := s.spots.FindById(ctx, strconv.Itoa(ser.SpotId))
+	if err != nil && apierror.From(err).Kind == apierror.Unavailable {
+		return Series{}, err
+	} else if err != nil {
+		return Series{}, ErrInvalidSpot
+	}
+	// the occurrences are booked on behalf of the user, so check the
+	// vehicle now
+	v, err := s.vehicles.Find(ctx, strconv.Itoa(ser.VehicleId))
+	if err == auth.ErrForbidden {
+		return Series{}, err
+	} else if err != nil {
+		return Series{}, ErrInvalidVehicle
+	}
+	if err := vehicle.CheckFit(v, sp); err != nil {
+		return Series{}, err
+	}
+	p, _ := auth.PrincipalFromContext(ctx)
+	ser.User = p.Subject
+	ser.Status = Active
+	ser.Occurrences = nil
+	ser.CreatedAt = s.now().UTC()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if ser, err = s.series.Create(ser); err != nil {
+		return Series{}, err
+	}
+	return s.materialise(ser, s.now())
+}
+
+func (s *service) List(ctx context.Context) ([]Series, error) {
+	all, err := s.series.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Series, 0, len(all))
+	for _, ser := range all {
+		if auth.CanAccess(ctx, ser.User) {
+			res = append(res, ser)
+		}
+	}
+	return res, nil
+}
+
+func (s *service) Find(ctx context.Context, id string) (Series, error) {
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		return Series{}, ErrInvalidReq
+	}
+	ser, err := s.series.Find(intId)
+	if err != nil {
+		return Series{}, err
+	}
+	if !auth.CanAccess(ctx, ser.User) {
+		return Series{}, auth.ErrForbidden
+	}
+	return ser, nil
+}
+
+func (s *service) Cancel(ctx context.Context, id string) (Series, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	ser, err := s.Find(ctx, id)
+	if err != nil {
+		return Series{}, err
+	}
+	if ser.Status != Active {
+		return Series{}, ErrClosed
+	}
+	now := s.now()
+	for i, o := range ser.Occurrences {
+		if o.Status != Booked || !now.Before(o.Start) {
+			continue
+		}
+		if err := s.cancel(o.BookingId); err != nil {
+			return Series{}, err
+		}
+		ser.Occurrences[i].Status = Withdrawn
+	}
+	ser.Status = Cancelled
+	return s.series.Update(ser)
+}
+
+func (s *service) Skip(ctx context.Context, id, date string) (Series, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	ser, err := s.Find(ctx, id)
+	if err != nil {
+		return Series{}, err
+	}
+	if ser.Status != Active {
+		return Series{}, ErrClosed
+	}
+	now := s.now()
+	if i, ok := ser.Occurrence(date); ok {
+		o := ser.Occurrences[i]
+		switch o.Status {
+		case Skipped, Withdrawn:
+			return ser, nil
+		case Booked:
+			if !now.Before(o.Start) {
+				return Series{}, ErrOccurrenceStarted
+			}
+			if err := s.cancel(o.BookingId); err != nil {
+				return Series{}, err
+			}
+		}
+		ser.Occurrences[i] = Occurrence{Date: o.Date, Start: o.Start, Status: Skipped}
+		return s.series.Update(ser)
+	}
+
+	day, err := time.ParseInLocation(DateLayout, date, ser.Start.Location())
+	if err != nil {
+		return Series{}, ErrInvalidDate
+	}
+	rule, err := ParseRule(ser.RRule, ser.Start.Location())
+	if err != nil {
+		return Series{}, err
+	}
+	for _, t := range rule.Starts(ser.Start, day.AddDate(0, 0, 1)) {
+		if t.Format(DateLayout) != date {
+			continue
+		}
+		if !now.Before(t) {
+			return Series{}, ErrOccurrenceStarted
+		}
+		ser.Occurrences = append(ser.Occurrences, Occurrence{Date: date, Start: t, Status: Skipped})
+		sort.Slice(ser.Occurrences, func(i, j int) bool { return ser.Occurrences[i].Start.Before(ser.Occurrences[j].Start) })
+		return s.series.Update(ser)
+	}
+	return Series{}, ErrNoOccurrence
+}
+
+func (s *service) Materialise(ctx context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	all, err := s.series.GetAll()
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, ser := range all {
+		if ser.Status != Active {
+			continue
+		}
+		if _, err := s.materialise(ser, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// materialise books the occurrences of ser that start within the window
+// and ends the series after its last occurrence. The caller holds mtx.
+func (s *service) materialise(ser Series, now time.Time) (Series, error) {
+	rule, err := ParseRule(ser.RRule, ser.Start.Location())
+	if err != nil {
+		return Series{}, err
+	}
+	// the series belongs to its user, not to whoever triggered the run
+	ctx := auth.NewContext(context.Background(), auth.System)
+	changed := false
+	for _, t := range rule.Starts(ser.Start, now.Add(s.window)) {
+		if !now.Before(t.Add(ser.Duration)) {
+			continue
+		}
+		date := t.Format(DateLayout)
+		i, ok := ser.Occurrence(date)
+		if ok && ser.Occurrences[i].Status != Conflict {
+			continue
+		}
+		o := Occurrence{Date: date, Start: t}
+		b, err := s.bookings.Book(ctx, strconv.Itoa(ser.SpotId), strconv.Itoa(ser.VehicleId), t, ser.Duration)
+		if err != nil && apierror.From(err).Kind == apierror.Unavailable {
+			// parking is down, try again on the next run
+			break
+		}
+		if err != nil {
+			o.Status, o.Reason = Conflict, apierror.From(err).Code
+		} else {
+			o.Status, o.BookingId = Booked, b.ID
+		}
+		if !ok || ser.Occurrences[i] != o {
+			if ok {
+				ser.Occurrences[i] = o
+			} else {
+				ser.Occurrences = append(ser.Occurrences, o)
+			}
+			changed = true
+		}
+	}
+	all := rule.Starts(ser.Start, ser.Start.Add(MaxSpan))
+	if last := all[len(all)-1]; !now.Before(last.Add(ser.Duration)) {
+		ser.Status = Ended
+		changed = true
+	}
+	if !changed {
+		return ser, nil
+	}
+	sort.Slice(ser.Occurrences, func(i, j int) bool { return ser.Occurrences[i].Start.Before(ser.Occurrences[j].Start) })
+	return s.series.Update(ser)
+}
+
+// cancel cancels the booking of an occurrence, unless it is gone already
+func (s *service) cancel(bookingId int) error {
+	err := s.bookings.Delete(auth.NewContext(context.Background(), auth.System), strconv.Itoa(bookingId))
+	if err == booking.ErrInvalidBookingId {
+		return nil
+	}
+	return err
+}
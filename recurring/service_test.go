@@ -0,0 +1,262 @@
+package recurring
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+)
+
+var cst = time.FixedZone("CST", -6*60*60)
+
+// monday is the first day of the test series
+var monday = time.Date(2026, 3, 2, 8, 0, 0, 0, cst)
+
+func TestRule(t *testing.T) {
+	for _, c := range []struct {
+		rule string
+		want []string
+	}{
+		{"FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=7", []string{"03-02", "03-03", "03-04", "03-05", "03-06", "03-09", "03-10"}},
+		{"RRULE:FREQ=DAILY;INTERVAL=2;UNTIL=20260308", []string{"03-02", "03-04", "03-06", "03-08"}},
+		{"FREQ=WEEKLY;INTERVAL=2;COUNT=3", []string{"03-02", "03-16", "03-30"}},
+		{"FREQ=WEEKLY;BYDAY=SU,TU;UNTIL=20260310T000000Z", []string{"03-03", "03-08"}},
+	} {
+		r, err := ParseRule(c.rule, cst)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", c.rule, err)
+		}
+		got := make([]string, 0)
+		for _, s := range r.Starts(monday, monday.AddDate(1, 0, 0)) {
+			if s.Hour() != 8 {
+				t.Errorf("Expected %s to keep the start time, got %s", c.rule, s)
+			}
+			got = append(got, s.Format("01-02"))
+		}
+		if strings.Join(got, " ") != strings.Join(c.want, " ") {
+			t.Errorf("Expected %s to recur on %v, got %v", c.rule, c.want, got)
+		}
+	}
+	if n := len(mustRule(t, "FREQ=DAILY;COUNT=10").Starts(monday, monday.AddDate(0, 0, 3))); n != 3 {
+		t.Errorf("Expected the limit to cut the occurrences, got %d", n)
+	}
+	for _, bad := range []string{"FREQ=HOURLY;COUNT=1", "FREQ=DAILY", "FREQ=DAILY;COUNT=1;UNTIL=20260301",
+		"FREQ=DAILY;BYDAY=XX;COUNT=1", "FREQ=DAILY;INTERVAL=0;COUNT=1", "FREQ=DAILY;BYMONTH=1;COUNT=1"} {
+		if _, err := ParseRule(bad, cst); err == nil {
+			t.Errorf("Expected %s to be rejected", bad)
+		}
+	}
+}
+
+func mustRule(t *testing.T, s string) Rule {
+	r, err := ParseRule(s, cst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+type fixture struct {
+	s        Service
+	spots    parking.Service
+	bookings booking.Service
+	car      int
+	// at sets the clock of the series and their bookings
+	at func(time.Time)
+}
+
+func newTestService(t *testing.T) fixture {
+	store, _ := parking.NewInMemParkingStore()
+	p := parking.NewService(store)
+	vehicleStore, _ := vehicle.NewInMemVehicleStore()
+	v := vehicle.NewService(vehicleStore)
+	car, err := v.Register(driver("alice"), vehicle.Vehicle{Plate: "AB12CD", Class: parking.Car,
+		Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bookingStore, _ := booking.NewInMemBookingStore()
+	holdStore, _ := booking.NewInMemHoldStore()
+	groupStore, _ := booking.NewInMemGroupStore()
+	now := new(time.Time)
+	clock := func() time.Time { return *now }
+	b := booking.NewService(bookingStore, p, v, booking.Options{Holds: holdStore, Groups: groupStore, Now: clock})
+	series, _ := NewInMemSeriesStore()
+	s := NewService(series, p, b, v, time.Hour)
+	s.(*service).now = clock
+	return fixture{s: s, spots: p, bookings: b, car: car.ID, at: func(t time.Time) { *now = t }}
+}
+
+func driver(name string) context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Subject: name, Roles: []auth.Role{auth.RoleDriver}})
+}
+
+func (f fixture) reserved(t *testing.T, id string) bool {
+	sp, err := f.spots.FindById(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sp.IsReserved
+}
+
+func TestSeries(t *testing.T) {
+	f := newTestService(t)
+	ctx := driver("alice")
+	f.at(monday.Add(-30 * time.Minute))
+
+	ser, err := f.s.Create(ctx, Series{SpotId: 1, VehicleId: f.car, Start: monday, Duration: 10 * time.Hour,
+		RRule: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ser.User != "alice" || ser.Status != Active || len(ser.Occurrences) != 1 || ser.Occurrences[0].Status != Booked {
+		t.Fatalf("Expected the first occurrence to be booked within the window, got %+v", ser)
+	}
+	id := strconv.Itoa(ser.ID)
+	if ser, err = f.s.Skip(ctx, id, "2026-03-04"); err != nil || len(ser.Occurrences) != 2 || ser.Occurrences[1].Status != Skipped {
+		t.Fatalf("Failed to skip an upcoming occurrence: %+v %v", ser, err)
+	}
+	if _, err := f.s.Skip(ctx, id, "2026-03-07"); err != ErrNoOccurrence {
+		t.Errorf("Expected a saturday not to be an occurrence, got %v", err)
+	}
+	if _, err := f.s.Skip(ctx, id, "03/04/2026"); err != ErrInvalidDate {
+		t.Errorf("Expected a malformed date to fail, got %v", err)
+	}
+	if _, err := f.s.Find(driver("bob"), id); err != auth.ErrForbidden {
+		t.Error("Expected another driver not to see the series")
+	}
+
+	// tuesday conflicts with an early booking of the spot
+	tuesday := monday.AddDate(0, 0, 1)
+	f.at(tuesday.Add(-30 * time.Minute))
+	if _, err := f.bookings.Expire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	early, err := f.bookings.Book(ctx, "1", strconv.Itoa(f.car), tuesday.Add(-20*time.Minute), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.s.Materialise(context.Background())
+	ser, _ = f.s.Find(ctx, id)
+	if o := ser.Occurrences[1]; o.Date != "2026-03-03" || o.Status != Conflict || o.Reason != "booking_overlap" {
+		t.Fatalf("Expected tuesday to conflict, got %+v", ser.Occurrences)
+	}
+	if err := f.bookings.Delete(ctx, strconv.Itoa(early.ID)); err != nil {
+		t.Fatal(err)
+	}
+	f.s.Materialise(context.Background())
+	ser, _ = f.s.Find(ctx, id)
+	o := ser.Occurrences[1]
+	if o.Status != Booked || o.Reason != "" {
+		t.Fatalf("Expected the conflicting occurrence to be retried, got %+v", o)
+	}
+	if ser, err = f.s.Skip(ctx, id, "2026-03-03"); err != nil || ser.Occurrences[1].Status != Skipped {
+		t.Fatalf("Expected skipping a booked occurrence to cancel its booking: %+v %v", ser, err)
+	}
+	if _, err := f.bookings.Find(ctx, strconv.Itoa(o.BookingId)); err != booking.ErrInvalidBookingId {
+		t.Errorf("Expected the booking of the skipped occurrence to be gone, got %v", err)
+	}
+
+	f.at(monday.AddDate(0, 0, 2).Add(-30 * time.Minute))
+	f.s.Materialise(context.Background())
+	if bb, _ := f.bookings.GetAll(context.Background()); len(bb) != 1 {
+		t.Errorf("Expected the skipped occurrence not to be booked, got %+v", bb)
+	}
+
+	f.at(monday.AddDate(0, 0, 3).Add(-30 * time.Minute))
+	f.s.Materialise(context.Background())
+	ser, _ = f.s.Find(ctx, id)
+	if o = ser.Occurrences[3]; o.Status != Booked {
+		t.Fatalf("Expected thursday to be booked, got %+v", ser.Occurrences)
+	}
+	if ser, err = f.s.Cancel(ctx, id); err != nil || ser.Status != Cancelled || ser.Occurrences[3].Status != Withdrawn {
+		t.Fatalf("Failed to cancel the series: %+v %v", ser, err)
+	}
+	if _, err := f.bookings.Find(ctx, strconv.Itoa(o.BookingId)); err != booking.ErrInvalidBookingId {
+		t.Errorf("Expected cancelling the series to cancel the upcoming booking, got %v", err)
+	}
+	if _, err := f.s.Skip(ctx, id, "2026-03-06"); err != ErrClosed {
+		t.Errorf("Expected a cancelled series to be closed, got %v", err)
+	}
+	if bb, _ := f.bookings.GetAll(context.Background()); len(bb) != 1 || bb[0].Status != booking.StatusExpired {
+		t.Errorf("Expected only monday's expired booking to remain, got %+v", bb)
+	}
+}
+
+func TestRollingWindow(t *testing.T) {
+	f := newTestService(t)
+	f.s.(*service).window = 48 * time.Hour
+	ctx := driver("alice")
+	f.at(monday.Add(-30 * time.Minute))
+
+	ser, err := f.s.Create(ctx, Series{SpotId: 1, VehicleId: f.car, Start: monday, Duration: 10 * time.Hour,
+		RRule: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;COUNT=5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every occurrence within the window is booked
+	if len(ser.Occurrences) != 2 || ser.Occurrences[0].Status != Booked || ser.Occurrences[1].Status != Booked {
+		t.Fatalf("Expected monday and tuesday to be booked, got %+v", ser.Occurrences)
+	}
+	if f.reserved(t, "1") {
+		t.Error("Expected the spot to be free before monday starts")
+	}
+
+	f.at(monday.Add(time.Minute))
+	f.bookings.Expire(context.Background())
+	if !f.reserved(t, "1") {
+		t.Error("Expected the spot to be reserved once monday starts")
+	}
+
+	// the spot is free overnight and wednesday is booked ahead
+	f.at(monday.Add(12 * time.Hour))
+	f.bookings.Expire(context.Background())
+	if f.reserved(t, "1") {
+		t.Error("Expected the spot to be free between the occurrences")
+	}
+	f.s.Materialise(context.Background())
+	ser, _ = f.s.Find(ctx, strconv.Itoa(ser.ID))
+	if len(ser.Occurrences) != 3 || ser.Occurrences[2].Date != "2026-03-04" || ser.Occurrences[2].Status != Booked {
+		t.Fatalf("Expected wednesday to be booked ahead, got %+v", ser.Occurrences)
+	}
+	other, _ := f.bookings.Book(ctx, "1", strconv.Itoa(f.car), monday.Add(13*time.Hour), 5*time.Hour)
+	if other.ID == 0 {
+		t.Error("Expected the spot to be bookable overnight")
+	}
+}
+
+func TestCreate(t *testing.T) {
+	f := newTestService(t)
+	ctx := driver("alice")
+	f.at(monday.AddDate(0, 1, 0))
+	ser, err := f.s.Create(ctx, Series{SpotId: 4, VehicleId: f.car, Start: monday, Duration: time.Hour, RRule: "FREQ=DAILY;COUNT=3"})
+	if err != nil || ser.Status != Ended || len(ser.Occurrences) != 0 {
+		t.Errorf("Expected a series in the past to end right away, got %+v %v", ser, err)
+	}
+
+	for _, c := range []struct {
+		ctx context.Context
+		s   Series
+		err error
+	}{
+		{ctx, Series{SpotId: 4, VehicleId: f.car, Start: monday, Duration: 0, RRule: "FREQ=DAILY;COUNT=3"}, ErrInvalidTime},
+		{ctx, Series{SpotId: 4, VehicleId: f.car, Start: monday, Duration: 25 * time.Hour, RRule: "FREQ=DAILY;COUNT=3"}, ErrInvalidTime},
+		{ctx, Series{SpotId: 9, VehicleId: f.car, Start: monday, Duration: time.Hour, RRule: "FREQ=DAILY;COUNT=3"}, ErrInvalidSpot},
+		{ctx, Series{SpotId: 4, VehicleId: 7, Start: monday, Duration: time.Hour, RRule: "FREQ=DAILY;COUNT=3"}, ErrInvalidVehicle},
+		{driver("bob"), Series{SpotId: 4, VehicleId: f.car, Start: monday, Duration: time.Hour, RRule: "FREQ=DAILY;COUNT=3"}, auth.ErrForbidden},
+		{ctx, Series{SpotId: 2, VehicleId: f.car, Start: monday, Duration: time.Hour, RRule: "FREQ=DAILY;COUNT=3"}, nil},
+	} {
+		if _, err := f.s.Create(c.ctx, c.s); err == nil || (c.err != nil && err != c.err) {
+			t.Errorf("Expected %+v to fail with %v, got %v", c.s, c.err, err)
+		}
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
@@ -0,0 +1,138 @@
+package recurring
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+type SeriesStore interface {
+	Create(s Series) (Series, error)
+	Update(s Series) (Series, error)
+	Find(id int) (Series, error)
+	GetAll() ([]Series, error)
+}
+
+type Status string
+
+const (
+	Active Status = "active"
+	// Ended series have no occurrences left to book
+	Ended     Status = "ended"
+	Cancelled Status = "cancelled"
+)
+
+type OccurrenceStatus string
+
+const (
+	Booked OccurrenceStatus = "booked"
+	// Conflict occurrences could not be booked, they are retried until
+	// they end
+	Conflict OccurrenceStatus = "conflict"
+	Skipped  OccurrenceStatus = "skipped"
+	// Withdrawn occurrences were booked and cancelled with the series
+	Withdrawn OccurrenceStatus = "cancelled"
+)
+
+// Series books a spot for a vehicle at every occurrence of RRule, each
+// lasting Duration from its start
+type Series struct {
+	ID        int           `json:"id"`
+	User      string        `json:"user"`
+	SpotId    int           `json:"spotId"`
+	VehicleId int           `json:"vehicleId"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+	RRule     string        `json:"rrule"`
+	Status    Status        `json:"status"`
+	// Occurrences are the occurrences materialised so far, and the
+	// skipped ones, in order
+	Occurrences []Occurrence `json:"occurrences"`
+	CreatedAt   time.Time    `json:"createdAt"`
+}
+
+type Occurrence struct {
+	// Date is the day of the occurrence in the zone of the series start,
+	// YYYY-MM-DD
+	Date      string           `json:"date"`
+	Start     time.Time        `json:"start"`
+	Status    OccurrenceStatus `json:"status"`
+	BookingId int              `json:"bookingId,omitempty"`
+	// Reason tells why a conflicting occurrence could not be booked
+	Reason string `json:"reason,omitempty"`
+}
+
+// DateLayout is the layout of Occurrence.Date
+const DateLayout = "2006-01-02"
+
+// Occurrence returns the occurrence on date, if recorded
+func (s Series) Occurrence(date string) (int, bool) {
+	for i, o := range s.Occurrences {
+		if o.Date == date {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+var (
+	ErrNotFound = apierror.New(apierror.NotFound, "series_not_found", "recurring booking series not found")
+)
+
+type inMemSeriesStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Series
+	nxtId int
+}
+
+func NewInMemSeriesStore() (SeriesStore, error) {
+	return &inMemSeriesStore{m: map[int]Series{}, nxtId: 1}, nil
+}
+
+func (s *inMemSeriesStore) Create(ss Series) (Series, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	ss.ID = s.nxtId
+	s.nxtId++
+	s.m[ss.ID] = clone(ss)
+	return ss, nil
+}
+
+func (s *inMemSeriesStore) Update(ss Series) (Series, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[ss.ID]; !ok {
+		return Series{}, ErrNotFound
+	}
+	s.m[ss.ID] = clone(ss)
+	return ss, nil
+}
+
+func (s *inMemSeriesStore) Find(id int) (Series, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	ss, ok := s.m[id]
+	if !ok {
+		return Series{}, ErrNotFound
+	}
+	return clone(ss), nil
+}
+
+func (s *inMemSeriesStore) GetAll() ([]Series, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	all := make([]Series, 0, len(s.m))
+	for _, ss := range s.m {
+		all = append(all, clone(ss))
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all, nil
+}
+
+// clone copies the occurrences, so callers can change them in place
+func clone(s Series) Series {
+	s.Occurrences = append(make([]Occurrence, 0, len(s.Occurrences)), s.Occurrences...)
+	return s
+}
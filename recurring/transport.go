@@ -0,0 +1,123 @@
+package recurring
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"CreateSeries":   {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"ListSeries":     {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"FindSeries":     {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"CancelSeries":   {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"SkipOccurrence": {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+}
+
+// MakeHTTPHandler mounts the recurring booking endpoints into an
+// http.Handler. Drivers only see and change their own series.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("POST").Path("/recurring/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "CreateSeries")(e.CreateEndpoint),
+		decodeCreateRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/recurring/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListSeries")(e.ListEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/recurring/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "FindSeries")(e.FindEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/recurring/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "CancelSeries")(e.CancelEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/recurring/v1/{id}/occurrences/{date}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "SkipOccurrence")(e.SkipEndpoint),
+		decodeSkipRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeCreateRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req createRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req listRequest
+	return req, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return idRequest{ID: id}, nil
+}
+
+func decodeSkipRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	date, ok := vars["date"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return skipRequest{ID: id, Date: date}, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,142 @@
+package sensor
+
+import (
+	"context"
+
+	"github.com/atuldaemon/rct/parking"
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	RegisterEndpoint   endpoint.Endpoint
+	ListEndpoint       endpoint.Endpoint
+	DeregisterEndpoint endpoint.Endpoint
+	HeartbeatEndpoint  endpoint.Endpoint
+	RecordEndpoint     endpoint.Endpoint
+	IngestEndpoint     endpoint.Endpoint
+	AlertsEndpoint     endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		RegisterEndpoint:   MakeRegisterEndpoint(s),
+		ListEndpoint:       MakeListEndpoint(s),
+		DeregisterEndpoint: MakeDeregisterEndpoint(s),
+		HeartbeatEndpoint:  MakeHeartbeatEndpoint(s),
+		RecordEndpoint:     MakeRecordEndpoint(s),
+		IngestEndpoint:     MakeIngestEndpoint(s),
+		AlertsEndpoint:     MakeAlertsEndpoint(s),
+	}
+}
+
+func MakeRegisterEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(registerRequest)
+		sn, e := s.Register(ctx, req.Sensor)
+		return sensorResponse{Sensor: sn, Err: e}, e
+	}
+}
+
+func MakeListEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		ss, e := s.List(ctx)
+		return listResponse{Sensors: ss, Err: e}, e
+	}
+}
+
+func MakeDeregisterEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e := s.Deregister(ctx, req.ID)
+		return deregisterResponse{Err: e}, e
+	}
+}
+
+func MakeHeartbeatEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		sn, e := s.Heartbeat(ctx, req.ID)
+		return sensorResponse{Sensor: sn, Err: e}, e
+	}
+}
+
+func MakeRecordEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(Reading)
+		sp, e := s.Record(ctx, req)
+		return recordResponse{Spot: sp, Err: e}, e
+	}
+}
+
+func MakeIngestEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ingestRequest)
+		res, e := s.Ingest(ctx, req.Readings)
+		return ingestResponse{IngestResult: res, Err: e}, e
+	}
+}
+
+func MakeAlertsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		aa, e := s.Alerts(ctx)
+		return alertsResponse{Alerts: aa, Err: e}, e
+	}
+}
+
+//
+
+type registerRequest struct {
+	Sensor Sensor `json:"sensor"`
+}
+
+type sensorResponse struct {
+	Err    error  `json:"err,omitempty"`
+	Sensor Sensor `json:"sensor"`
+}
+
+func (r sensorResponse) error() error { return r.Err }
+
+type listRequest struct {
+}
+
+type listResponse struct {
+	Err     error    `json:"err,omitempty"`
+	Sensors []Sensor `json:"sensors"`
+}
+
+func (r listResponse) error() error { return r.Err }
+
+type idRequest struct {
+	ID string `json:"id"`
+}
+
+type deregisterResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r deregisterResponse) error() error { return r.Err }
+
+type recordResponse struct {
+	Err  error        `json:"err,omitempty"`
+	Spot parking.Spot `json:"spot"`
+}
+
+func (r recordResponse) error() error { return r.Err }
+
+type ingestRequest struct {
+	Readings []Reading `json:"readings"`
+}
+
+type ingestResponse struct {
+	Err error `json:"err,omitempty"`
+	IngestResult
+}
+
+func (r ingestResponse) error() error { return r.Err }
+
+type alertsResponse struct {
+	Err    error   `json:"err,omitempty"`
+	Alerts []Alert `json:"alerts"`
+}
+
+func (r alertsResponse) error() error { return r.Err }
@@ -0,0 +1,21 @@
+package sensor
+
+import (
+	"github.com/atuldaemon/rct/event"
+)
+
+// The alert events published on the event bus
+
+type AlertRaised struct {
+	Alert Alert `json:"alert"`
+}
+
+type AlertResolved struct {
+	Alert Alert `json:"alert"`
+}
+
+func (AlertRaised) EventType() string   { return event.AlertRaised }
+func (AlertResolved) EventType() string { return event.AlertResolved }
+
+func (e AlertRaised) AggregateID() string   { return "alert/" + e.Alert.ID }
+func (e AlertResolved) AggregateID() string { return "alert/" + e.Alert.ID }
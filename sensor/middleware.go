@@ -0,0 +1,81 @@
+package sensor
+
+import (
+	"context"
+	"time"
+
+	"github.com/atuldaemon/rct/parking"
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Register(ctx context.Context, s Sensor) (sn Sensor, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Register", "id", s.ID, "kind", s.Kind, "spots", len(s.Spots), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Register(ctx, s)
+}
+
+func (mw loggingMiddleware) List(ctx context.Context) (ss []Sensor, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "List", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.List(ctx)
+}
+
+func (mw loggingMiddleware) Deregister(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Deregister", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Deregister(ctx, id)
+}
+
+func (mw loggingMiddleware) Heartbeat(ctx context.Context, id string) (sn Sensor, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Heartbeat", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Heartbeat(ctx, id)
+}
+
+func (mw loggingMiddleware) Record(ctx context.Context, r Reading) (sp parking.Spot, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Record", "sensor", r.SensorID, "spot", r.SpotId, "occupied", r.Occupied, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Record(ctx, r)
+}
+
+func (mw loggingMiddleware) Ingest(ctx context.Context, rr []Reading) (res IngestResult, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Ingest", "readings", len(rr), "accepted", res.Accepted, "rejected", len(res.Rejected), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Ingest(ctx, rr)
+}
+
+func (mw loggingMiddleware) Alerts(ctx context.Context) (aa []Alert, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Alerts", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Alerts(ctx)
+}
+
+func (mw loggingMiddleware) Check(ctx context.Context) (aa []Alert, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Check", "alerts", len(aa), "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Check(ctx)
+}
@@ -0,0 +1,47 @@
+package sensor
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(Kind(""), Ground, Camera)
+	d.Enum(AlertKind(""), AlertUnbooked, AlertBookedVacant, AlertSensorOffline)
+
+	d.Operation("POST", "/sensor/v1/", "registerSensor", "Register a ground sensor for one spot or a camera for several").
+		Tag("sensor").Require(requirements["RegisterSensor"]).
+		Body(registerRequest{}).
+		Returns(http.StatusOK, "The registered sensor", sensorResponse{}).
+		Fails(http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/sensor/v1/", "listSensors", "List the sensors and whether they are online").
+		Tag("sensor").Require(requirements["ListSensors"]).
+		Returns(http.StatusOK, "Sensors", listResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/sensor/v1/alerts", "listAlerts", "List the alerts currently raised").
+		Tag("sensor").Require(requirements["ListAlerts"]).
+		Returns(http.StatusOK, "Alerts", alertsResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/sensor/v1/readings", "recordReading", "Record a single occupancy reading").
+		Tag("sensor").Require(requirements["RecordReading"]).
+		Body(Reading{}).
+		Returns(http.StatusOK, "The spot read", recordResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/sensor/v1/readings/batch", "ingestReadings", "Record up to 500 readings, each is accepted or rejected on its own").
+		Tag("sensor").Require(requirements["IngestReadings"]).
+		Body(ingestRequest{}).
+		Returns(http.StatusOK, "The count of accepted readings and the rejected ones", ingestResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable)
+	d.Operation("DELETE", "/sensor/v1/{id}", "deregisterSensor", "Remove a sensor").
+		Tag("sensor").Require(requirements["DeregisterSensor"]).
+		PathParam("id", "Sensor id").
+		Returns(http.StatusOK, "Empty object", deregisterResponse{}).
+		Fails(http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/sensor/v1/{id}/heartbeat", "sensorHeartbeat", "Report that a sensor is alive, readings count as heartbeats too").
+		Tag("sensor").Require(requirements["SensorHeartbeat"]).
+		PathParam("id", "Sensor id").
+		Returns(http.StatusOK, "The sensor", sensorResponse{}).
+		Fails(http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
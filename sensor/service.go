@@ -0,0 +1,344 @@
+package sensor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+)
+
+// Sensor service. Ground sensors and cameras report whether spots are
+// occupied, which is tracked apart from the reservations. A periodic check
+// compares the two and raises alerts where they disagree.
+
+var (
+	ErrInvalidReq    = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInvalidSensor = apierror.New(apierror.Unprocessable, "invalid_sensor", "invalid sensor")
+	ErrUnknownSpot   = apierror.New(apierror.Unprocessable, "invalid_spot", "invalid spot").
+				WithField("spots", "no such spot")
+	ErrSpotNotCovered = apierror.New(apierror.Unprocessable, "spot_not_covered", "the sensor does not watch this spot").
+				WithField("spotId", "must be one of the spots of the sensor")
+	ErrFutureReading = apierror.New(apierror.Unprocessable, "reading_in_future", "the reading is from the future").
+				WithField("at", "must not be ahead of the server clock")
+)
+
+// Options tune the checks. Zero values take the defaults below.
+type Options struct {
+	// HeartbeatTimeout is how long a sensor may stay silent before it is
+	// considered offline
+	HeartbeatTimeout time.Duration
+	// Grace is how long occupancy and bookings may disagree before an
+	// alert is raised
+	Grace time.Duration
+}
+
+const (
+	DefaultHeartbeatTimeout = 5 * time.Minute
+	DefaultGrace            = 10 * time.Minute
+	// maxClockSkew is how far ahead of the server a reading may be
+	maxClockSkew = time.Minute
+)
+
+// Reading is a single occupancy observation. SpotId may be left out for
+// sensors watching a single spot, At for readings taken just now.
+type Reading struct {
+	SensorID string    `json:"sensorId"`
+	SpotId   int       `json:"spotId,omitempty"`
+	Occupied bool      `json:"occupied"`
+	At       time.Time `json:"at,omitempty"`
+}
+
+// IngestResult reports the outcome of a batch, rejected readings are named
+// by their index in the batch
+type IngestResult struct {
+	Accepted int         `json:"accepted"`
+	Rejected []Rejection `json:"rejected"`
+}
+
+type Rejection struct {
+	Index   int    `json:"index"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Bookings are the reservations occupancy is checked against. Without them
+// a reserved spot counts as booked since its last occupancy change.
+type Bookings interface {
+	GetAll(ctx context.Context) ([]booking.Booking, error)
+}
+
+type Service interface {
+	Register(ctx context.Context, s Sensor) (Sensor, error)
+	List(ctx context.Context) ([]Sensor, error)
+	Deregister(ctx context.Context, id string) error
+	Heartbeat(ctx context.Context, id string) (Sensor, error)
+	// Record stores a single reading and returns the spot it was about
+	Record(ctx context.Context, r Reading) (parking.Spot, error)
+	// Ingest records a batch of readings, each is accepted or rejected on
+	// its own
+	Ingest(ctx context.Context, rr []Reading) (IngestResult, error)
+	Alerts(ctx context.Context) ([]Alert, error)
+	// Check raises alerts for mismatches and silent sensors, and resolves
+	// the ones that cleared. It is run periodically, not exposed over HTTP.
+	Check(ctx context.Context) ([]Alert, error)
+}
+
+type service struct {
+	sensors  SensorStore
+	alerts   AlertStore
+	spots    parking.Service
+	bookings Bookings
+	events   event.Publisher
+	o        Options
+	now      func() time.Time
+}
+
+// NewService returns the sensor service. bookings and events may be nil.
+func NewService(sensors SensorStore, alerts AlertStore, spots parking.Service, bookings Bookings, events event.Publisher, o Options) Service {
+	if o.HeartbeatTimeout <= 0 {
+		o.HeartbeatTimeout = DefaultHeartbeatTimeout
+	}
+	if o.Grace <= 0 {
+		o.Grace = DefaultGrace
+	}
+	if events == nil {
+		events = event.Nop
+	}
+	return &service{sensors: sensors, alerts: alerts, spots: spots, bookings: bookings, events: events, o: o, now: time.Now}
+}
+
+func (s *service) Register(ctx context.Context, sn Sensor) (Sensor, error) {
+	if sn.ID == "" {
+		return Sensor{}, ErrInvalidSensor.WithField("id", "must not be empty")
+	}
+	if !sn.Kind.Valid() {
+		return Sensor{}, ErrInvalidSensor.WithField("kind", "must be ground or camera")
+	}
+	if len(sn.Spots) == 0 || (sn.Kind == Ground && len(sn.Spots) != 1) {
+		return Sensor{}, ErrInvalidSensor.WithField("spots", "ground sensors watch one spot, cameras at least one")
+	}
+	for _, id := range sn.Spots {
+		if _, err := s.spots.FindById(ctx, strconv.Itoa(id)); err == parking.ErrNotFound {
+			return Sensor{}, ErrUnknownSpot
+		} else if err != nil {
+			return Sensor{}, err
+		}
+	}
+	sn.RegisteredAt = s.now().UTC()
+	sn.LastSeen = time.Time{}
+	sn, err := s.sensors.Create(sn)
+	if err != nil {
+		return Sensor{}, err
+	}
+	sn.Online = true
+	return sn, nil
+}
+
+func (s *service) List(ctx context.Context) ([]Sensor, error) {
+	ss, err := s.sensors.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	for i := range ss {
+		ss[i].Online = s.online(ss[i], now)
+	}
+	return ss, nil
+}
+
+func (s *service) Deregister(ctx context.Context, id string) error {
+	return s.sensors.Delete(id)
+}
+
+func (s *service) Heartbeat(ctx context.Context, id string) (Sensor, error) {
+	sn, err := s.touch(id)
+	if err != nil {
+		return Sensor{}, err
+	}
+	sn.Online = true
+	return sn, nil
+}
+
+func (s *service) Record(ctx context.Context, r Reading) (parking.Spot, error) {
+	return s.record(ctx, r)
+}
+
+func (s *service) Ingest(ctx context.Context, rr []Reading) (IngestResult, error) {
+	res := IngestResult{Rejected: make([]Rejection, 0)}
+	for i, r := range rr {
+		if _, err := s.record(ctx, r); err != nil {
+			e := apierror.From(err)
+			if e.Kind == apierror.Unavailable || e.Kind == apierror.Internal {
+				// the rest of the batch would fail alike
+				return res, err
+			}
+			res.Rejected = append(res.Rejected, Rejection{Index: i, Code: e.Code, Message: e.Message})
+			continue
+		}
+		res.Accepted++
+	}
+	return res, nil
+}
+
+func (s *service) record(ctx context.Context, r Reading) (parking.Spot, error) {
+	sn, err := s.touch(r.SensorID)
+	if err != nil {
+		return parking.Spot{}, err
+	}
+	if r.SpotId == 0 && len(sn.Spots) == 1 {
+		r.SpotId = sn.Spots[0]
+	}
+	if !sn.Covers(r.SpotId) {
+		return parking.Spot{}, ErrSpotNotCovered
+	}
+	now := s.now()
+	if r.At.IsZero() {
+		r.At = now
+	} else if r.At.After(now.Add(maxClockSkew)) {
+		return parking.Spot{}, ErrFutureReading
+	}
+	o := parking.Vacant
+	if r.Occupied {
+		o = parking.Occupied
+	}
+	return s.spots.SetOccupancy(ctx, strconv.Itoa(r.SpotId), o, r.At)
+}
+
+// touch records a sign of life from sensor id
+func (s *service) touch(id string) (Sensor, error) {
+	sn, err := s.sensors.Find(id)
+	if err != nil {
+		return Sensor{}, err
+	}
+	sn.LastSeen = s.now().UTC()
+	return s.sensors.Update(sn)
+}
+
+func (s *service) online(sn Sensor, now time.Time) bool {
+	return now.Sub(sn.seenAt()) <= s.o.HeartbeatTimeout
+}
+
+func (s *service) Alerts(ctx context.Context) ([]Alert, error) {
+	return s.alerts.GetAll()
+}
+
+func (s *service) Check(ctx context.Context) ([]Alert, error) {
+	now := s.now()
+	sensors, err := s.sensors.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	spots, err := s.spots.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var bookings []booking.Booking
+	if s.bookings != nil {
+		if bookings, err = s.bookings.GetAll(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	found := map[string]Alert{}
+	raise := func(a Alert) { found[a.ID] = a }
+
+	// spots only count as watched while one of their sensors is online
+	watched := map[int]bool{}
+	for _, sn := range sensors {
+		if !s.online(sn, now) {
+			raise(Alert{
+				ID:       "sensor_offline:" + sn.ID,
+				Kind:     AlertSensorOffline,
+				SensorID: sn.ID,
+				Message:  fmt.Sprintf("sensor %s has not been heard from since %s", sn.ID, sn.seenAt().Format(time.RFC3339)),
+			})
+			continue
+		}
+		for _, id := range sn.Spots {
+			watched[id] = true
+		}
+	}
+
+	for _, sp := range spots {
+		if !watched[sp.ID] || sp.Occupancy == parking.OccupancyUnknown {
+			continue
+		}
+		b, booked := s.bookedAt(sp, bookings, now)
+		switch {
+		case sp.Occupancy == parking.Occupied && !booked && now.Sub(sp.OccupancyAt) > s.o.Grace:
+			raise(Alert{
+				ID:      fmt.Sprintf("occupied_unbooked:%d", sp.ID),
+				Kind:    AlertUnbooked,
+				SpotId:  sp.ID,
+				Message: fmt.Sprintf("spot %d is occupied without a booking", sp.ID),
+			})
+		case sp.Occupancy == parking.Vacant && booked && b.Status != booking.StatusCheckedIn && now.Sub(s.bookedSince(sp, b)) > s.o.Grace:
+			raise(Alert{
+				ID:        fmt.Sprintf("booked_vacant:%d", sp.ID),
+				Kind:      AlertBookedVacant,
+				SpotId:    sp.ID,
+				BookingId: b.ID,
+				Message:   fmt.Sprintf("spot %d is booked but still empty", sp.ID),
+			})
+		}
+	}
+	return s.reconcile(ctx, found, now)
+}
+
+// bookedAt returns the booking holding sp at now. Without bookings the
+// reservation flag of the spot stands in for it.
+func (s *service) bookedAt(sp parking.Spot, bookings []booking.Booking, now time.Time) (booking.Booking, bool) {
+	if s.bookings == nil {
+		return booking.Booking{}, sp.IsReserved
+	}
+	for _, b := range bookings {
+		if b.SpotId == sp.ID && !b.Released() && b.ActiveAt(now) {
+			return b, true
+		}
+	}
+	return booking.Booking{}, false
+}
+
+// bookedSince is when the spot should have been taken at the latest
+func (s *service) bookedSince(sp parking.Spot, b booking.Booking) time.Time {
+	if s.bookings == nil || b.StartTime.Before(sp.OccupancyAt) {
+		return sp.OccupancyAt
+	}
+	return b.StartTime
+}
+
+// reconcile stores the alerts found, publishing the new ones, and resolves
+// the stored alerts that were not found again
+func (s *service) reconcile(ctx context.Context, found map[string]Alert, now time.Time) ([]Alert, error) {
+	current, err := s.alerts.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	active := map[string]bool{}
+	for _, a := range current {
+		if _, ok := found[a.ID]; ok {
+			active[a.ID] = true
+			continue
+		}
+		if err := s.alerts.Remove(a.ID); err != nil {
+			return nil, err
+		}
+		s.events.Publish(ctx, event.New(AlertResolved{a}, now))
+	}
+	for id, a := range found {
+		if active[id] {
+			continue
+		}
+		a.RaisedAt = now.UTC()
+		if err := s.alerts.Put(a); err != nil {
+			return nil, err
+		}
+		s.events.Publish(ctx, event.New(AlertRaised{a}, now))
+	}
+	return s.alerts.GetAll()
+}
@@ -0,0 +1,177 @@
+package sensor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+)
+
+type fakeBookings []booking.Booking
+
+func (bb fakeBookings) GetAll(ctx context.Context) ([]booking.Booking, error) { return bb, nil }
+
+type recorder struct {
+	mtx    sync.Mutex
+	events []event.Event
+}
+
+func (r *recorder) Publish(ctx context.Context, e event.Event) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.events = append(r.events, e)
+}
+
+// newTestService returns the service on a clock the test moves with advance
+func newTestService(t *testing.T, bookings Bookings) (Service, parking.Service, *recorder, func(time.Duration)) {
+	sensors, _ := NewInMemSensorStore()
+	alerts, _ := NewInMemAlertStore()
+	store, _ := parking.NewInMemParkingStore()
+	spots := parking.NewService(store)
+	events := &recorder{}
+	s := NewService(sensors, alerts, spots, bookings, events, Options{HeartbeatTimeout: 5 * time.Minute, Grace: 10 * time.Minute})
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	s.(*service).now = func() time.Time { return now }
+	return s, spots, events, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRegister(t *testing.T) {
+	s, _, _, _ := newTestService(t, nil)
+	ctx := context.Background()
+	for _, sn := range []Sensor{
+		{Kind: Ground, Spots: []int{1}},
+		{ID: "g1", Kind: "radar", Spots: []int{1}},
+		{ID: "g1", Kind: Ground, Spots: []int{1, 2}},
+		{ID: "c1", Kind: Camera},
+	} {
+		if _, err := s.Register(ctx, sn); err == nil || apierror.From(err).Code != ErrInvalidSensor.Code {
+			t.Errorf("Expected %+v to be rejected, got %v", sn, err)
+		}
+	}
+	if _, err := s.Register(ctx, Sensor{ID: "g1", Kind: Ground, Spots: []int{99}}); err != ErrUnknownSpot {
+		t.Errorf("Expected an unknown spot to be rejected, got %v", err)
+	}
+	if _, err := s.Register(ctx, Sensor{ID: "g1", Kind: Ground, Spots: []int{1}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Register(ctx, Sensor{ID: "g1", Kind: Ground, Spots: []int{2}}); err != ErrAlreadyRegistered {
+		t.Errorf("Expected a duplicate id to be rejected, got %v", err)
+	}
+}
+
+func TestReadings(t *testing.T) {
+	s, spots, _, advance := newTestService(t, nil)
+	ctx := context.Background()
+	s.Register(ctx, Sensor{ID: "g1", Kind: Ground, Spots: []int{1}})
+	s.Register(ctx, Sensor{ID: "c1", Kind: Camera, Spots: []int{2, 3}})
+	start := s.(*service).now()
+
+	sp, err := s.Record(ctx, Reading{SensorID: "g1", Occupied: true})
+	if err != nil || sp.Occupancy != parking.Occupied || sp.IsReserved {
+		t.Fatalf("Expected spot 1 occupied and still free to book, got %+v %v", sp, err)
+	}
+	if _, err := s.Record(ctx, Reading{SensorID: "c1", Occupied: true}); err != ErrSpotNotCovered {
+		t.Errorf("Expected a camera reading without spot to be rejected, got %v", err)
+	}
+	if _, err := s.Record(ctx, Reading{SensorID: "g1", At: start.Add(time.Hour)}); err != ErrFutureReading {
+		t.Errorf("Expected a reading from the future to be rejected, got %v", err)
+	}
+
+	advance(time.Minute)
+	res, err := s.Ingest(ctx, []Reading{
+		{SensorID: "c1", SpotId: 2, Occupied: true},
+		{SensorID: "c1", SpotId: 3},
+		{SensorID: "nope", SpotId: 3},
+		{SensorID: "c1", SpotId: 4},
+		// arrives late, spot 1 has a newer reading
+		{SensorID: "g1", At: start.Add(-time.Minute)},
+	})
+	if err != nil || res.Accepted != 3 || len(res.Rejected) != 2 {
+		t.Fatalf("Expected 3 accepted and 2 rejected readings, got %+v %v", res, err)
+	}
+	if res.Rejected[0].Index != 2 || res.Rejected[0].Code != ErrNotFound.Code || res.Rejected[1].Code != ErrSpotNotCovered.Code {
+		t.Errorf("Unexpected rejections %+v", res.Rejected)
+	}
+	if sp, _ := spots.FindById(ctx, "1"); sp.Occupancy != parking.Occupied {
+		t.Error("Expected the late reading to be ignored")
+	}
+	if sp, _ := spots.FindById(ctx, "3"); sp.Occupancy != parking.Vacant {
+		t.Error("Expected spot 3 vacant")
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	bookings := fakeBookings{}
+	s, _, events, advance := newTestService(t, &bookings)
+	ctx := context.Background()
+	start := s.(*service).now()
+	s.Register(ctx, Sensor{ID: "g1", Kind: Ground, Spots: []int{1}})
+	s.Register(ctx, Sensor{ID: "g2", Kind: Ground, Spots: []int{2}})
+	s.Register(ctx, Sensor{ID: "g3", Kind: Ground, Spots: []int{3}})
+	bookings = append(bookings, booking.Booking{ID: 7, SpotId: 2, StartTime: start, Duration: time.Hour, Status: booking.StatusBooked})
+
+	s.Record(ctx, Reading{SensorID: "g1", Occupied: true})
+	s.Record(ctx, Reading{SensorID: "g2"})
+	if aa, _ := s.Check(ctx); len(aa) != 0 {
+		t.Fatalf("Expected no alerts within the grace period, got %+v", aa)
+	}
+
+	for i := 0; i < 2; i++ {
+		advance(4 * time.Minute)
+		s.Heartbeat(ctx, "g1")
+		s.Heartbeat(ctx, "g2")
+	}
+	advance(3 * time.Minute)
+	aa, _ := s.Check(ctx)
+	if len(aa) != 3 {
+		t.Fatalf("Expected 3 alerts, got %+v", aa)
+	}
+	want := map[string]AlertKind{"booked_vacant:2": AlertBookedVacant, "occupied_unbooked:1": AlertUnbooked, "sensor_offline:g3": AlertSensorOffline}
+	for _, a := range aa {
+		if want[a.ID] != a.Kind {
+			t.Errorf("Unexpected alert %+v", a)
+		}
+	}
+	if aa[0].BookingId != 7 {
+		t.Errorf("Expected the alert to name booking 7, got %d", aa[0].BookingId)
+	}
+
+	// the car arrives at spot 2, the alert clears once and only once
+	s.Record(ctx, Reading{SensorID: "g2", Occupied: true})
+	s.Heartbeat(ctx, "g1")
+	s.Heartbeat(ctx, "g3")
+	s.Check(ctx)
+	aa, _ = s.Check(ctx)
+	if len(aa) != 1 || aa[0].ID != "occupied_unbooked:1" {
+		t.Errorf("Expected only spot 1 still alerting, got %+v", aa)
+	}
+	raised, resolved := 0, 0
+	for _, e := range events.events {
+		switch e.Type {
+		case event.AlertRaised:
+			raised++
+		case event.AlertResolved:
+			resolved++
+		}
+	}
+	if raised != 3 || resolved != 2 {
+		t.Errorf("Expected 3 raised and 2 resolved events, got %d and %d", raised, resolved)
+	}
+
+	// an offline sensor no longer vouches for its spot
+	advance(6 * time.Minute)
+	aa, _ = s.Check(ctx)
+	for _, a := range aa {
+		if a.Kind != AlertSensorOffline {
+			t.Errorf("Expected only offline alerts, got %+v", a)
+		}
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
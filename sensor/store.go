@@ -0,0 +1,189 @@
+package sensor
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+type SensorStore interface {
+	Create(s Sensor) (Sensor, error)
+	Update(s Sensor) (Sensor, error)
+	Delete(id string) error
+	Find(id string) (Sensor, error)
+	GetAll() ([]Sensor, error)
+}
+
+// AlertStore holds the alerts currently raised, keyed by Alert.ID
+type AlertStore interface {
+	Put(a Alert) error
+	Remove(id string) error
+	GetAll() ([]Alert, error)
+}
+
+type Kind string
+
+const (
+	// Ground sensors sit in a single spot
+	Ground Kind = "ground"
+	// Camera sensors may watch several spots
+	Camera Kind = "camera"
+)
+
+func (k Kind) Valid() bool {
+	return k == Ground || k == Camera
+}
+
+type Sensor struct {
+	ID    string `json:"id"`
+	Kind  Kind   `json:"kind"`
+	Spots []int  `json:"spots"`
+	// LastSeen is when the sensor last sent a reading or heartbeat
+	LastSeen     time.Time `json:"lastSeen,omitempty"`
+	RegisteredAt time.Time `json:"registeredAt"`
+	// Online is worked out when listing, from LastSeen and the heartbeat
+	// timeout
+	Online bool `json:"online"`
+}
+
+// Covers reports whether the sensor watches spot id
+func (s Sensor) Covers(id int) bool {
+	for _, spot := range s.Spots {
+		if spot == id {
+			return true
+		}
+	}
+	return false
+}
+
+// seenAt is the last sign of life, registration counts as one
+func (s Sensor) seenAt() time.Time {
+	if s.LastSeen.After(s.RegisteredAt) {
+		return s.LastSeen
+	}
+	return s.RegisteredAt
+}
+
+type AlertKind string
+
+const (
+	// AlertUnbooked is raised for a spot occupied without a booking
+	AlertUnbooked AlertKind = "occupied_unbooked"
+	// AlertBookedVacant is raised for a booked spot still empty after the
+	// grace period
+	AlertBookedVacant AlertKind = "booked_vacant"
+	// AlertSensorOffline is raised for a sensor missing its heartbeats
+	AlertSensorOffline AlertKind = "sensor_offline"
+)
+
+type Alert struct {
+	// ID names the condition, the same condition keeps its ID while it lasts
+	ID        string    `json:"id"`
+	Kind      AlertKind `json:"kind"`
+	SpotId    int       `json:"spotId,omitempty"`
+	BookingId int       `json:"bookingId,omitempty"`
+	SensorID  string    `json:"sensorId,omitempty"`
+	Message   string    `json:"message"`
+	RaisedAt  time.Time `json:"raisedAt"`
+}
+
+var (
+	ErrNotFound          = apierror.New(apierror.NotFound, "sensor_not_found", "sensor not found")
+	ErrAlreadyRegistered = apierror.New(apierror.Conflict, "sensor_already_registered", "a sensor with this id is already registered")
+)
+
+type inMemSensorStore struct {
+	mtx sync.RWMutex
+	m   map[string]Sensor
+}
+
+func NewInMemSensorStore() (SensorStore, error) {
+	return &inMemSensorStore{m: map[string]Sensor{}}, nil
+}
+
+func (s *inMemSensorStore) Create(sn Sensor) (Sensor, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[sn.ID]; ok {
+		return Sensor{}, ErrAlreadyRegistered
+	}
+	s.m[sn.ID] = sn
+	return sn, nil
+}
+
+func (s *inMemSensorStore) Update(sn Sensor) (Sensor, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[sn.ID]; !ok {
+		return Sensor{}, ErrNotFound
+	}
+	s.m[sn.ID] = sn
+	return sn, nil
+}
+
+func (s *inMemSensorStore) Delete(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.m, id)
+	return nil
+}
+
+func (s *inMemSensorStore) Find(id string) (Sensor, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	sn, ok := s.m[id]
+	if !ok {
+		return Sensor{}, ErrNotFound
+	}
+	return sn, nil
+}
+
+func (s *inMemSensorStore) GetAll() ([]Sensor, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	ss := make([]Sensor, 0, len(s.m))
+	for _, sn := range s.m {
+		ss = append(ss, sn)
+	}
+	sort.Slice(ss, func(i, j int) bool { return ss[i].ID < ss[j].ID })
+	return ss, nil
+}
+
+type inMemAlertStore struct {
+	mtx sync.RWMutex
+	m   map[string]Alert
+}
+
+func NewInMemAlertStore() (AlertStore, error) {
+	return &inMemAlertStore{m: map[string]Alert{}}, nil
+}
+
+func (s *inMemAlertStore) Put(a Alert) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.m[a.ID] = a
+	return nil
+}
+
+func (s *inMemAlertStore) Remove(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.m, id)
+	return nil
+}
+
+func (s *inMemAlertStore) GetAll() ([]Alert, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	aa := make([]Alert, 0, len(s.m))
+	for _, a := range s.m {
+		aa = append(aa, a)
+	}
+	sort.Slice(aa, func(i, j int) bool { return aa[i].ID < aa[j].ID })
+	return aa, nil
+}
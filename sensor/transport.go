@@ -0,0 +1,147 @@
+package sensor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+// MaxBatch is the most readings accepted in one batch
+const MaxBatch = 500
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"RegisterSensor":   {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"ListSensors":      {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"ListAlerts":       {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"RecordReading":    {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleService, auth.RolePartner, auth.RoleOperator, auth.RoleAdmin}},
+	"IngestReadings":   {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleService, auth.RolePartner, auth.RoleOperator, auth.RoleAdmin}},
+	"DeregisterSensor": {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"SensorHeartbeat":  {Scope: auth.ScopeParkingWrite, Roles: []auth.Role{auth.RoleService, auth.RolePartner, auth.RoleOperator, auth.RoleAdmin}},
+}
+
+// MakeHTTPHandler mounts the sensor endpoints into an http.Handler. Sensors
+// are managed by operators and admins, readings and heartbeats come from
+// service accounts, usually partner API keys with the parking:write scope.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("POST").Path("/sensor/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "RegisterSensor")(e.RegisterEndpoint),
+		decodeRegisterRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/sensor/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListSensors")(e.ListEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/sensor/v1/alerts").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListAlerts")(e.AlertsEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/sensor/v1/readings").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "RecordReading")(e.RecordEndpoint),
+		decodeRecordRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/sensor/v1/readings/batch").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "IngestReadings")(e.IngestEndpoint),
+		decodeIngestRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/sensor/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "DeregisterSensor")(e.DeregisterEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/sensor/v1/{id}/heartbeat").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "SensorHeartbeat")(e.HeartbeatEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeRegisterRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req registerRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req listRequest
+	return req, nil
+}
+
+func decodeRecordRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req Reading
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeIngestRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req ingestRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	if len(req.Readings) == 0 || len(req.Readings) > MaxBatch {
+		return nil, ErrInvalidReq.WithField("readings", "must hold 1 to 500 readings")
+	}
+	return req, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return idRequest{ID: id}, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
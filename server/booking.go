@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	bookingpb "github.com/atuldaemon/rct/booking/pb"
+	"github.com/atuldaemon/rct/enforcement"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/recurring"
+	"github.com/atuldaemon/rct/subscriptions"
+	"github.com/atuldaemon/rct/vehicle"
+	"github.com/atuldaemon/rct/waitlist"
+	"github.com/go-kit/kit/log"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+// BookingOptions tune booking and the services built on it
+type BookingOptions struct {
+	PassSecret    string
+	Expiry        time.Duration
+	WaitlistOffer time.Duration
+	WaitlistRun   time.Duration
+	SeriesWindow  time.Duration
+	SeriesRun     time.Duration
+	PassNotice    time.Duration
+	PassPayment   time.Duration
+	PassRun       time.Duration
+	EnforceScan   time.Duration
+	EnforceGrace  time.Duration
+}
+
+// Flags registers the booking flags on fs
+func (o *BookingOptions) Flags(fs *flag.FlagSet) {
+	fs.StringVar(&o.PassSecret, "booking.passsecret", "", "Secret gate passes are signed with, random when empty")
+	fs.DurationVar(&o.Expiry, "booking.expiry", time.Minute, "Interval at which ended bookings expire and release their spots")
+	fs.DurationVar(&o.WaitlistOffer, "waitlist.offer", waitlist.DefaultOfferTTL, "How long a spot offered to the waitlist stands")
+	fs.DurationVar(&o.WaitlistRun, "waitlist.expiry", time.Minute, "Interval at which lapsed waitlist offers are passed on")
+	fs.DurationVar(&o.SeriesWindow, "recurring.window", recurring.DefaultWindow, "How long before it starts an occurrence of a recurring series is booked at most")
+	fs.DurationVar(&o.SeriesRun, "recurring.run", time.Minute, "Interval at which recurring occurrences are booked")
+	fs.DurationVar(&o.PassNotice, "subscriptions.notice", subscriptions.DefaultRenewalNotice, "How long before a pass ends its renewal payment is asked for")
+	fs.DurationVar(&o.PassPayment, "subscriptions.payment", subscriptions.DefaultPaymentWindow, "How long a new pass waits for its first payment")
+	fs.DurationVar(&o.PassRun, "subscriptions.run", time.Minute, "Interval at which renewals are asked for and unpaid passes lapse")
+	fs.DurationVar(&o.EnforceScan, "enforcement.scan", time.Minute, "Interval at which occupied spots are scanned for violations")
+	fs.DurationVar(&o.EnforceGrace, "enforcement.grace", enforcement.DefaultGrace, "How long a violation must last before it is reported")
+}
+
+// Booking holds booking and the services built on it
+type Booking struct {
+	Vehicles      vehicle.Service
+	Bookings      booking.Service
+	Waitlist      waitlist.Service
+	Recurring     recurring.Service
+	Subscriptions subscriptions.Service
+	Enforcement   enforcement.Service
+}
+
+// NewBooking builds the booking side on spots and starts its background
+// runs: expiring bookings, passing on waitlist offers, booking recurring
+// occurrences, renewing passes and scanning for violations.
+func NewBooking(logger log.Logger, spots parking.Service, events *Events, o BookingOptions) (*Booking, error) {
+	var bk Booking
+
+	vehicleStore, err := vehicle.NewInMemVehicleStore()
+	if err != nil {
+		return nil, err
+	}
+	{
+		bk.Vehicles = vehicle.NewService(vehicleStore)
+		bk.Vehicles = vehicle.LoggingMiddleware(logger)(bk.Vehicles)
+	}
+	v := bk.Vehicles
+
+	bookingStore, err := booking.NewInMemBookingStore()
+	if err != nil {
+		return nil, err
+	}
+	holdStore, err := booking.NewInMemHoldStore()
+	if err != nil {
+		return nil, err
+	}
+	groupStore, err := booking.NewInMemGroupStore()
+	if err != nil {
+		return nil, err
+	}
+	noShowStore, err := booking.NewInMemNoShowStore()
+	if err != nil {
+		return nil, err
+	}
+	bufferStore, err := booking.NewInMemBufferStore()
+	if err != nil {
+		return nil, err
+	}
+	passes, err := booking.NewPasses([]byte(o.PassSecret))
+	if err != nil {
+		return nil, err
+	}
+	var b booking.Service
+	{
+		b = booking.NewService(bookingStore, spots, v, booking.Options{Holds: holdStore, Groups: groupStore, NoShows: noShowStore, Buffers: bufferStore, Events: events.Bus, Passes: passes})
+		b = booking.LoggingMiddleware(logger)(b)
+		b = booking.NewInstrumentingService(
+			kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "api",
+				Subsystem: "booking_service",
+				Name:      "request_count",
+				Help:      "Number of requests received.",
+			}, fieldKeys),
+			kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+				Namespace: "api",
+				Subsystem: "booking_service",
+				Name:      "request_latency_microseconds",
+				Help:      "Total duration of requests in microseconds.",
+			}, fieldKeys),
+			b)
+	}
+	bk.Bookings = b
+	every(o.Expiry, func(ctx context.Context) { b.Expire(ctx) })
+
+	entryStore, err := waitlist.NewInMemEntryStore()
+	if err != nil {
+		return nil, err
+	}
+	var wl waitlist.Service
+	{
+		wl = waitlist.NewService(entryStore, spots, b, v, events.Bus, o.WaitlistOffer)
+		wl = waitlist.LoggingMiddleware(logger)(wl)
+	}
+	bk.Waitlist = wl
+	events.Bus.Subscribe("waitlist", waitlist.Assign(wl, log.With(logger, "component", "waitlist")), event.BookingCancelled, event.BookingExpired, event.BookingChanged, event.BookingNoShow, event.HoldReleased, event.HoldExpired)
+	every(o.WaitlistRun, func(ctx context.Context) { wl.Expire(ctx) })
+
+	seriesStore, err := recurring.NewInMemSeriesStore()
+	if err != nil {
+		return nil, err
+	}
+	var rc recurring.Service
+	{
+		rc = recurring.NewService(seriesStore, spots, b, v, o.SeriesWindow)
+		rc = recurring.LoggingMiddleware(logger)(rc)
+	}
+	bk.Recurring = rc
+	every(o.SeriesRun, func(ctx context.Context) { rc.Materialise(ctx) })
+
+	productStore, err := subscriptions.NewInMemProductStore()
+	if err != nil {
+		return nil, err
+	}
+	subscriptionStore, err := subscriptions.NewInMemSubscriptionStore()
+	if err != nil {
+		return nil, err
+	}
+	var sb subscriptions.Service
+	{
+		sb = subscriptions.NewService(productStore, subscriptionStore, spots, b, v, events.Bus, subscriptions.Options{RenewalNotice: o.PassNotice, PaymentWindow: o.PassPayment})
+		sb = subscriptions.LoggingMiddleware(logger)(sb)
+	}
+	bk.Subscriptions = sb
+	every(o.PassRun, func(ctx context.Context) { sb.Run(ctx) })
+
+	violationStore, err := enforcement.NewInMemViolationStore()
+	if err != nil {
+		return nil, err
+	}
+	citationStore, err := enforcement.NewInMemCitationStore()
+	if err != nil {
+		return nil, err
+	}
+	var en enforcement.Service
+	{
+		en = enforcement.NewService(violationStore, citationStore, spots, b, v, o.EnforceGrace)
+		en = enforcement.LoggingMiddleware(logger)(en)
+	}
+	bk.Enforcement = en
+	every(o.EnforceScan, func(ctx context.Context) { en.Scan(ctx) })
+
+	return &bk, nil
+}
+
+// Handle serves the booking side APIs on mux and g and describes them in doc
+func (bk *Booking) Handle(mux *http.ServeMux, g *grpc.Server, doc *openapi.Document, a *auth.Authorizer, logger log.Logger) {
+	httpLogger := log.With(logger, "component", "HTTP")
+	mux.Handle("/booking/v1/", booking.MakeHTTPHandler(bk.Bookings, a, httpLogger))
+	mux.Handle("/vehicle/v1/", vehicle.MakeHTTPHandler(bk.Vehicles, a, httpLogger))
+	mux.Handle("/enforcement/v1/", enforcement.MakeHTTPHandler(bk.Enforcement, a, httpLogger))
+	mux.Handle("/waitlist/v1/", waitlist.MakeHTTPHandler(bk.Waitlist, a, httpLogger))
+	mux.Handle("/recurring/v1/", recurring.MakeHTTPHandler(bk.Recurring, a, httpLogger))
+	mux.Handle("/subscriptions/v1/", subscriptions.MakeHTTPHandler(bk.Subscriptions, a, httpLogger))
+	bookingpb.RegisterBookingServiceServer(g, booking.MakeGRPCServer(bk.Bookings, a, log.With(logger, "component", "gRPC")))
+
+	booking.AddOpenAPI(doc)
+	vehicle.AddOpenAPI(doc)
+	enforcement.AddOpenAPI(doc)
+	waitlist.AddOpenAPI(doc)
+	recurring.AddOpenAPI(doc)
+	subscriptions.AddOpenAPI(doc)
+}
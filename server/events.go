@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/history"
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/webhook"
+	"github.com/go-kit/kit/log"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Events is the event bus of a process with its subscribers: webhook
+// delivery, the history, the event metrics and the optional events file
+type Events struct {
+	Bus      *event.Bus
+	Log      history.EventLog
+	Webhooks webhook.Service
+	History  history.Service
+
+	dispatcher *webhook.Dispatcher
+	file       *event.FileAdapter
+}
+
+// NewEvents starts the bus, appending every event to file unless it is
+// empty. Close stops it.
+func NewEvents(logger log.Logger, file string) (*Events, error) {
+	webhookStore, err := webhook.NewInMemSubscriptionStore()
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := webhook.NewInMemDeadLetterStore()
+	if err != nil {
+		return nil, err
+	}
+	eventLog, err := history.NewInMemEventLog()
+	if err != nil {
+		return nil, err
+	}
+	e := &Events{
+		Bus:        event.NewBus(log.With(logger, "component", "events")),
+		Log:        eventLog,
+		dispatcher: webhook.NewDispatcher(webhookStore, deadLetters, webhook.DispatcherOptions{}, log.With(logger, "component", "webhook")),
+	}
+	{
+		e.Webhooks = webhook.NewService(webhookStore, deadLetters, e.dispatcher)
+		e.Webhooks = webhook.LoggingMiddleware(logger)(e.Webhooks)
+	}
+	{
+		e.History = history.NewService(eventLog)
+		e.History = history.LoggingMiddleware(logger)(e.History)
+	}
+
+	e.Bus.Subscribe("webhook", e.dispatcher.Publish)
+	e.Bus.Subscribe("history", history.Record(eventLog, log.With(logger, "component", "history")))
+	e.Bus.Subscribe("metrics", event.Counting(kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+		Namespace: "api",
+		Subsystem: "events",
+		Name:      "published_count",
+		Help:      "Number of domain events published.",
+	}, []string{"type"})))
+	if file != "" {
+		if e.file, err = event.NewFileAdapter(file); err != nil {
+			e.Close()
+			return nil, err
+		}
+		e.Bus.Attach("file", e.file)
+	}
+	return e, nil
+}
+
+// Handle mounts the webhook and history APIs on mux and describes them in doc
+func (e *Events) Handle(mux *http.ServeMux, doc *openapi.Document, a *auth.Authorizer, logger log.Logger) {
+	logger = log.With(logger, "component", "HTTP")
+	mux.Handle("/webhook/v1/", webhook.MakeHTTPHandler(e.Webhooks, a, logger))
+	mux.Handle("/history/v1/", history.MakeHTTPHandler(e.History, a, logger))
+	webhook.AddOpenAPI(doc)
+	history.AddOpenAPI(doc)
+}
+
+// Close delivers the events still queued and stops the bus
+func (e *Events) Close() {
+	e.Bus.Close()
+	e.dispatcher.Close()
+	if e.file != nil {
+		e.file.Close()
+	}
+}
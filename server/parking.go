@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/history"
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/parking"
+	parkingpb "github.com/atuldaemon/rct/parking/pb"
+	"github.com/atuldaemon/rct/sensor"
+	"github.com/go-kit/kit/log"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+// Parking is the parking service of the process that owns the spots
+type Parking struct {
+	Spots parking.Service
+
+	stop func()
+}
+
+// NewParking records the spots the store starts with in the history, which
+// never saw them created, and publishes every later change on events.
+// Close stops publishing.
+func NewParking(logger log.Logger, events *Events) (*Parking, error) {
+	parkingStore, err := parking.NewInMemParkingStore()
+	if err != nil {
+		return nil, err
+	}
+	var p parking.Service
+	{
+		p = parking.NewService(parkingStore)
+		p = parking.LoggingMiddleware(logger)(p)
+		p = parking.NewInstrumentingService(
+			kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "api",
+				Subsystem: "parking_service",
+				Name:      "request_count",
+				Help:      "Number of requests received.",
+			}, fieldKeys),
+			kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+				Namespace: "api",
+				Subsystem: "parking_service",
+				Name:      "request_latency_microseconds",
+				Help:      "Total duration of requests in microseconds.",
+			}, fieldKeys),
+			p)
+	}
+	spots, err := parking.NewService(parkingStore).GetAll(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if err := history.Snapshot(events.Log, spots, time.Now()); err != nil {
+		return nil, err
+	}
+	stop := parking.PublishChanges(parkingStore, events.Bus, log.With(logger, "component", "changes"),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "api",
+			Subsystem: "events",
+			Name:      "spot_change_gaps",
+			Help:      "Number of times spot changes were lost before being published.",
+		}, []string{}))
+	return &Parking{Spots: p, stop: stop}, nil
+}
+
+// Handle serves the parking API on mux and g and describes it in doc
+func (p *Parking) Handle(mux *http.ServeMux, g *grpc.Server, doc *openapi.Document, a *auth.Authorizer, logger log.Logger) {
+	mux.Handle("/parking/v1/", parking.MakeHTTPHandler(p.Spots, a, log.With(logger, "component", "HTTP")))
+	parkingpb.RegisterParkingServiceServer(g, parking.MakeGRPCServer(p.Spots, a, log.With(logger, "component", "gRPC")))
+	parking.AddOpenAPI(doc)
+}
+
+func (p *Parking) Close() {
+	p.stop()
+}
+
+// SensorOptions tune the occupancy sensors
+type SensorOptions struct {
+	sensor.Options
+	Check time.Duration
+}
+
+// Flags registers the sensor flags on fs
+func (o *SensorOptions) Flags(fs *flag.FlagSet) {
+	fs.DurationVar(&o.HeartbeatTimeout, "sensor.heartbeat", sensor.DefaultHeartbeatTimeout, "How long a sensor may stay silent before it is reported offline")
+	fs.DurationVar(&o.Grace, "sensor.grace", sensor.DefaultGrace, "How long occupancy and bookings may disagree before an alert is raised")
+	fs.DurationVar(&o.Check, "sensor.check", time.Minute, "Interval at which occupancy is checked against bookings")
+}
+
+// Sensors tracks occupancy from the sensors on the spots
+type Sensors struct {
+	Sensors sensor.Service
+}
+
+// NewSensors checks occupancy every o.Check. Without bookings a reserved
+// spot counts as booked.
+func NewSensors(logger log.Logger, spots parking.Service, bookings sensor.Bookings, events *Events, o SensorOptions) (*Sensors, error) {
+	sensorStore, err := sensor.NewInMemSensorStore()
+	if err != nil {
+		return nil, err
+	}
+	alertStore, err := sensor.NewInMemAlertStore()
+	if err != nil {
+		return nil, err
+	}
+	var sn sensor.Service
+	{
+		sn = sensor.NewService(sensorStore, alertStore, spots, bookings, events.Bus, o.Options)
+		sn = sensor.LoggingMiddleware(logger)(sn)
+	}
+	every(o.Check, func(ctx context.Context) { sn.Check(ctx) })
+	return &Sensors{Sensors: sn}, nil
+}
+
+// Handle serves the sensor API on mux and describes it in doc
+func (s *Sensors) Handle(mux *http.ServeMux, doc *openapi.Document, a *auth.Authorizer, logger log.Logger) {
+	mux.Handle("/sensor/v1/", sensor.MakeHTTPHandler(s.Sensors, a, log.With(logger, "component", "HTTP")))
+	sensor.AddOpenAPI(doc)
+}
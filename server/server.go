@@ -0,0 +1,150 @@
+// Package server wires the services into processes. The root binary runs
+// all of them, cmd/parking and cmd/booking each run their half, and all
+// three build it from the constructors here.
+package server
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/atuldaemon/rct/apikey"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/go-kit/kit/log"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+// Options are the settings every process shares
+type Options struct {
+	AuthSecret string
+	ServiceKey string
+	EventsFile string
+}
+
+// Flags registers the shared flags on fs
+func (o *Options) Flags(fs *flag.FlagSet) {
+	fs.StringVar(&o.AuthSecret, "auth.secret", "", "Shared secret used to verify bearer tokens")
+	fs.StringVar(&o.ServiceKey, "auth.servicekey", "", "API key granted the service account role")
+	fs.StringVar(&o.EventsFile, "events.file", "", "Append every domain event to this file as JSON lines")
+}
+
+// fieldKeys label the request metrics of the services
+var fieldKeys = []string{"method"}
+
+func NewLogger() log.Logger {
+	logger := log.NewLogfmtLogger(os.Stderr)
+	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+	return log.With(logger, "caller", log.DefaultCaller)
+}
+
+// APIKeys issues the partner API keys of a process
+type APIKeys struct {
+	Keys apikey.Service
+
+	store apikey.KeyStore
+}
+
+func NewAPIKeys(logger log.Logger) (*APIKeys, error) {
+	keyStore, err := apikey.NewInMemKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	var k apikey.Service
+	{
+		k = apikey.NewService(keyStore)
+		k = apikey.LoggingMiddleware(logger)(k)
+	}
+	return &APIKeys{Keys: k, store: keyStore}, nil
+}
+
+// Handle serves the API key API on mux and describes it in doc
+func (k *APIKeys) Handle(mux *http.ServeMux, doc *openapi.Document, a *auth.Authorizer, logger log.Logger) {
+	mux.Handle("/apikey/v1/", apikey.MakeHTTPHandler(k.Keys, a, log.With(logger, "component", "HTTP")))
+	apikey.AddOpenAPI(doc)
+}
+
+// NewAuthorizer accepts bearer tokens signed with o.AuthSecret, o.ServiceKey
+// as the service account and the partner keys issued by keys
+func NewAuthorizer(logger log.Logger, o Options, keys *APIKeys) *auth.Authorizer {
+	var tokens auth.TokenVerifier
+	if o.AuthSecret != "" {
+		tokens = auth.NewHMACTokens([]byte(o.AuthSecret))
+	}
+	serviceKeys := auth.NewInMemKeyStore()
+	if o.ServiceKey != "" {
+		serviceKeys.Add(o.ServiceKey, auth.Principal{Subject: "service", Roles: []auth.Role{auth.RoleService}})
+	}
+	return auth.NewAuthorizer(
+		auth.NewAuthenticator(tokens, auth.ChainKeyStores(serviceKeys, apikey.NewVerifier(keys.store))),
+		log.With(logger, "component", "auth"),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "api",
+			Subsystem: "auth",
+			Name:      "denied_count",
+			Help:      "Number of requests denied by authorization.",
+		}, []string{"method", "reason"}),
+	)
+}
+
+// Serve serves h, next to the metrics, on httpAddr and g on grpcAddr until
+// either fails or the process is interrupted, and returns why it stopped
+func Serve(logger log.Logger, httpAddr, grpcAddr string, h http.Handler, g *grpc.Server) error {
+	mux := http.NewServeMux()
+	mux.Handle("/", accessControl(h))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	errs := make(chan error, 3)
+	go func() {
+		logger.Log("transport", "http", "address", httpAddr, "msg", "listening")
+		errs <- http.ListenAndServe(httpAddr, mux)
+	}()
+	go func() {
+		ln, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			errs <- err
+			return
+		}
+		logger.Log("transport", "gRPC", "address", grpcAddr, "msg", "listening")
+		errs <- g.Serve(ln)
+	}()
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		errs <- fmt.Errorf("%s", <-c)
+	}()
+	return <-errs
+}
+
+// every runs f as the system principal once per interval, for the
+// background work of the services
+func every(interval time.Duration, f func(ctx context.Context)) {
+	go func() {
+		for range time.Tick(interval) {
+			f(auth.NewContext(context.Background(), auth.System))
+		}
+	}()
+}
+
+func accessControl(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, OPTIONS, DELETE")
+		w.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization, X-API-Key, Last-Event-ID")
+
+		if r.Method == "OPTIONS" {
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,148 @@
+package subscriptions
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	CreateProductEndpoint endpoint.Endpoint
+	ListProductsEndpoint  endpoint.Endpoint
+	EnrolEndpoint         endpoint.Endpoint
+	ListEndpoint          endpoint.Endpoint
+	FindEndpoint          endpoint.Endpoint
+	CancelEndpoint        endpoint.Endpoint
+	PaidEndpoint          endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		CreateProductEndpoint: MakeCreateProductEndpoint(s),
+		ListProductsEndpoint:  MakeListProductsEndpoint(s),
+		EnrolEndpoint:         MakeEnrolEndpoint(s),
+		ListEndpoint:          MakeListEndpoint(s),
+		FindEndpoint:          MakeFindEndpoint(s),
+		CancelEndpoint:        MakeCancelEndpoint(s),
+		PaidEndpoint:          MakePaidEndpoint(s),
+	}
+}
+
+func MakeCreateProductEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(productRequest)
+		p, err := s.CreateProduct(ctx, Product{
+			Name:     req.Name,
+			SpotId:   req.SpotId,
+			Facility: req.Facility,
+			Capacity: req.Capacity,
+			Fee:      req.Fee,
+			Months:   req.Months,
+		})
+		return productResponse{Product: p, Err: err}, err
+	}
+}
+
+func MakeListProductsEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		pp, e := s.ListProducts(ctx)
+		return listProductsResponse{Products: pp, Err: e}, e
+	}
+}
+
+func MakeEnrolEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(enrolRequest)
+		sub, err := s.Enrol(ctx, strconv.Itoa(req.ProductId), strconv.Itoa(req.VehicleId))
+		return subscriptionResponse{Subscription: sub, Err: err}, err
+	}
+}
+
+func MakeListEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		ss, e := s.List(ctx)
+		return listResponse{Subscriptions: ss, Err: e}, e
+	}
+}
+
+func MakeFindEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		sub, err := s.Find(ctx, req.ID)
+		return subscriptionResponse{Subscription: sub, Err: err}, err
+	}
+}
+
+func MakeCancelEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		sub, err := s.Cancel(ctx, req.ID)
+		return subscriptionResponse{Subscription: sub, Err: err}, err
+	}
+}
+
+func MakePaidEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(paidRequest)
+		sub, err := s.Paid(ctx, req.ID, req.Reference)
+		return subscriptionResponse{Subscription: sub, Err: err}, err
+	}
+}
+
+//
+
+type productRequest struct {
+	Name     string `json:"name"`
+	SpotId   int    `json:"spotId,omitempty"`
+	Facility string `json:"facility,omitempty"`
+	Capacity int    `json:"capacity,omitempty"`
+	Fee      string `json:"fee"`
+	Months   int    `json:"months,omitempty"`
+}
+
+type productResponse struct {
+	Err     error   `json:"err,omitempty"`
+	Product Product `json:"product"`
+}
+
+func (r productResponse) error() error { return r.Err }
+
+type listProductsResponse struct {
+	Err      error     `json:"err,omitempty"`
+	Products []Product `json:"products"`
+}
+
+func (r listProductsResponse) error() error { return r.Err }
+
+type enrolRequest struct {
+	ProductId int `json:"productId"`
+	VehicleId int `json:"vehicleId"`
+}
+
+type subscriptionResponse struct {
+	Err          error        `json:"err,omitempty"`
+	Subscription Subscription `json:"subscription"`
+}
+
+func (r subscriptionResponse) error() error { return r.Err }
+
+type listRequest struct {
+}
+
+type listResponse struct {
+	Err           error          `json:"err,omitempty"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+func (r listResponse) error() error { return r.Err }
+
+type idRequest struct {
+	ID string `json:"id"`
+}
+
+// paidRequest is the payments provider reporting a payment
+type paidRequest struct {
+	ID        string `json:"-"`
+	Reference string `json:"reference"`
+}
@@ -0,0 +1,43 @@
+package subscriptions
+
+import (
+	"strconv"
+
+	"github.com/atuldaemon/rct/event"
+)
+
+// The subscription events published on the event bus. The payments provider
+// follows PaymentDue through a webhook and reports the payment back.
+
+type PaymentDue struct {
+	Subscription Subscription `json:"subscription"`
+	// Fee is the amount to collect, in the units of parking.Spot.Cost
+	Fee string `json:"fee"`
+}
+
+type SubscriptionActivated struct {
+	Subscription Subscription `json:"subscription"`
+}
+
+type SubscriptionRenewed struct {
+	Subscription Subscription `json:"subscription"`
+}
+
+type SubscriptionLapsed struct {
+	Subscription Subscription `json:"subscription"`
+}
+
+func (PaymentDue) EventType() string            { return event.SubscriptionPaymentDue }
+func (SubscriptionActivated) EventType() string { return event.SubscriptionActivated }
+func (SubscriptionRenewed) EventType() string   { return event.SubscriptionRenewed }
+func (SubscriptionLapsed) EventType() string    { return event.SubscriptionLapsed }
+
+func (e PaymentDue) AggregateID() string            { return SubscriptionAggregate(e.Subscription.ID) }
+func (e SubscriptionActivated) AggregateID() string { return SubscriptionAggregate(e.Subscription.ID) }
+func (e SubscriptionRenewed) AggregateID() string   { return SubscriptionAggregate(e.Subscription.ID) }
+func (e SubscriptionLapsed) AggregateID() string    { return SubscriptionAggregate(e.Subscription.ID) }
+
+// SubscriptionAggregate is the aggregate ID of the events of subscription id
+func SubscriptionAggregate(id int) string {
+	return "subscription/" + strconv.Itoa(id)
+}
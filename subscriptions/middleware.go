@@ -0,0 +1,80 @@
+package subscriptions
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) CreateProduct(ctx context.Context, p Product) (res Product, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "CreateProduct", "spotId", p.SpotId, "facility", p.Facility, "id", res.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.CreateProduct(ctx, p)
+}
+
+func (mw loggingMiddleware) ListProducts(ctx context.Context) (pp []Product, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "ListProducts", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.ListProducts(ctx)
+}
+
+func (mw loggingMiddleware) Enrol(ctx context.Context, productId, vehicleId string) (sub Subscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Enrol", "productId", productId, "vehicleId", vehicleId, "id", sub.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Enrol(ctx, productId, vehicleId)
+}
+
+func (mw loggingMiddleware) List(ctx context.Context) (ss []Subscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "List", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.List(ctx)
+}
+
+func (mw loggingMiddleware) Find(ctx context.Context, id string) (sub Subscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Find(ctx, id)
+}
+
+func (mw loggingMiddleware) Cancel(ctx context.Context, id string) (sub Subscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Cancel", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Cancel(ctx, id)
+}
+
+func (mw loggingMiddleware) Paid(ctx context.Context, id, reference string) (sub Subscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Paid", "id", id, "reference", reference, "status", sub.Status, "validUntil", sub.ValidUntil, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Paid(ctx, id, reference)
+}
+
+func (mw loggingMiddleware) Run(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Run", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Run(ctx)
+}
@@ -0,0 +1,47 @@
+package subscriptions
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(Status(""), Pending, Active, Lapsed, Cancelled)
+
+	d.Operation("POST", "/subscriptions/v1/products", "createProduct", "Put a monthly pass for a named spot, or for a number of spots of a facility, on sale").
+		Tag("subscriptions").Require(requirements["CreateProduct"]).
+		Body(productRequest{}).
+		Returns(http.StatusOK, "The product", productResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/subscriptions/v1/products", "listProducts", "List the passes on sale").
+		Tag("subscriptions").Require(requirements["ListProducts"]).
+		Returns(http.StatusOK, "Products", listProductsResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/subscriptions/v1/", "enrol", "Subscribe a vehicle to a pass, pending until the payments provider reports the first payment").
+		Tag("subscriptions").Require(requirements["Enrol"]).
+		Body(enrolRequest{}).
+		Returns(http.StatusOK, "The pending subscription", subscriptionResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/subscriptions/v1/", "listSubscriptions", "List the subscriptions of the caller, or all for operators").
+		Tag("subscriptions").Require(requirements["ListSubscriptions"]).
+		Returns(http.StatusOK, "Subscriptions", listResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/subscriptions/v1/{id}", "findSubscription", "Get a subscription with its assigned spot and validity").
+		Tag("subscriptions").Require(requirements["FindSubscription"]).
+		PathParam("id", "Subscription id").
+		Returns(http.StatusOK, "The subscription", subscriptionResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/subscriptions/v1/{id}", "cancelSubscription", "Cancel the subscription and free its spot right away").
+		Tag("subscriptions").Require(requirements["CancelSubscription"]).
+		PathParam("id", "Subscription id").
+		Returns(http.StatusOK, "The cancelled subscription", subscriptionResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/subscriptions/v1/{id}/payments", "reportPayment", "Report a payment of the fee, which activates a pending pass or renews an active one. A reference is recorded once.").
+		Tag("subscriptions").Require(requirements["ReportPayment"]).
+		PathParam("id", "Subscription id").
+		Body(paidRequest{}).
+		Returns(http.StatusOK, "The subscription", subscriptionResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
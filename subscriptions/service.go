@@ -0,0 +1,401 @@
+package subscriptions
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+)
+
+// Monthly passes. A driver enrols a vehicle in a product and the payments
+// provider, told by a PaymentDue event, reports the payment back. The
+// payment activates the pass, which books the assigned spot for the whole
+// period, so the spot is taken out of the free inventory and the holder
+// parks without a booking of their own. Later payments extend the booking,
+// passes that are not paid by the end of their period lapse.
+
+var (
+	ErrInvalidReq     = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInvalidProduct = apierror.New(apierror.Unprocessable, "invalid_product", "a product is for either a named spot or a facility").
+				WithField("spotId", "give either spotId or facility")
+	ErrInvalidSpot    = apierror.New(apierror.Unprocessable, "invalid_spot", "no such spot").WithField("spotId", "no such spot")
+	ErrInvalidVehicle = apierror.New(apierror.Unprocessable, "invalid_vehicle", "no such vehicle owned by the caller").
+				WithField("vehicleId", "no such vehicle owned by the caller")
+	ErrSoldOut = apierror.New(apierror.Conflict, "product_sold_out", "every pass of the product is taken")
+	// ErrNoSpot is returned to the payments provider when no spot of the
+	// facility is free to activate the pass, the payment is not recorded
+	ErrNoSpot = apierror.New(apierror.Conflict, "no_spot_available", "no spot is free for the pass")
+	ErrClosed = apierror.New(apierror.Conflict, "subscription_closed", "the subscription has lapsed or was cancelled")
+)
+
+const (
+	// DefaultRenewalNotice is how long before the end of a period the
+	// renewal payment is asked for
+	DefaultRenewalNotice = 72 * time.Hour
+	// DefaultPaymentWindow is how long a new subscription waits for its
+	// first payment
+	DefaultPaymentWindow = 24 * time.Hour
+)
+
+// Bookings books the assigned spots of the passes
+type Bookings interface {
+	BookPrepaid(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (booking.Booking, error)
+	Extend(ctx context.Context, bookingId string, d time.Duration) (booking.Booking, string, error)
+	CheckIn(ctx context.Context, bookingId string) (booking.Booking, error)
+	Delete(ctx context.Context, bookingId string) error
+}
+
+type Options struct {
+	RenewalNotice time.Duration
+	PaymentWindow time.Duration
+}
+
+type Service interface {
+	CreateProduct(ctx context.Context, p Product) (Product, error)
+	ListProducts(ctx context.Context) ([]Product, error)
+	// Enrol subscribes the caller's vehicle to a product, pending until
+	// the first payment
+	Enrol(ctx context.Context, productId, vehicleId string) (Subscription, error)
+	// List returns the subscriptions the caller may see
+	List(ctx context.Context) ([]Subscription, error)
+	Find(ctx context.Context, id string) (Subscription, error)
+	// Cancel ends the subscription and frees its spot right away
+	Cancel(ctx context.Context, id string) (Subscription, error)
+	// Paid records a payment reported by the payments provider. It
+	// activates a pending subscription and renews an active one by the
+	// months of its product. A reference is recorded once.
+	Paid(ctx context.Context, id, reference string) (Subscription, error)
+	// Run asks for the renewals coming due and lapses the subscriptions
+	// that were not paid in time. It is run periodically, not exposed over
+	// HTTP.
+	Run(ctx context.Context) error
+}
+
+type service struct {
+	// mtx serializes the changes to the subscriptions
+	mtx      sync.Mutex
+	products ProductStore
+	subs     SubscriptionStore
+	spots    parking.Service
+	bookings Bookings
+	vehicles vehicle.Service
+	events   event.Publisher
+	o        Options
+	now      func() time.Time
+}
+
+// NewService returns the subscription service. events may be nil.
+func NewService(products ProductStore, subs SubscriptionStore, spots parking.Service, bookings Bookings, vehicles vehicle.Service, events event.Publisher, o Options) Service {
+	if o.RenewalNotice <= 0 {
+		o.RenewalNotice = DefaultRenewalNotice
+	}
+	if o.PaymentWindow <= 0 {
+		o.PaymentWindow = DefaultPaymentWindow
+	}
+	if events == nil {
+		events = event.Nop
+	}
+	return &service{products: products, subs: subs, spots: spots, bookings: bookings, vehicles: vehicles, events: events, o: o, now: time.Now}
+}
+
+func (s *service) CreateProduct(ctx context.Context, p Product) (Product, error) {
+	if p.Name == "" {
+		return Product{}, ErrInvalidReq.WithField("name", "is required")
+	}
+	if fee, err := strconv.ParseFloat(p.Fee, 64); err != nil || fee < 0 {
+		return Product{}, ErrInvalidReq.WithField("fee", "must be a non-negative number")
+	}
+	if p.Months == 0 {
+		p.Months = 1
+	}
+	if p.Months < 0 || p.Months > 12 {
+		return Product{}, ErrInvalidReq.WithField("months", "must be between 1 and 12")
+	}
+	if (p.SpotId == 0) == (p.Facility == "") {
+		return Product{}, ErrInvalidProduct
+	}
+	if p.SpotId != 0 {
+		if _, err := s.spots.FindById(ctx, strconv.Itoa(p.SpotId)); err != nil && apierror.From(err).Kind == apierror.Unavailable {
+			return Product{}, err
+		} else if err != nil {
+			return Product{}, ErrInvalidSpot
+		}
+		p.Capacity = 1
+		return s.products.Create(p)
+	}
+	spots, err := s.facilitySpots(ctx, p.Facility)
+	if err != nil {
+		return Product{}, err
+	}
+	if p.Capacity < 1 || p.Capacity > len(spots) {
+		return Product{}, ErrInvalidReq.WithField("capacity", "must be between 1 and the number of spots of the facility")
+	}
+	return s.products.Create(p)
+}
+
+func (s *service) ListProducts(ctx context.Context) ([]Product, error) {
+	return s.products.GetAll()
+}
+
+func (s *service) Enrol(ctx context.Context, productId, vehicleId string) (Subscription, error) {
+	pid, err := strconv.Atoi(productId)
+	if err != nil {
+		return Subscription{}, ErrInvalidReq.WithField("productId", "is required")
+	}
+	p, err := s.products.Find(pid)
+	if err != nil {
+		return Subscription{}, err
+	}
+	// the pass books on behalf of the user, so check the vehicle now
+	v, err := s.vehicles.Find(ctx, vehicleId)
+	if err == auth.ErrForbidden {
+		return Subscription{}, err
+	} else if err != nil {
+		return Subscription{}, ErrInvalidVehicle
+	}
+	if p.SpotId != 0 {
+		sp, err := s.spots.FindById(ctx, strconv.Itoa(p.SpotId))
+		if err != nil {
+			return Subscription{}, err
+		}
+		if err := vehicle.CheckFit(v, sp); err != nil {
+			return Subscription{}, err
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	all, err := s.subs.GetAll()
+	if err != nil {
+		return Subscription{}, err
+	}
+	held := 0
+	for _, sub := range all {
+		if sub.ProductId == p.ID && sub.Holds() {
+			held++
+		}
+	}
+	if held >= p.Capacity {
+		return Subscription{}, ErrSoldOut
+	}
+	pr, _ := auth.PrincipalFromContext(ctx)
+	sub, err := s.subs.Create(Subscription{User: pr.Subject, ProductId: p.ID, VehicleId: v.ID, Status: Pending, CreatedAt: s.now().UTC()})
+	if err != nil {
+		return Subscription{}, err
+	}
+	s.publish(ctx, PaymentDue{sub, p.Fee})
+	return sub, nil
+}
+
+func (s *service) List(ctx context.Context) ([]Subscription, error) {
+	all, err := s.subs.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Subscription, 0, len(all))
+	for _, sub := range all {
+		if auth.CanAccess(ctx, sub.User) {
+			res = append(res, sub)
+		}
+	}
+	return res, nil
+}
+
+func (s *service) Find(ctx context.Context, id string) (Subscription, error) {
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		return Subscription{}, ErrInvalidReq
+	}
+	sub, err := s.subs.Find(intId)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if !auth.CanAccess(ctx, sub.User) {
+		return Subscription{}, auth.ErrForbidden
+	}
+	return sub, nil
+}
+
+func (s *service) Cancel(ctx context.Context, id string) (Subscription, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sub, err := s.Find(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if !sub.Holds() {
+		return Subscription{}, ErrClosed
+	}
+	if sub.Status == Active {
+		if err := s.cancelBooking(sub.BookingId); err != nil {
+			return Subscription{}, err
+		}
+	}
+	sub.Status = Cancelled
+	return s.subs.Update(sub)
+}
+
+func (s *service) Paid(ctx context.Context, id, reference string) (Subscription, error) {
+	if reference == "" {
+		return Subscription{}, ErrInvalidReq.WithField("reference", "is required")
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sub, err := s.Find(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if sub.Paid(reference) {
+		return sub, nil
+	}
+	if !sub.Holds() {
+		return Subscription{}, ErrClosed
+	}
+	p, err := s.products.Find(sub.ProductId)
+	if err != nil {
+		return Subscription{}, err
+	}
+	now := s.now()
+	if sub.Status == Pending {
+		sub.ValidFrom = now.UTC()
+		sub.ValidUntil = sub.ValidFrom.AddDate(0, p.Months, 0)
+		if sub, err = s.assign(sub, p, now); err != nil {
+			return Subscription{}, err
+		}
+		sub.Status = Active
+	} else {
+		until := sub.ValidUntil.AddDate(0, p.Months, 0)
+		_, _, err := s.bookings.Extend(auth.NewContext(context.Background(), auth.System), strconv.Itoa(sub.BookingId), until.Sub(sub.ValidUntil))
+		sub.ValidUntil = until
+		if err == booking.ErrInvalidBookingId || err == booking.ErrNotActive {
+			// the booking was cancelled by an operator, book again
+			sub, err = s.assign(sub, p, now)
+		}
+		if err != nil {
+			return Subscription{}, err
+		}
+		sub.RenewalDueAt = time.Time{}
+	}
+	sub.Payments = append(sub.Payments, Payment{Reference: reference, At: now.UTC()})
+	if sub, err = s.subs.Update(sub); err != nil {
+		return Subscription{}, err
+	}
+	if len(sub.Payments) == 1 {
+		s.publish(ctx, SubscriptionActivated{sub})
+	} else {
+		s.publish(ctx, SubscriptionRenewed{sub})
+	}
+	return sub, nil
+}
+
+// assign books a spot of the product for the vehicle from now until the
+// end of the validity. A named spot is assigned again, a facility pass
+// takes the first free spot the vehicle fits. The caller holds mtx.
+func (s *service) assign(sub Subscription, p Product, now time.Time) (Subscription, error) {
+	// the pass belongs to its user, not to the payments provider
+	ctx := auth.NewContext(context.Background(), auth.System)
+	candidates := []int{p.SpotId}
+	if p.SpotId == 0 {
+		spots, err := s.facilitySpots(ctx, p.Facility)
+		if err != nil {
+			return Subscription{}, err
+		}
+		candidates = candidates[:0]
+		for _, sp := range spots {
+			if sp.Free(now) {
+				candidates = append(candidates, sp.ID)
+			}
+		}
+	}
+	for _, id := range candidates {
+		// the pass is paid for by its fee
+		b, err := s.bookings.BookPrepaid(ctx, strconv.Itoa(id), strconv.Itoa(sub.VehicleId), now, sub.ValidUntil.Sub(now))
+		if err == nil {
+			// a pass holds its spot from activation and is never a no-show
+			if _, err := s.bookings.CheckIn(ctx, strconv.Itoa(b.ID)); err != nil {
+				s.cancelBooking(b.ID)
+				return Subscription{}, err
+			}
+			sub.SpotId, sub.BookingId = id, b.ID
+			return sub, nil
+		}
+		if kind := apierror.From(err).Kind; kind != apierror.Conflict && kind != apierror.Unprocessable {
+			return Subscription{}, err
+		}
+		if p.SpotId != 0 && err != booking.ErrAlreadyReserved {
+			// the vehicle no longer fits the named spot
+			return Subscription{}, err
+		}
+	}
+	return Subscription{}, ErrNoSpot
+}
+
+func (s *service) Run(ctx context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	all, err := s.subs.GetAll()
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, sub := range all {
+		switch {
+		case sub.Status == Pending && !now.Before(sub.CreatedAt.Add(s.o.PaymentWindow)),
+			sub.Status == Active && !now.Before(sub.ValidUntil):
+			// the booking of an active pass expires with the period
+			sub.Status = Lapsed
+			if sub, err = s.subs.Update(sub); err != nil {
+				return err
+			}
+			s.publish(ctx, SubscriptionLapsed{sub})
+		case sub.Status == Active && sub.RenewalDueAt.IsZero() && !now.Before(sub.ValidUntil.Add(-s.o.RenewalNotice)):
+			p, err := s.products.Find(sub.ProductId)
+			if err != nil {
+				return err
+			}
+			sub.RenewalDueAt = now.UTC()
+			if sub, err = s.subs.Update(sub); err != nil {
+				return err
+			}
+			s.publish(ctx, PaymentDue{sub, p.Fee})
+		}
+	}
+	return nil
+}
+
+// facilitySpots returns the spots of facility in ID order
+func (s *service) facilitySpots(ctx context.Context, facility string) ([]parking.Spot, error) {
+	all, err := s.spots.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spots := make([]parking.Spot, 0)
+	for _, sp := range all {
+		if sp.Facility == facility {
+			spots = append(spots, sp)
+		}
+	}
+	sort.Slice(spots, func(i, j int) bool { return spots[i].ID < spots[j].ID })
+	return spots, nil
+}
+
+// cancelBooking cancels the booking of a pass, unless it is gone already
+func (s *service) cancelBooking(bookingId int) error {
+	err := s.bookings.Delete(auth.NewContext(context.Background(), auth.System), strconv.Itoa(bookingId))
+	if err == booking.ErrInvalidBookingId {
+		return nil
+	}
+	return err
+}
+
+func (s *service) publish(ctx context.Context, p event.Payload) {
+	s.events.Publish(ctx, event.New(p, s.now()))
+}
@@ -0,0 +1,259 @@
+package subscriptions
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+)
+
+type recorder struct {
+	mtx    sync.Mutex
+	events []event.Event
+}
+
+func (r *recorder) Publish(ctx context.Context, e event.Event) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) types() []string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	tt := make([]string, 0)
+	for _, e := range r.events {
+		tt = append(tt, e.Type)
+	}
+	return tt
+}
+
+type fixture struct {
+	s        Service
+	store    parking.ParkingStore
+	bookings booking.Service
+	vehicles vehicle.Service
+	events   *recorder
+	// skip moves the subscription clock forward
+	skip func(time.Duration)
+}
+
+// newTestService sets up the subscriptions on top of the real parking,
+// vehicle and booking services, with a second spot at lakeside
+func newTestService(t *testing.T) fixture {
+	store, _ := parking.NewInMemParkingStore()
+	store.Create(parking.Spot{ID: 6, Lat: "44.92058", Lon: "-93.44787", Cost: "90", Facility: "lakeside"})
+	p := parking.NewService(store)
+	vehicleStore, _ := vehicle.NewInMemVehicleStore()
+	v := vehicle.NewService(vehicleStore)
+	bookingStore, _ := booking.NewInMemBookingStore()
+	holdStore, _ := booking.NewInMemHoldStore()
+	groupStore, _ := booking.NewInMemGroupStore()
+	b := booking.NewService(bookingStore, p, v, booking.Options{Holds: holdStore, Groups: groupStore})
+	products, _ := NewInMemProductStore()
+	subs, _ := NewInMemSubscriptionStore()
+	r := &recorder{}
+	s := NewService(products, subs, p, b, v, r, Options{})
+	var offset time.Duration
+	s.(*service).now = func() time.Time { return time.Now().Add(offset) }
+	return fixture{s: s, store: store, bookings: b, vehicles: v, events: r, skip: func(d time.Duration) { offset += d }}
+}
+
+func user(name string, roles ...auth.Role) context.Context {
+	if len(roles) == 0 {
+		roles = []auth.Role{auth.RoleDriver}
+	}
+	return auth.NewContext(context.Background(), auth.Principal{Subject: name, Roles: roles})
+}
+
+var (
+	operator = user("op", auth.RoleOperator)
+	payments = user("payments", auth.RoleService)
+)
+
+func (f fixture) car(t *testing.T, ctx context.Context) string {
+	c, err := f.vehicles.Register(ctx, vehicle.Vehicle{Plate: "AB12CD", Class: parking.Car,
+		Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strconv.Itoa(c.ID)
+}
+
+func (f fixture) product(t *testing.T, p Product) string {
+	p, err := f.s.CreateProduct(operator, p)
+	if err != nil {
+		t.Fatalf("Failed to create %+v: %v", p, err)
+	}
+	return strconv.Itoa(p.ID)
+}
+
+func (f fixture) enrol(t *testing.T, name, product string) Subscription {
+	ctx := user(name)
+	sub, err := f.s.Enrol(ctx, product, f.car(t, ctx))
+	if err != nil {
+		t.Fatalf("Failed to enrol %s: %v", name, err)
+	}
+	return sub
+}
+
+func (f fixture) reserved(t *testing.T, id int) bool {
+	sp, err := f.store.FindById(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sp.IsReserved
+}
+
+func TestFacilityPass(t *testing.T) {
+	f := newTestService(t)
+	lakeside := f.product(t, Product{Name: "Lakeside monthly", Facility: "lakeside", Capacity: 2, Fee: "120"})
+	alice := f.enrol(t, "alice", lakeside)
+	bob := f.enrol(t, "bob", lakeside)
+	if alice.Status != Pending || alice.User != "alice" || alice.SpotId != 0 {
+		t.Fatalf("Expected a pending subscription, got %+v", alice)
+	}
+	carol := user("carol")
+	if _, err := f.s.Enrol(carol, lakeside, f.car(t, carol)); err != ErrSoldOut {
+		t.Errorf("Expected the third pass to be sold out, got %v", err)
+	}
+
+	id := strconv.Itoa(alice.ID)
+	if _, err := f.s.Paid(user("bob"), id, "p1"); err != auth.ErrForbidden {
+		t.Errorf("Expected drivers not to report payments of others, got %v", err)
+	}
+	sub, err := f.s.Paid(payments, id, "p1")
+	if err != nil || sub.Status != Active || sub.SpotId != 5 || sub.BookingId == 0 {
+		t.Fatalf("Expected the payment to activate the pass on spot 5, got %+v %v", sub, err)
+	}
+	if want := sub.ValidFrom.AddDate(0, 1, 0); !sub.ValidUntil.Equal(want) {
+		t.Errorf("Expected the pass to be valid for a month, until %s, got %s", want, sub.ValidUntil)
+	}
+	if b, err := f.bookings.Find(payments, strconv.Itoa(sub.BookingId)); err != nil || !b.Prepaid || b.Price != "0.00" {
+		t.Errorf("Expected the booking of the pass to cost nothing, got %+v %v", b, err)
+	}
+	if again, _ := f.s.Paid(payments, id, "p1"); len(again.Payments) != 1 || !again.ValidUntil.Equal(sub.ValidUntil) {
+		t.Errorf("Expected a repeated payment to be recorded once, got %+v", again)
+	}
+	free, _ := parking.NewService(f.store).GetFree(context.Background())
+	for _, sp := range free {
+		if sp.ID == 5 {
+			t.Error("Expected the assigned spot to be taken out of the free spots")
+		}
+	}
+	dave := user("dave")
+	if _, err := f.bookings.Book(dave, "5", f.car(t, dave), time.Now(), time.Hour); err != booking.ErrAlreadyReserved {
+		t.Errorf("Expected the assigned spot not to be bookable, got %v", err)
+	}
+
+	// dave takes the last lakeside spot before bob pays
+	if _, err := f.bookings.Book(dave, "6", f.car(t, dave), time.Now(), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.s.Paid(payments, strconv.Itoa(bob.ID), "p2"); err != ErrNoSpot {
+		t.Errorf("Expected no spot for bob, got %v", err)
+	}
+	f.skip(25 * time.Hour)
+	if err := f.s.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sub, _ := f.s.Find(payments, strconv.Itoa(bob.ID)); sub.Status != Lapsed {
+		t.Errorf("Expected the unpaid pass to lapse, got %+v", sub)
+	}
+
+	// renewal is asked for once, three days ahead
+	f.skip(sub.ValidUntil.Sub(time.Now()) - 25*time.Hour - 48*time.Hour)
+	f.s.Run(context.Background())
+	f.s.Run(context.Background())
+	renewed, err := f.s.Paid(payments, id, "p3")
+	if err != nil || renewed.Status != Active || !renewed.ValidUntil.Equal(sub.ValidUntil.AddDate(0, 1, 0)) || !renewed.RenewalDueAt.IsZero() {
+		t.Fatalf("Expected the payment to renew the pass, got %+v %v", renewed, err)
+	}
+	bb, _ := f.bookings.GetAll(context.Background())
+	for _, b := range bb {
+		if b.ID == renewed.BookingId && (!b.EndTime().Equal(renewed.ValidUntil) || b.Price != "0.00") {
+			t.Errorf("Expected the booking to be extended to %s at no charge, got %+v", renewed.ValidUntil, b)
+		}
+	}
+
+	f.skip(renewed.ValidUntil.Sub(sub.ValidUntil) + 48*time.Hour)
+	f.s.Run(context.Background())
+	if sub, _ := f.s.Find(payments, id); sub.Status != Lapsed {
+		t.Errorf("Expected the unrenewed pass to lapse, got %+v", sub)
+	}
+	if _, err := f.s.Paid(payments, id, "p4"); err != ErrClosed {
+		t.Errorf("Expected a lapsed pass not to take payments, got %v", err)
+	}
+
+	want := []string{event.SubscriptionPaymentDue, event.SubscriptionPaymentDue, event.SubscriptionActivated, event.SubscriptionLapsed,
+		event.SubscriptionPaymentDue, event.SubscriptionRenewed, event.SubscriptionLapsed}
+	if got := f.events.types(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected events %v, got %v", want, got)
+	}
+}
+
+func TestSpotPass(t *testing.T) {
+	f := newTestService(t)
+	spot1 := f.product(t, Product{Name: "Spot 1", SpotId: 1, Capacity: 5, Fee: "300", Months: 3})
+	if pp, _ := f.s.ListProducts(user("alice")); len(pp) != 1 || pp[0].Capacity != 1 {
+		t.Errorf("Expected a named spot to sell a single pass, got %+v", pp)
+	}
+	sub := f.enrol(t, "alice", spot1)
+	if _, err := f.s.Enrol(user("bob"), spot1, f.car(t, user("bob"))); err != ErrSoldOut {
+		t.Errorf("Expected the spot to be sold out, got %v", err)
+	}
+	if _, err := f.s.Cancel(user("alice"), strconv.Itoa(sub.ID)); err != nil {
+		t.Fatal(err)
+	}
+	sub = f.enrol(t, "bob", spot1)
+	if sub, err := f.s.Paid(payments, strconv.Itoa(sub.ID), "p1"); err != nil || !sub.ValidUntil.Equal(sub.ValidFrom.AddDate(0, 3, 0)) {
+		t.Fatalf("Failed to activate a three month pass: %+v %v", sub, err)
+	}
+	if !f.reserved(t, 1) {
+		t.Error("Expected the named spot to be booked")
+	}
+	if bb, _ := f.bookings.GetAll(context.Background()); len(bb) != 1 || bb[0].Price != "0.00" {
+		t.Errorf("Expected the booking of the pass to cost nothing, got %+v", bb)
+	}
+	if _, err := f.s.Cancel(user("alice"), strconv.Itoa(sub.ID)); err != auth.ErrForbidden {
+		t.Errorf("Expected another driver not to cancel the pass, got %v", err)
+	}
+	if sub, err := f.s.Cancel(user("bob"), strconv.Itoa(sub.ID)); err != nil || sub.Status != Cancelled {
+		t.Fatalf("Failed to cancel: %+v %v", sub, err)
+	}
+	if f.reserved(t, 1) {
+		t.Error("Expected cancelling the pass to free the spot")
+	}
+	if bb, _ := f.bookings.GetAll(context.Background()); len(bb) != 0 {
+		t.Errorf("Expected the booking of the pass to be cancelled, got %+v", bb)
+	}
+
+	motorbikes := f.product(t, Product{Name: "Spot 2", SpotId: 2, Fee: "50"})
+	if _, err := f.s.Enrol(user("carol"), motorbikes, f.car(t, user("carol"))); err != vehicle.ErrClassNotAllowed {
+		t.Errorf("Expected a car not to get a motorcycle pass, got %v", err)
+	}
+	for _, p := range []Product{
+		{Name: "both", SpotId: 1, Facility: "lakeside", Fee: "1"},
+		{Name: "neither", Fee: "1"},
+		{Name: "missing", SpotId: 99, Fee: "1"},
+		{Name: "too big", Facility: "lakeside", Capacity: 3, Fee: "1"},
+		{Name: "free", SpotId: 1, Fee: "-1"},
+		{Name: "long", SpotId: 1, Fee: "1", Months: 13},
+		{SpotId: 1, Fee: "1"},
+	} {
+		if _, err := f.s.CreateProduct(operator, p); err == nil {
+			t.Errorf("Expected %+v to be rejected", p)
+		}
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
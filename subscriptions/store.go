@@ -0,0 +1,193 @@
+package subscriptions
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+type ProductStore interface {
+	Create(p Product) (Product, error)
+	Find(id int) (Product, error)
+	GetAll() ([]Product, error)
+}
+
+type SubscriptionStore interface {
+	Create(s Subscription) (Subscription, error)
+	Update(s Subscription) (Subscription, error)
+	Find(id int) (Subscription, error)
+	GetAll() ([]Subscription, error)
+}
+
+// Product is a pass on sale, for a named spot or for any spot of a
+// facility
+type Product struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	SpotId   int    `json:"spotId,omitempty"`
+	Facility string `json:"facility,omitempty"`
+	// Capacity is how many passes of the product may be held at once,
+	// always 1 for a named spot
+	Capacity int `json:"capacity"`
+	// Fee is charged for every Months of validity, in the units of
+	// parking.Spot.Cost
+	Fee    string `json:"fee"`
+	Months int    `json:"months"`
+}
+
+type Status string
+
+const (
+	// Pending subscriptions wait for their first payment
+	Pending Status = "pending"
+	Active  Status = "active"
+	// Lapsed subscriptions were not paid in time
+	Lapsed    Status = "lapsed"
+	Cancelled Status = "cancelled"
+)
+
+// Subscription is a driver's pass. While active it books the assigned spot
+// for the vehicle until ValidUntil.
+type Subscription struct {
+	ID        int    `json:"id"`
+	User      string `json:"user"`
+	ProductId int    `json:"productId"`
+	VehicleId int    `json:"vehicleId"`
+	Status    Status `json:"status"`
+	// SpotId and BookingId are assigned on activation
+	SpotId     int       `json:"spotId,omitempty"`
+	BookingId  int       `json:"bookingId,omitempty"`
+	ValidFrom  time.Time `json:"validFrom,omitempty"`
+	ValidUntil time.Time `json:"validUntil,omitempty"`
+	// RenewalDueAt is when the renewal of the current period was asked
+	// for, zero until then
+	RenewalDueAt time.Time `json:"renewalDueAt,omitempty"`
+	Payments     []Payment `json:"payments"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Payment is a payment reported by the payments provider
+type Payment struct {
+	// Reference is the provider's id of the payment, a payment is
+	// recorded once
+	Reference string    `json:"reference"`
+	At        time.Time `json:"at"`
+}
+
+// Paid reports whether the payment with reference was recorded
+func (s Subscription) Paid(reference string) bool {
+	for _, p := range s.Payments {
+		if p.Reference == reference {
+			return true
+		}
+	}
+	return false
+}
+
+// Holds reports whether the subscription takes up capacity of its product
+func (s Subscription) Holds() bool {
+	return s.Status == Pending || s.Status == Active
+}
+
+var (
+	ErrNotFound        = apierror.New(apierror.NotFound, "subscription_not_found", "subscription not found")
+	ErrProductNotFound = apierror.New(apierror.NotFound, "product_not_found", "pass product not found")
+)
+
+type inMemProductStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Product
+	nxtId int
+}
+
+func NewInMemProductStore() (ProductStore, error) {
+	return &inMemProductStore{m: map[int]Product{}, nxtId: 1}, nil
+}
+
+func (s *inMemProductStore) Create(p Product) (Product, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	p.ID = s.nxtId
+	s.nxtId++
+	s.m[p.ID] = p
+	return p, nil
+}
+
+func (s *inMemProductStore) Find(id int) (Product, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	p, ok := s.m[id]
+	if !ok {
+		return Product{}, ErrProductNotFound
+	}
+	return p, nil
+}
+
+func (s *inMemProductStore) GetAll() ([]Product, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	all := make([]Product, 0, len(s.m))
+	for _, p := range s.m {
+		all = append(all, p)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all, nil
+}
+
+type inMemSubscriptionStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Subscription
+	nxtId int
+}
+
+func NewInMemSubscriptionStore() (SubscriptionStore, error) {
+	return &inMemSubscriptionStore{m: map[int]Subscription{}, nxtId: 1}, nil
+}
+
+func (s *inMemSubscriptionStore) Create(sub Subscription) (Subscription, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sub.ID = s.nxtId
+	s.nxtId++
+	s.m[sub.ID] = clone(sub)
+	return sub, nil
+}
+
+func (s *inMemSubscriptionStore) Update(sub Subscription) (Subscription, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[sub.ID]; !ok {
+		return Subscription{}, ErrNotFound
+	}
+	s.m[sub.ID] = clone(sub)
+	return sub, nil
+}
+
+func (s *inMemSubscriptionStore) Find(id int) (Subscription, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	sub, ok := s.m[id]
+	if !ok {
+		return Subscription{}, ErrNotFound
+	}
+	return clone(sub), nil
+}
+
+func (s *inMemSubscriptionStore) GetAll() ([]Subscription, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	all := make([]Subscription, 0, len(s.m))
+	for _, sub := range s.m {
+		all = append(all, clone(sub))
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	return all, nil
+}
+
+// clone copies the payments, so callers can append to them
+func clone(s Subscription) Subscription {
+	s.Payments = append(make([]Payment, 0, len(s.Payments)), s.Payments...)
+	return s
+}
@@ -0,0 +1,147 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"CreateProduct":      {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"ListProducts":       {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"Enrol":              {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"ListSubscriptions":  {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"FindSubscription":   {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"CancelSubscription": {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"ReportPayment":      {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleService, auth.RoleOperator, auth.RoleAdmin}},
+}
+
+// MakeHTTPHandler mounts the subscription endpoints into an http.Handler.
+// Operators sell the products, drivers only see and cancel their own
+// passes, and payments are reported by the payments provider.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("POST").Path("/subscriptions/v1/products").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "CreateProduct")(e.CreateProductEndpoint),
+		decodeProductRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/subscriptions/v1/products").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListProducts")(e.ListProductsEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/subscriptions/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "Enrol")(e.EnrolEndpoint),
+		decodeEnrolRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/subscriptions/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListSubscriptions")(e.ListEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/subscriptions/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "FindSubscription")(e.FindEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/subscriptions/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "CancelSubscription")(e.CancelEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/subscriptions/v1/{id}/payments").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ReportPayment")(e.PaidEndpoint),
+		decodePaidRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeProductRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req productRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeEnrolRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req enrolRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req listRequest
+	return req, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return idRequest{ID: id}, nil
+}
+
+func decodePaidRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	var req paidRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	req.ID = id
+	return req, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
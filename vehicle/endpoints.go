@@ -0,0 +1,101 @@
+package vehicle
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	RegisterEndpoint      endpoint.Endpoint
+	FindEndpoint          endpoint.Endpoint
+	GetAllEndpoint        endpoint.Endpoint
+	DeleteEndpoint        endpoint.Endpoint
+	SearchByPlateEndpoint endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		RegisterEndpoint:      MakeRegisterEndpoint(s),
+		FindEndpoint:          MakeFindEndpoint(s),
+		GetAllEndpoint:        MakeGetAllEndpoint(s),
+		DeleteEndpoint:        MakeDeleteEndpoint(s),
+		SearchByPlateEndpoint: MakeSearchByPlateEndpoint(s),
+	}
+}
+
+func MakeRegisterEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(registerRequest)
+		v, e := s.Register(ctx, req.Vehicle)
+		return vehicleResponse{Vehicle: v, Err: e}, e
+	}
+}
+
+func MakeFindEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		v, e := s.Find(ctx, req.ID)
+		return vehicleResponse{Vehicle: v, Err: e}, e
+	}
+}
+
+func MakeGetAllEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		vv, e := s.GetAll(ctx)
+		return vehiclesResponse{Vehicles: vv, Err: e}, e
+	}
+}
+
+func MakeDeleteEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e := s.Delete(ctx, req.ID)
+		return deleteResponse{Err: e}, e
+	}
+}
+
+func MakeSearchByPlateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(plateRequest)
+		vv, e := s.SearchByPlate(ctx, req.Plate)
+		return vehiclesResponse{Vehicles: vv, Err: e}, e
+	}
+}
+
+//
+
+type registerRequest struct {
+	Vehicle
+}
+
+type idRequest struct {
+	ID string `json:"id"`
+}
+
+type plateRequest struct {
+	Plate string `json:"plate"`
+}
+
+type getAllRequest struct {
+}
+
+type vehicleResponse struct {
+	Err     error   `json:"err,omitempty"`
+	Vehicle Vehicle `json:"vehicle"`
+}
+
+func (r vehicleResponse) error() error { return r.Err }
+
+type vehiclesResponse struct {
+	Err      error     `json:"err,omitempty"`
+	Vehicles []Vehicle `json:"vehicles"`
+}
+
+func (r vehiclesResponse) error() error { return r.Err }
+
+type deleteResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r deleteResponse) error() error { return r.Err }
@@ -0,0 +1,59 @@
+package vehicle
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Register(ctx context.Context, v Vehicle) (res Vehicle, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Register", "plate", v.Plate, "class", v.Class, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Register(ctx, v)
+}
+
+func (mw loggingMiddleware) Find(ctx context.Context, id string) (v Vehicle, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Find(ctx, id)
+}
+
+func (mw loggingMiddleware) GetAll(ctx context.Context) (vv []Vehicle, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "GetAll", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.GetAll(ctx)
+}
+
+func (mw loggingMiddleware) Delete(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Delete", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Delete(ctx, id)
+}
+
+func (mw loggingMiddleware) SearchByPlate(ctx context.Context, plate string) (vv []Vehicle, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "SearchByPlate", "plate", plate, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.SearchByPlate(ctx, plate)
+}
@@ -0,0 +1,38 @@
+package vehicle
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+	"github.com/atuldaemon/rct/parking"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(parking.VehicleClass(""), parking.Motorcycle, parking.Car, parking.Van, parking.Truck)
+
+	d.Operation("POST", "/vehicle/v1/", "registerVehicle", "Register a vehicle owned by the caller").
+		Tag("vehicle").Require(requirements["Register"]).
+		Body(registerRequest{}).
+		Returns(http.StatusOK, "The registered vehicle", vehicleResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/vehicle/v1/", "getAllVehicles", "List the caller's vehicles, or every vehicle for operators and admins").
+		Tag("vehicle").Require(requirements["GetAll"]).
+		Returns(http.StatusOK, "Vehicles", vehiclesResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/vehicle/v1/plate/{plate}", "searchVehiclesByPlate", "Find vehicles by license plate").
+		Tag("vehicle").Require(requirements["SearchByPlate"]).
+		PathParam("plate", "License plate, spacing and case are ignored").
+		Returns(http.StatusOK, "Matching vehicles", vehiclesResponse{}).
+		Fails(http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/vehicle/v1/{id}", "findVehicle", "Find a vehicle by id").
+		Tag("vehicle").Require(requirements["Find"]).
+		PathParam("id", "Vehicle id").
+		Returns(http.StatusOK, "The vehicle", vehicleResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/vehicle/v1/{id}", "deleteVehicle", "Delete a vehicle").
+		Tag("vehicle").Require(requirements["Delete"]).
+		PathParam("id", "Vehicle id").
+		Returns(http.StatusOK, "Empty object", deleteResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
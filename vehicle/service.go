@@ -0,0 +1,121 @@
+package vehicle
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/parking"
+)
+
+// Vehicle registry service
+
+var (
+	ErrInvalidPlate = apierror.New(apierror.Unprocessable, "invalid_plate", "invalid plate passed in vehicle request").
+			WithField("plate", "must contain letters or digits")
+	ErrInvalidClass = apierror.New(apierror.Unprocessable, "invalid_vehicle_class", "invalid vehicle class").
+			WithField("class", "must be one of motorcycle, car, van, truck")
+	ErrInvalidDimension = apierror.New(apierror.Unprocessable, "invalid_dimensions", "invalid vehicle dimensions").
+				WithField("dimensions", "length, width and height must be positive")
+	ErrClassNotAllowed = apierror.New(apierror.Unprocessable, "vehicle_class_not_allowed", "vehicle class not allowed on spot")
+	ErrDoesNotFit      = apierror.New(apierror.Unprocessable, "vehicle_does_not_fit", "vehicle does not fit spot")
+)
+
+type Service interface {
+	Register(ctx context.Context, v Vehicle) (Vehicle, error)
+	Find(ctx context.Context, id string) (Vehicle, error)
+	GetAll(ctx context.Context) ([]Vehicle, error)
+	Delete(ctx context.Context, id string) error
+	SearchByPlate(ctx context.Context, plate string) ([]Vehicle, error)
+}
+
+type service struct {
+	vehicleStore VehicleStore
+}
+
+func NewService(store VehicleStore) Service {
+	return &service{vehicleStore: store}
+}
+
+// Register adds a vehicle owned by the caller
+func (s *service) Register(ctx context.Context, v Vehicle) (Vehicle, error) {
+	if NormalizePlate(v.Plate) == "" {
+		return Vehicle{}, ErrInvalidPlate
+	}
+	if !v.Class.Valid() {
+		return Vehicle{}, ErrInvalidClass
+	}
+	d := v.Dimensions
+	if d.Length <= 0 || d.Width <= 0 || d.Height <= 0 {
+		return Vehicle{}, ErrInvalidDimension
+	}
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		v.Owner = p.Subject
+	}
+	return s.vehicleStore.Create(v)
+}
+
+func (s *service) Find(ctx context.Context, id string) (Vehicle, error) {
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		return Vehicle{}, ErrInvalidReq
+	}
+	v, err := s.vehicleStore.Find(intId)
+	if err != nil {
+		return Vehicle{}, err
+	}
+	if !auth.CanAccess(ctx, v.Owner) {
+		return Vehicle{}, auth.ErrForbidden
+	}
+	return v, nil
+}
+
+// GetAll returns the vehicles the caller may see
+func (s *service) GetAll(ctx context.Context) ([]Vehicle, error) {
+	vv, err := s.vehicleStore.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return visible(ctx, vv), nil
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	v, err := s.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.vehicleStore.Delete(v.ID)
+}
+
+func (s *service) SearchByPlate(ctx context.Context, plate string) ([]Vehicle, error) {
+	if NormalizePlate(plate) == "" {
+		return nil, ErrInvalidPlate
+	}
+	vv, err := s.vehicleStore.FindByPlate(plate)
+	if err != nil {
+		return nil, err
+	}
+	return visible(ctx, vv), nil
+}
+
+func visible(ctx context.Context, vv []Vehicle) []Vehicle {
+	res := make([]Vehicle, 0, len(vv))
+	for _, v := range vv {
+		if auth.CanAccess(ctx, v.Owner) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// CheckFit verifies that v may park on sp, by class and by size
+func CheckFit(v Vehicle, sp parking.Spot) error {
+	if !sp.Allows(v.Class) {
+		return ErrClassNotAllowed
+	}
+	if !sp.Fits(v.Dimensions) {
+		return ErrDoesNotFit
+	}
+	return nil
+}
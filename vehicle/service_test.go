@@ -0,0 +1,84 @@
+package vehicle
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/parking"
+)
+
+func driver(subject string) context.Context {
+	return auth.NewContext(context.Background(), auth.Principal{Subject: subject, Roles: []auth.Role{auth.RoleDriver}})
+}
+
+func TestRegister(t *testing.T) {
+	inMemStore, err := NewInMemVehicleStore()
+	if err != nil {
+		t.Error("Failed to create inmem store")
+	}
+	service := NewService(inMemStore)
+
+	v, err := service.Register(driver("alice"), Vehicle{
+		Plate:      "ab-12 cd",
+		Region:     "MN",
+		Class:      parking.Car,
+		Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150},
+	})
+	if err != nil {
+		t.Error("Error in register")
+	}
+	if v.Owner != "alice" || v.Plate != "AB12CD" {
+		t.Error("Incorrect vehicle registered")
+	}
+
+	if _, err := service.Register(nil, Vehicle{Plate: "X", Class: "spaceship", Dimensions: v.Dimensions}); err != ErrInvalidClass {
+		t.Error("Expecting unknown class to be rejected")
+	}
+	if _, err := service.Register(nil, Vehicle{Plate: "X", Class: parking.Car}); err != ErrInvalidDimension {
+		t.Error("Expecting missing dimensions to be rejected")
+	}
+}
+
+func TestOwnership(t *testing.T) {
+	inMemStore, _ := NewInMemVehicleStore()
+	service := NewService(inMemStore)
+
+	v, _ := service.Register(driver("alice"), Vehicle{
+		Plate:      "AB12CD",
+		Class:      parking.Car,
+		Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150},
+	})
+	id := strconv.Itoa(v.ID)
+
+	if _, err := service.Find(driver("bob"), id); err != auth.ErrForbidden {
+		t.Error("Expecting another driver to be denied")
+	}
+	vv, _ := service.GetAll(driver("bob"))
+	if len(vv) != 0 {
+		t.Error("Expecting other drivers' vehicles to be hidden")
+	}
+
+	operator := auth.NewContext(context.Background(), auth.Principal{Subject: "op", Roles: []auth.Role{auth.RoleOperator}})
+	vv, err := service.SearchByPlate(operator, "ab 12 cd")
+	if err != nil || len(vv) != 1 {
+		t.Error("Expecting operator to find vehicle by plate")
+	}
+}
+
+func TestCheckFit(t *testing.T) {
+	car := Vehicle{Class: parking.Car, Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}}
+	if err := CheckFit(car, parking.Spot{}); err != nil {
+		t.Error("Expecting an unrestricted spot to fit a car")
+	}
+	if err := CheckFit(car, parking.Spot{Classes: []parking.VehicleClass{parking.Motorcycle}}); err != ErrClassNotAllowed {
+		t.Error("Expecting class restriction to apply")
+	}
+	if err := CheckFit(car, parking.Spot{MaxSize: &parking.Dimensions{Height: 140}}); err != ErrDoesNotFit {
+		t.Error("Expecting height restriction to apply")
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
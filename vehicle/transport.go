@@ -0,0 +1,119 @@
+package vehicle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"Register":      {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"GetAll":        {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"SearchByPlate": {Scope: auth.ScopeBookingRead, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin, auth.RoleService}},
+	"Find":          {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"Delete":        {Scope: auth.ScopeBookingWrite, Roles: []auth.Role{auth.RoleDriver, auth.RolePartner, auth.RoleOperator, auth.RoleAdmin}},
+}
+
+// MakeHTTPHandler mounts all of the service endpoints into an http.Handler.
+// Every route declares the roles allowed to call it, enforced by a.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("POST").Path("/vehicle/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "Register")(e.RegisterEndpoint),
+		decodeRegisterRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/vehicle/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "GetAll")(e.GetAllEndpoint),
+		decodeGetAllRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/vehicle/v1/plate/{plate}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "SearchByPlate")(e.SearchByPlateEndpoint),
+		decodePlateRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/vehicle/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "Find")(e.FindEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/vehicle/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "Delete")(e.DeleteEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeRegisterRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req registerRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeGetAllRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req getAllRequest
+	return req, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return idRequest{ID: id}, nil
+}
+
+func decodePlateRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	plate, ok := vars["plate"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return plateRequest{Plate: plate}, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,120 @@
+package vehicle
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/parking"
+)
+
+// The vehicle store which stores the vehicles registered by users
+
+type VehicleStore interface {
+	Create(Vehicle) (Vehicle, error)
+	Delete(id int) error
+	Find(id int) (Vehicle, error)
+	FindByPlate(plate string) ([]Vehicle, error)
+	GetAll() ([]Vehicle, error)
+}
+
+type Vehicle struct {
+	ID         int                  `json:"id"`
+	Owner      string               `json:"owner"`
+	Plate      string               `json:"plate"`
+	Region     string               `json:"region"`
+	Class      parking.VehicleClass `json:"class"`
+	Dimensions parking.Dimensions   `json:"dimensions"`
+}
+
+var (
+	ErrInconsistentIDs = apierror.New(apierror.Conflict, "inconsistent_ids", "inconsistent IDs")
+	ErrNotFound        = apierror.New(apierror.NotFound, "vehicle_not_found", "not found")
+	ErrInvalidReq      = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInternal        = apierror.New(apierror.Internal, "internal", "internal data error")
+)
+
+// NormalizePlate upper cases a plate and strips separators so that
+// "ab-12 cd" and "AB12CD" match
+func NormalizePlate(plate string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(plate))
+}
+
+type InMemStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Vehicle
+	nxtId int // keeps track of the id of the next element to be created
+}
+
+func NewInMemVehicleStore() (VehicleStore, error) {
+	s := &InMemStore{m: make(map[int]Vehicle, 0), nxtId: 1}
+	return s, nil
+}
+
+func (s *InMemStore) Create(v Vehicle) (Vehicle, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	v.ID = s.nxtId
+	v.Plate = NormalizePlate(v.Plate)
+	s.m[v.ID] = v
+	s.nxtId++
+	return v, nil
+}
+
+func (s *InMemStore) Delete(id int) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.m, id)
+	return nil
+}
+
+func (s *InMemStore) Find(id int) (Vehicle, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	v, ok := s.m[id]
+	if !ok {
+		return Vehicle{}, ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *InMemStore) FindByPlate(plate string) ([]Vehicle, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	plate = NormalizePlate(plate)
+	vv := make([]Vehicle, 0)
+	for _, v := range s.m {
+		if v.Plate == plate {
+			vv = append(vv, v)
+		}
+	}
+	sortVehicles(vv)
+	return vv, nil
+}
+
+func (s *InMemStore) GetAll() ([]Vehicle, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	vv := make([]Vehicle, 0)
+	for _, v := range s.m {
+		vv = append(vv, v)
+	}
+	sortVehicles(vv)
+	return vv, nil
+}
+
+func sortVehicles(vv []Vehicle) {
+	sort.Slice(vv, func(i, j int) bool {
+		return vv[i].ID < vv[j].ID
+	})
+}
@@ -0,0 +1,114 @@
+package waitlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	JoinEndpoint    endpoint.Endpoint
+	ListEndpoint    endpoint.Endpoint
+	FindEndpoint    endpoint.Endpoint
+	LeaveEndpoint   endpoint.Endpoint
+	AcceptEndpoint  endpoint.Endpoint
+	DeclineEndpoint endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		JoinEndpoint:    MakeJoinEndpoint(s),
+		ListEndpoint:    MakeListEndpoint(s),
+		FindEndpoint:    MakeFindEndpoint(s),
+		LeaveEndpoint:   MakeLeaveEndpoint(s),
+		AcceptEndpoint:  MakeAcceptEndpoint(s),
+		DeclineEndpoint: MakeDeclineEndpoint(s),
+	}
+}
+
+func MakeJoinEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(joinRequest)
+		e, err := s.Join(ctx, Entry{
+			VehicleId: req.VehicleId,
+			Target:    req.Target,
+			From:      req.From,
+			Until:     req.Until,
+			Tier:      req.Tier,
+			Mode:      req.Mode,
+		})
+		return entryResponse{Entry: e, Err: err}, err
+	}
+}
+
+func MakeListEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		ee, e := s.List(ctx)
+		return listResponse{Entries: ee, Err: e}, e
+	}
+}
+
+func MakeFindEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e, err := s.Find(ctx, req.ID)
+		return entryResponse{Entry: e, Err: err}, err
+	}
+}
+
+func MakeLeaveEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e, err := s.Leave(ctx, req.ID)
+		return entryResponse{Entry: e, Err: err}, err
+	}
+}
+
+func MakeAcceptEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e, err := s.Accept(ctx, req.ID)
+		return entryResponse{Entry: e, Err: err}, err
+	}
+}
+
+func MakeDeclineEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e, err := s.Decline(ctx, req.ID)
+		return entryResponse{Entry: e, Err: err}, err
+	}
+}
+
+//
+
+type joinRequest struct {
+	VehicleId int       `json:"vehicleId"`
+	Target    Target    `json:"target"`
+	From      time.Time `json:"from,omitempty"`
+	Until     time.Time `json:"until"`
+	Tier      Tier      `json:"tier,omitempty"`
+	Mode      Mode      `json:"mode,omitempty"`
+}
+
+type entryResponse struct {
+	Err   error `json:"err,omitempty"`
+	Entry Entry `json:"entry"`
+}
+
+func (r entryResponse) error() error { return r.Err }
+
+type listRequest struct {
+}
+
+type listResponse struct {
+	Err     error   `json:"err,omitempty"`
+	Entries []Entry `json:"entries"`
+}
+
+func (r listResponse) error() error { return r.Err }
+
+type idRequest struct {
+	ID string `json:"id"`
+}
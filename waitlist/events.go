@@ -0,0 +1,29 @@
+package waitlist
+
+import (
+	"strconv"
+
+	"github.com/atuldaemon/rct/event"
+)
+
+// The waitlist events published on the event bus, so users can be told
+// about offers
+
+type EntryOffered struct {
+	Entry Entry `json:"entry"`
+}
+
+type EntryBooked struct {
+	Entry Entry `json:"entry"`
+}
+
+func (EntryOffered) EventType() string { return event.WaitlistOffered }
+func (EntryBooked) EventType() string  { return event.WaitlistBooked }
+
+func (e EntryOffered) AggregateID() string { return EntryAggregate(e.Entry.ID) }
+func (e EntryBooked) AggregateID() string  { return EntryAggregate(e.Entry.ID) }
+
+// EntryAggregate is the aggregate ID of the events of entry id
+func EntryAggregate(id int) string {
+	return "waitlist/" + strconv.Itoa(id)
+}
@@ -0,0 +1,80 @@
+package waitlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Join(ctx context.Context, e Entry) (res Entry, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Join", "vehicleId", e.VehicleId, "tier", e.Tier, "mode", e.Mode, "id", res.ID, "status", res.Status, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Join(ctx, e)
+}
+
+func (mw loggingMiddleware) List(ctx context.Context) (ee []Entry, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "List", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.List(ctx)
+}
+
+func (mw loggingMiddleware) Find(ctx context.Context, id string) (e Entry, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Find", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Find(ctx, id)
+}
+
+func (mw loggingMiddleware) Leave(ctx context.Context, id string) (e Entry, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Leave", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Leave(ctx, id)
+}
+
+func (mw loggingMiddleware) Accept(ctx context.Context, id string) (e Entry, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Accept", "id", id, "bookingId", e.BookingId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Accept(ctx, id)
+}
+
+func (mw loggingMiddleware) Decline(ctx context.Context, id string) (e Entry, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Decline", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Decline(ctx, id)
+}
+
+func (mw loggingMiddleware) Released(ctx context.Context, spotId int) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Released", "spotId", spotId, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Released(ctx, spotId)
+}
+
+func (mw loggingMiddleware) Expire(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Expire", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Expire(ctx)
+}
@@ -0,0 +1,44 @@
+package waitlist
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Enum(Tier(""), Priority, Standard)
+	d.Enum(Mode(""), Offer, Auto)
+	d.Enum(Status(""), Waiting, Offered, Booked, Expired, Left)
+
+	d.Operation("POST", "/waitlist/v1/", "joinWaitlist", "Wait for a spot, a facility or an area, a free match is handed out right away").
+		Tag("waitlist").Require(requirements["JoinWaitlist"]).
+		Body(joinRequest{}).
+		Returns(http.StatusOK, "The entry", entryResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/waitlist/v1/", "listWaitlist", "List the entries of the caller, or all for operators, in line order").
+		Tag("waitlist").Require(requirements["ListWaitlist"]).
+		Returns(http.StatusOK, "Entries", listResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/waitlist/v1/{id}", "findWaitlistEntry", "Get an entry and its offer").
+		Tag("waitlist").Require(requirements["FindWaitlistEntry"]).
+		PathParam("id", "Entry id").
+		Returns(http.StatusOK, "The entry", entryResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/waitlist/v1/{id}", "leaveWaitlist", "Leave the waitlist, an open offer goes to the next in line").
+		Tag("waitlist").Require(requirements["LeaveWaitlist"]).
+		PathParam("id", "Entry id").
+		Returns(http.StatusOK, "The entry", entryResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/waitlist/v1/{id}/accept", "acceptOffer", "Book the offered spot").
+		Tag("waitlist").Require(requirements["AcceptOffer"]).
+		PathParam("id", "Entry id").
+		Returns(http.StatusOK, "The entry with its booking", entryResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/waitlist/v1/{id}/decline", "declineOffer", "Pass the offered spot on and keep waiting").
+		Tag("waitlist").Require(requirements["DeclineOffer"]).
+		PathParam("id", "Entry id").
+		Returns(http.StatusOK, "The entry", entryResponse{}).
+		Fails(http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
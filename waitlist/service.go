@@ -0,0 +1,500 @@
+package waitlist
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/umahmood/haversine"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+)
+
+// Waitlist service. Users wait for a spot, a facility or an area. When a
+// spot is released the first matching entry in line gets it, offered for a
+// while or booked right away.
+
+var (
+	ErrInvalidReq     = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInvalidTarget  = apierror.New(apierror.Unprocessable, "invalid_waitlist_target", "wait for a spot id, a facility, or lat, lon and rad")
+	ErrInvalidWindow  = apierror.New(apierror.Unprocessable, "invalid_waitlist_window", "until must be after from and in the future")
+	ErrInvalidVehicle = apierror.New(apierror.Unprocessable, "invalid_vehicle", "no such vehicle owned by the caller").
+				WithField("vehicleId", "no such vehicle owned by the caller")
+	ErrPriorityDenied = apierror.New(apierror.Forbidden, "waitlist_priority_denied", "only operators may put entries in the priority tier")
+	ErrNoOffer        = apierror.New(apierror.Conflict, "waitlist_no_offer", "the entry has no open offer")
+	ErrClosed         = apierror.New(apierror.Conflict, "waitlist_entry_closed", "the entry is no longer in line")
+	ErrSpotTaken      = apierror.New(apierror.Conflict, "waitlist_spot_taken", "the offered spot was booked meanwhile, the entry is back in line")
+)
+
+// DefaultOfferTTL is how long an offer stands
+const DefaultOfferTTL = 10 * time.Minute
+
+// Bookings books the spots handed out
+type Bookings interface {
+	Book(ctx context.Context, spotId, vehicleId string, startTime time.Time, duration time.Duration) (booking.Booking, error)
+}
+
+type Service interface {
+	Join(ctx context.Context, e Entry) (Entry, error)
+	// List returns the entries the caller may see, in line order
+	List(ctx context.Context) ([]Entry, error)
+	Find(ctx context.Context, id string) (Entry, error)
+	Leave(ctx context.Context, id string) (Entry, error)
+	// Accept books the offered spot
+	Accept(ctx context.Context, id string) (Entry, error)
+	// Decline passes the offered spot on to the next in line
+	Decline(ctx context.Context, id string) (Entry, error)
+	// Released hands a released spot to the line. It is called from the
+	// booking events, not exposed over HTTP.
+	Released(ctx context.Context, spotId int) error
+	// Expire passes on lapsed offers and closes the entries whose window
+	// ended. It is run periodically, not exposed over HTTP.
+	Expire(ctx context.Context) error
+}
+
+type service struct {
+	// mtx serializes the changes to the line
+	mtx      sync.Mutex
+	entries  EntryStore
+	spots    parking.Service
+	bookings Bookings
+	vehicles vehicle.Service
+	events   event.Publisher
+	offerTTL time.Duration
+	now      func() time.Time
+}
+
+// NewService returns the waitlist service. Offers stand for offerTTL,
+// DefaultOfferTTL when zero. Events may be nil.
+func NewService(entries EntryStore, spots parking.Service, bookings Bookings, vehicles vehicle.Service, events event.Publisher, offerTTL time.Duration) Service {
+	if offerTTL <= 0 {
+		offerTTL = DefaultOfferTTL
+	}
+	if events == nil {
+		events = event.Nop
+	}
+	return &service{
+		entries:  entries,
+		spots:    spots,
+		bookings: bookings,
+		vehicles: vehicles,
+		events:   events,
+		offerTTL: offerTTL,
+		now:      time.Now,
+	}
+}
+
+func (s *service) Join(ctx context.Context, e Entry) (Entry, error) {
+	now := s.now()
+	if err := s.validTarget(ctx, e.Target); err != nil {
+		return Entry{}, err
+	}
+	if e.From.IsZero() {
+		e.From = now
+	}
+	if !e.Until.After(e.From) || !e.Until.After(now) {
+		return Entry{}, ErrInvalidWindow
+	}
+	if e.Tier == "" {
+		e.Tier = Standard
+	}
+	if !e.Tier.Valid() {
+		return Entry{}, ErrInvalidReq.WithField("tier", "must be priority or standard")
+	}
+	if e.Tier == Priority && !privileged(ctx) {
+		return Entry{}, ErrPriorityDenied
+	}
+	if e.Mode == "" {
+		e.Mode = Offer
+	}
+	if !e.Mode.Valid() {
+		return Entry{}, ErrInvalidReq.WithField("mode", "must be offer or auto")
+	}
+	if _, err := s.vehicles.Find(ctx, strconv.Itoa(e.VehicleId)); err == auth.ErrForbidden {
+		return Entry{}, err
+	} else if err != nil {
+		return Entry{}, ErrInvalidVehicle
+	}
+	p, _ := auth.PrincipalFromContext(ctx)
+	e.User = p.Subject
+	e.Status = Waiting
+	e.OfferedSpot, e.OfferExpires, e.BookingId, e.Passed = 0, time.Time{}, 0, nil
+	e.CreatedAt = now.UTC()
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	e, err := s.entries.Create(e)
+	if err != nil {
+		return Entry{}, err
+	}
+	// a matching spot may be free already
+	free, err := s.spots.GetFree(ctx)
+	if err != nil {
+		return e, nil
+	}
+	for _, sp := range free {
+		if !matches(e.Target, sp) {
+			continue
+		}
+		if err := s.assign(ctx, sp); err != nil {
+			break
+		}
+		if cur, err := s.entries.Find(e.ID); err == nil {
+			e = cur
+		}
+		if e.Status != Waiting {
+			break
+		}
+	}
+	return e, nil
+}
+
+func (s *service) validTarget(ctx context.Context, t Target) error {
+	n := 0
+	if t.SpotId != 0 {
+		n++
+		if _, err := s.spots.FindById(ctx, strconv.Itoa(t.SpotId)); err != nil {
+			return ErrInvalidTarget.WithField("spotId", "no such spot")
+		}
+	}
+	if t.Facility != "" {
+		n++
+	}
+	if t.Lat != "" || t.Lon != "" || t.Rad != "" {
+		n++
+		if _, ok := point(t.Lat, t.Lon); !ok {
+			return ErrInvalidTarget.WithField("lat", "lat and lon must be numbers")
+		}
+		if rad, err := strconv.ParseFloat(t.Rad, 64); err != nil || rad <= 0 {
+			return ErrInvalidTarget.WithField("rad", "must be a positive number of meters")
+		}
+	}
+	if n != 1 {
+		return ErrInvalidTarget
+	}
+	return nil
+}
+
+func (s *service) List(ctx context.Context) ([]Entry, error) {
+	ee, err := s.entries.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Entry, 0, len(ee))
+	for _, e := range ee {
+		if auth.CanAccess(ctx, e.User) {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (s *service) Find(ctx context.Context, id string) (Entry, error) {
+	intId, err := strconv.Atoi(id)
+	if err != nil {
+		return Entry{}, ErrInvalidReq
+	}
+	e, err := s.entries.Find(intId)
+	if err != nil {
+		return Entry{}, err
+	}
+	if !auth.CanAccess(ctx, e.User) {
+		return Entry{}, auth.ErrForbidden
+	}
+	return e, nil
+}
+
+func (s *service) Leave(ctx context.Context, id string) (Entry, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	e, err := s.Find(ctx, id)
+	if err != nil {
+		return Entry{}, err
+	}
+	if !e.Status.Open() {
+		return Entry{}, ErrClosed
+	}
+	offered := e.OfferedSpot
+	e.Status, e.OfferedSpot, e.OfferExpires = Left, 0, time.Time{}
+	if e, err = s.entries.Update(e); err != nil {
+		return Entry{}, err
+	}
+	if offered != 0 {
+		s.reassign(ctx, offered)
+	}
+	return e, nil
+}
+
+func (s *service) Accept(ctx context.Context, id string) (Entry, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	e, err := s.Find(ctx, id)
+	if err != nil {
+		return Entry{}, err
+	}
+	now := s.now()
+	if e.Status != Offered || !now.Before(e.OfferExpires) {
+		return Entry{}, ErrNoOffer
+	}
+	b, err := s.book(ctx, e, e.OfferedSpot, now)
+	if err == booking.ErrAlreadyReserved {
+		// back in line, keeping its place
+		e.Status, e.OfferedSpot, e.OfferExpires = Waiting, 0, time.Time{}
+		s.entries.Update(e)
+		return Entry{}, ErrSpotTaken
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+	return s.booked(ctx, e, b)
+}
+
+func (s *service) Decline(ctx context.Context, id string) (Entry, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	e, err := s.Find(ctx, id)
+	if err != nil {
+		return Entry{}, err
+	}
+	if e.Status != Offered {
+		return Entry{}, ErrNoOffer
+	}
+	spotId := e.OfferedSpot
+	if e, err = s.pass(e); err != nil {
+		return Entry{}, err
+	}
+	s.reassign(ctx, spotId)
+	return e, nil
+}
+
+func (s *service) Released(ctx context.Context, spotId int) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sp, err := s.spots.FindById(ctx, strconv.Itoa(spotId))
+	if err == parking.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.assign(ctx, sp)
+}
+
+func (s *service) Expire(ctx context.Context) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	ee, err := s.entries.GetAll()
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	lapsed := make([]int, 0)
+	for _, e := range ee {
+		if !e.Status.Open() {
+			continue
+		}
+		if !now.Before(e.Until) {
+			if e.Status == Offered {
+				lapsed = append(lapsed, e.OfferedSpot)
+			}
+			e.Status, e.OfferedSpot, e.OfferExpires = Expired, 0, time.Time{}
+			if _, err := s.entries.Update(e); err != nil {
+				return err
+			}
+			continue
+		}
+		if e.Status == Offered && !now.Before(e.OfferExpires) {
+			lapsed = append(lapsed, e.OfferedSpot)
+			if _, err := s.pass(e); err != nil {
+				return err
+			}
+		}
+	}
+	for _, spotId := range lapsed {
+		s.reassign(ctx, spotId)
+	}
+	return nil
+}
+
+// reassign hands a spot given back by an entry to the next in line
+func (s *service) reassign(ctx context.Context, spotId int) {
+	if sp, err := s.spots.FindById(ctx, strconv.Itoa(spotId)); err == nil {
+		s.assign(ctx, sp)
+	}
+}
+
+// assign offers or books the free spot sp for the first matching entry in
+// line. The caller holds mtx.
+func (s *service) assign(ctx context.Context, sp parking.Spot) error {
+	// the line is served by the service itself, not on behalf of whoever
+	// released the spot
+	ctx = auth.NewContext(context.Background(), auth.System)
+	if sp.IsReserved {
+		return nil
+	}
+	ee, err := s.entries.GetAll()
+	if err != nil {
+		return err
+	}
+	for _, e := range ee {
+		if e.Status == Offered && e.OfferedSpot == sp.ID {
+			// promised already
+			return nil
+		}
+	}
+	now := s.now()
+	for _, tier := range Tiers {
+		for _, e := range ee {
+			if e.Tier != tier || !s.eligible(ctx, e, sp, now) {
+				continue
+			}
+			if e.Mode == Offer {
+				e.Status, e.OfferedSpot, e.OfferExpires = Offered, sp.ID, now.Add(s.offerTTL).UTC()
+				if e, err = s.entries.Update(e); err != nil {
+					return err
+				}
+				s.publish(ctx, EntryOffered{e})
+				return nil
+			}
+			b, err := s.book(ctx, e, sp.ID, now)
+			if err == booking.ErrAlreadyReserved {
+				return nil
+			}
+			if err != nil {
+				// e.g. the vehicle is gone, try the next in line
+				continue
+			}
+			_, err = s.booked(ctx, e, b)
+			return err
+		}
+	}
+	return nil
+}
+
+// eligible reports whether e is waiting for a spot like sp and its vehicle
+// fits
+func (s *service) eligible(ctx context.Context, e Entry, sp parking.Spot, now time.Time) bool {
+	if e.Status != Waiting || !now.Before(e.Until) || !matches(e.Target, sp) {
+		return false
+	}
+	for _, id := range e.Passed {
+		if id == sp.ID {
+			return false
+		}
+	}
+	v, err := s.vehicles.Find(ctx, strconv.Itoa(e.VehicleId))
+	return err == nil && vehicle.CheckFit(v, sp) == nil
+}
+
+// book books spotId for the rest of the entry's window, from when the spot
+// has been turned over
+func (s *service) book(ctx context.Context, e Entry, spotId int, now time.Time) (booking.Booking, error) {
+	start := e.From
+	if start.Before(now) {
+		start = now
+	}
+	if sp, err := s.spots.FindById(ctx, strconv.Itoa(spotId)); err == nil && start.Before(sp.TurnoverUntil) {
+		start = sp.TurnoverUntil
+	}
+	if !start.Before(e.Until) {
+		return booking.Booking{}, booking.ErrOverlap
+	}
+	return s.bookings.Book(ctx, strconv.Itoa(spotId), strconv.Itoa(e.VehicleId), start, e.Until.Sub(start))
+}
+
+func (s *service) booked(ctx context.Context, e Entry, b booking.Booking) (Entry, error) {
+	e.Status, e.BookingId, e.OfferedSpot, e.OfferExpires = Booked, b.ID, 0, time.Time{}
+	e, err := s.entries.Update(e)
+	if err != nil {
+		return Entry{}, err
+	}
+	s.publish(ctx, EntryBooked{e})
+	return e, nil
+}
+
+// pass puts e back in line, the offered spot won't be offered to it again
+func (s *service) pass(e Entry) (Entry, error) {
+	e.Passed = append(append([]int(nil), e.Passed...), e.OfferedSpot)
+	e.Status, e.OfferedSpot, e.OfferExpires = Waiting, 0, time.Time{}
+	return s.entries.Update(e)
+}
+
+func (s *service) publish(ctx context.Context, p event.Payload) {
+	s.events.Publish(ctx, event.New(p, s.now()))
+}
+
+// matches reports whether sp is a spot t waits for
+func matches(t Target, sp parking.Spot) bool {
+	switch {
+	case t.SpotId != 0:
+		return sp.ID == t.SpotId
+	case t.Facility != "":
+		return sp.Facility == t.Facility
+	}
+	from, ok := point(t.Lat, t.Lon)
+	if !ok {
+		return false
+	}
+	to, ok := point(sp.Lat, sp.Lon)
+	if !ok {
+		return false
+	}
+	rad, _ := strconv.ParseFloat(t.Rad, 64)
+	_, km := haversine.Distance(from, to)
+	return km*1000 <= rad
+}
+
+func point(lat, lon string) (haversine.Coord, bool) {
+	la, err := strconv.ParseFloat(lat, 64)
+	if err != nil {
+		return haversine.Coord{}, false
+	}
+	lo, err := strconv.ParseFloat(lon, 64)
+	if err != nil {
+		return haversine.Coord{}, false
+	}
+	return haversine.Coord{Lat: la, Lon: lo}, true
+}
+
+// privileged reports whether the caller may choose the tier
+func privileged(ctx context.Context) bool {
+	p, ok := auth.PrincipalFromContext(ctx)
+	return ok && (p.HasAnyRole(auth.RoleOperator, auth.RoleAdmin, auth.RoleService) || p.HasScope(auth.ScopeAdmin))
+}
+
+// Assign is the event handler which hands the spots of cancelled, expired,
+// moved and no-show bookings and of given up holds to the line
+func Assign(s Service, logger log.Logger) event.Handler {
+	return func(ctx context.Context, e event.Event) {
+		var spotId int
+		switch d := e.Data.(type) {
+		case booking.BookingCancelled:
+			spotId = d.Booking.SpotId
+		case booking.BookingExpired:
+			spotId = d.Booking.SpotId
+		case booking.BookingNoShow:
+			spotId = d.Booking.SpotId
+		case booking.BookingChanged:
+			if d.Previous.SpotId == d.Booking.SpotId {
+				return
+			}
+			spotId = d.Previous.SpotId
+		case booking.HoldReleased:
+			spotId = d.Hold.SpotId
+		case booking.HoldExpired:
+			spotId = d.Hold.SpotId
+		default:
+			return
+		}
+		if err := s.Released(ctx, spotId); err != nil {
+			logger.Log("event", e.ID, "spot", spotId, "err", err)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package waitlist
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/auth"
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/atuldaemon/rct/vehicle"
+	"github.com/go-kit/kit/log"
+)
+
+type recorder struct {
+	mtx    sync.Mutex
+	events []event.Event
+}
+
+func (r *recorder) Publish(ctx context.Context, e event.Event) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) types() []string {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	tt := make([]string, 0)
+	for _, e := range r.events {
+		tt = append(tt, e.Type)
+	}
+	return tt
+}
+
+type fixture struct {
+	s        Service
+	store    parking.ParkingStore
+	bookings booking.Service
+	vehicles vehicle.Service
+	events   *recorder
+	// skip moves the waitlist clock forward
+	skip func(time.Duration)
+}
+
+// setReserved reserves or releases spot id, keeping the rest of the spot
+func setReserved(store parking.ParkingStore, id int, reserved bool) {
+	sp, _ := store.FindById(id)
+	sp.IsReserved = reserved
+	store.Update(sp)
+}
+
+// newTestService sets up the waitlist on top of the real parking, vehicle
+// and booking services. With full set every spot starts reserved.
+func newTestService(t *testing.T, full bool) fixture {
+	store, _ := parking.NewInMemParkingStore()
+	if full {
+		for id := 1; id <= 5; id++ {
+			setReserved(store, id, true)
+		}
+	}
+	p := parking.NewService(store)
+	vehicleStore, _ := vehicle.NewInMemVehicleStore()
+	v := vehicle.NewService(vehicleStore)
+	bookingStore, _ := booking.NewInMemBookingStore()
+	holdStore, _ := booking.NewInMemHoldStore()
+	groupStore, _ := booking.NewInMemGroupStore()
+	b := booking.NewService(bookingStore, p, v, booking.Options{Holds: holdStore, Groups: groupStore})
+	entries, _ := NewInMemEntryStore()
+	r := &recorder{}
+	s := NewService(entries, p, b, v, r, 10*time.Minute)
+	var offset time.Duration
+	s.(*service).now = func() time.Time { return time.Now().Add(offset) }
+	return fixture{s: s, store: store, bookings: b, vehicles: v, events: r, skip: func(d time.Duration) { offset += d }}
+}
+
+// system is the context of the background work inside the process
+var system = auth.NewContext(context.Background(), auth.System)
+
+func user(name string, roles ...auth.Role) context.Context {
+	if len(roles) == 0 {
+		roles = []auth.Role{auth.RoleDriver}
+	}
+	return auth.NewContext(context.Background(), auth.Principal{Subject: name, Roles: roles})
+}
+
+func (f fixture) car(t *testing.T, ctx context.Context) int {
+	c, err := f.vehicles.Register(ctx, vehicle.Vehicle{Plate: "AB12CD", Class: parking.Car,
+		Dimensions: parking.Dimensions{Length: 450, Width: 180, Height: 150}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.ID
+}
+
+func (f fixture) join(t *testing.T, ctx context.Context, e Entry) Entry {
+	e.VehicleId = f.car(t, ctx)
+	if e.Until.IsZero() {
+		e.Until = time.Now().Add(2 * time.Hour)
+	}
+	res, err := f.s.Join(ctx, e)
+	if err != nil {
+		t.Fatalf("Failed to join: %v", err)
+	}
+	return res
+}
+
+func (f fixture) find(t *testing.T, id int) Entry {
+	e, err := f.s.Find(system, strconv.Itoa(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestLineOrder(t *testing.T) {
+	f := newTestService(t, true)
+	lakeside := Target{Facility: "lakeside"}
+	alice := f.join(t, user("alice"), Entry{Target: lakeside})
+	bob := f.join(t, user("bob"), Entry{Target: lakeside})
+	op := f.join(t, user("op", auth.RoleOperator), Entry{Target: lakeside, Tier: Priority})
+	other := f.join(t, user("carol"), Entry{Target: Target{SpotId: 1}})
+	if alice.Status != Waiting || op.Tier != Priority || alice.Mode != Offer {
+		t.Fatalf("Expected waiting entries with the defaults, got %+v", alice)
+	}
+
+	setReserved(f.store, 5, false)
+	if err := f.s.Released(system, 5); err != nil {
+		t.Fatal(err)
+	}
+	if e := f.find(t, op.ID); e.Status != Offered || e.OfferedSpot != 5 {
+		t.Fatalf("Expected the priority tier to be served first, got %+v", e)
+	}
+	if e := f.find(t, other.ID); e.Status != Waiting {
+		t.Error("Expected an entry for another spot to keep waiting")
+	}
+	// a second release of the same spot doesn't promise it twice
+	f.s.Released(system, 5)
+	if e := f.find(t, alice.ID); e.Status != Waiting {
+		t.Error("Expected the offered spot not to be offered again")
+	}
+
+	if _, err := f.s.Decline(user("op", auth.RoleOperator), strconv.Itoa(op.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if e := f.find(t, alice.ID); e.Status != Offered || e.OfferedSpot != 5 {
+		t.Fatalf("Expected the first standard entry to get the declined spot, got %+v", e)
+	}
+	if e := f.find(t, op.ID); e.Status != Waiting || len(e.Passed) != 1 {
+		t.Errorf("Expected the declining entry back in line, got %+v", e)
+	}
+	if _, err := f.s.Accept(user("bob"), strconv.Itoa(alice.ID)); err != auth.ErrForbidden {
+		t.Errorf("Expected another user's offer to be out of reach, got %v", err)
+	}
+
+	// alice lets the offer lapse
+	f.skip(11 * time.Minute)
+	if err := f.s.Expire(system); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.s.Accept(user("alice"), strconv.Itoa(alice.ID)); err != ErrNoOffer {
+		t.Errorf("Expected the lapsed offer to be gone, got %v", err)
+	}
+	e, err := f.s.Accept(user("bob"), strconv.Itoa(bob.ID))
+	if err != nil || e.Status != Booked || e.BookingId == 0 {
+		t.Fatalf("Expected bob to book the spot, got %+v %v", e, err)
+	}
+	if b, err := f.bookings.Find(system, strconv.Itoa(e.BookingId)); err != nil || b.SpotId != 5 {
+		t.Errorf("Expected the accepted spot to be booked, got %+v %v", b, err)
+	}
+
+	want := []string{event.WaitlistOffered, event.WaitlistOffered, event.WaitlistOffered, event.WaitlistBooked}
+	if got := f.events.types(); len(got) != len(want) || got[3] != want[3] {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if _, err := f.s.Leave(user("bob"), strconv.Itoa(bob.ID)); err != ErrClosed {
+		t.Errorf("Expected a booked entry to be out of line, got %v", err)
+	}
+}
+
+func TestAutoBook(t *testing.T) {
+	f := newTestService(t, true)
+	// spot 1 is booked by dave
+	setReserved(f.store, 1, false)
+	dave := user("dave")
+	b, err := f.bookings.Book(dave, "1", strconv.Itoa(f.car(t, dave)), time.Now(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// erin waits for anything within 500m of spot 1
+	erin := f.join(t, user("erin"), Entry{Target: Target{Lat: "44.967", Lon: "-94.420", Rad: "500"}, Mode: Auto})
+	far := f.join(t, user("frank"), Entry{Target: Target{Lat: "44.9", Lon: "-94.4", Rad: "500"}, Mode: Auto})
+
+	if err := f.bookings.Delete(dave, strconv.Itoa(b.ID)); err != nil {
+		t.Fatal(err)
+	}
+	assign := Assign(f.s, log.NewNopLogger())
+	assign(context.Background(), event.New(booking.BookingCancelled{Booking: b}, time.Now()))
+
+	e := f.find(t, erin.ID)
+	if e.Status != Booked || e.BookingId == 0 {
+		t.Fatalf("Expected the released spot to be booked for erin, got %+v", e)
+	}
+	got, err := f.bookings.GetAll(system)
+	if err != nil || len(got) != 1 || got[0].ID != e.BookingId || got[0].SpotId != 1 {
+		t.Errorf("Expected erin's booking of spot 1, got %+v", got)
+	}
+	if e := f.find(t, far.ID); e.Status != Waiting {
+		t.Error("Expected an entry outside the radius to keep waiting")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	f := newTestService(t, false)
+	ctx := user("alice")
+	// spot 3 is free right away
+	e := f.join(t, ctx, Entry{Target: Target{Facility: "desert-ramp"}})
+	if e.Status != Offered || e.OfferedSpot != 3 || e.User != "alice" {
+		t.Fatalf("Expected a free spot to be offered on joining, got %+v", e)
+	}
+
+	// someone else books it before alice accepts
+	bob := user("bob")
+	if _, err := f.bookings.Book(bob, "3", strconv.Itoa(f.car(t, bob)), time.Now(), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.s.Accept(ctx, strconv.Itoa(e.ID)); err != ErrSpotTaken {
+		t.Errorf("Expected the taken spot to fail, got %v", err)
+	}
+	if e := f.find(t, e.ID); e.Status != Waiting {
+		t.Errorf("Expected the entry back in line, got %+v", e)
+	}
+
+	car := f.car(t, ctx)
+	until := time.Now().Add(time.Hour)
+	for _, c := range []struct {
+		e   Entry
+		err error
+	}{
+		{Entry{VehicleId: car, Until: until}, ErrInvalidTarget},
+		{Entry{VehicleId: car, Until: until, Target: Target{SpotId: 1, Facility: "lakeside"}}, ErrInvalidTarget},
+		{Entry{VehicleId: car, Until: until, Target: Target{SpotId: 99}}, nil},
+		{Entry{VehicleId: car, Until: until, Target: Target{Lat: "44", Lon: "x", Rad: "5"}}, nil},
+		{Entry{VehicleId: car, Until: time.Now().Add(-time.Hour), Target: Target{SpotId: 1}}, ErrInvalidWindow},
+		{Entry{VehicleId: car, Until: until, Target: Target{SpotId: 1}, Tier: Priority}, ErrPriorityDenied},
+		{Entry{VehicleId: f.car(t, bob), Until: until, Target: Target{SpotId: 1}}, auth.ErrForbidden},
+	} {
+		_, err := f.s.Join(ctx, c.e)
+		if err == nil || (c.err != nil && err != c.err) {
+			t.Errorf("Expected %+v to fail with %v, got %v", c.e, c.err, err)
+		}
+	}
+
+	if ee, _ := f.s.List(bob); len(ee) != 0 {
+		t.Error("Expected bob not to see alice's entry")
+	}
+	if _, err := f.s.Leave(ctx, strconv.Itoa(e.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if ee, _ := f.s.List(ctx); len(ee) != 1 || ee[0].Status != Left {
+		t.Errorf("Expected alice's entry to have left, got %+v", ee)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	f := newTestService(t, false)
+	e := f.join(t, user("alice"), Entry{Target: Target{SpotId: 4}, Until: time.Now().Add(time.Hour)})
+	if e.Status != Offered {
+		t.Fatalf("Expected an offer, got %+v", e)
+	}
+	f.skip(2 * time.Hour)
+	f.s.Expire(system)
+	if e := f.find(t, e.ID); e.Status != Expired || e.OfferedSpot != 0 {
+		t.Errorf("Expected the entry to expire with its window, got %+v", e)
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
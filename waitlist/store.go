@@ -0,0 +1,148 @@
+package waitlist
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+)
+
+type EntryStore interface {
+	Create(e Entry) (Entry, error)
+	Update(e Entry) (Entry, error)
+	Find(id int) (Entry, error)
+	// GetAll returns the entries in the order they joined
+	GetAll() ([]Entry, error)
+}
+
+// Tier is the priority of an entry. Tiers are served in the order of
+// Tiers, entries of one tier first come first served.
+type Tier string
+
+const (
+	// Priority is for permit holders and the like, set by operators
+	Priority Tier = "priority"
+	Standard Tier = "standard"
+)
+
+var Tiers = []Tier{Priority, Standard}
+
+func (t Tier) Valid() bool {
+	return t == Priority || t == Standard
+}
+
+// Mode is what happens when a spot frees up for an entry
+type Mode string
+
+const (
+	// Offer lets the user accept the spot before the offer expires
+	Offer Mode = "offer"
+	// Auto books the spot right away
+	Auto Mode = "auto"
+)
+
+func (m Mode) Valid() bool {
+	return m == Offer || m == Auto
+}
+
+type Status string
+
+const (
+	Waiting Status = "waiting"
+	Offered Status = "offered"
+	Booked  Status = "booked"
+	// Expired entries reached the end of their window without a spot
+	Expired Status = "expired"
+	Left    Status = "left"
+)
+
+// Open reports whether the entry is still in line
+func (s Status) Open() bool {
+	return s == Waiting || s == Offered
+}
+
+// Target is what the user waits for: a spot, any spot of a facility, or any
+// spot within Rad meters of Lat, Lon
+type Target struct {
+	SpotId   int    `json:"spotId,omitempty"`
+	Facility string `json:"facility,omitempty"`
+	Lat      string `json:"lat,omitempty"`
+	Lon      string `json:"lon,omitempty"`
+	Rad      string `json:"rad,omitempty"`
+}
+
+type Entry struct {
+	ID        int    `json:"id"`
+	User      string `json:"user"`
+	VehicleId int    `json:"vehicleId"`
+	Target    Target `json:"target"`
+	// From and Until is the time the user wants to park
+	From   time.Time `json:"from"`
+	Until  time.Time `json:"until"`
+	Tier   Tier      `json:"tier"`
+	Mode   Mode      `json:"mode"`
+	Status Status    `json:"status"`
+	// OfferedSpot is the spot offered until OfferExpires
+	OfferedSpot  int       `json:"offeredSpot,omitempty"`
+	OfferExpires time.Time `json:"offerExpires,omitempty"`
+	BookingId    int       `json:"bookingId,omitempty"`
+	// Passed lists the spots declined or let lapse, they aren't offered
+	// again
+	Passed    []int     `json:"passed,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+var (
+	ErrNotFound = apierror.New(apierror.NotFound, "waitlist_entry_not_found", "waitlist entry not found")
+)
+
+type inMemEntryStore struct {
+	mtx   sync.RWMutex
+	m     map[int]Entry
+	nxtId int
+}
+
+func NewInMemEntryStore() (EntryStore, error) {
+	return &inMemEntryStore{m: map[int]Entry{}, nxtId: 1}, nil
+}
+
+func (s *inMemEntryStore) Create(e Entry) (Entry, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	e.ID = s.nxtId
+	s.nxtId++
+	s.m[e.ID] = e
+	return e, nil
+}
+
+func (s *inMemEntryStore) Update(e Entry) (Entry, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[e.ID]; !ok {
+		return Entry{}, ErrNotFound
+	}
+	s.m[e.ID] = e
+	return e, nil
+}
+
+func (s *inMemEntryStore) Find(id int) (Entry, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	e, ok := s.m[id]
+	if !ok {
+		return Entry{}, ErrNotFound
+	}
+	return e, nil
+}
+
+func (s *inMemEntryStore) GetAll() ([]Entry, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	ee := make([]Entry, 0, len(s.m))
+	for _, e := range s.m {
+		ee = append(ee, e)
+	}
+	sort.Slice(ee, func(i, j int) bool { return ee[i].ID < ee[j].ID })
+	return ee, nil
+}
@@ -0,0 +1,117 @@
+package waitlist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"JoinWaitlist":      {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"ListWaitlist":      {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"FindWaitlistEntry": {Scope: auth.ScopeBookingRead, Roles: auth.AllRoles},
+	"LeaveWaitlist":     {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"AcceptOffer":       {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+	"DeclineOffer":      {Scope: auth.ScopeBookingWrite, Roles: auth.AllRoles},
+}
+
+// MakeHTTPHandler mounts the waitlist endpoints into an http.Handler. Anyone
+// may wait, drivers only see and act on their own entries.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("POST").Path("/waitlist/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "JoinWaitlist")(e.JoinEndpoint),
+		decodeJoinRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/waitlist/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListWaitlist")(e.ListEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/waitlist/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "FindWaitlistEntry")(e.FindEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/waitlist/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "LeaveWaitlist")(e.LeaveEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/waitlist/v1/{id}/accept").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "AcceptOffer")(e.AcceptEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/waitlist/v1/{id}/decline").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "DeclineOffer")(e.DeclineEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeJoinRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req joinRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req listRequest
+	return req, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return idRequest{ID: id}, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/event"
+	"github.com/go-kit/kit/log"
+)
+
+var (
+	ErrDeliveryFailed   = apierror.New(apierror.Unavailable, "webhook_delivery_failed", "the receiver did not accept the delivery")
+	ErrSubscriptionGone = apierror.New(apierror.Conflict, "webhook_subscription_gone", "the subscription of the delivery was deleted")
+)
+
+// DispatcherOptions tune the deliveries. Zero values take the defaults below.
+type DispatcherOptions struct {
+	// Attempts is the number of tries, including the first, before a
+	// delivery goes to the dead-letter log
+	Attempts int
+	// Backoff is the wait before the first retry, it doubles after every
+	// further failure up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout bounds a single attempt
+	Timeout time.Duration
+}
+
+const (
+	DefaultAttempts   = 6
+	DefaultBackoff    = time.Second
+	DefaultMaxBackoff = 5 * time.Minute
+	DefaultTimeout    = 5 * time.Second
+)
+
+// Dispatcher delivers the published events to the matching subscriptions.
+// It is an event.Publisher, every delivery runs in its own goroutine so
+// Publish never waits for a receiver.
+type Dispatcher struct {
+	subs   SubscriptionStore
+	dead   DeadLetterStore
+	o      DispatcherOptions
+	client *http.Client
+	logger log.Logger
+	now    func() time.Time
+
+	mtx    sync.Mutex // guards closed, so no delivery starts after Close
+	closed bool
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(subs SubscriptionStore, dead DeadLetterStore, o DispatcherOptions, logger log.Logger) *Dispatcher {
+	if o.Attempts <= 0 {
+		o.Attempts = DefaultAttempts
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = DefaultBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultMaxBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	return &Dispatcher{
+		subs:   subs,
+		dead:   dead,
+		o:      o,
+		client: &http.Client{Timeout: o.Timeout},
+		logger: logger,
+		now:    time.Now,
+		quit:   make(chan struct{}),
+	}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, e event.Event) {
+	ss, err := d.subs.GetAll()
+	if err != nil {
+		d.logger.Log("event", e.ID, "err", err)
+		return
+	}
+	for _, sub := range ss {
+		if !sub.Wants(e.Type) {
+			continue
+		}
+		id, err := randomHex(8)
+		if err != nil {
+			d.logger.Log("event", e.ID, "err", err)
+			return
+		}
+		d.mtx.Lock()
+		if d.closed {
+			d.mtx.Unlock()
+			return
+		}
+		d.wg.Add(1)
+		d.mtx.Unlock()
+		go d.deliver(sub, Delivery{ID: id, SubscriptionID: sub.ID, Event: e})
+	}
+}
+
+// Close stops retrying and waits for the deliveries in flight. Deliveries
+// still waiting for a retry go to the dead-letter log.
+func (d *Dispatcher) Close() {
+	d.mtx.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.quit)
+	}
+	d.mtx.Unlock()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) deliver(sub Subscription, del Delivery) {
+	defer d.wg.Done()
+	backoff := d.o.Backoff
+	for {
+		if d.attempt(sub, &del) {
+			return
+		}
+		if del.Attempts >= d.o.Attempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+		case <-d.quit:
+			del.LastError += " (dispatcher stopped)"
+			d.deadLetter(del)
+			return
+		}
+		if backoff *= 2; backoff > d.o.MaxBackoff {
+			backoff = d.o.MaxBackoff
+		}
+	}
+	d.deadLetter(del)
+}
+
+func (d *Dispatcher) deadLetter(del Delivery) {
+	del.FailedAt = d.now().UTC()
+	if err := d.dead.Add(del); err != nil {
+		d.logger.Log("delivery", del.ID, "err", err)
+		return
+	}
+	d.logger.Log("delivery", del.ID, "subscription", del.SubscriptionID, "event", del.Event.ID, "attempts", del.Attempts, "msg", "dead-lettered", "err", del.LastError)
+}
+
+// attempt sends del once and records the outcome on it
+func (d *Dispatcher) attempt(sub Subscription, del *Delivery) bool {
+	del.Attempts++
+	del.LastStatus, del.LastError = 0, ""
+	status, err := d.send(sub, *del)
+	del.LastStatus = status
+	if err != nil {
+		del.LastError = err.Error()
+		return false
+	}
+	return true
+}
+
+func (d *Dispatcher) send(sub Subscription, del Delivery) (int, error) {
+	body, err := json.Marshal(del.Event)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest("POST", sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", "rct-webhook")
+	req.Header.Set(EventHeader, del.Event.Type)
+	req.Header.Set(DeliveryHeader, del.ID)
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, d.now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Replay sends a dead-lettered delivery once more, signed with the current
+// secret of its subscription. It leaves the log when the receiver accepts it.
+func (d *Dispatcher) Replay(id string) (Delivery, error) {
+	del, err := d.dead.Find(id)
+	if err != nil {
+		return Delivery{}, err
+	}
+	sub, err := d.subs.Find(del.SubscriptionID)
+	if err == ErrNotFound {
+		return Delivery{}, ErrSubscriptionGone
+	}
+	if err != nil {
+		return Delivery{}, err
+	}
+	if d.attempt(sub, &del) {
+		return del, d.dead.Remove(del.ID)
+	}
+	del.FailedAt = d.now().UTC()
+	if err := d.dead.Update(del); err != nil {
+		return Delivery{}, err
+	}
+	return del, ErrDeliveryFailed
+}
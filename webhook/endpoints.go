@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+)
+
+type Endpoints struct {
+	CreateEndpoint      endpoint.Endpoint
+	ListEndpoint        endpoint.Endpoint
+	DeleteEndpoint      endpoint.Endpoint
+	DeadLettersEndpoint endpoint.Endpoint
+	ReplayEndpoint      endpoint.Endpoint
+}
+
+func MakeServerEndpoints(s Service) Endpoints {
+	return Endpoints{
+		CreateEndpoint:      MakeCreateEndpoint(s),
+		ListEndpoint:        MakeListEndpoint(s),
+		DeleteEndpoint:      MakeDeleteEndpoint(s),
+		DeadLettersEndpoint: MakeDeadLettersEndpoint(s),
+		ReplayEndpoint:      MakeReplayEndpoint(s),
+	}
+}
+
+func MakeCreateEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(createRequest)
+		sub, e := s.Create(ctx, req.URL, req.Events)
+		return createResponse{Subscription: sub, Err: e}, e
+	}
+}
+
+func MakeListEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		ss, e := s.List(ctx)
+		return listResponse{Subscriptions: ss, Err: e}, e
+	}
+}
+
+func MakeDeleteEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		e := s.Delete(ctx, req.ID)
+		return deleteResponse{Err: e}, e
+	}
+}
+
+func MakeDeadLettersEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		dd, e := s.DeadLetters(ctx)
+		return deadLettersResponse{Deliveries: dd, Err: e}, e
+	}
+}
+
+func MakeReplayEndpoint(s Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(idRequest)
+		d, e := s.Replay(ctx, req.ID)
+		return replayResponse{Delivery: d, Err: e}, e
+	}
+}
+
+//
+
+type createRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+}
+
+type createResponse struct {
+	Err          error               `json:"err,omitempty"`
+	Subscription CreatedSubscription `json:"subscription"`
+}
+
+func (r createResponse) error() error { return r.Err }
+
+type listRequest struct {
+}
+
+type listResponse struct {
+	Err           error          `json:"err,omitempty"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+func (r listResponse) error() error { return r.Err }
+
+type idRequest struct {
+	ID string `json:"id"`
+}
+
+type deleteResponse struct {
+	Err error `json:"err,omitempty"`
+}
+
+func (r deleteResponse) error() error { return r.Err }
+
+type deadLettersResponse struct {
+	Err        error      `json:"err,omitempty"`
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+func (r deadLettersResponse) error() error { return r.Err }
+
+type replayResponse struct {
+	Err      error    `json:"err,omitempty"`
+	Delivery Delivery `json:"delivery"`
+}
+
+func (r replayResponse) error() error { return r.Err }
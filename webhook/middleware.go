@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+)
+
+type Middleware func(Service) Service
+
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+type loggingMiddleware struct {
+	next   Service
+	logger log.Logger
+}
+
+func (mw loggingMiddleware) Create(ctx context.Context, url string, events []string) (sub CreatedSubscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Create", "url", url, "events", len(events), "id", sub.ID, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Create(ctx, url, events)
+}
+
+func (mw loggingMiddleware) List(ctx context.Context) (ss []Subscription, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "List", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.List(ctx)
+}
+
+func (mw loggingMiddleware) Delete(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Delete", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Delete(ctx, id)
+}
+
+func (mw loggingMiddleware) DeadLetters(ctx context.Context) (dd []Delivery, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "DeadLetters", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.DeadLetters(ctx)
+}
+
+func (mw loggingMiddleware) Replay(ctx context.Context, id string) (d Delivery, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("method", "Replay", "id", id, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.Replay(ctx, id)
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/atuldaemon/rct/openapi"
+)
+
+// AddOpenAPI describes the routes of MakeHTTPHandler in d
+func AddOpenAPI(d *openapi.Document) {
+	d.Operation("POST", "/webhook/v1/", "createWebhook", "Subscribe a url to events, the signing secret is only returned here").
+		Tag("webhook").Require(requirements["CreateWebhook"]).
+		Body(createRequest{}).
+		Returns(http.StatusOK, "The subscription with its secret", createResponse{}).
+		Fails(http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/webhook/v1/", "listWebhooks", "List the subscriptions without their secrets").
+		Tag("webhook").Require(requirements["ListWebhooks"]).
+		Returns(http.StatusOK, "Subscriptions", listResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("DELETE", "/webhook/v1/{id}", "deleteWebhook", "Delete a subscription").
+		Tag("webhook").Require(requirements["DeleteWebhook"]).
+		PathParam("id", "Subscription id").
+		Returns(http.StatusOK, "Empty object", deleteResponse{}).
+		Fails(http.StatusNotFound, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("GET", "/webhook/v1/deadletters", "listDeadLetters", "List the deliveries that failed every attempt").
+		Tag("webhook").Require(requirements["ListDeadLetters"]).
+		Returns(http.StatusOK, "Dead-lettered deliveries", deadLettersResponse{}).
+		Fails(http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+	d.Operation("POST", "/webhook/v1/deadletters/{id}/replay", "replayDelivery", "Send a dead-lettered delivery again, it leaves the log once accepted").
+		Tag("webhook").Require(requirements["ReplayDelivery"]).
+		PathParam("id", "Delivery id").
+		Returns(http.StatusOK, "The accepted delivery", replayResponse{}).
+		Fails(http.StatusNotFound, http.StatusConflict, http.StatusServiceUnavailable, http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests, http.StatusInternalServerError)
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/event"
+)
+
+// Webhook subscription service. Subscribers receive the domain events of
+// the services as signed JSON POSTs.
+
+const secretPrefix = "whsec_"
+
+var (
+	ErrInvalidURL = apierror.New(apierror.Unprocessable, "invalid_webhook_url", "invalid webhook url").
+			WithField("url", "must be an absolute http or https url")
+	ErrUnknownEvent = apierror.New(apierror.Unprocessable, "unknown_event_type", "unknown event type").
+			WithField("events", "must be known event types")
+)
+
+// CreatedSubscription is returned on creation. Secret verifies the
+// signatures of the deliveries and is never returned again.
+type CreatedSubscription struct {
+	Subscription
+	Secret string `json:"secret"`
+}
+
+type Service interface {
+	Create(ctx context.Context, url string, events []string) (CreatedSubscription, error)
+	List(ctx context.Context) ([]Subscription, error)
+	Delete(ctx context.Context, id string) error
+	// DeadLetters lists the deliveries that failed every attempt
+	DeadLetters(ctx context.Context) ([]Delivery, error)
+	// Replay retries a dead-lettered delivery once
+	Replay(ctx context.Context, id string) (Delivery, error)
+}
+
+type service struct {
+	subs       SubscriptionStore
+	dead       DeadLetterStore
+	dispatcher *Dispatcher
+	now        func() time.Time
+}
+
+func NewService(subs SubscriptionStore, dead DeadLetterStore, d *Dispatcher) Service {
+	return &service{subs: subs, dead: dead, dispatcher: d, now: time.Now}
+}
+
+func (s *service) Create(ctx context.Context, rawurl string, events []string) (CreatedSubscription, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return CreatedSubscription{}, ErrInvalidURL
+	}
+	for _, t := range events {
+		if !event.Known(t) {
+			return CreatedSubscription{}, ErrUnknownEvent
+		}
+	}
+	id, err := randomHex(8)
+	if err != nil {
+		return CreatedSubscription{}, ErrInternal
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return CreatedSubscription{}, ErrInternal
+	}
+	sub, err := s.subs.Create(Subscription{
+		ID:        id,
+		URL:       u.String(),
+		Events:    events,
+		Secret:    secretPrefix + secret,
+		CreatedAt: s.now(),
+	})
+	if err != nil {
+		return CreatedSubscription{}, err
+	}
+	return CreatedSubscription{Subscription: sub, Secret: sub.Secret}, nil
+}
+
+func (s *service) List(ctx context.Context) ([]Subscription, error) {
+	return s.subs.GetAll()
+}
+
+func (s *service) Delete(ctx context.Context, id string) error {
+	return s.subs.Delete(id)
+}
+
+func (s *service) DeadLetters(ctx context.Context) ([]Delivery, error) {
+	return s.dead.GetAll()
+}
+
+func (s *service) Replay(ctx context.Context, id string) (Delivery, error) {
+	return s.dispatcher.Replay(id)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
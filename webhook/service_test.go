@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atuldaemon/rct/booking"
+	"github.com/atuldaemon/rct/event"
+	"github.com/atuldaemon/rct/parking"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+)
+
+type nopCounter struct{}
+
+func (c nopCounter) With(labelValues ...string) metrics.Counter { return c }
+func (c nopCounter) Add(delta float64)                          {}
+
+// receiver is a local webhook endpoint which fails the first failures
+// deliveries and records the accepted ones
+type receiver struct {
+	*httptest.Server
+	t *testing.T
+
+	mtx      sync.Mutex
+	secret   string
+	failures int
+	attempts int
+	accepted []event.Event
+	got      chan event.Event
+}
+
+func newReceiver(t *testing.T, failures int) *receiver {
+	r := &receiver{t: t, failures: failures, got: make(chan event.Event, 16)}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		r.mtx.Lock()
+		defer r.mtx.Unlock()
+		r.attempts++
+		if err := Verify(r.secret, req.Header.Get(SignatureHeader), body, time.Now(), time.Minute); err != nil {
+			t.Errorf("Bad signature: %v", err)
+		}
+		if r.failures > 0 {
+			r.failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var e event.Event
+		if err := json.Unmarshal(body, &e); err != nil || req.Header.Get(EventHeader) != e.Type || req.Header.Get(DeliveryHeader) == "" {
+			t.Errorf("Unexpected delivery %s", body)
+		}
+		r.accepted = append(r.accepted, e)
+		r.got <- e
+	}))
+	return r
+}
+
+func (r *receiver) subscribe(t *testing.T, s Service, events ...string) Subscription {
+	sub, err := s.Create(context.Background(), r.URL+"/hook", events)
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	r.mtx.Lock()
+	r.secret = sub.Secret
+	r.mtx.Unlock()
+	return sub.Subscription
+}
+
+func (r *receiver) wait(t *testing.T) event.Event {
+	select {
+	case e := <-r.got:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("No delivery received")
+	}
+	return event.Event{}
+}
+
+func newTestService(o DispatcherOptions) (Service, *Dispatcher) {
+	subs, _ := NewInMemSubscriptionStore()
+	dead, _ := NewInMemDeadLetterStore()
+	d := NewDispatcher(subs, dead, o, log.NewNopLogger())
+	return NewService(subs, dead, d), d
+}
+
+func TestDeliveries(t *testing.T) {
+	s, d := newTestService(DispatcherOptions{})
+	defer d.Close()
+	r := newReceiver(t, 0)
+	defer r.Close()
+	r.subscribe(t, s, event.SpotReleased)
+
+	store, _ := parking.NewInMemParkingStore()
+	stop := parking.PublishChanges(store, d, log.NewNopLogger(), nopCounter{})
+	defer stop()
+
+	store.Update(parking.Spot{ID: 1, IsReserved: true})
+	store.Update(parking.Spot{ID: 1})
+
+	e := r.wait(t)
+	if e.Type != event.SpotReleased {
+		t.Errorf("Expected %s, got %s", event.SpotReleased, e.Type)
+	}
+	data, _ := e.Data.(map[string]interface{})
+	if spot, ok := data["spot"].(map[string]interface{}); !ok || spot["id"] != 1.0 || e.Aggregate != "spot/1" {
+		t.Errorf("Expected spot 1 in the event, got %v", e.Data)
+	}
+	d.Close()
+	if len(r.accepted) != 1 {
+		t.Errorf("Expected only the subscribed event, got %d", len(r.accepted))
+	}
+}
+
+func TestRetriesAndDeadLetters(t *testing.T) {
+	s, d := newTestService(DispatcherOptions{Attempts: 3, Backoff: time.Millisecond})
+	defer d.Close()
+
+	flaky := newReceiver(t, 2)
+	defer flaky.Close()
+	flaky.subscribe(t, s)
+	down := newReceiver(t, 3)
+	defer down.Close()
+	sub := down.subscribe(t, s, event.BookingCreated)
+
+	d.Publish(context.Background(), event.New(booking.BookingCreated{Booking: booking.Booking{ID: 1}}, time.Now()))
+	flaky.wait(t)
+	d.Close()
+
+	if flaky.attempts != 3 {
+		t.Errorf("Expected the third attempt to succeed, got %d attempts", flaky.attempts)
+	}
+	dd, _ := s.DeadLetters(context.Background())
+	if len(dd) != 1 || dd[0].SubscriptionID != sub.ID || dd[0].Attempts != 3 || dd[0].LastStatus != http.StatusInternalServerError {
+		t.Fatalf("Expected the delivery to the failing receiver to be dead-lettered, got %+v", dd)
+	}
+
+	// the receiver is back, replay by hand
+	if _, err := s.Replay(context.Background(), dd[0].ID); err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+	if e := down.wait(t); e.ID != dd[0].Event.ID {
+		t.Error("Replay delivered a different event")
+	}
+	if dd, _ := s.DeadLetters(context.Background()); len(dd) != 0 {
+		t.Error("Expected the replayed delivery to leave the log")
+	}
+	if _, err := s.Replay(context.Background(), dd[0].ID); err != ErrNotFound {
+		t.Error("Expected a second replay to find nothing")
+	}
+}
+
+func TestReplayFailure(t *testing.T) {
+	s, d := newTestService(DispatcherOptions{Attempts: 1})
+	defer d.Close()
+	r := newReceiver(t, 2)
+	defer r.Close()
+	sub := r.subscribe(t, s)
+
+	d.Publish(context.Background(), event.New(parking.SpotCreated{Spot: parking.Spot{ID: 1}}, time.Now()))
+	d.Close()
+	dd, _ := s.DeadLetters(context.Background())
+	if len(dd) != 1 {
+		t.Fatal("Expected a dead letter")
+	}
+	del, err := s.Replay(context.Background(), dd[0].ID)
+	if err != ErrDeliveryFailed || del.Attempts != 2 {
+		t.Errorf("Expected a failed second attempt, got %d %v", del.Attempts, err)
+	}
+	s.Delete(context.Background(), sub.ID)
+	if _, err := s.Replay(context.Background(), dd[0].ID); err != ErrSubscriptionGone {
+		t.Errorf("Expected the replay of a deleted subscription to fail, got %v", err)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	s, d := newTestService(DispatcherOptions{})
+	defer d.Close()
+	ctx := context.Background()
+
+	for _, url := range []string{"", "example.com/hook", "ftp://example.com", "http://"} {
+		if _, err := s.Create(ctx, url, nil); err != ErrInvalidURL {
+			t.Errorf("Expected %q to be rejected, got %v", url, err)
+		}
+	}
+	if _, err := s.Create(ctx, "https://example.com", []string{"booking.paid"}); err != ErrUnknownEvent {
+		t.Error("Expected an unknown event type to be rejected")
+	}
+	sub, err := s.Create(ctx, "https://example.com/hook", []string{event.BookingCreated})
+	if err != nil || len(sub.Secret) != len(secretPrefix)+48 {
+		t.Fatal("Expected a subscription with a secret")
+	}
+	ss, _ := s.List(ctx)
+	if len(ss) != 1 {
+		t.Fatal("Expected one subscription")
+	}
+	if b, _ := json.Marshal(ss); strings.Contains(string(b), sub.Secret) {
+		t.Error("Expected the secret to be left out of the list")
+	}
+}
+
+func TestSignature(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"id":"1"}`)
+	header := Sign("secret", now, body)
+	if err := Verify("secret", header, body, now, time.Minute); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+	if Verify("other", header, body, now, time.Minute) == nil {
+		t.Error("Expected another secret to fail")
+	}
+	if Verify("secret", header, []byte(`{"id":"2"}`), now, time.Minute) == nil {
+		t.Error("Expected another body to fail")
+	}
+	if Verify("secret", header, body, now.Add(2*time.Minute), time.Minute) == nil {
+		t.Error("Expected an old signature to fail")
+	}
+	if Verify("secret", "v1=abc", body, now, time.Minute) == nil {
+		t.Error("Expected a header without time to fail")
+	}
+}
+
+// TestOpenAPI fails when a route of MakeHTTPHandler has no OpenAPI entry or
+// an entry outlives its route
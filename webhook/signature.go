@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Every delivery is signed with the secret of its subscription. The
+// signature header holds the signing time and the hex HMAC-SHA256 of
+// "<unix time>.<body>", e.g. "t=1538000000,v1=5f3c...". Including the time
+// lets receivers reject replayed requests.
+
+const (
+	SignatureHeader = "X-RCT-Signature"
+	EventHeader     = "X-RCT-Event"
+	DeliveryHeader  = "X-RCT-Delivery"
+)
+
+var (
+	errBadSignatureHeader = errors.New("malformed signature header")
+	errSignatureMismatch  = errors.New("signature mismatch")
+	errSignatureTooOld    = errors.New("signature outside the tolerance")
+)
+
+// Sign returns the signature header value for body sent at t
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + mac(secret, ts, body)
+}
+
+// Verify checks a signature header for body as received at now. Signatures
+// made more than tolerance away from now are rejected.
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, sig string
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return errBadSignatureHeader
+		}
+		switch kv[0] {
+		case "t":
+			ts = kv[1]
+		case "v1":
+			sig = kv[1]
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || sig == "" {
+		return errBadSignatureHeader
+	}
+	if !hmac.Equal([]byte(sig), []byte(mac(secret, ts, body))) {
+		return errSignatureMismatch
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > tolerance || d < -tolerance {
+		return errSignatureTooOld
+	}
+	return nil
+}
+
+func mac(secret, ts string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
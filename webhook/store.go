@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/event"
+)
+
+// The stores keep the webhook subscriptions and the dead-letter log of the
+// deliveries that failed every attempt
+
+type SubscriptionStore interface {
+	Create(Subscription) (Subscription, error)
+	Delete(id string) error
+	Find(id string) (Subscription, error)
+	GetAll() ([]Subscription, error)
+}
+
+type DeadLetterStore interface {
+	Add(Delivery) error
+	Update(Delivery) error
+	Remove(id string) error
+	Find(id string) (Delivery, error)
+	GetAll() ([]Delivery, error)
+}
+
+type Subscription struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Events lists the event types delivered, empty means all of them
+	Events []string `json:"events,omitempty"`
+	// Secret signs the deliveries, it is only returned on creation
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Wants reports whether events of type t are delivered to the subscription
+func (s Subscription) Wants(t string) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, want := range s.Events {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery is an event on its way to a subscription. The dead-letter log
+// keeps the ones that exhausted their attempts.
+type Delivery struct {
+	ID             string      `json:"id"`
+	SubscriptionID string      `json:"subscriptionId"`
+	Event          event.Event `json:"event"`
+	Attempts       int         `json:"attempts"`
+	// LastStatus is the HTTP status of the last attempt, zero when the
+	// receiver could not be reached
+	LastStatus int       `json:"lastStatus,omitempty"`
+	LastError  string    `json:"lastError"`
+	FailedAt   time.Time `json:"failedAt"`
+}
+
+var (
+	ErrInconsistentIDs = apierror.New(apierror.Conflict, "inconsistent_ids", "inconsistent IDs")
+	ErrNotFound        = apierror.New(apierror.NotFound, "webhook_not_found", "not found")
+	ErrInvalidReq      = apierror.New(apierror.Invalid, "invalid_request", "invalid request")
+	ErrInternal        = apierror.New(apierror.Internal, "internal", "internal data error")
+)
+
+type InMemSubscriptionStore struct {
+	mtx sync.RWMutex
+	m   map[string]Subscription
+}
+
+func NewInMemSubscriptionStore() (SubscriptionStore, error) {
+	return &InMemSubscriptionStore{m: make(map[string]Subscription, 0)}, nil
+}
+
+func (s *InMemSubscriptionStore) Create(sub Subscription) (Subscription, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[sub.ID]; ok {
+		return Subscription{}, ErrInconsistentIDs
+	}
+	s.m[sub.ID] = sub
+	return sub, nil
+}
+
+func (s *InMemSubscriptionStore) Delete(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.m, id)
+	return nil
+}
+
+func (s *InMemSubscriptionStore) Find(id string) (Subscription, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	sub, ok := s.m[id]
+	if !ok {
+		return Subscription{}, ErrNotFound
+	}
+	return sub, nil
+}
+
+func (s *InMemSubscriptionStore) GetAll() ([]Subscription, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	ss := make([]Subscription, 0)
+	for _, sub := range s.m {
+		ss = append(ss, sub)
+	}
+	sort.Slice(ss, func(i, j int) bool {
+		return ss[i].CreatedAt.Before(ss[j].CreatedAt)
+	})
+	return ss, nil
+}
+
+type InMemDeadLetterStore struct {
+	mtx sync.RWMutex
+	m   map[string]Delivery
+}
+
+func NewInMemDeadLetterStore() (DeadLetterStore, error) {
+	return &InMemDeadLetterStore{m: make(map[string]Delivery, 0)}, nil
+}
+
+func (s *InMemDeadLetterStore) Add(d Delivery) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[d.ID]; ok {
+		return ErrInconsistentIDs
+	}
+	s.m[d.ID] = d
+	return nil
+}
+
+func (s *InMemDeadLetterStore) Update(d Delivery) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[d.ID]; !ok {
+		return ErrNotFound
+	}
+	s.m[d.ID] = d
+	return nil
+}
+
+func (s *InMemDeadLetterStore) Remove(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.m[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.m, id)
+	return nil
+}
+
+func (s *InMemDeadLetterStore) Find(id string) (Delivery, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	d, ok := s.m[id]
+	if !ok {
+		return Delivery{}, ErrNotFound
+	}
+	return d, nil
+}
+
+func (s *InMemDeadLetterStore) GetAll() ([]Delivery, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	dd := make([]Delivery, 0)
+	for _, d := range s.m {
+		dd = append(dd, d)
+	}
+	sort.Slice(dd, func(i, j int) bool {
+		return dd[i].FailedAt.Before(dd[j].FailedAt)
+	})
+	return dd, nil
+}
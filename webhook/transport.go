@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/atuldaemon/rct/apierror"
+	"github.com/atuldaemon/rct/auth"
+	"github.com/go-kit/kit/log"
+	httptransport "github.com/go-kit/kit/transport/http"
+)
+
+var (
+	ErrBadRouting = apierror.New(apierror.Internal, "bad_routing", "inconsistent mapping between route and handler (programmer error)")
+)
+
+// requirements holds the scope and roles of every method, enforced by
+// MakeHTTPHandler and described by AddOpenAPI
+var requirements = auth.Requirements{
+	"CreateWebhook":   {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"ListWebhooks":    {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"DeleteWebhook":   {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"ListDeadLetters": {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+	"ReplayDelivery":  {Scope: auth.ScopeAdmin, Roles: []auth.Role{auth.RoleOperator, auth.RoleAdmin}},
+}
+
+// MakeHTTPHandler mounts the subscription management endpoints into an
+// http.Handler. They are restricted to operators and admins.
+func MakeHTTPHandler(s Service, a *auth.Authorizer, logger log.Logger) http.Handler {
+	r := mux.NewRouter()
+	e := MakeServerEndpoints(s)
+	options := []httptransport.ServerOption{
+		httptransport.ServerBefore(auth.HTTPToContext),
+		httptransport.ServerErrorLogger(logger),
+		httptransport.ServerErrorEncoder(apierror.EncodeError),
+	}
+
+	r.Methods("POST").Path("/webhook/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "CreateWebhook")(e.CreateEndpoint),
+		decodeCreateRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/webhook/v1/").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListWebhooks")(e.ListEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("DELETE").Path("/webhook/v1/{id}").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "DeleteWebhook")(e.DeleteEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("GET").Path("/webhook/v1/deadletters").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ListDeadLetters")(e.DeadLettersEndpoint),
+		decodeListRequest,
+		encodeResponse,
+		options...,
+	))
+	r.Methods("POST").Path("/webhook/v1/deadletters/{id}/replay").Handler(httptransport.NewServer(
+		a.Enforce(requirements, "ReplayDelivery")(e.ReplayEndpoint),
+		decodeIdRequest,
+		encodeResponse,
+		options...,
+	))
+	return r
+}
+
+func decodeCreateRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req createRequest
+	if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+		return nil, e
+	}
+	return req, nil
+}
+
+func decodeListRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	var req listRequest
+	return req, nil
+}
+
+func decodeIdRequest(_ context.Context, r *http.Request) (request interface{}, err error) {
+	vars := mux.Vars(r)
+	id, ok := vars["id"]
+	if !ok {
+		return nil, ErrBadRouting
+	}
+	return idRequest{ID: id}, nil
+}
+
+// errorer is implemented by all concrete response types that may contain
+// errors. It allows us to change the HTTP response code without needing to
+// trigger an endpoint (transport-level) error.
+type errorer interface {
+	error() error
+}
+
+func encodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	if e, ok := response.(errorer); ok && e.error() != nil {
+		apierror.EncodeError(ctx, e.error(), w)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	return json.NewEncoder(w).Encode(response)
+}